@@ -0,0 +1,423 @@
+package edit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/abi/edit.log")
+
+type editStep int
+
+const (
+	stepLoading editStep = iota
+	stepEnterName
+	stepEnterSource
+	stepReadingABI
+	stepReview
+	stepSuccess
+	stepError
+)
+
+// Model adds a new ABI, or edits the ABI given by the "id" query parameter. Before an edited ABI is
+// saved, the changes from the stored version are reviewed with breaking changes flagged.
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+
+	currentStep editStep
+
+	// existing is the stored ABI being edited, nil when adding a new one.
+	existing *models.EvmAbi
+
+	nameInput   textinput.Model
+	sourceInput textinput.Model
+
+	elements abi.AbiArray
+	diff     abi.ABIDiff
+
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithStorage(router, sharedMemory, nil)
+}
+
+// NewPageWithStorage creates a new ABI edit page with an optional storage client (for testing).
+func NewPageWithStorage(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage) view.View {
+	nameInput := textinput.New()
+	nameInput.Placeholder = "Enter ABI name"
+	nameInput.Width = 40
+
+	sourceInput := textinput.New()
+	sourceInput.Placeholder = "path/to/abi.json, https://..., JSON or human-readable signatures"
+	sourceInput.Width = 66
+
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		currentStep:   stepLoading,
+		nameInput:     nameInput,
+		sourceInput:   sourceInput,
+	}
+}
+
+type abiLoadedMsg struct {
+	storageClient sql.Storage
+	existing      *models.EvmAbi
+	err           error
+}
+
+type sourceReadMsg struct {
+	elements abi.AbiArray
+	err      error
+}
+
+type abiSavedMsg struct {
+	err error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadABI
+}
+
+func (m Model) loadABI() tea.Msg {
+	storageClient := m.storageClient
+	if storageClient == nil {
+		sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get storage client from shared memory: %v", err)
+			return abiLoadedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+		}
+		storageClient = sqlStorage
+	}
+
+	idParam := m.router.GetQueryParam("id")
+	if idParam == "" {
+		return abiLoadedMsg{storageClient: storageClient}
+	}
+
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		return abiLoadedMsg{err: fmt.Errorf("invalid ABI id %q", idParam)}
+	}
+	existing, err := storageClient.GetABIByID(uint(id))
+	if err != nil {
+		logger.Error("Failed to load ABI %d: %v", id, err)
+		return abiLoadedMsg{err: err}
+	}
+	return abiLoadedMsg{storageClient: storageClient, existing: &existing}
+}
+
+// readSource reads the ABI from a file or URL, or parses it when the JSON or human-readable
+// signatures are entered directly. Signatures entered on one line are separated by ";".
+func readSource(source string) (abi.AbiArray, error) {
+	if strings.HasPrefix(source, "[") || strings.HasPrefix(source, "{") {
+		return abi.ParseAbi(source)
+	}
+	if strings.Contains(source, "(") {
+		return abi.ParseHumanReadableString(strings.ReplaceAll(source, ";", "\n"))
+	}
+	return abi.ReadAbi(source)
+}
+
+func (m Model) readSourceCmd(source string) tea.Cmd {
+	return func() tea.Msg {
+		elements, err := readSource(source)
+		if err != nil {
+			logger.Error("Failed to read ABI from %s: %v", source, err)
+			return sourceReadMsg{err: err}
+		}
+		return sourceReadMsg{elements: elements}
+	}
+}
+
+func (m Model) saveABI() tea.Msg {
+	record := models.EvmAbi{
+		Name: strings.TrimSpace(m.nameInput.Value()),
+		Abi:  models.AbiArrayType{AbiArray: m.elements},
+	}
+
+	if m.existing == nil {
+		if _, err := m.storageClient.CreateABI(record); err != nil {
+			logger.Error("Failed to create ABI: %v", err)
+			return abiSavedMsg{err: fmt.Errorf("failed to create ABI: %w", err)}
+		}
+		return abiSavedMsg{}
+	}
+
+	if err := m.storageClient.UpdateABI(m.existing.ID, record); err != nil {
+		logger.Error("Failed to update ABI %d: %v", m.existing.ID, err)
+		return abiSavedMsg{err: fmt.Errorf("failed to update ABI: %w", err)}
+	}
+	return abiSavedMsg{}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case abiLoadedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.storageClient = msg.storageClient
+		m.existing = msg.existing
+		if m.existing != nil {
+			m.nameInput.SetValue(m.existing.Name)
+		}
+		m.currentStep = stepEnterName
+		m.nameInput.Focus()
+		return m, textinput.Blink
+
+	case sourceReadMsg:
+		if msg.err != nil {
+			m.currentStep = stepEnterSource
+			m.errorMsg = msg.err.Error()
+			m.sourceInput.Focus()
+			return m, textinput.Blink
+		}
+		m.showReview(msg.elements)
+		return m, nil
+
+	case abiSavedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.currentStep = stepSuccess
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepEnterName:
+			return m.handleEnterName(msg)
+		case stepEnterSource:
+			return m.handleEnterSource(msg)
+		case stepReview:
+			return m.handleReview(msg)
+		case stepSuccess, stepError:
+			// Any key returns to the ABI list
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/abi", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// showReview moves to the review of the new ABI elements, comparing them with the stored version when editing.
+func (m *Model) showReview(elements abi.AbiArray) {
+	m.elements = elements
+	m.diff = abi.ABIDiff{}
+	if m.existing != nil {
+		m.diff = abi.Diff(m.existing.Abi.AbiArray, elements)
+	}
+	m.errorMsg = ""
+	m.currentStep = stepReview
+}
+
+func (m Model) handleEnterName(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "enter" {
+		if strings.TrimSpace(m.nameInput.Value()) == "" {
+			m.errorMsg = "ABI name cannot be empty"
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.nameInput.Blur()
+		m.currentStep = stepEnterSource
+		m.sourceInput.Focus()
+		return m, textinput.Blink
+	}
+
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleEnterSource(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "enter" {
+		source := strings.TrimSpace(m.sourceInput.Value())
+		if source == "" {
+			if m.existing == nil {
+				m.errorMsg = "ABI source cannot be empty"
+				return m, nil
+			}
+			// Keep the stored ABI and only rename it
+			m.sourceInput.Blur()
+			m.showReview(m.existing.Abi.AbiArray)
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.sourceInput.Blur()
+		m.currentStep = stepReadingABI
+		return m, m.readSourceCmd(source)
+	}
+
+	var cmd tea.Cmd
+	m.sourceInput, cmd = m.sourceInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		return m, m.saveABI
+	case "b":
+		m.currentStep = stepEnterSource
+		m.sourceInput.Focus()
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+func (m Model) title() string {
+	if m.existing != nil {
+		return "Edit ABI - " + m.existing.Name
+	}
+	return "Add New ABI"
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepEnterName, stepEnterSource:
+		return "enter: continue • esc: cancel", view.HelpDisplayOptionOverride
+	case stepReview:
+		return "enter/y: save • b: change ABI source • esc: cancel", view.HelpDisplayOptionOverride
+	case stepSuccess, stepError:
+		return "Press any key to return to ABI list", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	switch m.currentStep {
+	case stepEnterName:
+		return m.renderInput("Step 1/2: Enter ABI name", m.nameInput, nil)
+	case stepEnterSource:
+		hints := []component.Component{
+			component.T("Enter a file path or URL, paste the ABI JSON, or type human-readable").Muted(),
+			component.T("signatures separated by ';'.").Muted(),
+		}
+		if m.existing != nil {
+			hints = append(hints, component.T("Leave empty to keep the current ABI.").Muted())
+		}
+		return m.renderInput("Step 2/2: Enter the ABI source", m.sourceInput, hints)
+	case stepReadingABI:
+		return component.VStackC(
+			component.T(m.title()).Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Reading ABI from "+strings.TrimSpace(m.sourceInput.Value())+"...").Muted(),
+		).Render()
+	case stepReview:
+		return m.renderReview()
+	case stepSuccess:
+		return component.VStackC(
+			component.T(m.title()).Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("✓ ABI saved successfully").Bold(true),
+		).Render()
+	case stepError:
+		return component.VStackC(
+			component.T(m.title()).Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			component.T("ABI Management").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Loading...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) renderError() component.Component {
+	if m.errorMsg == "" {
+		return component.Empty()
+	}
+	return component.T("Error: " + m.errorMsg).Error()
+}
+
+func (m Model) renderInput(title string, input textinput.Model, hints []component.Component) string {
+	return component.VStackC(
+		component.T(m.title()).Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T(title).Bold(true),
+		component.SpacerV(1),
+		component.T(input.View()),
+		component.SpacerV(1),
+		component.VStackC(hints...),
+		component.IfC(len(hints) > 0, component.SpacerV(1), component.Empty()),
+		m.renderError(),
+	).Render()
+}
+
+func (m Model) renderReview() string {
+	functions, events, errors := 0, 0, 0
+	for _, element := range m.elements {
+		switch element.Type {
+		case "function":
+			functions++
+		case "event":
+			events++
+		case "error":
+			errors++
+		}
+	}
+
+	return component.VStackC(
+		component.T(m.title()).Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Name: "+strings.TrimSpace(m.nameInput.Value())),
+		component.T(fmt.Sprintf("Functions: %d • Events: %d • Errors: %d", functions, events, errors)),
+		component.SpacerV(1),
+		component.IfC(m.existing != nil, renderDiff(m.diff), component.Empty()),
+	).Render()
+}
+
+func renderDiff(diff abi.ABIDiff) component.Component {
+	items := []component.Component{
+		component.T("Changes: " + diff.Summary()).Bold(true),
+		component.SpacerV(1),
+	}
+
+	for _, change := range diff.Changes {
+		for index, line := range change.Lines() {
+			text := component.T(line)
+			switch {
+			case index > 0:
+				text = text.Muted()
+			case change.Breaking:
+				text = text.Error()
+			}
+			items = append(items, text)
+		}
+	}
+
+	if breaking := len(diff.Breaking()); breaking > 0 {
+		items = append(items,
+			component.SpacerV(1),
+			component.T(fmt.Sprintf("⚠ %d breaking changes: existing callers of contracts using this ABI may fail", breaking)).Warning(),
+		)
+	}
+	return component.VStackC(items...)
+}
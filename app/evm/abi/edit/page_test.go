@@ -0,0 +1,149 @@
+package edit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type EditABIPageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	model       Model
+}
+
+func TestEditABIPageTestSuite(t *testing.T) {
+	suite.Run(t, new(EditABIPageTestSuite))
+}
+
+func (s *EditABIPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.model = NewPageWithStorage(s.mockRouter, storage.NewSharedMemory(), s.mockStorage).(Model)
+}
+
+func (s *EditABIPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *EditABIPageTestSuite) update(msg tea.Msg) tea.Cmd {
+	updated, cmd := s.model.Update(msg)
+	s.model = updated.(Model)
+	return cmd
+}
+
+func (s *EditABIPageTestSuite) press(key string) tea.Cmd {
+	if key == "enter" {
+		return s.update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	return s.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+}
+
+func (s *EditABIPageTestSuite) load(id string) {
+	s.mockRouter.EXPECT().GetQueryParam("id").Return(id)
+	s.update(s.model.loadABI())
+}
+
+func storedABI() models.EvmAbi {
+	elements, _ := abi.ParseHumanReadable([]string{
+		"function balanceOf(address owner) view returns (uint256)",
+		"function burn(uint256 amount)",
+	})
+	return models.EvmAbi{ID: 4, Name: "Token", Abi: models.AbiArrayType{AbiArray: elements}}
+}
+
+func (s *EditABIPageTestSuite) TestEditShowsBreakingChangesBeforeSaving() {
+	s.mockStorage.EXPECT().GetABIByID(uint(4)).Return(storedABI(), nil)
+	s.load("4")
+	s.Equal(stepEnterName, s.model.currentStep)
+	s.Equal("Token", s.model.nameInput.Value())
+
+	s.press("enter")
+	s.Equal(stepEnterSource, s.model.currentStep)
+
+	path := filepath.Join(s.T().TempDir(), "token.json")
+	s.Require().NoError(os.WriteFile(path, []byte(`["function balanceOf(address owner) returns (uint256)", "function mint(address to, uint256 amount)"]`), 0o600))
+	s.model.sourceInput.SetValue(path)
+	cmd := s.press("enter")
+	s.Equal(stepReadingABI, s.model.currentStep)
+	s.Require().NotNil(cmd)
+	s.update(cmd())
+
+	s.Equal(stepReview, s.model.currentStep)
+	s.True(s.model.diff.HasBreakingChanges())
+	output := s.model.View()
+	s.Contains(output, "1 added, 1 modified, 1 removed (2 breaking)")
+	s.Contains(output, "state mutability changed from view to nonpayable")
+	s.Contains(output, "- removed function burn(uint256 amount)  [breaking]")
+	s.Contains(output, "⚠ 2 breaking changes")
+
+	s.mockStorage.EXPECT().UpdateABI(uint(4), gomock.Any()).DoAndReturn(func(id uint, record models.EvmAbi) error {
+		s.Equal("Token", record.Name)
+		s.Len(record.Abi.AbiArray, 2)
+		return nil
+	})
+	cmd = s.press("y")
+	s.Require().NotNil(cmd)
+	s.update(cmd())
+	s.Equal(stepSuccess, s.model.currentStep)
+}
+
+func (s *EditABIPageTestSuite) TestEditWithEmptySourceKeepsABI() {
+	s.mockStorage.EXPECT().GetABIByID(uint(4)).Return(storedABI(), nil)
+	s.load("4")
+	s.press("enter")
+	s.press("enter")
+
+	s.Equal(stepReview, s.model.currentStep)
+	s.True(s.model.diff.IsEmpty())
+	s.Contains(s.model.View(), "Changes: No changes")
+}
+
+func (s *EditABIPageTestSuite) TestAddParsesInlineSignatures() {
+	s.load("")
+	s.Nil(s.model.existing)
+
+	s.press("enter")
+	s.Contains(s.model.View(), "ABI name cannot be empty")
+
+	s.model.nameInput.SetValue("Vault")
+	s.press("enter")
+	s.press("enter")
+	s.Contains(s.model.View(), "ABI source cannot be empty")
+
+	s.model.sourceInput.SetValue("function deposit() payable; event Deposited(address from, uint256 amount)")
+	cmd := s.press("enter")
+	s.update(cmd())
+	s.Equal(stepReview, s.model.currentStep)
+	s.Contains(s.model.View(), "Functions: 1 • Events: 1 • Errors: 0")
+	s.NotContains(s.model.View(), "Changes:")
+
+	s.mockStorage.EXPECT().CreateABI(gomock.Any()).Return(uint(7), nil)
+	cmd = s.press("enter")
+	s.update(cmd())
+	s.Equal(stepSuccess, s.model.currentStep)
+}
+
+func (s *EditABIPageTestSuite) TestInvalidSourceStaysOnInput() {
+	s.load("")
+	s.model.nameInput.SetValue("Vault")
+	s.press("enter")
+
+	s.model.sourceInput.SetValue("[not json")
+	cmd := s.press("enter")
+	s.update(cmd())
+	s.Equal(stepEnterSource, s.model.currentStep)
+	s.NotEmpty(s.model.errorMsg)
+}
@@ -1,50 +1,306 @@
 package abi
 
 import (
+	"fmt"
+	"strconv"
+
 	tea "github.com/charmbracelet/bubbletea"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
 	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
 	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
 	"github.com/rxtech-lab/smart-contract-cli/internal/view"
 )
 
+var logger, _ = log.NewFileLogger("./logs/evm/abi/page.log")
+
+const pageSize = 5
+
 type Model struct {
-	router       view.Router
-	sharedMemory storage.SharedMemory
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+
+	abis          []models.EvmAbi
+	selectedIndex int
+	currentPage   int64
+	totalPages    int64
+	totalItems    int64
+
+	confirmDelete bool
+
+	loading  bool
+	errorMsg string
 }
 
 func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithStorage(router, sharedMemory, nil)
+}
+
+// NewPageWithStorage creates a new ABI management page with an optional storage client (for testing).
+func NewPageWithStorage(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage) view.View {
 	return Model{
-		router:       router,
-		sharedMemory: sharedMemory,
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		currentPage:   1,
+		loading:       true,
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return nil
+	return m.loadABIs
+}
+
+type abisLoadedMsg struct {
+	abis          []models.EvmAbi
+	storageClient sql.Storage
+	totalPages    int64
+	totalItems    int64
+	err           error
+}
+
+type abiDeletedMsg struct {
+	err error
+}
+
+func (m Model) getStorageClient() (sql.Storage, error) {
+	if m.storageClient != nil {
+		return m.storageClient, nil
+	}
+
+	sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+	if err != nil {
+		logger.Error("Failed to get storage client from shared memory: %v", err)
+		return nil, fmt.Errorf("failed to get storage client from shared memory: %w", err)
+	}
+	return sqlStorage, nil
+}
+
+func (m Model) loadABIs() tea.Msg {
+	storageClient, err := m.getStorageClient()
+	if err != nil {
+		return abisLoadedMsg{err: err}
+	}
+
+	result, err := storageClient.ListABIs(m.currentPage, pageSize)
+	if err != nil {
+		logger.Error("Failed to list ABIs: %v", err)
+		return abisLoadedMsg{err: err}
+	}
+
+	return abisLoadedMsg{
+		abis:          result.Items,
+		storageClient: storageClient,
+		totalPages:    result.TotalPages,
+		totalItems:    result.TotalItems,
+	}
+}
+
+func (m Model) deleteABI() tea.Msg {
+	abi := m.abis[m.selectedIndex]
+	if err := m.storageClient.DeleteABI(abi.ID); err != nil {
+		logger.Error("Failed to delete ABI %d: %v", abi.ID, err)
+		return abiDeletedMsg{err: err}
+	}
+	return abiDeletedMsg{}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	keyMsg, ok := msg.(tea.KeyMsg)
-	if !ok {
+	switch msg := msg.(type) {
+	case abisLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.errorMsg = ""
+		m.abis = msg.abis
+		m.storageClient = msg.storageClient
+		m.totalPages = msg.totalPages
+		m.totalItems = msg.totalItems
+		if m.selectedIndex >= len(m.abis) {
+			m.selectedIndex = max(len(m.abis)-1, 0)
+		}
 		return m, nil
+
+	case abiDeletedMsg:
+		m.confirmDelete = false
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.loading = true
+		return m, m.loadABIs
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+
+		if m.confirmDelete {
+			switch msg.String() {
+			case "y":
+				return m, m.deleteABI
+			case "n":
+				m.confirmDelete = false
+			}
+			return m, nil
+		}
+
+		return m.handleKey(msg)
 	}
 
-	switch keyMsg.String() {
-	case "esc", "q":
-		m.router.Back()
+	return m, nil
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.selectedIndex > 0 {
+			m.selectedIndex--
+		}
+	case "down", "j":
+		if m.selectedIndex < len(m.abis)-1 {
+			m.selectedIndex++
+		}
+	case "n":
+		if m.currentPage < m.totalPages {
+			m.currentPage++
+			m.selectedIndex = 0
+			m.loading = true
+			return m, m.loadABIs
+		}
+	case "p":
+		if m.currentPage > 1 {
+			m.currentPage--
+			m.selectedIndex = 0
+			m.loading = true
+			return m, m.loadABIs
+		}
+	case "a":
+		if err := m.router.NavigateTo("/evm/abi/edit", nil); err != nil {
+			logger.Error("Failed to navigate to add ABI page: %v", err)
+		}
+	case "enter", "e":
+		abi, ok := m.selectedABI()
+		if !ok {
+			return m, nil
+		}
+		if err := m.router.NavigateTo("/evm/abi/edit", map[string]string{
+			"id": strconv.FormatUint(uint64(abi.ID), 10),
+		}); err != nil {
+			logger.Error("Failed to navigate to edit ABI page: %v", err)
+		}
+	case "d":
+		if _, ok := m.selectedABI(); ok {
+			m.confirmDelete = true
+		}
+	case "r":
+		m.loading = true
+		return m, m.loadABIs
 	}
 
 	return m, nil
 }
 
+func (m Model) selectedABI() (models.EvmAbi, bool) {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.abis) {
+		return models.EvmAbi{}, false
+	}
+	return m.abis[m.selectedIndex], true
+}
+
 func (m Model) Help() (string, view.HelpDisplayOption) {
-	return "esc/q: back", view.HelpDisplayOptionAppend
+	if m.loading {
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+	if m.confirmDelete {
+		return "y: delete • n: cancel", view.HelpDisplayOptionOverride
+	}
+	if len(m.abis) == 0 {
+		return "a: add new • esc/q: back", view.HelpDisplayOptionAppend
+	}
+
+	return "↑/k: up • ↓/j: down • enter/e: edit • a: add new • d: delete • n: next page • p: previous page • r: refresh • esc/q: back", view.HelpDisplayOptionAppend
 }
 
 func (m Model) View() string {
+	if m.loading {
+		return component.VStackC(
+			component.T("ABI Management").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Loading ABIs...").Muted(),
+		).Render()
+	}
+
+	if len(m.abis) == 0 {
+		return component.VStackC(
+			component.T("ABI Management").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.IfC(m.errorMsg != "", component.T("Error: "+m.errorMsg).Error(), component.Empty()),
+			component.T("No ABIs found").Bold(true),
+			component.SpacerV(1),
+			component.T("You haven't added any ABIs yet. ABIs (Application Binary Interfaces) are"),
+			component.T("required to interact with smart contracts."),
+			component.SpacerV(1),
+			component.T("Press 'a' to add your first ABI").Muted(),
+		).Render()
+	}
+
+	items := make([]component.Component, 0, len(m.abis))
+	for index, abi := range m.abis {
+		items = append(items, renderABI(abi, index == m.selectedIndex))
+	}
+
+	footer := component.Empty()
+	if m.confirmDelete {
+		abi, _ := m.selectedABI()
+		footer = component.T(fmt.Sprintf("Delete ABI %s? (y/n)", abi.Name)).Warning()
+	} else if m.errorMsg != "" {
+		footer = component.T("Error: " + m.errorMsg).Error()
+	}
+
 	return component.VStackC(
 		component.T("ABI Management").Bold(true).Primary(),
 		component.SpacerV(1),
-		component.T("Coming soon...").Muted(),
+		component.T("Manage your contract ABIs").Muted(),
+		component.SpacerV(1),
+		component.VStackC(items...),
+		component.T(fmt.Sprintf("Page %d of %d • Showing %d of %d ABIs", m.currentPage, max(m.totalPages, 1), len(m.abis), m.totalItems)).Muted(),
+		component.SpacerV(1),
+		footer,
 	).Render()
 }
+
+func renderABI(abi models.EvmAbi, isCursor bool) component.Component {
+	prefix := "  "
+	if isCursor {
+		prefix = "> "
+	}
+
+	functions, events := 0, 0
+	for _, element := range abi.Abi.AbiArray {
+		switch element.Type {
+		case "function":
+			functions++
+		case "event":
+			events++
+		}
+	}
+
+	name := component.T(prefix + abi.Name)
+	if isCursor {
+		name = name.Bold(true)
+	}
+
+	return component.VStackC(
+		name,
+		component.T(fmt.Sprintf("    Functions: %d • Events: %d", functions, events)).Muted(),
+		component.T("    Created: "+abi.CreatedAt.Format("2006-01-02 3:04 PM")).Muted(),
+		component.SpacerV(1),
+	)
+}
@@ -0,0 +1,120 @@
+package abi
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ABIPageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	model       Model
+}
+
+func TestABIPageTestSuite(t *testing.T) {
+	suite.Run(t, new(ABIPageTestSuite))
+}
+
+func (s *ABIPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.model = NewPageWithStorage(s.mockRouter, storage.NewSharedMemory(), s.mockStorage).(Model)
+}
+
+func (s *ABIPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *ABIPageTestSuite) loadABIs(abis ...models.EvmAbi) {
+	s.mockStorage.EXPECT().ListABIs(int64(1), int64(pageSize)).Return(types.Pagination[models.EvmAbi]{
+		Items:      abis,
+		TotalPages: 1,
+		TotalItems: int64(len(abis)),
+	}, nil)
+
+	updated, _ := s.model.Update(s.model.loadABIs())
+	s.model = updated.(Model)
+}
+
+func (s *ABIPageTestSuite) press(key string) tea.Cmd {
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+
+	updated, cmd := s.model.Update(msg)
+	s.model = updated.(Model)
+	return cmd
+}
+
+func tokenABI() models.EvmAbi {
+	elements, _ := abi.ParseHumanReadable([]string{
+		"function balanceOf(address owner) view returns (uint256)",
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+	})
+	return models.EvmAbi{ID: 3, Name: "Token", Abi: models.AbiArrayType{AbiArray: elements}}
+}
+
+func (s *ABIPageTestSuite) TestViewListsABIs() {
+	s.loadABIs(tokenABI())
+
+	output := s.model.View()
+	s.Contains(output, "Token")
+	s.Contains(output, "Functions: 2 • Events: 1")
+	s.Contains(output, "Page 1 of 1 • Showing 1 of 1 ABIs")
+}
+
+func (s *ABIPageTestSuite) TestViewEmpty() {
+	s.loadABIs()
+
+	s.Contains(s.model.View(), "No ABIs found")
+}
+
+func (s *ABIPageTestSuite) TestAddAndEditNavigate() {
+	s.loadABIs(tokenABI())
+	s.mockRouter.EXPECT().NavigateTo("/evm/abi/edit", gomock.Nil()).Return(nil)
+	s.mockRouter.EXPECT().NavigateTo("/evm/abi/edit", map[string]string{"id": "3"}).Return(nil).Times(2)
+
+	s.press("a")
+	s.press("e")
+	s.press("enter")
+}
+
+func (s *ABIPageTestSuite) TestDeleteRequiresConfirmation() {
+	s.loadABIs(tokenABI())
+
+	s.press("d")
+	s.Contains(s.model.View(), "Delete ABI Token?")
+	s.press("n")
+	s.False(s.model.confirmDelete)
+
+	s.press("d")
+	cmd := s.press("y")
+	s.Require().NotNil(cmd)
+
+	s.mockStorage.EXPECT().DeleteABI(uint(3)).Return(nil)
+	s.mockStorage.EXPECT().ListABIs(int64(1), int64(pageSize)).Return(types.Pagination[models.EvmAbi]{}, nil)
+
+	updated, reload := s.model.Update(cmd())
+	s.model = updated.(Model)
+	s.Require().NotNil(reload)
+	updated, _ = s.model.Update(reload())
+	s.model = updated.(Model)
+	s.Empty(s.model.abis)
+}
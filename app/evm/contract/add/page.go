@@ -0,0 +1,623 @@
+package add
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/contract/add.log")
+
+// maxOptions is the number of endpoints and ABIs loaded for selection.
+const maxOptions = 100
+
+type addStep int
+
+const (
+	stepLoading addStep = iota
+	stepEnterName
+	stepSelectEndpoint
+	stepSelectABI
+	stepSelectSource
+	stepEnterAddress
+	stepEnterBytecode
+	stepEnterSourcePath
+	stepConfirm
+	stepSuccess
+	stepError
+)
+
+type contractSource int
+
+const (
+	sourceDeployed contractSource = iota
+	sourceBytecode
+	sourceSolidity
+)
+
+type sourceOption struct {
+	label       string
+	description string
+	source      contractSource
+}
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+
+	currentStep   addStep
+	selectedIndex int
+
+	endpoints     []models.EVMEndpoint
+	abis          []models.EvmAbi
+	sourceOptions []sourceOption
+
+	nameInput     textinput.Model
+	addressInput  textinput.Model
+	bytecodeInput textinput.Model
+	sourceInput   textinput.Model
+
+	endpoint *models.EVMEndpoint
+	abi      *models.EvmAbi
+	source   contractSource
+	bytecode string
+	code     string
+
+	createdID uint
+
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithStorage(router, sharedMemory, nil)
+}
+
+// NewPageWithStorage creates a new add contract page with an optional storage client (for testing).
+func NewPageWithStorage(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage) view.View {
+	nameInput := textinput.New()
+	nameInput.Placeholder = "Enter contract name"
+	nameInput.Width = 40
+
+	addressInput := textinput.New()
+	addressInput.Placeholder = "0x..."
+	addressInput.Width = 44
+
+	bytecodeInput := textinput.New()
+	bytecodeInput.Placeholder = "0x6080... or path to a file containing the bytecode"
+	bytecodeInput.Width = 66
+
+	sourceInput := textinput.New()
+	sourceInput.Placeholder = "path/to/Contract.sol"
+	sourceInput.Width = 66
+
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		currentStep:   stepLoading,
+		nameInput:     nameInput,
+		addressInput:  addressInput,
+		bytecodeInput: bytecodeInput,
+		sourceInput:   sourceInput,
+		sourceOptions: []sourceOption{
+			{label: "Existing deployment", description: "Track a contract that is already deployed at an address", source: sourceDeployed},
+			{label: "Deploy from bytecode", description: "Store creation bytecode and deploy it later", source: sourceBytecode},
+			{label: "Compile from Solidity source", description: "Store a Solidity file, compile it, then deploy it later", source: sourceSolidity},
+		},
+	}
+}
+
+type optionsLoadedMsg struct {
+	storageClient sql.Storage
+	endpoints     []models.EVMEndpoint
+	abis          []models.EvmAbi
+	err           error
+}
+
+type contractCreatedMsg struct {
+	id  uint
+	err error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadOptions
+}
+
+func (m Model) loadOptions() tea.Msg {
+	storageClient := m.storageClient
+	if storageClient == nil {
+		sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get storage client from shared memory: %v", err)
+			return optionsLoadedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+		}
+		storageClient = sqlStorage
+	}
+
+	endpoints, err := storageClient.ListEndpoints(1, maxOptions)
+	if err != nil {
+		logger.Error("Failed to list endpoints: %v", err)
+		return optionsLoadedMsg{err: fmt.Errorf("failed to list endpoints: %w", err)}
+	}
+
+	abis, err := storageClient.ListABIs(1, maxOptions)
+	if err != nil {
+		logger.Error("Failed to list ABIs: %v", err)
+		return optionsLoadedMsg{err: fmt.Errorf("failed to list ABIs: %w", err)}
+	}
+
+	return optionsLoadedMsg{storageClient: storageClient, endpoints: endpoints.Items, abis: abis.Items}
+}
+
+func (m Model) createContract() tea.Msg {
+	contract := models.EVMContract{
+		Name:       strings.TrimSpace(m.nameInput.Value()),
+		EndpointId: m.endpoint.ID,
+	}
+	// The ABI of a Solidity contract is produced when it is compiled
+	if m.abi != nil && m.source != sourceSolidity {
+		contract.AbiId = &m.abi.ID
+	}
+
+	switch m.source {
+	case sourceDeployed:
+		contract.Address = common.HexToAddress(strings.TrimSpace(m.addressInput.Value())).Hex()
+		contract.Status = models.DeploymentStatusDeployed
+	case sourceBytecode:
+		bytecode := m.bytecode
+		contract.Bytecode = &bytecode
+		contract.Status = models.DeploymentStatusPending
+	case sourceSolidity:
+		code := m.code
+		contract.ContractCode = &code
+		contract.Status = models.DeploymentStatusPending
+	}
+
+	id, err := m.storageClient.CreateContract(contract)
+	if err != nil {
+		logger.Error("Failed to create contract: %v", err)
+		return contractCreatedMsg{err: fmt.Errorf("failed to create contract: %w", err)}
+	}
+	return contractCreatedMsg{id: id}
+}
+
+// compileParams returns the query parameters that pre-fill the compile page for the source file.
+func (m Model) compileParams() map[string]string {
+	path, err := filepath.Abs(strings.TrimSpace(m.sourceInput.Value()))
+	if err != nil {
+		path = strings.TrimSpace(m.sourceInput.Value())
+	}
+	return map[string]string{
+		"id":   strconv.FormatUint(uint64(m.createdID), 10),
+		"dir":  filepath.Dir(path),
+		"file": filepath.Base(path),
+	}
+}
+
+// parseBytecode accepts either hex encoded bytecode or a path to a file containing it,
+// and returns the normalized 0x-prefixed hex string.
+func parseBytecode(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("bytecode cannot be empty")
+	}
+
+	if !strings.HasPrefix(input, "0x") {
+		if content, err := os.ReadFile(input); err == nil {
+			input = strings.TrimSpace(string(content))
+		}
+	}
+
+	hexCode := strings.TrimPrefix(input, "0x")
+	if hexCode == "" || len(hexCode)%2 != 0 || !isHex(hexCode) {
+		return "", fmt.Errorf("bytecode must be a non-empty hex string or a path to a file containing one")
+	}
+	return "0x" + strings.ToLower(hexCode), nil
+}
+
+func isHex(value string) bool {
+	for _, char := range value {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", char) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case optionsLoadedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		if len(msg.endpoints) == 0 {
+			m.currentStep = stepError
+			m.errorMsg = "no endpoints configured. Please add an endpoint first"
+			return m, nil
+		}
+
+		m.storageClient = msg.storageClient
+		m.endpoints = msg.endpoints
+		m.abis = msg.abis
+		m.currentStep = stepEnterName
+		m.nameInput.Focus()
+		return m, textinput.Blink
+
+	case contractCreatedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.createdID = msg.id
+		m.currentStep = stepSuccess
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepEnterName:
+			return m.handleEnterName(msg)
+		case stepSelectEndpoint:
+			return m.handleSelectEndpoint(msg)
+		case stepSelectABI:
+			return m.handleSelectABI(msg)
+		case stepSelectSource:
+			return m.handleSelectSource(msg)
+		case stepEnterAddress:
+			return m.handleEnterAddress(msg)
+		case stepEnterBytecode:
+			return m.handleEnterBytecode(msg)
+		case stepEnterSourcePath:
+			return m.handleEnterSourcePath(msg)
+		case stepConfirm:
+			return m.handleConfirm(msg)
+		case stepSuccess:
+			if m.source == sourceSolidity {
+				// Any key continues to compiling the stored source
+				return m, func() tea.Msg {
+					_ = m.router.NavigateTo("/evm/contract/compile", m.compileParams())
+					return nil
+				}
+			}
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/contract", nil)
+				return nil
+			}
+		case stepError:
+			// Any key returns to contract list
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/contract", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// moveCursor handles up/down navigation in a list with the given number of options.
+func (m Model) moveCursor(key string, count int) Model {
+	switch key {
+	case "up", "k":
+		if m.selectedIndex > 0 {
+			m.selectedIndex--
+		}
+	case "down", "j":
+		if m.selectedIndex < count-1 {
+			m.selectedIndex++
+		}
+	}
+	return m
+}
+
+func (m Model) handleEnterName(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "enter" {
+		if strings.TrimSpace(m.nameInput.Value()) == "" {
+			m.errorMsg = "contract name cannot be empty"
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.nameInput.Blur()
+		m.currentStep = stepSelectEndpoint
+		m.selectedIndex = 0
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleSelectEndpoint(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "enter" {
+		endpoint := m.endpoints[m.selectedIndex]
+		m.endpoint = &endpoint
+		m.currentStep = stepSelectABI
+		m.selectedIndex = 0
+		return m, nil
+	}
+	return m.moveCursor(msg.String(), len(m.endpoints)), nil
+}
+
+func (m Model) handleSelectABI(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The last option is "No ABI"
+	if msg.String() == "enter" {
+		m.abi = nil
+		if m.selectedIndex < len(m.abis) {
+			abi := m.abis[m.selectedIndex]
+			m.abi = &abi
+		}
+		m.currentStep = stepSelectSource
+		m.selectedIndex = 0
+		return m, nil
+	}
+	return m.moveCursor(msg.String(), len(m.abis)+1), nil
+}
+
+func (m Model) handleSelectSource(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "enter" {
+		m.source = m.sourceOptions[m.selectedIndex].source
+		m.selectedIndex = 0
+		switch m.source {
+		case sourceDeployed:
+			m.currentStep = stepEnterAddress
+			m.addressInput.Focus()
+		case sourceBytecode:
+			m.currentStep = stepEnterBytecode
+			m.bytecodeInput.Focus()
+		case sourceSolidity:
+			m.currentStep = stepEnterSourcePath
+			m.sourceInput.Focus()
+		}
+		return m, textinput.Blink
+	}
+	return m.moveCursor(msg.String(), len(m.sourceOptions)), nil
+}
+
+func (m Model) handleEnterAddress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "enter" {
+		if !common.IsHexAddress(strings.TrimSpace(m.addressInput.Value())) {
+			m.errorMsg = "invalid contract address"
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.addressInput.Blur()
+		m.currentStep = stepConfirm
+		m.selectedIndex = 0
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.addressInput, cmd = m.addressInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleEnterBytecode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "enter" {
+		bytecode, err := parseBytecode(m.bytecodeInput.Value())
+		if err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.bytecode = bytecode
+		m.bytecodeInput.Blur()
+		m.currentStep = stepConfirm
+		m.selectedIndex = 0
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.bytecodeInput, cmd = m.bytecodeInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleEnterSourcePath(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "enter" {
+		content, err := os.ReadFile(strings.TrimSpace(m.sourceInput.Value()))
+		if err != nil {
+			m.errorMsg = fmt.Sprintf("failed to read source file: %v", err)
+			return m, nil
+		}
+		if strings.TrimSpace(string(content)) == "" {
+			m.errorMsg = "source file is empty"
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.code = string(content)
+		m.sourceInput.Blur()
+		m.currentStep = stepConfirm
+		m.selectedIndex = 0
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.sourceInput, cmd = m.sourceInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "enter" {
+		if m.selectedIndex == 0 {
+			return m, m.createContract
+		}
+		return m, func() tea.Msg {
+			_ = m.router.NavigateTo("/evm/contract", nil)
+			return nil
+		}
+	}
+	return m.moveCursor(msg.String(), 2), nil
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepEnterName, stepEnterAddress, stepEnterBytecode, stepEnterSourcePath:
+		return "enter: continue • esc: cancel", view.HelpDisplayOptionOverride
+	case stepSelectEndpoint, stepSelectABI, stepSelectSource, stepConfirm:
+		return "↑/k: up • ↓/j: down • enter: select • esc: cancel", view.HelpDisplayOptionOverride
+	case stepSuccess:
+		if m.source == sourceSolidity {
+			return "Press any key to compile the contract", view.HelpDisplayOptionOverride
+		}
+		return "Press any key to return to contract list", view.HelpDisplayOptionOverride
+	case stepError:
+		return "Press any key to return to contract list", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	switch m.currentStep {
+	case stepEnterName:
+		return m.renderInput("Step 1: Enter a name for the contract", m.nameInput)
+	case stepSelectEndpoint:
+		options := make([]string, 0, len(m.endpoints))
+		for _, endpoint := range m.endpoints {
+			options = append(options, fmt.Sprintf("%s (%s)", endpoint.Name, endpoint.Url))
+		}
+		return m.renderOptions("Step 2: Select the network endpoint", options)
+	case stepSelectABI:
+		options := make([]string, 0, len(m.abis)+1)
+		for _, abi := range m.abis {
+			options = append(options, abi.Name)
+		}
+		options = append(options, "No ABI")
+		return m.renderOptions("Step 3: Select the contract ABI", options)
+	case stepSelectSource:
+		options := make([]string, 0, len(m.sourceOptions))
+		for _, option := range m.sourceOptions {
+			options = append(options, option.label+" - "+option.description)
+		}
+		return m.renderOptions("Step 4: How is this contract deployed?", options)
+	case stepEnterAddress:
+		return m.renderInput("Step 5: Enter the deployed contract address", m.addressInput)
+	case stepEnterBytecode:
+		return m.renderInput("Step 5: Enter the contract creation bytecode", m.bytecodeInput)
+	case stepEnterSourcePath:
+		return m.renderInput("Step 5: Enter the path to the Solidity source file", m.sourceInput)
+	case stepConfirm:
+		return m.renderConfirm()
+	case stepSuccess:
+		return component.VStackC(
+			component.T("Add Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("✓ Contract added successfully").Bold(true),
+		).Render()
+	case stepError:
+		return component.VStackC(
+			component.T("Add Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			component.T("Add Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Loading...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) renderError() component.Component {
+	if m.errorMsg == "" {
+		return component.Empty()
+	}
+	return component.T("Error: " + m.errorMsg).Error()
+}
+
+func (m Model) renderInput(title string, input textinput.Model) string {
+	return component.VStackC(
+		component.T("Add Contract").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T(title).Bold(true),
+		component.SpacerV(1),
+		component.T(input.View()),
+		component.SpacerV(1),
+		m.renderError(),
+	).Render()
+}
+
+func (m Model) renderOptions(title string, options []string) string {
+	items := make([]component.Component, 0, len(options))
+	for index, option := range options {
+		if index == m.selectedIndex {
+			items = append(items, component.T("> "+option).Bold(true))
+		} else {
+			items = append(items, component.T("  "+option))
+		}
+	}
+
+	return component.VStackC(
+		component.T("Add Contract").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T(title).Bold(true),
+		component.SpacerV(1),
+		component.VStackC(items...),
+	).Render()
+}
+
+func (m Model) renderConfirm() string {
+	abiName := "none"
+	if m.source == sourceSolidity {
+		abiName = "generated by the compiler"
+	} else if m.abi != nil {
+		abiName = m.abi.Name
+	}
+
+	details := []component.Component{
+		component.T("Name: " + strings.TrimSpace(m.nameInput.Value())),
+		component.T(fmt.Sprintf("Network: %s (%s)", m.endpoint.Name, m.endpoint.Url)),
+		component.T("ABI: " + abiName),
+	}
+	switch m.source {
+	case sourceDeployed:
+		details = append(details, component.T("Address: "+common.HexToAddress(strings.TrimSpace(m.addressInput.Value())).Hex()))
+	case sourceBytecode:
+		details = append(details,
+			component.T(fmt.Sprintf("Bytecode: %d bytes", len(common.FromHex(m.bytecode)))),
+			component.T("Status: pending deployment").Muted(),
+		)
+	case sourceSolidity:
+		details = append(details,
+			component.T(fmt.Sprintf("Source: %s (%d lines)", strings.TrimSpace(m.sourceInput.Value()), strings.Count(m.code, "\n")+1)),
+			component.T("Status: pending compilation").Muted(),
+		)
+	}
+
+	options := []string{"Save contract", "Cancel"}
+	items := make([]component.Component, 0, len(options))
+	for index, option := range options {
+		if index == m.selectedIndex {
+			items = append(items, component.T("> "+option).Bold(true))
+		} else {
+			items = append(items, component.T("  "+option))
+		}
+	}
+
+	return component.VStackC(
+		component.T("Add Contract").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Step 6: Confirm").Bold(true),
+		component.SpacerV(1),
+		component.VStackC(details...),
+		component.SpacerV(1),
+		component.VStackC(items...),
+	).Render()
+}
@@ -0,0 +1,220 @@
+package add
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AddContractPageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	model       Model
+}
+
+func TestAddContractPageTestSuite(t *testing.T) {
+	suite.Run(t, new(AddContractPageTestSuite))
+}
+
+func (s *AddContractPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.model = NewPageWithStorage(s.mockRouter, storage.NewSharedMemory(), s.mockStorage).(Model)
+}
+
+func (s *AddContractPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *AddContractPageTestSuite) loadOptions(endpoints []models.EVMEndpoint, abis []models.EvmAbi) {
+	s.mockStorage.EXPECT().ListEndpoints(int64(1), int64(maxOptions)).Return(types.Pagination[models.EVMEndpoint]{Items: endpoints}, nil)
+	s.mockStorage.EXPECT().ListABIs(int64(1), int64(maxOptions)).Return(types.Pagination[models.EvmAbi]{Items: abis}, nil)
+
+	updated, _ := s.model.Update(s.model.loadOptions())
+	s.model = updated.(Model)
+}
+
+func (s *AddContractPageTestSuite) press(msg tea.KeyMsg) tea.Cmd {
+	updated, cmd := s.model.Update(msg)
+	s.model = updated.(Model)
+	return cmd
+}
+
+func (s *AddContractPageTestSuite) typeText(text string) {
+	s.press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+}
+
+func (s *AddContractPageTestSuite) enter() tea.Cmd {
+	return s.press(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func (s *AddContractPageTestSuite) down() {
+	s.press(tea.KeyMsg{Type: tea.KeyDown})
+}
+
+func (s *AddContractPageTestSuite) TestNoEndpoints() {
+	s.loadOptions(nil, nil)
+
+	s.Equal(stepError, s.model.currentStep)
+	s.Contains(s.model.View(), "no endpoints configured")
+}
+
+func (s *AddContractPageTestSuite) TestAddPendingContractFromBytecode() {
+	s.loadOptions(
+		[]models.EVMEndpoint{{ID: 1, Name: "Mainnet", Url: "https://mainnet"}, {ID: 7, Name: "Anvil", Url: "http://localhost:8545"}},
+		[]models.EvmAbi{{ID: 3, Name: "TokenABI"}},
+	)
+	s.Equal(stepEnterName, s.model.currentStep)
+
+	s.enter()
+	s.Contains(s.model.errorMsg, "name cannot be empty")
+
+	s.typeText("Token")
+	s.enter()
+	s.Equal(stepSelectEndpoint, s.model.currentStep)
+
+	s.down()
+	s.enter()
+	s.Equal(stepSelectABI, s.model.currentStep)
+	s.Contains(s.model.View(), "No ABI")
+
+	s.enter()
+	s.Equal(stepSelectSource, s.model.currentStep)
+
+	s.down()
+	s.enter()
+	s.Equal(stepEnterBytecode, s.model.currentStep)
+
+	s.typeText("0xzz")
+	s.enter()
+	s.Contains(s.model.errorMsg, "hex")
+
+	s.model.bytecodeInput.SetValue("0x6080AB")
+	s.enter()
+	s.Equal(stepConfirm, s.model.currentStep)
+	s.Contains(s.model.View(), "Bytecode: 3 bytes")
+
+	cmd := s.enter()
+	s.Require().NotNil(cmd)
+
+	s.mockStorage.EXPECT().CreateContract(gomock.Any()).DoAndReturn(func(contract models.EVMContract) (uint, error) {
+		s.Equal("Token", contract.Name)
+		s.Equal(uint(7), contract.EndpointId)
+		s.Require().NotNil(contract.AbiId)
+		s.Equal(uint(3), *contract.AbiId)
+		s.Equal(models.DeploymentStatusPending, contract.Status)
+		s.Require().NotNil(contract.Bytecode)
+		s.Equal("0x6080ab", *contract.Bytecode)
+		s.True(contract.IsDeployable())
+		return 1, nil
+	})
+
+	updated, _ := s.model.Update(cmd())
+	s.model = updated.(Model)
+	s.Equal(stepSuccess, s.model.currentStep)
+}
+
+func (s *AddContractPageTestSuite) TestAddDeployedContractWithoutABI() {
+	s.loadOptions([]models.EVMEndpoint{{ID: 1, Name: "Mainnet"}}, []models.EvmAbi{{ID: 3, Name: "TokenABI"}})
+
+	s.typeText("Vault")
+	s.enter()
+	s.enter()
+	s.down()
+	s.enter()
+	s.Nil(s.model.abi)
+
+	s.enter()
+	s.Equal(stepEnterAddress, s.model.currentStep)
+
+	s.typeText("0x1234")
+	s.enter()
+	s.Equal("invalid contract address", s.model.errorMsg)
+
+	s.model.addressInput.SetValue("0x5fbdb2315678afecb367f032d93f642f64180aa3")
+	s.enter()
+	s.Equal(stepConfirm, s.model.currentStep)
+
+	s.mockStorage.EXPECT().CreateContract(gomock.Any()).DoAndReturn(func(contract models.EVMContract) (uint, error) {
+		s.Nil(contract.AbiId)
+		s.Nil(contract.Bytecode)
+		s.Equal("0x5FbDB2315678afecb367f032d93F642f64180aa3", contract.Address)
+		s.Equal(models.DeploymentStatusDeployed, contract.Status)
+		return 2, nil
+	})
+
+	cmd := s.enter()
+	updated, _ := s.model.Update(cmd())
+	s.model = updated.(Model)
+	s.Equal(stepSuccess, s.model.currentStep)
+}
+
+func (s *AddContractPageTestSuite) TestAddContractFromSoliditySource() {
+	dir := s.T().TempDir()
+	path := filepath.Join(dir, "Counter.sol")
+	s.Require().NoError(os.WriteFile(path, []byte("pragma solidity ^0.8.0;\ncontract Counter {}\n"), 0o600))
+
+	s.loadOptions([]models.EVMEndpoint{{ID: 1, Name: "Mainnet"}}, []models.EvmAbi{{ID: 3, Name: "TokenABI"}})
+	s.typeText("Counter")
+	s.enter()
+	s.enter()
+	s.enter()
+	s.down()
+	s.down()
+	s.enter()
+	s.Equal(stepEnterSourcePath, s.model.currentStep)
+
+	s.typeText(filepath.Join(dir, "Missing.sol"))
+	s.enter()
+	s.Contains(s.model.errorMsg, "failed to read source file")
+
+	s.model.sourceInput.SetValue(path)
+	s.enter()
+	s.Equal(stepConfirm, s.model.currentStep)
+	s.Contains(s.model.View(), "generated by the compiler")
+
+	s.mockStorage.EXPECT().CreateContract(gomock.Any()).DoAndReturn(func(contract models.EVMContract) (uint, error) {
+		s.Nil(contract.AbiId)
+		s.Require().NotNil(contract.ContractCode)
+		s.Contains(*contract.ContractCode, "contract Counter")
+		s.Equal(models.DeploymentStatusPending, contract.Status)
+		return 11, nil
+	})
+
+	cmd := s.enter()
+	updated, _ := s.model.Update(cmd())
+	s.model = updated.(Model)
+	s.Equal(stepSuccess, s.model.currentStep)
+
+	s.mockRouter.EXPECT().NavigateTo("/evm/contract/compile", map[string]string{"id": "11", "dir": dir, "file": "Counter.sol"}).Return(nil)
+	cmd = s.enter()
+	s.Require().NotNil(cmd)
+	cmd()
+}
+
+func (s *AddContractPageTestSuite) TestParseBytecodeFromFile() {
+	path := filepath.Join(s.T().TempDir(), "Token.bin")
+	s.Require().NoError(os.WriteFile(path, []byte("6080604052\n"), 0o600))
+
+	bytecode, err := parseBytecode(path)
+	s.NoError(err)
+	s.Equal("0x6080604052", bytecode)
+
+	_, err = parseBytecode("0x608")
+	s.Error(err)
+
+	_, err = parseBytecode("")
+	s.Error(err)
+}
@@ -0,0 +1,314 @@
+package artifacts
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/artifact"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/contract/artifacts.log")
+
+// maxEndpoints is the number of endpoints offered for pending contracts.
+const maxEndpoints = 100
+
+type importStep int
+
+const (
+	stepEnterDir importStep = iota
+	stepScanning
+	stepSelectEndpoint
+	stepImporting
+	stepResult
+	stepError
+)
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+
+	currentStep   importStep
+	selectedIndex int
+	dirInput      textinput.Model
+
+	project   *artifact.Project
+	endpoints []models.EVMEndpoint
+	result    *artifact.ImportResult
+
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithStorage(router, sharedMemory, nil)
+}
+
+// NewPageWithStorage creates a new artifact import page with an optional storage client (for testing).
+func NewPageWithStorage(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage) view.View {
+	dirInput := textinput.New()
+	dirInput.Placeholder = "Foundry or Hardhat project directory"
+	dirInput.Width = 66
+	if cwd, err := os.Getwd(); err == nil {
+		dirInput.SetValue(cwd)
+	}
+	dirInput.Focus()
+
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		currentStep:   stepEnterDir,
+		dirInput:      dirInput,
+	}
+}
+
+type projectScannedMsg struct {
+	storageClient sql.Storage
+	project       *artifact.Project
+	endpoints     []models.EVMEndpoint
+	err           error
+}
+
+type projectImportedMsg struct {
+	result *artifact.ImportResult
+	err    error
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) scanProject() tea.Msg {
+	project, err := artifact.ScanProject(strings.TrimSpace(m.dirInput.Value()), artifact.ScanOptions{})
+	if err != nil {
+		logger.Error("Failed to scan project: %v", err)
+		return projectScannedMsg{err: err}
+	}
+
+	storageClient := m.storageClient
+	if storageClient == nil {
+		sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get storage client from shared memory: %v", err)
+			return projectScannedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+		}
+		storageClient = sqlStorage
+	}
+
+	endpoints, err := storageClient.ListEndpoints(1, maxEndpoints)
+	if err != nil {
+		logger.Error("Failed to list endpoints: %v", err)
+		return projectScannedMsg{err: fmt.Errorf("failed to list endpoints: %w", err)}
+	}
+
+	return projectScannedMsg{storageClient: storageClient, project: project, endpoints: endpoints.Items}
+}
+
+func (m Model) importProject(endpointID uint) tea.Cmd {
+	return func() tea.Msg {
+		result, err := artifact.Import(m.storageClient, m.project, endpointID)
+		if err != nil {
+			logger.Error("Failed to import project: %v", err)
+			return projectImportedMsg{err: err}
+		}
+		return projectImportedMsg{result: result}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case projectScannedMsg:
+		if msg.err != nil {
+			m.currentStep = stepEnterDir
+			m.errorMsg = msg.err.Error()
+			return m, textinput.Blink
+		}
+		if len(msg.project.Artifacts) == 0 && len(msg.project.Deployments) == 0 {
+			m.currentStep = stepEnterDir
+			m.errorMsg = "no artifacts found. Build the project first (forge build / npx hardhat compile)"
+			return m, textinput.Blink
+		}
+
+		m.errorMsg = ""
+		m.storageClient = msg.storageClient
+		m.project = msg.project
+		m.endpoints = msg.endpoints
+		m.selectedIndex = 0
+		m.currentStep = stepSelectEndpoint
+		return m, nil
+
+	case projectImportedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.result = msg.result
+		m.currentStep = stepResult
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepEnterDir:
+			if msg.String() == "enter" {
+				m.currentStep = stepScanning
+				return m, m.scanProject
+			}
+			var cmd tea.Cmd
+			m.dirInput, cmd = m.dirInput.Update(msg)
+			return m, cmd
+
+		case stepSelectEndpoint:
+			return m.handleSelectEndpoint(msg)
+
+		case stepResult, stepError:
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/contract", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleSelectEndpoint(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The last option skips adding pending contracts
+	switch msg.String() {
+	case "up", "k":
+		if m.selectedIndex > 0 {
+			m.selectedIndex--
+		}
+	case "down", "j":
+		if m.selectedIndex < len(m.endpoints) {
+			m.selectedIndex++
+		}
+	case "enter":
+		var endpointID uint
+		if m.selectedIndex < len(m.endpoints) {
+			endpointID = m.endpoints[m.selectedIndex].ID
+		}
+		m.currentStep = stepImporting
+		return m, m.importProject(endpointID)
+	}
+	return m, nil
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepEnterDir:
+		return "enter: scan project • esc: cancel", view.HelpDisplayOptionOverride
+	case stepSelectEndpoint:
+		return "↑/k: up • ↓/j: down • enter: import • esc: cancel", view.HelpDisplayOptionOverride
+	case stepResult, stepError:
+		return "Press any key to return to contract list", view.HelpDisplayOptionOverride
+	default:
+		return "Working...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	title := component.T("Import Build Artifacts").Bold(true).Primary()
+
+	switch m.currentStep {
+	case stepEnterDir:
+		errorLine := component.Empty()
+		if m.errorMsg != "" {
+			errorLine = component.T("Error: " + m.errorMsg).Error()
+		}
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Enter the project directory").Bold(true),
+			component.T("Reads Foundry out/ and broadcast/, Hardhat artifacts/, hardhat-deploy deployments/ and Ignition deployments").Muted(),
+			component.SpacerV(1),
+			component.T(m.dirInput.View()),
+			component.SpacerV(1),
+			errorLine,
+		).Render()
+	case stepScanning:
+		return component.VStackC(title, component.SpacerV(1), component.T("Scanning project...").Muted()).Render()
+	case stepSelectEndpoint:
+		return m.renderSelectEndpoint()
+	case stepImporting:
+		return component.VStackC(title, component.SpacerV(1), component.T("Importing...").Muted()).Render()
+	case stepResult:
+		return m.renderResult()
+	default:
+		return component.VStackC(title, component.SpacerV(1), component.T("Error: "+m.errorMsg).Error()).Render()
+	}
+}
+
+func (m Model) renderSelectEndpoint() string {
+	found := []component.Component{}
+	for _, item := range m.project.Artifacts {
+		found = append(found, component.T(fmt.Sprintf("  %s (%s, %s)", item.Name, item.SourceName, item.Format)))
+	}
+	for _, deployment := range m.project.Deployments {
+		found = append(found, component.T(fmt.Sprintf("  %s deployed at %s on chain %d", deployment.ContractName, deployment.Address, deployment.ChainID)))
+	}
+	for _, collision := range m.project.Collisions {
+		found = append(found, component.T(fmt.Sprintf("  ⚠ %s, they are imported under their qualified names", collision)).Warning())
+	}
+
+	options := []component.Component{}
+	labels := make([]string, 0, len(m.endpoints)+1)
+	for _, endpoint := range m.endpoints {
+		labels = append(labels, fmt.Sprintf("%s (chain %s)", endpoint.Name, endpoint.ChainId))
+	}
+	labels = append(labels, "Only import ABIs and deployments")
+	for index, label := range labels {
+		if index == m.selectedIndex {
+			options = append(options, component.T("> "+label).Bold(true))
+		} else {
+			options = append(options, component.T("  "+label))
+		}
+	}
+
+	return component.VStackC(
+		component.T("Import Build Artifacts").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T(fmt.Sprintf("Found %d artifacts and %d deployments", len(m.project.Artifacts), len(m.project.Deployments))).Bold(true),
+		component.VStackC(found...),
+		component.SpacerV(1),
+		component.T("Add deployable artifacts as pending contracts on").Bold(true),
+		component.T("Deployments are registered on the endpoint matching their chain ID").Muted(),
+		component.SpacerV(1),
+		component.VStackC(options...),
+	).Render()
+}
+
+func (m Model) renderResult() string {
+	contracts := []component.Component{}
+	for _, contract := range m.result.Contracts {
+		label := fmt.Sprintf("  ✓ %s (%s)", contract.Name, contract.Status)
+		if contract.Address != "" {
+			label = fmt.Sprintf("  ✓ %s at %s", contract.Name, contract.Address)
+		}
+		contracts = append(contracts, component.T(label))
+	}
+
+	skipped := []component.Component{}
+	for _, reason := range m.result.Skipped {
+		skipped = append(skipped, component.T("  ⚠ "+reason).Warning())
+	}
+
+	return component.VStackC(
+		component.T("Import Build Artifacts").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T(fmt.Sprintf("Imported %d ABIs and %d contracts", len(m.result.ABIs), len(m.result.Contracts))).Bold(true),
+		component.VStackC(contracts...),
+		component.IfC(len(skipped) > 0, component.T(fmt.Sprintf("Skipped %d", len(skipped))).Bold(true), component.Empty()),
+		component.VStackC(skipped...),
+	).Render()
+}
@@ -0,0 +1,127 @@
+package artifacts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const counterArtifact = `{
+  "abi": [{"type": "function", "name": "increment", "inputs": [], "outputs": [], "stateMutability": "nonpayable"}],
+  "bytecode": {"object": "0x6080604052"},
+  "ast": {"absolutePath": "src/Counter.sol"}
+}`
+
+type ArtifactsPageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	model       Model
+	projectDir  string
+}
+
+func TestArtifactsPageTestSuite(t *testing.T) {
+	suite.Run(t, new(ArtifactsPageTestSuite))
+}
+
+func (s *ArtifactsPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.model = NewPageWithStorage(s.mockRouter, storage.NewSharedMemory(), s.mockStorage).(Model)
+
+	s.projectDir = s.T().TempDir()
+	path := filepath.Join(s.projectDir, "out", "Counter.sol", "Counter.json")
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	s.Require().NoError(os.WriteFile(path, []byte(counterArtifact), 0o600))
+}
+
+func (s *ArtifactsPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *ArtifactsPageTestSuite) press(msg tea.KeyMsg) tea.Cmd {
+	updated, cmd := s.model.Update(msg)
+	s.model = updated.(Model)
+	return cmd
+}
+
+func (s *ArtifactsPageTestSuite) run(cmd tea.Cmd) {
+	s.Require().NotNil(cmd)
+	updated, _ := s.model.Update(cmd())
+	s.model = updated.(Model)
+}
+
+func (s *ArtifactsPageTestSuite) TestEmptyProject() {
+	s.model.dirInput.SetValue(s.T().TempDir())
+	s.mockStorage.EXPECT().ListEndpoints(int64(1), int64(maxEndpoints)).Return(types.Pagination[models.EVMEndpoint]{}, nil)
+
+	s.run(s.press(tea.KeyMsg{Type: tea.KeyEnter}))
+	s.Equal(stepEnterDir, s.model.currentStep)
+	s.Contains(s.model.View(), "no artifacts found")
+}
+
+func (s *ArtifactsPageTestSuite) TestImportAsPendingContracts() {
+	s.model.dirInput.SetValue(s.projectDir)
+	endpoints := []models.EVMEndpoint{{ID: 4, Name: "Anvil", ChainId: "31337"}}
+	s.mockStorage.EXPECT().ListEndpoints(gomock.Any(), gomock.Any()).Return(types.Pagination[models.EVMEndpoint]{Items: endpoints}, nil).Times(2)
+
+	s.run(s.press(tea.KeyMsg{Type: tea.KeyEnter}))
+	s.Equal(stepSelectEndpoint, s.model.currentStep)
+	output := s.model.View()
+	s.Contains(output, "Found 1 artifacts and 0 deployments")
+	s.Contains(output, "Counter (src/Counter.sol, foundry)")
+	s.Contains(output, "Anvil (chain 31337)")
+
+	s.mockStorage.EXPECT().SearchABIs("Counter").Return(types.Pagination[models.EvmAbi]{}, nil)
+	s.mockStorage.EXPECT().CreateABI(gomock.Any()).Return(uint(2), nil)
+	s.mockStorage.EXPECT().CreateContract(gomock.Any()).DoAndReturn(func(contract models.EVMContract) (uint, error) {
+		s.Equal(uint(4), contract.EndpointId)
+		s.Equal(models.DeploymentStatusPending, contract.Status)
+		s.Require().NotNil(contract.AbiId)
+		s.Equal(uint(2), *contract.AbiId)
+		return 9, nil
+	})
+
+	s.run(s.press(tea.KeyMsg{Type: tea.KeyEnter}))
+	s.Equal(stepResult, s.model.currentStep)
+	s.Contains(s.model.View(), "Imported 1 ABIs and 1 contracts")
+}
+
+func (s *ArtifactsPageTestSuite) TestImportOnlyABIs() {
+	s.model.dirInput.SetValue(s.projectDir)
+	s.mockStorage.EXPECT().ListEndpoints(gomock.Any(), gomock.Any()).Return(types.Pagination[models.EVMEndpoint]{Items: []models.EVMEndpoint{{ID: 4, Name: "Anvil"}}}, nil).Times(2)
+	s.run(s.press(tea.KeyMsg{Type: tea.KeyEnter}))
+
+	s.press(tea.KeyMsg{Type: tea.KeyDown})
+	s.mockStorage.EXPECT().SearchABIs("Counter").Return(types.Pagination[models.EvmAbi]{}, nil)
+	s.mockStorage.EXPECT().CreateABI(gomock.Any()).Return(uint(2), nil)
+
+	s.run(s.press(tea.KeyMsg{Type: tea.KeyEnter}))
+	s.Contains(s.model.View(), "Imported 1 ABIs and 0 contracts")
+}
+
+// TestCollisionsAreShown tests that contracts with the same name in different files are pointed out before importing.
+func (s *ArtifactsPageTestSuite) TestCollisionsAreShown() {
+	path := filepath.Join(s.projectDir, "out", "v2", "Counter.sol", "Counter.json")
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	s.Require().NoError(os.WriteFile(path, []byte(strings.Replace(counterArtifact, "src/Counter.sol", "src/v2/Counter.sol", 1)), 0o600))
+	s.model.dirInput.SetValue(s.projectDir)
+	s.mockStorage.EXPECT().ListEndpoints(gomock.Any(), gomock.Any()).Return(types.Pagination[models.EVMEndpoint]{}, nil)
+
+	s.run(s.press(tea.KeyMsg{Type: tea.KeyEnter}))
+	output := s.model.View()
+	s.Contains(output, "Found 2 artifacts")
+	s.Contains(output, "⚠ Counter is declared in src/Counter.sol, src/v2/Counter.sol, they are imported under their qualified names")
+}
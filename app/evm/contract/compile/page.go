@@ -0,0 +1,434 @@
+package compile
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/compiler"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/contract/compile.log")
+
+type compileStep int
+
+const (
+	stepLoading compileStep = iota
+	stepOptions
+	stepCompiling
+	stepResult
+	stepError
+)
+
+// Form fields, in focus order.
+const (
+	fieldVersion = iota
+	fieldOptimizer
+	fieldRuns
+	fieldFileName
+	fieldImportDir
+	fieldContractName
+	fieldCount
+)
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+
+	currentStep compileStep
+	contract    *models.EVMContract
+
+	focusIndex       int
+	optimizerEnabled bool
+	versionInput     textinput.Model
+	runsInput        textinput.Model
+	fileNameInput    textinput.Model
+	importDirInput   textinput.Model
+	contractInput    textinput.Model
+
+	result     *compiler.Result
+	compileErr string
+
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithStorage(router, sharedMemory, nil)
+}
+
+// NewPageWithStorage creates a new compile page with an optional storage client (for testing).
+func NewPageWithStorage(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage) view.View {
+	versionInput := textinput.New()
+	versionInput.Placeholder = compiler.DefaultVersion
+	versionInput.SetValue(compiler.DefaultVersion)
+	versionInput.Width = 20
+
+	runsInput := textinput.New()
+	runsInput.Placeholder = strconv.Itoa(compiler.DefaultOptimizerRuns)
+	runsInput.SetValue(strconv.Itoa(compiler.DefaultOptimizerRuns))
+	runsInput.Width = 20
+
+	fileNameInput := textinput.New()
+	fileNameInput.Placeholder = compiler.DefaultFileName
+	fileNameInput.Width = 40
+
+	importDirInput := textinput.New()
+	importDirInput.Placeholder = "Directory used to resolve imports"
+	importDirInput.Width = 66
+
+	contractInput := textinput.New()
+	contractInput.Placeholder = "Contract to store (optional if the file declares one)"
+	contractInput.Width = 66
+
+	return Model{
+		router:         router,
+		sharedMemory:   sharedMemory,
+		storageClient:  storageClient,
+		currentStep:    stepLoading,
+		versionInput:   versionInput,
+		runsInput:      runsInput,
+		fileNameInput:  fileNameInput,
+		importDirInput: importDirInput,
+		contractInput:  contractInput,
+	}
+}
+
+type contractLoadedMsg struct {
+	storageClient sql.Storage
+	contract      *models.EVMContract
+	err           error
+}
+
+type contractCompiledMsg struct {
+	contract *models.EVMContract
+	result   *compiler.Result
+	err      error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadContract
+}
+
+func (m Model) loadContract() tea.Msg {
+	contractID, err := strconv.ParseUint(m.router.GetQueryParam("id"), 10, 64)
+	if err != nil {
+		return contractLoadedMsg{err: fmt.Errorf("invalid contract ID: %w", err)}
+	}
+
+	storageClient := m.storageClient
+	if storageClient == nil {
+		sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get storage client from shared memory: %v", err)
+			return contractLoadedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+		}
+		storageClient = sqlStorage
+	}
+
+	contract, err := storageClient.GetContractByID(uint(contractID))
+	if err != nil {
+		logger.Error("Failed to load contract %d: %v", contractID, err)
+		return contractLoadedMsg{err: fmt.Errorf("failed to load contract: %w", err)}
+	}
+	if contract.ContractCode == nil {
+		return contractLoadedMsg{err: fmt.Errorf("contract %s has no source code to compile", contract.Name)}
+	}
+
+	return contractLoadedMsg{storageClient: storageClient, contract: &contract}
+}
+
+func (m Model) options() (compiler.Options, error) {
+	options := compiler.Options{
+		Version:          strings.TrimSpace(m.versionInput.Value()),
+		OptimizerEnabled: m.optimizerEnabled,
+		FileName:         strings.TrimSpace(m.fileNameInput.Value()),
+		ImportDir:        strings.TrimSpace(m.importDirInput.Value()),
+	}
+	if m.optimizerEnabled {
+		runs, err := strconv.Atoi(strings.TrimSpace(m.runsInput.Value()))
+		if err != nil || runs <= 0 {
+			return options, fmt.Errorf("optimizer runs must be a positive integer")
+		}
+		options.OptimizerRuns = runs
+	}
+	return options, nil
+}
+
+func (m Model) compile(options compiler.Options, contractName string) tea.Cmd {
+	contract := *m.contract
+	return func() tea.Msg {
+		result, err := compiler.CompileContract(&contract, options, contractName)
+		if err != nil {
+			logger.Error("Failed to compile contract %d: %v", contract.ID, err)
+			return contractCompiledMsg{contract: &contract, result: result, err: err}
+		}
+
+		if err := sql.SaveContractABI(m.storageClient, &contract); err != nil {
+			logger.Error("Failed to save ABI for contract %d: %v", contract.ID, err)
+			return contractCompiledMsg{contract: &contract, result: result, err: fmt.Errorf("failed to save ABI: %w", err)}
+		}
+		if err := m.storageClient.UpdateContract(contract.ID, contract); err != nil {
+			logger.Error("Failed to update contract %d: %v", contract.ID, err)
+			return contractCompiledMsg{contract: &contract, result: result, err: fmt.Errorf("failed to save contract: %w", err)}
+		}
+
+		return contractCompiledMsg{contract: &contract, result: result}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case contractLoadedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.storageClient = msg.storageClient
+		m.contract = msg.contract
+		m.importDirInput.SetValue(m.router.GetQueryParam("dir"))
+		if m.importDirInput.Value() == "" {
+			if cwd, err := os.Getwd(); err == nil {
+				m.importDirInput.SetValue(cwd)
+			}
+		}
+		m.fileNameInput.SetValue(m.router.GetQueryParam("file"))
+		m.currentStep = stepOptions
+		m.focusIndex = fieldVersion
+		return m, m.focusField()
+
+	case contractCompiledMsg:
+		m.result = msg.result
+		m.compileErr = ""
+		if msg.err != nil {
+			m.compileErr = msg.err.Error()
+		} else {
+			m.contract = msg.contract
+		}
+		m.currentStep = stepResult
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepOptions:
+			return m.handleOptions(msg)
+		case stepResult:
+			if msg.String() == "r" {
+				m.currentStep = stepOptions
+				return m, m.focusField()
+			}
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/contract", nil)
+				return nil
+			}
+		case stepError:
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/contract", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleOptions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "down":
+		m.focusIndex = (m.focusIndex + 1) % fieldCount
+		return m, m.focusField()
+	case "shift+tab", "up":
+		m.focusIndex = (m.focusIndex - 1 + fieldCount) % fieldCount
+		return m, m.focusField()
+	case "enter":
+		options, err := m.options()
+		if err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.currentStep = stepCompiling
+		return m, m.compile(options, strings.TrimSpace(m.contractInput.Value()))
+	}
+
+	if m.focusIndex == fieldOptimizer {
+		if msg.String() == " " {
+			m.optimizerEnabled = !m.optimizerEnabled
+		}
+		return m, nil
+	}
+
+	input := m.input(m.focusIndex)
+	updated, cmd := input.Update(msg)
+	*input = updated
+	return m, cmd
+}
+
+// input returns the text input for the field, or nil for the optimizer toggle.
+func (m *Model) input(field int) *textinput.Model {
+	switch field {
+	case fieldVersion:
+		return &m.versionInput
+	case fieldRuns:
+		return &m.runsInput
+	case fieldFileName:
+		return &m.fileNameInput
+	case fieldImportDir:
+		return &m.importDirInput
+	case fieldContractName:
+		return &m.contractInput
+	default:
+		return nil
+	}
+}
+
+// focusField focuses the text input of the current field and blurs the others.
+func (m *Model) focusField() tea.Cmd {
+	for field := 0; field < fieldCount; field++ {
+		if input := m.input(field); input != nil {
+			input.Blur()
+		}
+	}
+	if input := m.input(m.focusIndex); input != nil {
+		input.Focus()
+		return textinput.Blink
+	}
+	return nil
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepOptions:
+		return "tab/↓: next field • shift+tab/↑: previous field • space: toggle optimizer • enter: compile • esc: cancel", view.HelpDisplayOptionOverride
+	case stepCompiling:
+		return "Compiling...", view.HelpDisplayOptionOverride
+	case stepResult:
+		return "r: change options and recompile • any other key: return to contract list", view.HelpDisplayOptionOverride
+	case stepError:
+		return "Press any key to return to contract list", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	switch m.currentStep {
+	case stepOptions:
+		return m.renderOptions()
+	case stepCompiling:
+		return component.VStackC(
+			component.T("Compile Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T(fmt.Sprintf("Compiling %s with solc %s...", m.contract.Name, m.versionInput.Value())).Muted(),
+		).Render()
+	case stepResult:
+		return m.renderResult()
+	case stepError:
+		return component.VStackC(
+			component.T("Compile Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			component.T("Compile Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Loading contract...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) renderField(field int, label string, value string) component.Component {
+	prefix := "  "
+	if field == m.focusIndex {
+		prefix = "> "
+	}
+	return component.VStackC(
+		component.T(prefix+label).Bold(field == m.focusIndex),
+		component.T("    "+value),
+	)
+}
+
+func (m Model) renderOptions() string {
+	optimizer := "[ ] disabled"
+	if m.optimizerEnabled {
+		optimizer = "[x] enabled"
+	}
+
+	errorLine := component.Empty()
+	if m.errorMsg != "" {
+		errorLine = component.T("Error: " + m.errorMsg).Error()
+	}
+
+	return component.VStackC(
+		component.T("Compile Contract").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Contract: "+m.contract.Name),
+		component.T(fmt.Sprintf("Source: %d lines", strings.Count(*m.contract.ContractCode, "\n")+1)).Muted(),
+		component.SpacerV(1),
+		m.renderField(fieldVersion, "Compiler version", m.versionInput.View()),
+		component.T("    Available offline: "+strings.Join(compiler.AvailableVersions(), ", ")+" (other versions are downloaded)").Muted(),
+		m.renderField(fieldOptimizer, "Optimizer", optimizer),
+		m.renderField(fieldRuns, "Optimizer runs", m.runsInput.View()),
+		m.renderField(fieldFileName, "Source file name", m.fileNameInput.View()),
+		m.renderField(fieldImportDir, "Import directory", m.importDirInput.View()),
+		m.renderField(fieldContractName, "Contract name", m.contractInput.View()),
+		component.SpacerV(1),
+		errorLine,
+	).Render()
+}
+
+func (m Model) renderResult() string {
+	status := component.T(fmt.Sprintf("✓ Compiled %s", m.contract.Name)).Bold(true)
+	details := []component.Component{}
+	if m.compileErr != "" {
+		status = component.T("✗ Compilation failed: " + m.compileErr).Error()
+	} else {
+		abiName := "none"
+		if m.contract.Abi != nil {
+			abiName = m.contract.Abi.Name
+		}
+		details = append(details,
+			component.T("ABI saved as: "+abiName),
+			component.T(fmt.Sprintf("Bytecode: %d bytes", len(common.FromHex(*m.contract.Bytecode)))),
+			component.T("Status: "+string(m.contract.Status)).Muted(),
+		)
+	}
+
+	diagnostics := []component.Component{}
+	if m.result != nil {
+		for _, diagnostic := range m.result.Errors() {
+			diagnostics = append(diagnostics, component.T("✗ "+diagnostic.String()).Error())
+		}
+		for _, diagnostic := range m.result.Warnings() {
+			diagnostics = append(diagnostics, component.T("⚠ "+diagnostic.String()).Warning())
+		}
+	}
+	if len(diagnostics) > 0 {
+		diagnostics = append([]component.Component{
+			component.SpacerV(1),
+			component.T(fmt.Sprintf("Diagnostics (%d errors, %d warnings)", len(m.result.Errors()), len(m.result.Warnings()))).Bold(true),
+		}, diagnostics...)
+	}
+
+	return component.VStackC(
+		component.T("Compile Contract").Bold(true).Primary(),
+		component.SpacerV(1),
+		status,
+		component.VStackC(details...),
+		component.VStackC(diagnostics...),
+	).Render()
+}
@@ -0,0 +1,151 @@
+package compile
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const counterSource = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Counter {
+    uint256 public count;
+
+    function increment() public {
+        uint256 unused;
+        count++;
+    }
+}
+`
+
+type CompilePageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	model       Model
+}
+
+func TestCompilePageTestSuite(t *testing.T) {
+	suite.Run(t, new(CompilePageTestSuite))
+}
+
+func (s *CompilePageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.model = NewPageWithStorage(s.mockRouter, storage.NewSharedMemory(), s.mockStorage).(Model)
+}
+
+func (s *CompilePageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *CompilePageTestSuite) load(source string) {
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("3")
+	s.mockRouter.EXPECT().GetQueryParam("dir").Return("/tmp/contracts")
+	s.mockRouter.EXPECT().GetQueryParam("file").Return("Counter.sol")
+	s.mockStorage.EXPECT().GetContractByID(uint(3)).Return(models.EVMContract{
+		ID:           3,
+		Name:         "Counter",
+		Status:       models.DeploymentStatusPending,
+		ContractCode: &source,
+	}, nil)
+
+	updated, _ := s.model.Update(s.model.loadContract())
+	s.model = updated.(Model)
+}
+
+func (s *CompilePageTestSuite) press(msg tea.KeyMsg) tea.Cmd {
+	updated, cmd := s.model.Update(msg)
+	s.model = updated.(Model)
+	return cmd
+}
+
+func (s *CompilePageTestSuite) TestLoadPrefillsOptions() {
+	s.load(counterSource)
+
+	s.Equal(stepOptions, s.model.currentStep)
+	s.Equal("/tmp/contracts", s.model.importDirInput.Value())
+	s.Equal("Counter.sol", s.model.fileNameInput.Value())
+
+	output := s.model.View()
+	s.Contains(output, "Compiler version")
+	s.Contains(output, "[ ] disabled")
+}
+
+func (s *CompilePageTestSuite) TestLoadWithoutSource() {
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("3")
+	s.mockStorage.EXPECT().GetContractByID(uint(3)).Return(models.EVMContract{ID: 3, Name: "Counter"}, nil)
+
+	updated, _ := s.model.Update(s.model.loadContract())
+	s.model = updated.(Model)
+
+	s.Equal(stepError, s.model.currentStep)
+	s.Contains(s.model.View(), "no source code")
+}
+
+func (s *CompilePageTestSuite) TestOptimizerToggleAndRunsValidation() {
+	s.load(counterSource)
+
+	s.press(tea.KeyMsg{Type: tea.KeyTab})
+	s.Equal(fieldOptimizer, s.model.focusIndex)
+	s.press(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	s.True(s.model.optimizerEnabled)
+
+	s.model.runsInput.SetValue("zero")
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Nil(cmd)
+	s.Contains(s.model.errorMsg, "optimizer runs")
+}
+
+func (s *CompilePageTestSuite) TestCompileStoresABIAndBytecode() {
+	s.load(counterSource)
+
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Require().NotNil(cmd)
+	s.Equal(stepCompiling, s.model.currentStep)
+
+	s.mockStorage.EXPECT().CreateABI(gomock.Any()).DoAndReturn(func(abi models.EvmAbi) (uint, error) {
+		s.Equal("Counter", abi.Name)
+		s.NotEmpty(abi.Abi.AbiArray)
+		return 8, nil
+	})
+	s.mockStorage.EXPECT().UpdateContract(uint(3), gomock.Any()).DoAndReturn(func(_ uint, contract models.EVMContract) error {
+		s.Require().NotNil(contract.AbiId)
+		s.Equal(uint(8), *contract.AbiId)
+		s.True(contract.IsDeployable())
+		return nil
+	})
+
+	updated, _ := s.model.Update(cmd())
+	s.model = updated.(Model)
+
+	output := s.model.View()
+	s.Equal(stepResult, s.model.currentStep)
+	s.Contains(output, "✓ Compiled Counter")
+	s.Contains(output, "ABI saved as: Counter")
+	s.Contains(output, "Counter.sol:8:9: Warning: Unused local variable.")
+}
+
+func (s *CompilePageTestSuite) TestCompileErrorsShowLineNumbers() {
+	s.load("pragma solidity ^0.8.0;\ncontract Broken {\n    function f() public { missing(); }\n}\n")
+
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ := s.model.Update(cmd())
+	s.model = updated.(Model)
+
+	output := s.model.View()
+	s.Contains(output, "✗ Compilation failed")
+	s.Contains(output, "Counter.sol:3:27: DeclarationError")
+
+	s.press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	s.Equal(stepOptions, s.model.currentStep)
+}
@@ -0,0 +1,442 @@
+package deploy
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/endpointgroup"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/gasreport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/contract/deploy.log")
+
+type deployStep int
+
+const (
+	stepLoading deployStep = iota
+	stepEnterArgs
+	stepDeploying
+	stepResult
+	stepError
+)
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+	walletService wallet.WalletService
+
+	currentStep deployStep
+	contract    *models.EVMContract
+	walletID    uint
+	walletLabel string
+
+	constructorInputs []abi.ABIParam
+	payable           bool
+	inputs            []textinput.Model
+	focusIndex        int
+
+	receipt   *types.Receipt
+	deployErr string
+
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithService(router, sharedMemory, nil, nil)
+}
+
+// NewPageWithService creates a new deploy page with an optional storage client and wallet service (for testing).
+func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage, walletService wallet.WalletService) view.View {
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		walletService: walletService,
+		currentStep:   stepLoading,
+	}
+}
+
+type contractLoadedMsg struct {
+	storageClient sql.Storage
+	walletService wallet.WalletService
+	contract      *models.EVMContract
+	walletID      uint
+	walletLabel   string
+	err           error
+}
+
+type contractDeployedMsg struct {
+	contract *models.EVMContract
+	receipt  *types.Receipt
+	err      error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadContract
+}
+
+func (m Model) loadContract() tea.Msg {
+	contractID, err := strconv.ParseUint(m.router.GetQueryParam("id"), 10, 64)
+	if err != nil {
+		return contractLoadedMsg{err: fmt.Errorf("invalid contract ID: %w", err)}
+	}
+
+	storageClient := m.storageClient
+	if storageClient == nil {
+		sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get storage client from shared memory: %v", err)
+			return contractLoadedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+		}
+		storageClient = sqlStorage
+	}
+
+	walletService := m.walletService
+	if walletService == nil {
+		secureStorage, _, err := utils.GetSecureStorageFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get secure storage from shared memory: %v", err)
+			return contractLoadedMsg{err: fmt.Errorf("failed to get secure storage from shared memory: %w", err)}
+		}
+		walletService = wallet.NewWalletService(storageClient, secureStorage)
+	}
+
+	contract, err := storageClient.GetContractByID(uint(contractID))
+	if err != nil {
+		logger.Error("Failed to load contract %d: %v", contractID, err)
+		return contractLoadedMsg{err: fmt.Errorf("failed to load contract: %w", err)}
+	}
+	if !contract.IsDeployable() {
+		return contractLoadedMsg{err: fmt.Errorf("contract %s is not deployable (status: %s)", contract.Name, contract.Status)}
+	}
+	if contract.Endpoint == nil {
+		return contractLoadedMsg{err: fmt.Errorf("contract %s has no network endpoint", contract.Name)}
+	}
+
+	config, err := storageClient.GetCurrentConfig()
+	if err != nil {
+		logger.Error("Failed to get current config: %v", err)
+		return contractLoadedMsg{err: fmt.Errorf("failed to get current config: %w", err)}
+	}
+	if config.SelectedWalletID == nil {
+		return contractLoadedMsg{err: fmt.Errorf("no wallet selected. Please select a wallet first")}
+	}
+
+	selectedWallet, err := walletService.GetWallet(*config.SelectedWalletID)
+	if err != nil {
+		logger.Error("Failed to load selected wallet: %v", err)
+		return contractLoadedMsg{err: fmt.Errorf("failed to load selected wallet: %w", err)}
+	}
+
+	return contractLoadedMsg{
+		storageClient: storageClient,
+		walletService: walletService,
+		contract:      &contract,
+		walletID:      selectedWallet.ID,
+		walletLabel:   fmt.Sprintf("%s (%s)", selectedWallet.Alias, selectedWallet.Address),
+	}
+}
+
+func (m Model) deploy(value *big.Int, args []any) tea.Cmd {
+	contract := *m.contract
+	return func() tea.Msg {
+		tr, err := endpointgroup.NewTransport(m.storageClient, *contract.Endpoint, 30*time.Second)
+		if err != nil {
+			return contractDeployedMsg{contract: &contract, err: err}
+		}
+
+		walletSigner, err := wallet.NewSigner(m.walletService, m.walletID, tr)
+		if err != nil {
+			return contractDeployedMsg{contract: &contract, err: err}
+		}
+
+		receipt, deployErr := signer.DeployEVMContract(walletSigner, &contract, value, args...)
+		if deployErr != nil {
+			logger.Error("Failed to deploy contract %d: %v", contract.ID, deployErr)
+		}
+
+		if contract.Status != models.DeploymentStatusPending {
+			if err := m.storageClient.UpdateContract(contract.ID, contract); err != nil {
+				logger.Error("Failed to update contract %d: %v", contract.ID, err)
+				if deployErr == nil {
+					deployErr = fmt.Errorf("contract deployed at %s but failed to save: %w", contract.Address, err)
+				}
+			}
+		}
+		if receipt != nil {
+			if _, err := m.storageClient.RecordTransaction(gasreport.DeploymentRecord(contract.ID, receipt)); err != nil {
+				logger.Error("Failed to record gas of deployment %d: %v", contract.ID, err)
+			}
+		}
+
+		return contractDeployedMsg{contract: &contract, receipt: receipt, err: deployErr}
+	}
+}
+
+// constructorOf returns the constructor element of the ABI, if any.
+func constructorOf(contract *models.EVMContract) *abi.ABIElement {
+	contractABI := signer.ContractABI(contract)
+	for _, element := range contractABI.Elements() {
+		if element.Type == "constructor" {
+			return &element
+		}
+	}
+	return nil
+}
+
+// collectArguments parses every constructor input, and the value input for payable constructors.
+func (m Model) collectArguments() (*big.Int, []any, error) {
+	inputs := make([]string, 0, len(m.constructorInputs))
+	for index := range m.constructorInputs {
+		inputs = append(inputs, m.inputs[index].Value())
+	}
+	args, err := abi.ParseArguments(m.constructorInputs, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	value := big.NewInt(0)
+	if m.payable {
+		input := strings.TrimSpace(m.inputs[len(m.constructorInputs)].Value())
+		if input != "" {
+			parsed, err := abi.ParseArgument(abi.ABIParam{Name: "value", Type: "uint256"}, input)
+			if err != nil {
+				return nil, nil, err
+			}
+			value = parsed.(*big.Int)
+		}
+	}
+
+	return value, args, nil
+}
+
+func paramLabel(param abi.ABIParam, index int) string {
+	if param.Name == "" {
+		return fmt.Sprintf("arg%d (%s)", index, param.Type)
+	}
+	return fmt.Sprintf("%s (%s)", param.Name, param.Type)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case contractLoadedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.storageClient = msg.storageClient
+		m.walletService = msg.walletService
+		m.contract = msg.contract
+		m.walletID = msg.walletID
+		m.walletLabel = msg.walletLabel
+		m.constructorInputs = nil
+		m.payable = false
+		if constructor := constructorOf(msg.contract); constructor != nil {
+			m.constructorInputs = constructor.Inputs
+			m.payable = constructor.IsPayable()
+		}
+
+		m.inputs = make([]textinput.Model, 0, len(m.constructorInputs)+1)
+		for index, param := range m.constructorInputs {
+			input := textinput.New()
+			input.Placeholder = paramLabel(param, index)
+			input.Width = 66
+			m.inputs = append(m.inputs, input)
+		}
+		if m.payable {
+			input := textinput.New()
+			input.Placeholder = "value in wei (default 0)"
+			input.Width = 40
+			m.inputs = append(m.inputs, input)
+		}
+		m.currentStep = stepEnterArgs
+		m.focusIndex = 0
+		if len(m.inputs) > 0 {
+			m.inputs[0].Focus()
+			return m, textinput.Blink
+		}
+		return m, nil
+
+	case contractDeployedMsg:
+		m.contract = msg.contract
+		m.receipt = msg.receipt
+		m.deployErr = ""
+		if msg.err != nil {
+			m.deployErr = msg.err.Error()
+		}
+		m.currentStep = stepResult
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepEnterArgs:
+			return m.handleEnterArgs(msg)
+		case stepResult, stepError:
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/contract", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleEnterArgs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "down":
+		return m.moveFocus(1)
+	case "shift+tab", "up":
+		return m.moveFocus(-1)
+	case "ctrl+d":
+		value, args, err := m.collectArguments()
+		if err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.currentStep = stepDeploying
+		return m, m.deploy(value, args)
+	}
+
+	if len(m.inputs) == 0 {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focusIndex], cmd = m.inputs[m.focusIndex].Update(msg)
+	return m, cmd
+}
+
+func (m Model) moveFocus(delta int) (tea.Model, tea.Cmd) {
+	if len(m.inputs) == 0 {
+		return m, nil
+	}
+	m.inputs[m.focusIndex].Blur()
+	m.focusIndex = (m.focusIndex + delta + len(m.inputs)) % len(m.inputs)
+	m.inputs[m.focusIndex].Focus()
+	return m, textinput.Blink
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepEnterArgs:
+		return "tab/↓: next field • shift+tab/↑: previous field • ctrl+d: deploy • esc: cancel", view.HelpDisplayOptionOverride
+	case stepDeploying:
+		return "Deploying...", view.HelpDisplayOptionOverride
+	case stepResult, stepError:
+		return "Press any key to return to contract list", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	switch m.currentStep {
+	case stepEnterArgs:
+		return m.renderEnterArgs()
+	case stepDeploying:
+		return component.VStackC(
+			component.T("Deploy Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T(fmt.Sprintf("Deploying %s to %s...", m.contract.Name, m.contract.Endpoint.Name)).Muted(),
+			component.T("Waiting for the transaction to be mined.").Muted(),
+		).Render()
+	case stepResult:
+		return m.renderResult()
+	case stepError:
+		return component.VStackC(
+			component.T("Deploy Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			component.T("Deploy Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Loading contract...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) renderEnterArgs() string {
+	fields := make([]component.Component, 0, len(m.inputs)*2)
+	for index, param := range m.constructorInputs {
+		fields = append(fields,
+			component.T(paramLabel(param, index)).Bold(index == m.focusIndex),
+			component.T(m.inputs[index].View()),
+		)
+	}
+	if m.payable {
+		index := len(m.constructorInputs)
+		fields = append(fields,
+			component.T("value (wei)").Bold(index == m.focusIndex),
+			component.T(m.inputs[index].View()),
+		)
+	}
+	if len(fields) == 0 {
+		fields = append(fields, component.T("The constructor takes no arguments.").Muted())
+	}
+
+	errorLine := component.Empty()
+	if m.errorMsg != "" {
+		errorLine = component.T("Error: " + m.errorMsg).Error()
+	}
+
+	return component.VStackC(
+		component.T("Deploy Contract").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Contract: "+m.contract.Name),
+		component.T(fmt.Sprintf("Network: %s (%s)", m.contract.Endpoint.Name, m.contract.Endpoint.Url)),
+		component.T("Deployer: "+m.walletLabel),
+		component.SpacerV(1),
+		component.T("Constructor arguments").Bold(true),
+		component.SpacerV(1),
+		component.VStackC(fields...),
+		component.SpacerV(1),
+		errorLine,
+	).Render()
+}
+
+func (m Model) renderResult() string {
+	details := []component.Component{}
+	if m.receipt != nil {
+		details = append(details,
+			component.T("Transaction: "+m.receipt.TxHash.Hex()),
+			component.T(fmt.Sprintf("Block: %s • Gas used: %d", m.receipt.BlockNumber, m.receipt.GasUsed)),
+		)
+	}
+
+	status := component.T("✓ Contract deployed at " + m.contract.Address).Bold(true)
+	if m.deployErr != "" {
+		status = component.T("✗ Deployment failed: " + m.deployErr).Error()
+	}
+
+	return component.VStackC(
+		component.T("Deploy Contract").Bold(true).Primary(),
+		component.SpacerV(1),
+		status,
+		component.T("Status: "+string(m.contract.Status)).Muted(),
+		component.SpacerV(1),
+		component.VStackC(details...),
+	).Render()
+}
@@ -0,0 +1,163 @@
+package deploy
+
+import (
+	"math/big"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	walletsvc "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type DeployPageTestSuite struct {
+	suite.Suite
+	mockCtrl          *gomock.Controller
+	mockRouter        *view.MockRouter
+	mockStorage       *sql.MockStorage
+	mockWalletService *walletsvc.MockWalletService
+	model             Model
+}
+
+func TestDeployPageTestSuite(t *testing.T) {
+	suite.Run(t, new(DeployPageTestSuite))
+}
+
+func (s *DeployPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.mockWalletService = walletsvc.NewMockWalletService(s.mockCtrl)
+	s.model = NewPageWithService(s.mockRouter, storage.NewSharedMemory(), s.mockStorage, s.mockWalletService).(Model)
+}
+
+func (s *DeployPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func tokenContract() models.EVMContract {
+	bytecode := "0x6080"
+	return models.EVMContract{
+		ID:       4,
+		Name:     "Token",
+		Status:   models.DeploymentStatusPending,
+		Bytecode: &bytecode,
+		Endpoint: &models.EVMEndpoint{Name: "Anvil", Url: "http://localhost:8545"},
+		Abi: &models.EvmAbi{Name: "TokenABI", Abi: models.AbiArrayType{AbiArray: abi.AbiArray{{
+			Type:            "constructor",
+			StateMutability: "payable",
+			Inputs: []abi.ABIParam{
+				{Name: "owner", Type: "address"},
+				{Name: "supply", Type: "uint256"},
+				{Name: "decimals", Type: "uint8"},
+			},
+		}}}},
+	}
+}
+
+func (s *DeployPageTestSuite) load(contract models.EVMContract) {
+	walletID := uint(9)
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("4")
+	s.mockStorage.EXPECT().GetContractByID(uint(4)).Return(contract, nil)
+	s.mockStorage.EXPECT().GetCurrentConfig().Return(models.EVMConfig{SelectedWalletID: &walletID}, nil).AnyTimes()
+	s.mockWalletService.EXPECT().GetWallet(walletID).Return(&models.EVMWallet{ID: walletID, Alias: "deployer", Address: "0xabc"}, nil).AnyTimes()
+
+	updated, _ := s.model.Update(s.model.loadContract())
+	s.model = updated.(Model)
+}
+
+func (s *DeployPageTestSuite) TestLoadBuildsConstructorInputs() {
+	s.load(tokenContract())
+
+	s.Equal(stepEnterArgs, s.model.currentStep)
+	s.Len(s.model.constructorInputs, 3)
+	s.True(s.model.payable)
+	s.Len(s.model.inputs, 4)
+
+	output := s.model.View()
+	s.Contains(output, "owner (address)")
+	s.Contains(output, "value (wei)")
+	s.Contains(output, "deployer (0xabc)")
+}
+
+func (s *DeployPageTestSuite) TestLoadRejectsDeployedContract() {
+	contract := tokenContract()
+	contract.Status = models.DeploymentStatusDeployed
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("4")
+	s.mockStorage.EXPECT().GetContractByID(uint(4)).Return(contract, nil)
+
+	updated, _ := s.model.Update(s.model.loadContract())
+	s.model = updated.(Model)
+
+	s.Equal(stepError, s.model.currentStep)
+	s.Contains(s.model.View(), "not deployable")
+}
+
+func (s *DeployPageTestSuite) TestCollectArguments() {
+	s.load(tokenContract())
+	s.model.inputs[0].SetValue("0x5fbdb2315678afecb367f032d93f642f64180aa3")
+	s.model.inputs[1].SetValue("1000000000000000000000")
+	s.model.inputs[2].SetValue("18")
+	s.model.inputs[3].SetValue("5")
+
+	value, args, err := s.model.collectArguments()
+	s.Require().NoError(err)
+	s.Equal(big.NewInt(5), value)
+	s.Equal(common.HexToAddress("0x5fbdb2315678afecb367f032d93f642f64180aa3"), args[0])
+	expectedSupply, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	s.Equal(expectedSupply, args[1])
+	s.Equal(uint8(18), args[2])
+}
+
+func (s *DeployPageTestSuite) TestInvalidArgumentShowsFieldError() {
+	s.load(tokenContract())
+	s.model.inputs[0].SetValue("0x5fbdb2315678afecb367f032d93f642f64180aa3")
+	s.model.inputs[1].SetValue("1")
+	s.model.inputs[2].SetValue("300")
+
+	updated, cmd := s.model.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	s.model = updated.(Model)
+
+	s.Nil(cmd)
+	s.Equal(stepEnterArgs, s.model.currentStep)
+	s.Contains(s.model.errorMsg, "decimals (uint8)")
+}
+
+func (s *DeployPageTestSuite) TestDeployResult() {
+	s.load(tokenContract())
+
+	deployed := tokenContract()
+	deployed.Address = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	deployed.Status = models.DeploymentStatusDeployed
+	updated, _ := s.model.Update(contractDeployedMsg{
+		contract: &deployed,
+		receipt:  &types.Receipt{TxHash: common.HexToHash("0x01"), BlockNumber: big.NewInt(12), GasUsed: 21000},
+	})
+	s.model = updated.(Model)
+
+	output := s.model.View()
+	s.Equal(stepResult, s.model.currentStep)
+	s.Contains(output, "Contract deployed at 0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	s.Contains(output, "Gas used: 21000")
+}
+
+func (s *DeployPageTestSuite) TestCollectArgumentsAcceptsUnits() {
+	s.load(tokenContract())
+	s.model.inputs[0].SetValue("0x5fbdb2315678afecb367f032d93f642f64180aa3")
+	s.model.inputs[1].SetValue("1000 ether")
+	s.model.inputs[2].SetValue("18")
+	s.model.inputs[3].SetValue("1 gwei")
+
+	value, args, err := s.model.collectArguments()
+	s.Require().NoError(err)
+	s.Equal(big.NewInt(1_000_000_000), value)
+	expectedSupply, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	s.Equal(expectedSupply, args[1])
+}
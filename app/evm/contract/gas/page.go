@@ -0,0 +1,424 @@
+package gas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/gasreport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/contract/gas.log")
+
+type gasStep int
+
+const (
+	stepLoading gasStep = iota
+	stepReport
+	stepExport
+	stepError
+)
+
+// reportTab is the table shown by the report.
+type reportTab int
+
+const (
+	tabFunctions reportTab = iota
+	tabNetworks
+	tabTrends
+)
+
+var tabNames = []string{"Functions", "Networks", "Trends"}
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+
+	currentStep gasStep
+	contract    *models.EVMContract
+	report      gasreport.Report
+	tab         reportTab
+
+	exportInput textinput.Model
+	notice      string
+	errorMsg    string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithStorage(router, sharedMemory, nil)
+}
+
+// NewPageWithStorage creates a new gas report page with an optional storage client (for testing).
+func NewPageWithStorage(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage) view.View {
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		currentStep:   stepLoading,
+	}
+}
+
+type reportLoadedMsg struct {
+	storageClient sql.Storage
+	contract      *models.EVMContract
+	report        gasreport.Report
+	err           error
+}
+
+type exportedMsg struct {
+	path string
+	err  error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadReport
+}
+
+func (m Model) loadReport() tea.Msg {
+	contractID, err := strconv.ParseUint(m.router.GetQueryParam("id"), 10, 64)
+	if err != nil {
+		return reportLoadedMsg{err: fmt.Errorf("invalid contract ID: %w", err)}
+	}
+
+	storageClient := m.storageClient
+	if storageClient == nil {
+		sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get storage client from shared memory: %v", err)
+			return reportLoadedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+		}
+		storageClient = sqlStorage
+	}
+
+	contract, err := storageClient.GetContractByID(uint(contractID))
+	if err != nil {
+		logger.Error("Failed to load contract %d: %v", contractID, err)
+		return reportLoadedMsg{err: fmt.Errorf("failed to load contract: %w", err)}
+	}
+
+	transactions, err := storageClient.ListTransactionsByContractName(contract.Name)
+	if err != nil {
+		logger.Error("Failed to load transactions of %s: %v", contract.Name, err)
+		return reportLoadedMsg{err: fmt.Errorf("failed to load transactions: %w", err)}
+	}
+
+	return reportLoadedMsg{
+		storageClient: storageClient,
+		contract:      &contract,
+		report:        gasreport.Build(contract.Name, transactions),
+	}
+}
+
+func (m Model) export(path string) tea.Cmd {
+	report := m.report
+	return func() tea.Msg {
+		if err := report.Export(path); err != nil {
+			logger.Error("Failed to export gas report to %s: %v", path, err)
+			return exportedMsg{err: err}
+		}
+		return exportedMsg{path: path}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case reportLoadedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			m.currentStep = stepError
+			return m, nil
+		}
+		m.storageClient = msg.storageClient
+		m.contract = msg.contract
+		m.report = msg.report
+		m.currentStep = stepReport
+		return m, nil
+
+	case exportedMsg:
+		m.currentStep = stepReport
+		m.notice = ""
+		m.errorMsg = ""
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.notice = "Exported to " + msg.path
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepReport:
+			return m.handleReport(msg)
+		case stepExport:
+			return m.handleExport(msg)
+		case stepError:
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/contract", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleReport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "right", "l":
+		m.tab = (m.tab + 1) % reportTab(len(tabNames))
+	case "shift+tab", "left", "h":
+		m.tab = (m.tab + reportTab(len(tabNames)) - 1) % reportTab(len(tabNames))
+	case "e":
+		m.exportInput = textinput.New()
+		m.exportInput.Placeholder = "file path ending in .csv or .json"
+		m.exportInput.SetValue(defaultExportPath(m.contract.Name))
+		m.exportInput.Width = 66
+		m.exportInput.Focus()
+		m.notice = ""
+		m.errorMsg = ""
+		m.currentStep = stepExport
+		return m, textinput.Blink
+	case "r":
+		m.currentStep = stepLoading
+		return m, m.loadReport
+	}
+	return m, nil
+}
+
+func (m Model) handleExport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+b":
+		m.currentStep = stepReport
+		return m, nil
+	case "enter":
+		path := strings.TrimSpace(m.exportInput.Value())
+		if path == "" {
+			m.errorMsg = "enter a file path"
+			return m, nil
+		}
+		return m, m.export(path)
+	}
+
+	var cmd tea.Cmd
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	return m, cmd
+}
+
+// defaultExportPath is a CSV file in the working directory named after the contract.
+func defaultExportPath(name string) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, name)
+	return slug + "-gas-report.csv"
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepReport:
+		return "tab: next table • e: export CSV/JSON • r: refresh • esc: back", view.HelpDisplayOptionOverride
+	case stepExport:
+		return "enter: export • ctrl+b: back to report", view.HelpDisplayOptionOverride
+	case stepError:
+		return "Press any key to return to contracts", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	switch m.currentStep {
+	case stepReport:
+		return m.renderReport()
+	case stepExport:
+		return m.renderExport()
+	case stepError:
+		return component.VStackC(
+			component.T("Gas Report").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			component.T("Gas Report").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Loading transaction history...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) title() string {
+	return "Gas Report - " + m.contract.Name
+}
+
+func (m Model) renderReport() string {
+	items := []component.Component{
+		component.T(m.title()).Bold(true).Primary(),
+		component.T(fmt.Sprintf("%d deployment(s) • gas of sent transactions and simulated calls", len(m.report.Deployments))).Muted(),
+		component.SpacerV(1),
+	}
+
+	if len(m.report.Functions) == 0 {
+		items = append(items, component.T("No transactions recorded yet. Deploy the contract or call its functions to collect gas usage.").Muted())
+		return component.VStackC(items...).Render()
+	}
+
+	tabs := make([]string, 0, len(tabNames))
+	for index, name := range tabNames {
+		if reportTab(index) == m.tab {
+			name = "[" + name + "]"
+		}
+		tabs = append(tabs, name)
+	}
+	items = append(items, component.T(strings.Join(tabs, "  ")).Bold(true), component.SpacerV(1))
+
+	var lines []string
+	switch m.tab {
+	case tabNetworks:
+		lines = m.networkLines()
+	case tabTrends:
+		lines = m.trendLines()
+	default:
+		lines = m.functionLines()
+	}
+	for _, line := range lines {
+		items = append(items, component.T(line))
+	}
+
+	if m.notice != "" {
+		items = append(items, component.SpacerV(1), component.T(m.notice).Success())
+	}
+	if m.errorMsg != "" {
+		items = append(items, component.SpacerV(1), component.T("Error: "+m.errorMsg).Error())
+	}
+	return component.VStackC(items...).Render()
+}
+
+func (m Model) functionLines() []string {
+	rows := [][]string{{"Function", "Selector", "Calls", "Simulated", "Reverted", "Min gas", "Avg gas", "Max gas"}}
+	for _, function := range m.report.Functions {
+		rows = append(rows, []string{
+			function.Label(),
+			function.Selector,
+			strconv.Itoa(function.Calls),
+			strconv.Itoa(function.Simulations),
+			strconv.Itoa(function.Reverted),
+			gasCell(function.MinGas, function.Samples()),
+			gasCell(function.AvgGas, function.Samples()),
+			gasCell(function.MaxGas, function.Samples()),
+		})
+	}
+	return append(table(rows), "", "Fees are paid in the currency of each chain, see the Networks tab.")
+}
+
+func (m Model) networkLines() []string {
+	if len(m.report.Networks) == 0 {
+		return []string{"No transactions sent yet, simulated calls pay no fees."}
+	}
+	rows := [][]string{{"Network", "Chain ID", "Transactions", "Gas used", "Fee (wei)"}}
+	for _, network := range m.report.Networks {
+		rows = append(rows, []string{
+			network.Network,
+			network.ChainID,
+			strconv.Itoa(network.Transactions),
+			strconv.FormatUint(network.GasUsed, 10),
+			network.FeeWei.String(),
+		})
+	}
+	return table(rows)
+}
+
+func (m Model) trendLines() []string {
+	header := []string{"Function"}
+	lines := []string{}
+	for index, deployment := range m.report.Deployments {
+		header = append(header, fmt.Sprintf("#%d", index+1))
+		lines = append(lines, fmt.Sprintf("#%d: %s on %s", index+1, deployment.Address, deployment.Network))
+	}
+	header = append(header, "Change")
+
+	rows := [][]string{header}
+	for _, trend := range m.report.Trends {
+		row := []string{trendLabel(trend)}
+		for _, point := range trend.Points {
+			row = append(row, gasCell(point.AvgGas, point.Samples))
+		}
+		change := "-"
+		if percent, ok := trend.Change(); ok {
+			change = fmt.Sprintf("%+.1f%%", percent)
+		}
+		rows = append(rows, append(row, change))
+	}
+	return append(append(table(rows), ""), lines...)
+}
+
+func trendLabel(trend gasreport.Trend) string {
+	if trend.Function != "" {
+		return trend.Function
+	}
+	return trend.Selector
+}
+
+// gasCell renders a gas amount, or "-" when there is no sample.
+func gasCell(gas uint64, samples int) string {
+	if samples == 0 {
+		return "-"
+	}
+	return strconv.FormatUint(gas, 10)
+}
+
+// table aligns the columns of the rows, the first row is the header.
+func table(rows [][]string) []string {
+	widths := []int{}
+	for _, row := range rows {
+		for index, cell := range row {
+			if index >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[index] = max(widths[index], len(cell))
+		}
+	}
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		cells := make([]string, 0, len(row))
+		for index, cell := range row {
+			cells = append(cells, cell+strings.Repeat(" ", widths[index]-len(cell)))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, "  "), " "))
+	}
+	return lines
+}
+
+func (m Model) renderExport() string {
+	errorLine := component.Empty()
+	if m.errorMsg != "" {
+		errorLine = component.T("Error: " + m.errorMsg).Error()
+	}
+	return component.VStackC(
+		component.T(m.title()+" - Export").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Export one row per function of every deployment as CSV, or the whole report as JSON.").Muted(),
+		component.T("The format follows the file extension.").Muted(),
+		component.SpacerV(1),
+		component.T(m.exportInput.View()),
+		component.SpacerV(1),
+		errorLine,
+	).Render()
+}
@@ -0,0 +1,136 @@
+package gas
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const transferSelector = "0xa9059cbb"
+
+type GasPageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	model       Model
+}
+
+func TestGasPageTestSuite(t *testing.T) {
+	suite.Run(t, new(GasPageTestSuite))
+}
+
+func (s *GasPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.model = NewPageWithStorage(s.mockRouter, storage.NewSharedMemory(), s.mockStorage).(Model)
+}
+
+func (s *GasPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *GasPageTestSuite) load(transactions []models.EVMTransaction) {
+	contract := models.EVMContract{ID: 1, Name: "Token"}
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("1")
+	s.mockStorage.EXPECT().GetContractByID(uint(1)).Return(contract, nil)
+	s.mockStorage.EXPECT().ListTransactionsByContractName("Token").Return(transactions, nil)
+
+	updated, _ := s.model.Update(s.model.Init()())
+	s.model = updated.(Model)
+	s.Require().Equal(stepReport, s.model.currentStep)
+}
+
+func (s *GasPageTestSuite) press(key string) tea.Cmd {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case "tab":
+		msg = tea.KeyMsg{Type: tea.KeyTab}
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	updated, cmd := s.model.Update(msg)
+	s.model = updated.(Model)
+	return cmd
+}
+
+func history() []models.EVMTransaction {
+	sepolia := &models.EVMEndpoint{Name: "sepolia", ChainId: "11155111"}
+	first := &models.EVMContract{ID: 1, Name: "Token", Address: "0x5FbDB2315678afecb367f032d93F642f64180aa3", Endpoint: sepolia}
+	second := &models.EVMContract{ID: 2, Name: "Token", Address: "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512", Endpoint: sepolia}
+	transfer := func(contract *models.EVMContract, kind models.TransactionKind, gasUsed uint64) models.EVMTransaction {
+		return models.EVMTransaction{
+			ContractId: contract.ID, Contract: contract, Kind: kind, Selector: transferSelector,
+			Function: "transfer(address,uint256)", GasUsed: gasUsed, GasPrice: "2", Success: true,
+		}
+	}
+	return []models.EVMTransaction{
+		transfer(first, models.TransactionKindCall, 50000),
+		transfer(first, models.TransactionKindCall, 40000),
+		transfer(second, models.TransactionKindSimulation, 36000),
+	}
+}
+
+func (s *GasPageTestSuite) TestShowsTables() {
+	s.load(history())
+
+	output := s.model.View()
+	s.Contains(output, "Gas Report - Token")
+	s.Contains(output, "Function                   Selector    Calls  Simulated  Reverted  Min gas  Avg gas  Max gas\n")
+	s.Contains(output, "transfer(address,uint256)  0xa9059cbb  2      1          0         36000    42000    50000\n")
+	s.Contains(output, "Fees are paid in the currency of each chain, see the Networks tab.")
+
+	s.press("tab")
+	s.Contains(s.model.View(), "sepolia  11155111  2             90000     180000")
+
+	s.press("tab")
+	output = s.model.View()
+	s.Contains(output, "transfer(address,uint256)  45000  36000  -20.0%")
+	s.Contains(output, "#2: 0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512 on sepolia")
+}
+
+func (s *GasPageTestSuite) TestEmptyHistory() {
+	s.load(nil)
+	s.Contains(s.model.View(), "No transactions recorded yet")
+}
+
+func (s *GasPageTestSuite) TestExport() {
+	s.load(history())
+	s.press("e")
+	s.Require().Equal(stepExport, s.model.currentStep)
+	s.Equal("token-gas-report.csv", s.model.exportInput.Value())
+
+	path := filepath.Join(s.T().TempDir(), "report.json")
+	s.model.exportInput.SetValue(path)
+	cmd := s.press("enter")
+	s.Require().NotNil(cmd)
+	updated, _ := s.model.Update(cmd())
+	s.model = updated.(Model)
+
+	s.Equal(stepReport, s.model.currentStep)
+	s.Contains(s.model.View(), "Exported to "+path)
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(string(data), "{"))
+}
+
+func (s *GasPageTestSuite) TestContractNotFound() {
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("7")
+	s.mockStorage.EXPECT().GetContractByID(uint(7)).Return(models.EVMContract{}, os.ErrNotExist)
+
+	updated, _ := s.model.Update(s.model.Init()())
+	s.model = updated.(Model)
+
+	s.Equal(stepError, s.model.currentStep)
+	s.Contains(s.model.View(), "failed to load contract")
+}
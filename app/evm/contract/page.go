@@ -0,0 +1,382 @@
+package contract
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/contract/page.log")
+
+const pageSize = 5
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+
+	contracts     []models.EVMContract
+	selectedIndex int
+	currentPage   int64
+	totalPages    int64
+	totalItems    int64
+
+	confirmDelete bool
+
+	loading  bool
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithStorage(router, sharedMemory, nil)
+}
+
+// NewPageWithStorage creates a new contract management page with an optional storage client (for testing).
+func NewPageWithStorage(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage) view.View {
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		currentPage:   1,
+		loading:       true,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadContracts
+}
+
+type contractsLoadedMsg struct {
+	contracts     []models.EVMContract
+	storageClient sql.Storage
+	totalPages    int64
+	totalItems    int64
+	err           error
+}
+
+type contractDeletedMsg struct {
+	err error
+}
+
+func (m Model) getStorageClient() (sql.Storage, error) {
+	if m.storageClient != nil {
+		return m.storageClient, nil
+	}
+
+	sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+	if err != nil {
+		logger.Error("Failed to get storage client from shared memory: %v", err)
+		return nil, fmt.Errorf("failed to get storage client from shared memory: %w", err)
+	}
+	return sqlStorage, nil
+}
+
+func (m Model) loadContracts() tea.Msg {
+	storageClient, err := m.getStorageClient()
+	if err != nil {
+		return contractsLoadedMsg{err: err}
+	}
+
+	result, err := storageClient.ListContracts(m.currentPage, pageSize)
+	if err != nil {
+		logger.Error("Failed to list contracts: %v", err)
+		return contractsLoadedMsg{err: err}
+	}
+
+	return contractsLoadedMsg{
+		contracts:     result.Items,
+		storageClient: storageClient,
+		totalPages:    result.TotalPages,
+		totalItems:    result.TotalItems,
+	}
+}
+
+func (m Model) deleteContract() tea.Msg {
+	contract := m.contracts[m.selectedIndex]
+	if err := m.storageClient.DeleteContract(contract.ID); err != nil {
+		logger.Error("Failed to delete contract %d: %v", contract.ID, err)
+		return contractDeletedMsg{err: err}
+	}
+	return contractDeletedMsg{}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case contractsLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.errorMsg = ""
+		m.contracts = msg.contracts
+		m.storageClient = msg.storageClient
+		m.totalPages = msg.totalPages
+		m.totalItems = msg.totalItems
+		if m.selectedIndex >= len(m.contracts) {
+			m.selectedIndex = max(len(m.contracts)-1, 0)
+		}
+		return m, nil
+
+	case contractDeletedMsg:
+		m.confirmDelete = false
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.loading = true
+		return m, m.loadContracts
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+
+		if m.confirmDelete {
+			switch msg.String() {
+			case "y":
+				return m, m.deleteContract
+			case "n":
+				m.confirmDelete = false
+			}
+			return m, nil
+		}
+
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.selectedIndex > 0 {
+			m.selectedIndex--
+		}
+	case "down", "j":
+		if m.selectedIndex < len(m.contracts)-1 {
+			m.selectedIndex++
+		}
+	case "n":
+		if m.currentPage < m.totalPages {
+			m.currentPage++
+			m.selectedIndex = 0
+			m.loading = true
+			return m, m.loadContracts
+		}
+	case "p":
+		if m.currentPage > 1 {
+			m.currentPage--
+			m.selectedIndex = 0
+			m.loading = true
+			return m, m.loadContracts
+		}
+	case "enter":
+		return m.openDeployed("/evm/contract/run")
+	case "s":
+		return m.openDeployed("/evm/contract/storage")
+	case "g":
+		contract, ok := m.selectedContract()
+		if !ok {
+			return m, nil
+		}
+		if err := m.router.NavigateTo("/evm/contract/gas", map[string]string{
+			"id": strconv.FormatUint(uint64(contract.ID), 10),
+		}); err != nil {
+			logger.Error("Failed to navigate to gas report page: %v", err)
+		}
+	case "a":
+		if err := m.router.NavigateTo("/evm/contract/add", nil); err != nil {
+			logger.Error("Failed to navigate to add contract page: %v", err)
+		}
+	case "D":
+		contract, ok := m.selectedContract()
+		if !ok {
+			return m, nil
+		}
+		if !contract.IsDeployable() {
+			m.errorMsg = fmt.Sprintf("Contract %s is not deployable (status: %s)", contract.Name, contract.Status)
+			return m, nil
+		}
+		m.errorMsg = ""
+		if err := m.router.NavigateTo("/evm/contract/deploy", map[string]string{
+			"id": strconv.FormatUint(uint64(contract.ID), 10),
+		}); err != nil {
+			logger.Error("Failed to navigate to deploy page: %v", err)
+		}
+	case "i":
+		if err := m.router.NavigateTo("/evm/contract/artifacts", nil); err != nil {
+			logger.Error("Failed to navigate to artifact import page: %v", err)
+		}
+	case "c":
+		contract, ok := m.selectedContract()
+		if !ok {
+			return m, nil
+		}
+		if contract.ContractCode == nil {
+			m.errorMsg = fmt.Sprintf("Contract %s has no source code to compile", contract.Name)
+			return m, nil
+		}
+		m.errorMsg = ""
+		if err := m.router.NavigateTo("/evm/contract/compile", map[string]string{
+			"id": strconv.FormatUint(uint64(contract.ID), 10),
+		}); err != nil {
+			logger.Error("Failed to navigate to compile page: %v", err)
+		}
+	case "d":
+		if _, ok := m.selectedContract(); ok {
+			m.confirmDelete = true
+		}
+	case "r":
+		m.loading = true
+		return m, m.loadContracts
+	}
+
+	return m, nil
+}
+
+func (m Model) selectedContract() (models.EVMContract, bool) {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.contracts) {
+		return models.EVMContract{}, false
+	}
+	return m.contracts[m.selectedIndex], true
+}
+
+// openDeployed opens a page that works on the selected contract's on-chain state.
+func (m Model) openDeployed(route string) (tea.Model, tea.Cmd) {
+	contract, ok := m.selectedContract()
+	if !ok {
+		return m, nil
+	}
+	if contract.Status != models.DeploymentStatusDeployed {
+		m.errorMsg = fmt.Sprintf("Contract %s is not deployed", contract.Name)
+		return m, nil
+	}
+	m.errorMsg = ""
+	if err := m.router.NavigateTo(route, map[string]string{
+		"id": strconv.FormatUint(uint64(contract.ID), 10),
+	}); err != nil {
+		logger.Error("Failed to navigate to %s: %v", route, err)
+	}
+	return m, nil
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	if m.loading {
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+	if m.confirmDelete {
+		return "y: delete • n: cancel", view.HelpDisplayOptionOverride
+	}
+
+	return "↑/k: up • ↓/j: down • enter: interact • s: storage • g: gas report • a: add new • i: import artifacts • c: compile • D: deploy • d: delete • n: next page • p: previous page • r: refresh • esc/q: back", view.HelpDisplayOptionAppend
+}
+
+func (m Model) View() string {
+	if m.loading {
+		return component.VStackC(
+			component.T("Contract Management").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Loading contracts...").Muted(),
+		).Render()
+	}
+
+	if len(m.contracts) == 0 {
+		return component.VStackC(
+			component.T("Contract Management").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.IfC(m.errorMsg != "", component.T("Error: "+m.errorMsg).Error(), component.Empty()),
+			component.T("No contracts found").Bold(true),
+			component.SpacerV(1),
+			component.T("You haven't added any contracts yet. Contracts represent deployed smart"),
+			component.T("contracts, or bytecode waiting to be deployed, on a network endpoint."),
+			component.SpacerV(1),
+			component.T("Press 'a' to add your first contract").Muted(),
+		).Render()
+	}
+
+	items := make([]component.Component, 0, len(m.contracts))
+	for index, contract := range m.contracts {
+		items = append(items, renderContract(contract, index == m.selectedIndex))
+	}
+
+	footer := component.Empty()
+	if m.confirmDelete {
+		contract, _ := m.selectedContract()
+		footer = component.T(fmt.Sprintf("Delete contract %s? (y/n)", contract.Name)).Warning()
+	} else if m.errorMsg != "" {
+		footer = component.T("Error: " + m.errorMsg).Error()
+	}
+
+	return component.VStackC(
+		component.T("Contract Management").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Manage your smart contracts").Muted(),
+		component.SpacerV(1),
+		component.VStackC(items...),
+		component.T(fmt.Sprintf("Page %d of %d • Showing %d of %d contracts", m.currentPage, max(m.totalPages, 1), len(m.contracts), m.totalItems)).Muted(),
+		component.SpacerV(1),
+		footer,
+	).Render()
+}
+
+func renderContract(contract models.EVMContract, isCursor bool) component.Component {
+	prefix := "  "
+	if isCursor {
+		prefix = "> "
+	}
+
+	address := contract.Address
+	if address == "" {
+		address = "not deployed"
+	}
+
+	abiName := "none"
+	if contract.Abi == nil && contract.ContractCode != nil {
+		abiName = "not compiled"
+	}
+	if contract.Abi != nil {
+		abiName = contract.Abi.Name
+	}
+
+	network := "unknown"
+	if contract.Endpoint != nil {
+		network = contract.Endpoint.Name
+	}
+
+	name := component.T(prefix + contract.Name)
+	if isCursor {
+		name = name.Bold(true)
+	}
+
+	return component.VStackC(
+		name,
+		component.T("    Address: "+address).Muted(),
+		component.T("    Status: "+renderStatus(contract.Status)).Muted(),
+		component.T("    ABI: "+abiName).Muted(),
+		component.T("    Network: "+network).Muted(),
+		component.T("    Created: "+contract.CreatedAt.Format("2006-01-02 3:04 PM")).Muted(),
+		component.SpacerV(1),
+	)
+}
+
+func renderStatus(status models.DeploymentStatus) string {
+	switch status {
+	case models.DeploymentStatusDeployed:
+		return "✓ deployed"
+	case models.DeploymentStatusFailed:
+		return "✗ failed"
+	default:
+		return "pending deployment"
+	}
+}
@@ -0,0 +1,180 @@
+package contract
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ContractPageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	model       Model
+}
+
+func TestContractPageTestSuite(t *testing.T) {
+	suite.Run(t, new(ContractPageTestSuite))
+}
+
+func (s *ContractPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.model = NewPageWithStorage(s.mockRouter, storage.NewSharedMemory(), s.mockStorage).(Model)
+}
+
+func (s *ContractPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *ContractPageTestSuite) loadContracts(contracts ...models.EVMContract) {
+	s.mockStorage.EXPECT().ListContracts(int64(1), int64(pageSize)).Return(types.Pagination[models.EVMContract]{
+		Items:      contracts,
+		TotalPages: 1,
+		TotalItems: int64(len(contracts)),
+	}, nil)
+
+	updated, _ := s.model.Update(s.model.loadContracts())
+	s.model = updated.(Model)
+}
+
+func (s *ContractPageTestSuite) press(key string) tea.Cmd {
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+
+	updated, cmd := s.model.Update(msg)
+	s.model = updated.(Model)
+	return cmd
+}
+
+func pendingContract() models.EVMContract {
+	bytecode := "0x6080"
+	return models.EVMContract{
+		ID:       2,
+		Name:     "Token",
+		Status:   models.DeploymentStatusPending,
+		Bytecode: &bytecode,
+		Endpoint: &models.EVMEndpoint{Name: "Anvil"},
+	}
+}
+
+func (s *ContractPageTestSuite) TestViewListsContracts() {
+	s.loadContracts(
+		models.EVMContract{ID: 1, Name: "Vault", Address: "0x1234", Status: models.DeploymentStatusDeployed, Abi: &models.EvmAbi{Name: "VaultABI"}},
+		pendingContract(),
+	)
+
+	output := s.model.View()
+	s.Contains(output, "Vault")
+	s.Contains(output, "VaultABI")
+	s.Contains(output, "✓ deployed")
+	s.Contains(output, "Token")
+	s.Contains(output, "not deployed")
+	s.Contains(output, "pending deployment")
+}
+
+func (s *ContractPageTestSuite) TestViewEmpty() {
+	s.loadContracts()
+
+	s.Contains(s.model.View(), "No contracts found")
+}
+
+func (s *ContractPageTestSuite) TestAddNavigates() {
+	s.loadContracts()
+	s.mockRouter.EXPECT().NavigateTo("/evm/contract/add", gomock.Nil()).Return(nil)
+
+	s.press("a")
+}
+
+func (s *ContractPageTestSuite) TestDeployNavigatesForPendingContract() {
+	s.loadContracts(pendingContract())
+	s.mockRouter.EXPECT().NavigateTo("/evm/contract/deploy", map[string]string{"id": "2"}).Return(nil)
+
+	s.press("D")
+	s.Empty(s.model.errorMsg)
+}
+
+func (s *ContractPageTestSuite) TestDeployRejectsDeployedContract() {
+	s.loadContracts(models.EVMContract{ID: 1, Name: "Vault", Address: "0x1234", Status: models.DeploymentStatusDeployed})
+
+	s.press("D")
+	s.Contains(s.model.errorMsg, "not deployable")
+}
+
+func (s *ContractPageTestSuite) TestEnterOpensRunnerForDeployedContract() {
+	s.loadContracts(models.EVMContract{ID: 1, Name: "Vault", Address: "0x1234", Status: models.DeploymentStatusDeployed}, pendingContract())
+	s.mockRouter.EXPECT().NavigateTo("/evm/contract/run", map[string]string{"id": "1"}).Return(nil)
+
+	s.press("enter")
+	s.Empty(s.model.errorMsg)
+
+	s.press("j")
+	s.press("enter")
+	s.Contains(s.model.errorMsg, "not deployed")
+}
+
+func (s *ContractPageTestSuite) TestStorageOpensInspectorForDeployedContract() {
+	s.loadContracts(models.EVMContract{ID: 1, Name: "Vault", Address: "0x1234", Status: models.DeploymentStatusDeployed}, pendingContract())
+	s.mockRouter.EXPECT().NavigateTo("/evm/contract/storage", map[string]string{"id": "1"}).Return(nil)
+
+	s.press("s")
+	s.Empty(s.model.errorMsg)
+
+	s.press("j")
+	s.press("s")
+	s.Contains(s.model.errorMsg, "not deployed")
+}
+
+func (s *ContractPageTestSuite) TestCompileNavigatesForContractWithSource() {
+	source := "contract Counter {}"
+	s.loadContracts(models.EVMContract{ID: 5, Name: "Counter", ContractCode: &source}, pendingContract())
+	s.Contains(s.model.View(), "not compiled")
+	s.mockRouter.EXPECT().NavigateTo("/evm/contract/compile", map[string]string{"id": "5"}).Return(nil)
+
+	s.press("c")
+	s.Empty(s.model.errorMsg)
+
+	s.press("j")
+	s.press("c")
+	s.Contains(s.model.errorMsg, "no source code")
+}
+
+func (s *ContractPageTestSuite) TestDeleteRequiresConfirmation() {
+	s.loadContracts(pendingContract())
+
+	s.press("d")
+	s.True(s.model.confirmDelete)
+	s.Contains(s.model.View(), "Delete contract Token?")
+
+	s.press("n")
+	s.False(s.model.confirmDelete)
+
+	s.press("d")
+	cmd := s.press("y")
+	s.Require().NotNil(cmd)
+
+	s.mockStorage.EXPECT().DeleteContract(uint(2)).Return(nil)
+	s.mockStorage.EXPECT().ListContracts(int64(1), int64(pageSize)).Return(types.Pagination[models.EVMContract]{}, nil)
+
+	updated, reload := s.model.Update(cmd())
+	s.model = updated.(Model)
+	s.Require().NotNil(reload)
+	updated, _ = s.model.Update(reload())
+	s.model = updated.(Model)
+	s.False(s.model.confirmDelete)
+	s.Empty(s.model.contracts)
+}
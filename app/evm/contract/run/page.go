@@ -0,0 +1,971 @@
+package run
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/endpointgroup"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/gasreport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/proxy"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/safe"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/signatures"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/simulation"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/contract/run.log")
+
+type runStep int
+
+const (
+	stepLoading runStep = iota
+	stepMethods
+	stepArgs
+	stepSimulating
+	stepConfirm
+	stepSafeAddress
+	stepRunning
+	stepResult
+	stepError
+)
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+	walletService wallet.WalletService
+	transport     transport.Transport
+
+	currentStep runStep
+	contract    *models.EVMContract
+
+	proxy *proxy.Proxy
+	// proxyErr is set when proxy detection failed and only the stored ABI is shown
+	proxyErr             string
+	implementationSource string
+	elements             abi.AbiArray
+	functions            []abi.ABIElement
+	selectedIndex        int
+
+	inputs     []textinput.Model
+	focusIndex int
+
+	// callOptions pin a read-only call to a block and override state, read from the last two inputs.
+	callOptions transport.CallOptions
+
+	// pendingValue and pendingArgs hold a parsed write call while it is simulated and confirmed,
+	// or proposed to the Safe in safeInput instead of sent.
+	pendingValue *big.Int
+	pendingArgs  []any
+	simulation   *simulation.Result
+	safeInput    textinput.Model
+	proposed     bool
+
+	resultLines []string
+	resultErr   string
+
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithService(router, sharedMemory, nil, nil, nil)
+}
+
+// NewPageWithService creates a new contract runner page with an optional storage client, wallet service
+// and transport (for testing).
+func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage, walletService wallet.WalletService, tr transport.Transport) view.View {
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		walletService: walletService,
+		transport:     tr,
+		currentStep:   stepLoading,
+	}
+}
+
+type contractLoadedMsg struct {
+	storageClient        sql.Storage
+	transport            transport.Transport
+	contract             *models.EVMContract
+	proxy                *proxy.Proxy
+	proxyErr             string
+	implementationSource string
+	elements             abi.AbiArray
+	functions            []abi.ABIElement
+	err                  error
+}
+
+type methodRunMsg struct {
+	lines []string
+	err   error
+}
+
+type simulationMsg struct {
+	result *simulation.Result
+	err    error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadContract
+}
+
+func (m Model) loadContract() tea.Msg {
+	contractID, err := strconv.ParseUint(m.router.GetQueryParam("id"), 10, 64)
+	if err != nil {
+		return contractLoadedMsg{err: fmt.Errorf("invalid contract ID: %w", err)}
+	}
+
+	storageClient := m.storageClient
+	if storageClient == nil {
+		sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get storage client from shared memory: %v", err)
+			return contractLoadedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+		}
+		storageClient = sqlStorage
+	}
+
+	contract, err := storageClient.GetContractByID(uint(contractID))
+	if err != nil {
+		logger.Error("Failed to load contract %d: %v", contractID, err)
+		return contractLoadedMsg{err: fmt.Errorf("failed to load contract: %w", err)}
+	}
+	if contract.Status != models.DeploymentStatusDeployed || !common.IsHexAddress(contract.Address) {
+		return contractLoadedMsg{err: fmt.Errorf("contract %s is not deployed", contract.Name)}
+	}
+	if contract.Endpoint == nil {
+		return contractLoadedMsg{err: fmt.Errorf("contract %s has no network endpoint", contract.Name)}
+	}
+
+	tr := m.transport
+	if tr == nil {
+		tr, err = endpointgroup.NewTransport(storageClient, *contract.Endpoint, 30*time.Second)
+		if err != nil {
+			return contractLoadedMsg{err: err}
+		}
+	}
+
+	// Proxy detection only adds the implementation ABI, so a node that cannot answer it, or a beacon
+	// that reverts, leaves the stored ABI usable
+	proxyErr := ""
+	resolved, err := proxy.ResolveContract(tr, contract)
+	if err != nil {
+		logger.Error("Failed to resolve proxy of contract %d: %v", contract.ID, err)
+		resolved, proxyErr = nil, err.Error()
+	}
+
+	elements := abi.AbiArray{}
+	if contract.Abi != nil {
+		elements = contract.Abi.Abi.AbiArray
+	}
+	implementationSource := ""
+	if resolved != nil && resolved.IsProxy() {
+		implementationABI := abi.AbiArray{}
+		if implementation, ok := findImplementation(storageClient, contract.EndpointId, resolved.Implementation); ok {
+			implementationABI = implementation.Abi.Abi.AbiArray
+			implementationSource = implementation.Name
+		}
+		elements = proxy.MergeABIs(append(append(abi.AbiArray{}, elements...), resolved.ABI()...), implementationABI)
+	}
+
+	return contractLoadedMsg{
+		storageClient:        storageClient,
+		transport:            tr,
+		contract:             &contract,
+		proxy:                resolved,
+		proxyErr:             proxyErr,
+		implementationSource: implementationSource,
+		elements:             elements,
+		functions:            functionsOf(elements),
+	}
+}
+
+// findImplementation looks up the stored contract, with an ABI, deployed at the implementation address
+// on the same endpoint as the proxy.
+func findImplementation(storageClient sql.Storage, endpointID uint, address common.Address) (models.EVMContract, bool) {
+	result, err := storageClient.SearchContracts(address.Hex())
+	if err != nil {
+		logger.Error("Failed to search implementation contract %s: %v", address.Hex(), err)
+		return models.EVMContract{}, false
+	}
+	for _, contract := range result.Items {
+		if contract.EndpointId == endpointID && contract.Abi != nil && strings.EqualFold(contract.Address, address.Hex()) {
+			return contract, true
+		}
+	}
+	return models.EVMContract{}, false
+}
+
+func functionsOf(elements abi.AbiArray) []abi.ABIElement {
+	functions := []abi.ABIElement{}
+	for _, element := range elements {
+		if element.Type == "function" {
+			element.StateMutability = string(element.EffectiveStateMutability())
+			functions = append(functions, element)
+		}
+	}
+	return functions
+}
+
+func (m Model) selectedFunction() abi.ABIElement {
+	return m.functions[m.selectedIndex]
+}
+
+func (m Model) selectedIsReadOnly() bool {
+	function := m.selectedFunction()
+	return function.IsReadOnly()
+}
+
+// run calls a read-only function through the transport, or sends a transaction signed by the
+// selected wallet for any other function.
+func (m Model) run(value *big.Int, args []any) tea.Cmd {
+	function := m.selectedFunction()
+	address := common.HexToAddress(m.contract.Address)
+	contractABI := abi.ABI{}
+	contractABI.SetElements(abi.ABIArray{function})
+
+	if function.IsReadOnly() {
+		options := m.callOptions
+		return func() tea.Msg {
+			data, err := m.transport.CallContractWithOptions(address, contractABI, function.Name, options, args...)
+			if err != nil {
+				return methodRunMsg{err: err}
+			}
+			values, err := decoder.DecodeValues(function.Outputs, data)
+			if err != nil {
+				return methodRunMsg{err: err}
+			}
+			lines := optionLines(options)
+			for _, value := range values {
+				lines = append(lines, value.Lines()...)
+			}
+			if len(values) == 0 {
+				lines = append(lines, "(no return values)")
+			}
+			return methodRunMsg{lines: lines}
+		}
+	}
+
+	return func() tea.Msg {
+		walletService, walletID, err := m.selectedWallet()
+		if err != nil {
+			return methodRunMsg{err: err}
+		}
+		walletSigner, err := wallet.NewSigner(walletService, walletID, m.transport)
+		if err != nil {
+			return methodRunMsg{err: err}
+		}
+		data, err := function.EncodeCall(args...)
+		if err != nil {
+			return methodRunMsg{err: err}
+		}
+		receipt, err := walletSigner.Transact(address, data, value, 0, nil)
+		if err != nil {
+			logger.Error("Failed to send %s: %v", function.Name, err)
+			return methodRunMsg{err: err}
+		}
+		m.recordGas(gasreport.CallRecord(m.contract.ID, function, receipt))
+		lines := []string{"Transaction: " + receipt.TxHash.Hex()}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return methodRunMsg{lines: lines, err: fmt.Errorf("transaction reverted")}
+		}
+		return methodRunMsg{lines: append(lines, "Status: success")}
+	}
+}
+
+// optionLines describes the block and state overrides of a read-only call, nothing for the defaults.
+func optionLines(options transport.CallOptions) []string {
+	lines := []string{}
+	if options.Block != nil {
+		lines = append(lines, "At block: "+options.Block.String())
+	}
+	if len(options.StateOverrides) > 0 {
+		lines = append(lines, "State overrides:")
+		for _, line := range options.StateOverrides.Lines() {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return lines
+}
+
+// simulate runs the pending write call from the selected wallet at the pending block, so its effect is
+// shown before the transaction is signed.
+func (m Model) simulate() tea.Cmd {
+	function := m.selectedFunction()
+	address := common.HexToAddress(m.contract.Address)
+	value, args := m.pendingValue, m.pendingArgs
+
+	return func() tea.Msg {
+		data, err := function.EncodeCall(args...)
+		if err != nil {
+			return simulationMsg{err: err}
+		}
+		walletService, walletID, err := m.selectedWallet()
+		if err != nil {
+			return simulationMsg{err: err}
+		}
+		walletData, err := walletService.GetWallet(walletID)
+		if err != nil {
+			return simulationMsg{err: fmt.Errorf("failed to get wallet: %w", err)}
+		}
+
+		result, err := simulation.Simulate(m.transport, simulation.Call{
+			From:       common.HexToAddress(walletData.Address),
+			To:         address,
+			Value:      value,
+			Data:       data,
+			Function:   function,
+			ABI:        m.elements,
+			Signatures: signatures.NewDatabase(m.storageClient),
+		})
+		if err != nil {
+			logger.Error("Failed to simulate %s: %v", function.Name, err)
+			return simulationMsg{err: err}
+		}
+		if result.Traced && !result.Reverted {
+			m.recordGas(gasreport.SimulationRecord(m.contract.ID, function, result.GasUsed))
+		}
+		return simulationMsg{result: result}
+	}
+}
+
+// recordGas adds a call to the transaction history of the gas report. A failure is only logged, it
+// does not fail the call.
+func (m Model) recordGas(record models.EVMTransaction) {
+	if _, err := m.storageClient.RecordTransaction(record); err != nil {
+		logger.Error("Failed to record gas of %s: %v", record.Selector, err)
+	}
+}
+
+// propose builds the selected call as a transaction of the Safe, signs it with the selected wallet when it
+// is an owner and stores the proposal so the other owners can sign it.
+func (m Model) propose(safeAddress common.Address) tea.Cmd {
+	function := m.selectedFunction()
+	address := common.HexToAddress(m.contract.Address)
+	value, args := m.pendingValue, m.pendingArgs
+
+	return func() tea.Msg {
+		data, err := function.EncodeCall(args...)
+		if err != nil {
+			return methodRunMsg{err: err}
+		}
+
+		walletService, walletID, err := m.selectedWallet()
+		if err != nil {
+			return methodRunMsg{err: err}
+		}
+		walletData, err := walletService.GetWallet(walletID)
+		if err != nil {
+			return methodRunMsg{err: fmt.Errorf("failed to get wallet: %w", err)}
+		}
+		proposer, err := wallet.NewAccountSigner(walletService, walletData)
+		if err != nil {
+			return methodRunMsg{err: err}
+		}
+
+		proposal, err := safe.Propose(m.transport, proposer, safeAddress, m.contract.EndpointId, address, value, data, function.HumanReadable())
+		if err != nil {
+			logger.Error("Failed to propose %s to Safe %s: %v", function.Name, safeAddress.Hex(), err)
+			return methodRunMsg{err: err}
+		}
+		id, err := m.storageClient.CreateSafeProposal(proposal)
+		if err != nil {
+			logger.Error("Failed to store Safe proposal: %v", err)
+			return methodRunMsg{err: err}
+		}
+
+		lines := []string{
+			fmt.Sprintf("Proposal: #%d", id),
+			"Safe: " + proposal.SafeAddress,
+			fmt.Sprintf("Nonce: %d", proposal.Nonce),
+			"SafeTx hash: " + proposal.SafeTxHash,
+		}
+		if len(proposal.Signatures) > 0 {
+			lines = append(lines, fmt.Sprintf("Signed by %s (%s)", walletData.Alias, walletData.Address))
+		} else {
+			lines = append(lines, fmt.Sprintf("Not signed: %s is not an owner of the Safe", walletData.Alias))
+		}
+		lines = append(lines, "Collect the remaining signatures and execute it from Safe Proposals.")
+		return methodRunMsg{lines: lines}
+	}
+}
+
+func (m Model) selectedWallet() (wallet.WalletService, uint, error) {
+	config, err := m.storageClient.GetCurrentConfig()
+	if err != nil {
+		logger.Error("Failed to get current config: %v", err)
+		return nil, 0, fmt.Errorf("failed to get current config: %w", err)
+	}
+	if config.SelectedWalletID == nil {
+		return nil, 0, fmt.Errorf("no wallet selected. Please select a wallet first")
+	}
+
+	walletService := m.walletService
+	if walletService == nil {
+		secureStorage, _, err := utils.GetSecureStorageFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get secure storage from shared memory: %v", err)
+			return nil, 0, fmt.Errorf("failed to get secure storage from shared memory: %w", err)
+		}
+		walletService = wallet.NewWalletService(m.storageClient, secureStorage)
+	}
+	return walletService, *config.SelectedWalletID, nil
+}
+
+// collectArguments parses every function input, and the value input for payable functions.
+func (m Model) collectArguments() (*big.Int, []any, error) {
+	function := m.selectedFunction()
+	inputs := make([]string, 0, len(function.Inputs))
+	for index := range function.Inputs {
+		inputs = append(inputs, m.inputs[index].Value())
+	}
+	args, err := abi.ParseArguments(function.Inputs, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	value := big.NewInt(0)
+	if function.IsPayable() {
+		input := strings.TrimSpace(m.inputs[len(function.Inputs)].Value())
+		if input != "" {
+			parsed, err := abi.ParseArgument(abi.ABIParam{Name: "value", Type: "uint256"}, input)
+			if err != nil {
+				return nil, nil, err
+			}
+			value = parsed.(*big.Int)
+		}
+	}
+
+	return value, args, nil
+}
+
+// collectCallOptions parses the block and state override inputs that follow the arguments of a
+// read-only function.
+func (m Model) collectCallOptions() (transport.CallOptions, error) {
+	index := len(m.selectedFunction().Inputs)
+	block, err := transport.ParseBlock(m.inputs[index].Value())
+	if err != nil {
+		return transport.CallOptions{}, err
+	}
+	overrides, err := transport.ParseStateOverrides(m.inputs[index+1].Value())
+	if err != nil {
+		return transport.CallOptions{}, err
+	}
+	return transport.CallOptions{Block: block, StateOverrides: overrides}, nil
+}
+
+func paramLabel(param abi.ABIParam, index int) string {
+	return fmt.Sprintf("%s (%s)", abi.ArgumentName(param, index), param.Type)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case contractLoadedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.storageClient = msg.storageClient
+		m.transport = msg.transport
+		m.contract = msg.contract
+		m.proxy = msg.proxy
+		m.proxyErr = msg.proxyErr
+		m.implementationSource = msg.implementationSource
+		m.elements = msg.elements
+		m.functions = msg.functions
+		m.selectedIndex = 0
+		m.currentStep = stepMethods
+		return m, nil
+
+	case simulationMsg:
+		if msg.err != nil {
+			m.resultLines = nil
+			m.resultErr = msg.err.Error()
+			m.currentStep = stepResult
+			return m, nil
+		}
+		m.simulation = msg.result
+		m.currentStep = stepConfirm
+		return m, nil
+
+	case methodRunMsg:
+		m.resultLines = msg.lines
+		m.resultErr = ""
+		if msg.err != nil {
+			m.resultErr = msg.err.Error()
+		}
+		m.currentStep = stepResult
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepMethods:
+			return m.handleMethods(msg)
+		case stepArgs:
+			return m.handleArgs(msg)
+		case stepConfirm:
+			return m.handleConfirm(msg)
+		case stepSafeAddress:
+			return m.handleSafeAddress(msg)
+		case stepResult:
+			m.currentStep = stepMethods
+			return m, nil
+		case stepError:
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/contract", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleMethods(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.selectedIndex > 0 {
+			m.selectedIndex--
+		}
+	case "down", "j":
+		if m.selectedIndex < len(m.functions)-1 {
+			m.selectedIndex++
+		}
+	case "enter":
+		if len(m.functions) == 0 {
+			return m, nil
+		}
+		return m.selectFunction(false)
+	case "o":
+		if len(m.functions) == 0 || !m.selectedIsReadOnly() {
+			return m, nil
+		}
+		return m.selectFunction(true)
+	}
+	return m, nil
+}
+
+// selectFunction runs read-only functions without inputs straight away, unless withOptions asks for
+// the block and state overrides, and asks for the arguments of every other function.
+func (m Model) selectFunction(withOptions bool) (tea.Model, tea.Cmd) {
+	function := m.selectedFunction()
+	m.errorMsg = ""
+	m.proposed = false
+	m.inputs = make([]textinput.Model, 0, len(function.Inputs)+1)
+	for index, param := range function.Inputs {
+		input := textinput.New()
+		input.Placeholder = paramLabel(param, index)
+		input.Width = 66
+		m.inputs = append(m.inputs, input)
+	}
+	if function.IsPayable() {
+		input := textinput.New()
+		input.Placeholder = "value in wei (default 0)"
+		input.Width = 40
+		m.inputs = append(m.inputs, input)
+	}
+	if function.IsReadOnly() {
+		block := textinput.New()
+		block.Placeholder = "latest (number, tag or hash)"
+		block.Width = 66
+		overrides := textinput.New()
+		overrides.Placeholder = "0xAddress.balance=<wei>; 0xAddress.storage[<slot>]=<value>"
+		overrides.Width = 66
+		m.inputs = append(m.inputs, block, overrides)
+	}
+
+	m.callOptions = transport.CallOptions{}
+	if function.IsReadOnly() && len(function.Inputs) == 0 && !withOptions {
+		m.currentStep = stepRunning
+		return m, m.run(big.NewInt(0), nil)
+	}
+
+	m.currentStep = stepArgs
+	m.focusIndex = 0
+	if len(m.inputs) > 0 {
+		m.inputs[0].Focus()
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+func (m Model) handleArgs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "down":
+		return m.moveFocus(1)
+	case "shift+tab", "up":
+		return m.moveFocus(-1)
+	case "ctrl+b":
+		m.currentStep = stepMethods
+		m.errorMsg = ""
+		return m, nil
+	case "enter":
+		value, args, err := m.collectArguments()
+		if err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+		m.errorMsg = ""
+		if m.selectedIsReadOnly() {
+			options, err := m.collectCallOptions()
+			if err != nil {
+				m.errorMsg = err.Error()
+				return m, nil
+			}
+			m.callOptions = options
+			m.currentStep = stepRunning
+			return m, m.run(value, args)
+		}
+		m.pendingValue = value
+		m.pendingArgs = args
+		m.simulation = nil
+		m.currentStep = stepSimulating
+		return m, m.simulate()
+	case "ctrl+p":
+		if m.selectedIsReadOnly() {
+			return m, nil
+		}
+		value, args, err := m.collectArguments()
+		if err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+		return m.enterSafeAddress(value, args)
+	}
+
+	if len(m.inputs) == 0 {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focusIndex], cmd = m.inputs[m.focusIndex].Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+b":
+		m.currentStep = stepArgs
+		return m, nil
+	case "enter":
+		m.currentStep = stepRunning
+		return m, m.run(m.pendingValue, m.pendingArgs)
+	}
+	return m, nil
+}
+
+// enterSafeAddress keeps the parsed call and asks for the Safe that should make it.
+func (m Model) enterSafeAddress(value *big.Int, args []any) (tea.Model, tea.Cmd) {
+	m.pendingValue = value
+	m.pendingArgs = args
+	m.errorMsg = ""
+	m.safeInput = textinput.New()
+	m.safeInput.Placeholder = "Safe address (0x...)"
+	m.safeInput.Width = 44
+	m.safeInput.Focus()
+	m.currentStep = stepSafeAddress
+	return m, textinput.Blink
+}
+
+func (m Model) handleSafeAddress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+b":
+		m.currentStep = stepArgs
+		m.errorMsg = ""
+		return m, nil
+	case "enter":
+		input := strings.TrimSpace(m.safeInput.Value())
+		if !common.IsHexAddress(input) {
+			m.errorMsg = "invalid Safe address"
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.proposed = true
+		m.currentStep = stepRunning
+		return m, m.propose(common.HexToAddress(input))
+	}
+
+	var cmd tea.Cmd
+	m.safeInput, cmd = m.safeInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) moveFocus(delta int) (tea.Model, tea.Cmd) {
+	if len(m.inputs) == 0 {
+		return m, nil
+	}
+	m.inputs[m.focusIndex].Blur()
+	m.focusIndex = (m.focusIndex + delta + len(m.inputs)) % len(m.inputs)
+	m.inputs[m.focusIndex].Focus()
+	return m, textinput.Blink
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepMethods:
+		return "↑/k: up • ↓/j: down • enter: call method • o: call at block / with state overrides • esc/q: back", view.HelpDisplayOptionAppend
+	case stepArgs:
+		if m.selectedIsReadOnly() {
+			return "tab/↓: next field • shift+tab/↑: previous field • enter: call function • ctrl+b: back to methods", view.HelpDisplayOptionOverride
+		}
+		return "tab/↓: next field • shift+tab/↑: previous field • enter: simulate • ctrl+p: propose to Safe • ctrl+b: back to methods", view.HelpDisplayOptionOverride
+	case stepSimulating:
+		return "Simulating...", view.HelpDisplayOptionOverride
+	case stepConfirm:
+		return "enter: send transaction • ctrl+b: back to arguments", view.HelpDisplayOptionOverride
+	case stepSafeAddress:
+		return "enter: propose • ctrl+b: back to arguments", view.HelpDisplayOptionOverride
+	case stepRunning:
+		return "Running...", view.HelpDisplayOptionOverride
+	case stepResult:
+		return "Press any key to return to the method list", view.HelpDisplayOptionOverride
+	case stepError:
+		return "Press any key to return to contract list", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	switch m.currentStep {
+	case stepMethods:
+		return m.renderMethods()
+	case stepArgs:
+		return m.renderArgs()
+	case stepSimulating:
+		return component.VStackC(
+			component.T("Call Method - "+m.selectedFunction().HumanReadable()).Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Simulating the transaction at the pending block...").Muted(),
+		).Render()
+	case stepConfirm:
+		return m.renderConfirm()
+	case stepSafeAddress:
+		return m.renderSafeAddress()
+	case stepRunning:
+		return component.VStackC(
+			component.T("Call Method - "+m.selectedFunction().HumanReadable()).Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T(m.runningMessage()).Muted(),
+		).Render()
+	case stepResult:
+		return m.renderResult()
+	case stepError:
+		return component.VStackC(
+			component.T("Interact with Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			component.T("Interact with Contract").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Loading contract and detecting proxy...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) runningMessage() string {
+	if m.proposed {
+		return "Checking the Safe and signing the proposal..."
+	}
+	if m.selectedIsReadOnly() {
+		return "Calling function..."
+	}
+	return "Sending transaction and waiting for the receipt..."
+}
+
+func (m Model) renderHeader() component.Component {
+	lines := []component.Component{
+		component.T("Contract: " + m.contract.Name),
+		component.T("Address: " + m.contract.Address),
+		component.T(fmt.Sprintf("Network: %s (%s)", m.contract.Endpoint.Name, m.contract.Endpoint.Url)),
+	}
+	if m.proxyErr != "" {
+		lines = append(lines, component.T("⚠ Proxy detection failed, only the stored ABI is shown: "+m.proxyErr).Warning())
+	}
+	if m.proxy == nil || !m.proxy.IsProxy() {
+		return component.VStackC(lines...)
+	}
+
+	lines = append(lines, component.T("Proxy: "+m.proxy.Description()))
+	implementation := "Implementation: " + m.proxy.Implementation.Hex()
+	if m.implementationSource != "" {
+		implementation += " (ABI from " + m.implementationSource + ")"
+	}
+	lines = append(lines, component.T(implementation))
+	if m.proxy.Admin != (common.Address{}) {
+		lines = append(lines, component.T("Admin: "+m.proxy.Admin.Hex()))
+	}
+	if m.proxy.Beacon != (common.Address{}) {
+		lines = append(lines, component.T("Beacon: "+m.proxy.Beacon.Hex()))
+	}
+	if m.implementationSource == "" {
+		lines = append(lines, component.T("⚠ No stored contract has the implementation ABI. It is only taken from a contract stored at the implementation address on this network, add one to call its methods.").Warning())
+	}
+	return component.VStackC(lines...)
+}
+
+func (m Model) renderMethods() string {
+	items := make([]component.Component, 0, len(m.functions))
+	for index, function := range m.functions {
+		prefix := "  "
+		if index == m.selectedIndex {
+			prefix = "> "
+		}
+		kind := "write"
+		switch {
+		case function.IsReadOnly():
+			kind = "read"
+		case function.IsPayable():
+			kind = "payable"
+		}
+		item := component.T(fmt.Sprintf("%s%s  [%s]", prefix, function.HumanReadable(), kind))
+		if index == m.selectedIndex {
+			item = item.Bold(true)
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		items = append(items, component.T("The contract ABI has no functions.").Muted())
+	}
+
+	return component.VStackC(
+		component.T("Interact with Contract").Bold(true).Primary(),
+		component.SpacerV(1),
+		m.renderHeader(),
+		component.SpacerV(1),
+		component.T("Methods").Bold(true),
+		component.VStackC(items...),
+	).Render()
+}
+
+func (m Model) renderArgs() string {
+	function := m.selectedFunction()
+	fields := make([]component.Component, 0, len(m.inputs)*2)
+	for index, param := range function.Inputs {
+		fields = append(fields,
+			component.T(paramLabel(param, index)).Bold(index == m.focusIndex),
+			component.T(m.inputs[index].View()),
+		)
+	}
+	if function.IsPayable() {
+		index := len(function.Inputs)
+		fields = append(fields,
+			component.T("value (wei)").Bold(index == m.focusIndex),
+			component.T(m.inputs[index].View()),
+		)
+	}
+	if len(function.Inputs) == 0 {
+		fields = append([]component.Component{component.T("The function takes no arguments.").Muted()}, fields...)
+	}
+	if function.IsReadOnly() {
+		index := len(function.Inputs)
+		fields = append(fields,
+			component.SpacerV(1),
+			component.T("block (optional)").Bold(index == m.focusIndex),
+			component.T(m.inputs[index].View()),
+			component.T("state overrides (optional)").Bold(index+1 == m.focusIndex),
+			component.T(m.inputs[index+1].View()),
+			component.T("Fields: balance, nonce, code, storage[slot]. Separate overrides with ;").Muted(),
+		)
+	}
+
+	errorLine := component.Empty()
+	if m.errorMsg != "" {
+		errorLine = component.T("Error: " + m.errorMsg).Error()
+	}
+
+	return component.VStackC(
+		component.T("Call Method - "+function.HumanReadable()).Bold(true).Primary(),
+		component.SpacerV(1),
+		component.VStackC(fields...),
+		component.SpacerV(1),
+		errorLine,
+	).Render()
+}
+
+func (m Model) renderConfirm() string {
+	status := component.T("✓ The transaction is expected to succeed").Success()
+	if m.simulation.Reverted {
+		status = component.T("✗ The transaction is expected to revert").Error()
+	}
+
+	lines := make([]component.Component, 0)
+	for _, line := range m.simulation.Lines() {
+		lines = append(lines, component.T(line))
+	}
+
+	return component.VStackC(
+		component.T("Confirm Transaction - "+m.selectedFunction().HumanReadable()).Bold(true).Primary(),
+		component.SpacerV(1),
+		status,
+		component.SpacerV(1),
+		component.VStackC(lines...),
+		component.SpacerV(1),
+		component.T("Press enter to sign and send the transaction.").Muted(),
+	).Render()
+}
+
+func (m Model) renderSafeAddress() string {
+	errorLine := component.Empty()
+	if m.errorMsg != "" {
+		errorLine = component.T("Error: " + m.errorMsg).Error()
+	}
+
+	return component.VStackC(
+		component.T("Propose to Safe - "+m.selectedFunction().HumanReadable()).Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("The Safe calls "+m.contract.Address+" once enough owners have signed.").Muted(),
+		component.SpacerV(1),
+		component.T("Safe address").Bold(true),
+		component.T(m.safeInput.View()),
+		component.SpacerV(1),
+		errorLine,
+	).Render()
+}
+
+func (m Model) renderResult() string {
+	status := component.T("✓ Function called successfully").Bold(true)
+	switch {
+	case m.proposed:
+		status = component.T("✓ Safe transaction proposed").Bold(true)
+	case !m.selectedIsReadOnly():
+		status = component.T("✓ Transaction confirmed").Bold(true)
+	}
+	if m.resultErr != "" {
+		status = component.T("✗ " + m.resultErr).Error()
+	}
+
+	lines := make([]component.Component, 0, len(m.resultLines))
+	for _, line := range m.resultLines {
+		lines = append(lines, component.T(line))
+	}
+
+	return component.VStackC(
+		component.T("Call Method - "+m.selectedFunction().HumanReadable()).Bold(true).Primary(),
+		component.SpacerV(1),
+		status,
+		component.SpacerV(1),
+		component.VStackC(lines...),
+	).Render()
+}
@@ -0,0 +1,401 @@
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"math/big"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/proxy"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	proxyAddress          = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	implementationAddress = "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512"
+)
+
+// fakeTransport serves the EIP-1967 implementation slot of the proxy and answers every call with a
+// single uint256.
+type fakeTransport struct {
+	transport.Transport
+	storage     map[common.Hash]common.Hash
+	storageErr  error
+	called      string
+	callArgs    []any
+	callOptions transport.CallOptions
+
+	simulated   *ethereum.CallMsg
+	simulateErr error
+	traces      map[string]string
+}
+
+func (f *fakeTransport) GetStorageAt(address common.Address, slot common.Hash) (common.Hash, error) {
+	if f.storageErr != nil {
+		return common.Hash{}, f.storageErr
+	}
+	if address != common.HexToAddress(proxyAddress) {
+		return common.Hash{}, nil
+	}
+	return f.storage[slot], nil
+}
+
+func (f *fakeTransport) GetCode(common.Address) ([]byte, error) {
+	return common.FromHex("0x6080"), nil
+}
+
+func (f *fakeTransport) CallContract(_ common.Address, _ abi.ABI, functionName string, args ...any) ([]byte, error) {
+	f.called = functionName
+	f.callArgs = args
+	return common.LeftPadBytes(big.NewInt(42).Bytes(), 32), nil
+}
+
+func (f *fakeTransport) CallContractWithOptions(address common.Address, contractABI abi.ABI, functionName string, options transport.CallOptions, args ...any) ([]byte, error) {
+	f.callOptions = options
+	return f.CallContract(address, contractABI, functionName, args...)
+}
+
+func (f *fakeTransport) SimulateCall(msg ethereum.CallMsg) ([]byte, error) {
+	f.simulated = &msg
+	return nil, f.simulateErr
+}
+
+func (f *fakeTransport) TraceCall(_ ethereum.CallMsg, tracer string, _ map[string]any) (json.RawMessage, error) {
+	if trace, ok := f.traces[tracer]; ok {
+		return json.RawMessage(trace), nil
+	}
+	return nil, fmt.Errorf("the method debug_traceCall does not exist")
+}
+
+type RunPageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	transport   *fakeTransport
+	model       Model
+}
+
+func TestRunPageTestSuite(t *testing.T) {
+	suite.Run(t, new(RunPageTestSuite))
+}
+
+func (s *RunPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.transport = &fakeTransport{storage: map[common.Hash]common.Hash{}}
+	s.model = NewPageWithService(s.mockRouter, storage.NewSharedMemory(), s.mockStorage, nil, s.transport).(Model)
+}
+
+func (s *RunPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func parseABI(s *RunPageTestSuite, signatures ...string) *models.EvmAbi {
+	elements, err := abi.ParseHumanReadable(signatures)
+	s.Require().NoError(err)
+	return &models.EvmAbi{Name: "ABI", Abi: models.AbiArrayType{AbiArray: elements}}
+}
+
+func (s *RunPageTestSuite) proxyContract() models.EVMContract {
+	return models.EVMContract{
+		ID:         3,
+		Name:       "TokenProxy",
+		Address:    proxyAddress,
+		Status:     models.DeploymentStatusDeployed,
+		EndpointId: 1,
+		Endpoint:   &models.EVMEndpoint{Name: "Anvil", Url: "http://localhost:8545"},
+		Abi:        parseABI(s, "function upgradeToAndCall(address implementation, bytes data) payable"),
+	}
+}
+
+func (s *RunPageTestSuite) load(contract models.EVMContract) {
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("3")
+	s.mockStorage.EXPECT().GetContractByID(uint(3)).Return(contract, nil)
+
+	updated, _ := s.model.Update(s.model.loadContract())
+	s.model = updated.(Model)
+}
+
+func (s *RunPageTestSuite) press(msg tea.KeyMsg) tea.Cmd {
+	updated, cmd := s.model.Update(msg)
+	s.model = updated.(Model)
+	return cmd
+}
+
+func (s *RunPageTestSuite) TestProxyMergesImplementationABI() {
+	s.transport.storage[proxy.ImplementationSlot] = common.BytesToHash(common.HexToAddress(implementationAddress).Bytes())
+	implementation := models.EVMContract{
+		ID:         4,
+		Name:       "TokenV1",
+		Address:    implementationAddress,
+		EndpointId: 1,
+		Abi:        parseABI(s, "function totalSupply() view returns (uint256)", "function transfer(address to, uint256 amount) returns (bool)"),
+	}
+	s.mockStorage.EXPECT().SearchContracts(common.HexToAddress(implementationAddress).Hex()).Return(types.Pagination[models.EVMContract]{
+		Items: []models.EVMContract{implementation},
+	}, nil)
+
+	s.load(s.proxyContract())
+
+	s.Require().Equal(stepMethods, s.model.currentStep)
+	s.Equal(proxy.KindEIP1967, s.model.proxy.Kind)
+	s.Len(s.model.functions, 3)
+	output := s.model.View()
+	s.Contains(output, "Proxy: EIP-1967 proxy")
+	s.Contains(output, "(ABI from TokenV1)")
+	s.Contains(output, "function totalSupply() view returns (uint256)  [read]")
+	s.Contains(output, "function upgradeToAndCall(address implementation, bytes data) payable  [payable]")
+}
+
+func (s *RunPageTestSuite) TestProxyWithoutStoredImplementationWarns() {
+	s.transport.storage[proxy.ImplementationSlot] = common.BytesToHash(common.HexToAddress(implementationAddress).Bytes())
+	s.mockStorage.EXPECT().SearchContracts(gomock.Any()).Return(types.Pagination[models.EVMContract]{}, nil)
+
+	s.load(s.proxyContract())
+
+	s.Len(s.model.functions, 1)
+	s.Contains(s.model.View(), "No stored contract has the implementation ABI")
+}
+
+// TestProxyDetectionFailureFallsBackToStoredABI tests that a node failing the proxy slot reads leaves
+// the functions of the stored ABI callable.
+func (s *RunPageTestSuite) TestProxyDetectionFailureFallsBackToStoredABI() {
+	s.transport.storageErr = fmt.Errorf("eth_getStorageAt is not available")
+
+	s.load(s.proxyContract())
+
+	s.Require().Equal(stepMethods, s.model.currentStep)
+	s.Nil(s.model.proxy)
+	s.Len(s.model.functions, 1)
+	s.Contains(s.model.View(), "Proxy detection failed, only the stored ABI is shown")
+}
+
+func (s *RunPageTestSuite) TestCallReadFunction() {
+	contract := s.proxyContract()
+	contract.Abi = parseABI(s, "function balanceOf(address owner) view returns (uint256 balance)")
+	s.load(contract)
+	s.NotContains(s.model.View(), "Proxy:")
+
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Require().Equal(stepArgs, s.model.currentStep)
+
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Contains(s.model.errorMsg, "owner")
+
+	s.model.inputs[0].SetValue(implementationAddress)
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Require().Equal(stepRunning, s.model.currentStep)
+	s.model = s.mustUpdate(cmd())
+
+	s.Equal(stepResult, s.model.currentStep)
+	s.Equal("balanceOf", s.transport.called)
+	s.Equal([]any{common.HexToAddress(implementationAddress)}, s.transport.callArgs)
+	s.Contains(s.model.View(), "balance (uint256): 42")
+
+	s.press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	s.Equal(stepMethods, s.model.currentStep)
+}
+
+func (s *RunPageTestSuite) TestCallReadFunctionAtBlockWithOverrides() {
+	contract := s.proxyContract()
+	contract.Abi = parseABI(s, "function totalSupply() view returns (uint256)")
+	s.load(contract)
+	help, _ := s.model.Help()
+	s.Contains(help, "o: call at block / with state overrides")
+
+	s.press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	s.Require().Equal(stepArgs, s.model.currentStep)
+	output := s.model.View()
+	s.Contains(output, "The function takes no arguments.")
+	s.Contains(output, "block (optional)")
+	s.Contains(output, "state overrides (optional)")
+
+	s.model.inputs[0].SetValue("12")
+	s.model.inputs[1].SetValue(proxyAddress + ".owner=1")
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Equal(stepArgs, s.model.currentStep)
+	s.Contains(s.model.errorMsg, "unknown field owner")
+
+	s.model.inputs[1].SetValue(proxyAddress + ".storage[0]=0x2a")
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Require().Equal(stepRunning, s.model.currentStep)
+	s.model = s.mustUpdate(cmd())
+
+	s.Equal("totalSupply", s.transport.called)
+	s.Equal("0xc", s.transport.callOptions.Block.String())
+	s.Len(s.transport.callOptions.StateOverrides, 1)
+	output = s.model.View()
+	s.Contains(output, "At block: 0xc")
+	s.Contains(output, "State overrides:")
+	s.Contains(output, "(uint256): 42")
+}
+
+func (s *RunPageTestSuite) TestReadFunctionWithoutArgumentsRunsAtLatestBlock() {
+	contract := s.proxyContract()
+	contract.Abi = parseABI(s, "function totalSupply() view returns (uint256)")
+	s.load(contract)
+
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Require().Equal(stepRunning, s.model.currentStep)
+	s.model = s.mustUpdate(cmd())
+
+	s.True(s.transport.callOptions.IsZero())
+	s.NotContains(s.model.View(), "At block")
+}
+
+func (s *RunPageTestSuite) TestWriteFunctionRequiresWallet() {
+	contract := s.proxyContract()
+	contract.Abi = parseABI(s, "function pause()")
+	s.load(contract)
+	s.mockStorage.EXPECT().GetCurrentConfig().Return(models.EVMConfig{}, nil)
+
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Require().Equal(stepArgs, s.model.currentStep)
+	s.Contains(s.model.View(), "The function takes no arguments.")
+
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model = s.mustUpdate(cmd())
+	s.Contains(s.model.View(), "no wallet selected")
+}
+
+func (s *RunPageTestSuite) TestProposeToSafeAsksForSafeAddress() {
+	contract := s.proxyContract()
+	contract.Abi = parseABI(s, "function setValue(uint256 newValue)")
+	s.load(contract)
+
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Require().Equal(stepArgs, s.model.currentStep)
+	help, _ := s.model.Help()
+	s.Contains(help, "ctrl+p: propose to Safe")
+
+	s.model.inputs[0].SetValue("42")
+	s.press(tea.KeyMsg{Type: tea.KeyCtrlP})
+	s.Require().Equal(stepSafeAddress, s.model.currentStep)
+	s.Equal([]any{big.NewInt(42)}, s.model.pendingArgs)
+	s.Contains(s.model.View(), "Propose to Safe - function setValue(uint256 newValue)")
+
+	s.model.safeInput.SetValue("not an address")
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Equal(stepSafeAddress, s.model.currentStep)
+	s.Contains(s.model.View(), "invalid Safe address")
+
+	s.press(tea.KeyMsg{Type: tea.KeyCtrlB})
+	s.Equal(stepArgs, s.model.currentStep)
+}
+
+func (s *RunPageTestSuite) TestReadFunctionCannotBeProposed() {
+	contract := s.proxyContract()
+	contract.Abi = parseABI(s, "function balanceOf(address owner) view returns (uint256 balance)")
+	s.load(contract)
+
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model.inputs[0].SetValue(implementationAddress)
+	s.press(tea.KeyMsg{Type: tea.KeyCtrlP})
+	s.Equal(stepArgs, s.model.currentStep)
+}
+
+func (s *RunPageTestSuite) selectWallet() {
+	walletID := uint(1)
+	walletService := wallet.NewMockWalletService(s.mockCtrl)
+	s.model.walletService = walletService
+	s.mockStorage.EXPECT().GetCurrentConfig().Return(models.EVMConfig{SelectedWalletID: &walletID}, nil)
+	walletService.EXPECT().GetWallet(walletID).Return(&models.EVMWallet{ID: walletID, Alias: "deployer", Address: implementationAddress}, nil)
+}
+
+func (s *RunPageTestSuite) TestWriteFunctionIsSimulatedBeforeSending() {
+	contract := s.proxyContract()
+	contract.Abi = parseABI(s, "function setValue(uint256 newValue)")
+	s.load(contract)
+	s.selectWallet()
+
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model.inputs[0].SetValue("42")
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Require().Equal(stepSimulating, s.model.currentStep)
+	s.model = s.mustUpdate(cmd())
+
+	s.Require().Equal(stepConfirm, s.model.currentStep)
+	s.Require().NotNil(s.transport.simulated)
+	s.Equal(common.HexToAddress(implementationAddress), s.transport.simulated.From)
+	s.Equal(common.HexToAddress(proxyAddress), *s.transport.simulated.To)
+	output := s.model.View()
+	s.Contains(output, "The transaction is expected to succeed")
+	s.Contains(output, "the node does not support debug_traceCall")
+
+	s.press(tea.KeyMsg{Type: tea.KeyCtrlB})
+	s.Equal(stepArgs, s.model.currentStep)
+}
+
+func (s *RunPageTestSuite) TestTracedSimulationIsRecordedForGasReport() {
+	contract := s.proxyContract()
+	contract.Abi = parseABI(s, "function setValue(uint256 newValue)")
+	s.load(contract)
+	s.selectWallet()
+	s.transport.traces = map[string]string{
+		"callTracer":     `{"type":"CALL","gasUsed":"0xafc8"}`,
+		"prestateTracer": `{"pre":{},"post":{}}`,
+	}
+	s.mockStorage.EXPECT().RecordTransaction(gomock.Any()).DoAndReturn(func(record models.EVMTransaction) (uint, error) {
+		s.Equal(uint(3), record.ContractId)
+		s.Equal(models.TransactionKindSimulation, record.Kind)
+		s.Equal("0x55241077", record.Selector)
+		s.Equal("setValue(uint256)", record.Function)
+		s.Equal(uint64(45000), record.GasUsed)
+		return 1, nil
+	})
+
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model.inputs[0].SetValue("42")
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model = s.mustUpdate(cmd())
+
+	s.Require().Equal(stepConfirm, s.model.currentStep)
+	s.Require().NotNil(s.model.simulation)
+	s.True(s.model.simulation.Traced)
+}
+
+func (s *RunPageTestSuite) TestSimulatedRevertIsShown() {
+	contract := s.proxyContract()
+	contract.Abi = parseABI(s, "function pause()")
+	s.load(contract)
+	s.selectWallet()
+	s.transport.simulateErr = errors.NewTransportError(errors.ErrCodeCallReverted, "simulated call reverted")
+
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model = s.mustUpdate(cmd())
+
+	s.Require().Equal(stepConfirm, s.model.currentStep)
+	output := s.model.View()
+	s.Contains(output, "The transaction is expected to revert")
+	s.Contains(output, "Reverts: execution reverted without data")
+}
+
+func (s *RunPageTestSuite) TestUndeployedContractShowsError() {
+	contract := s.proxyContract()
+	contract.Status = models.DeploymentStatusPending
+	s.load(contract)
+
+	s.Equal(stepError, s.model.currentStep)
+	s.Contains(s.model.View(), "not deployed")
+}
+
+func (s *RunPageTestSuite) mustUpdate(msg tea.Msg) Model {
+	updated, _ := s.model.Update(msg)
+	return updated.(Model)
+}
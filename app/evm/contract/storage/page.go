@@ -0,0 +1,401 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/endpointgroup"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/proxy"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storagelayout"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storagelayout/inspector"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/contract/storage.log")
+
+type storageStep int
+
+const (
+	stepLoading storageStep = iota
+	stepInspect
+	stepError
+)
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+	transport     transport.Transport
+
+	currentStep storageStep
+	contract    *models.EVMContract
+
+	proxy        *proxy.Proxy
+	layoutSource string
+	layout       *storagelayout.Layout
+	inspector    *inspector.Inspector
+
+	variables []decoder.Value
+	readErr   string
+
+	input       textinput.Model
+	evaluating  bool
+	resultLines []string
+
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithService(router, sharedMemory, nil, nil)
+}
+
+// NewPageWithService creates a new storage inspector page with an optional storage client and
+// transport (for testing).
+func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage, tr transport.Transport) view.View {
+	input := textinput.New()
+	input.Placeholder = "balances[0x…], owners[1].wallet or a slot number"
+	input.Width = 66
+	input.Focus()
+
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		transport:     tr,
+		currentStep:   stepLoading,
+		input:         input,
+	}
+}
+
+type contractLoadedMsg struct {
+	storageClient sql.Storage
+	transport     transport.Transport
+	contract      *models.EVMContract
+	proxy         *proxy.Proxy
+	layoutSource  string
+	layout        *storagelayout.Layout
+	err           error
+}
+
+type variablesReadMsg struct {
+	inspector *inspector.Inspector
+	variables []decoder.Value
+	err       error
+}
+
+type evaluatedMsg struct {
+	lines []string
+	err   error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadContract
+}
+
+func (m Model) loadContract() tea.Msg {
+	contractID, err := strconv.ParseUint(m.router.GetQueryParam("id"), 10, 64)
+	if err != nil {
+		return contractLoadedMsg{err: fmt.Errorf("invalid contract ID: %w", err)}
+	}
+
+	storageClient := m.storageClient
+	if storageClient == nil {
+		sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get storage client from shared memory: %v", err)
+			return contractLoadedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+		}
+		storageClient = sqlStorage
+	}
+
+	contract, err := storageClient.GetContractByID(uint(contractID))
+	if err != nil {
+		logger.Error("Failed to load contract %d: %v", contractID, err)
+		return contractLoadedMsg{err: fmt.Errorf("failed to load contract: %w", err)}
+	}
+	if contract.Status != models.DeploymentStatusDeployed || !common.IsHexAddress(contract.Address) {
+		return contractLoadedMsg{err: fmt.Errorf("contract %s is not deployed", contract.Name)}
+	}
+	if contract.Endpoint == nil {
+		return contractLoadedMsg{err: fmt.Errorf("contract %s has no network endpoint", contract.Name)}
+	}
+
+	tr := m.transport
+	if tr == nil {
+		tr, err = endpointgroup.NewTransport(storageClient, *contract.Endpoint, 30*time.Second)
+		if err != nil {
+			return contractLoadedMsg{err: err}
+		}
+	}
+
+	resolved, err := proxy.ResolveContract(tr, contract)
+	if err != nil {
+		logger.Error("Failed to resolve proxy of contract %d: %v", contract.ID, err)
+		return contractLoadedMsg{err: fmt.Errorf("failed to detect proxy: %w", err)}
+	}
+
+	// A proxy keeps the state of its implementation, so the implementation's layout describes it.
+	layout := contract.StorageLayout.Layout
+	layoutSource := contract.Name
+	if resolved.IsProxy() {
+		layout, layoutSource = nil, ""
+		if implementation, ok := findImplementation(storageClient, contract.EndpointId, resolved.Implementation); ok {
+			layout = implementation.StorageLayout.Layout
+			layoutSource = implementation.Name
+		}
+	}
+	if layout.IsEmpty() {
+		layoutSource = ""
+	}
+
+	return contractLoadedMsg{
+		storageClient: storageClient,
+		transport:     tr,
+		contract:      &contract,
+		proxy:         resolved,
+		layoutSource:  layoutSource,
+		layout:        layout,
+	}
+}
+
+// findImplementation looks up the stored contract, with a storage layout, deployed at the
+// implementation address on the same endpoint as the proxy.
+func findImplementation(storageClient sql.Storage, endpointID uint, address common.Address) (models.EVMContract, bool) {
+	result, err := storageClient.SearchContracts(address.Hex())
+	if err != nil {
+		logger.Error("Failed to search implementation contract %s: %v", address.Hex(), err)
+		return models.EVMContract{}, false
+	}
+	for _, contract := range result.Items {
+		if contract.EndpointId == endpointID && !contract.StorageLayout.IsEmpty() && strings.EqualFold(contract.Address, address.Hex()) {
+			return contract, true
+		}
+	}
+	return models.EVMContract{}, false
+}
+
+// readVariables reads every state variable with a fresh inspector, so refreshing bypasses the slot cache.
+func (m Model) readVariables() tea.Msg {
+	slots := inspector.New(m.transport, common.HexToAddress(m.contract.Address), m.layout)
+	if !slots.HasLayout() {
+		return variablesReadMsg{inspector: slots}
+	}
+	variables, err := slots.Variables()
+	if err != nil {
+		logger.Error("Failed to read state variables of contract %d: %v", m.contract.ID, err)
+	}
+	return variablesReadMsg{inspector: slots, variables: variables, err: err}
+}
+
+func (m Model) evaluate(expression string) tea.Cmd {
+	slots := m.inspector
+	return func() tea.Msg {
+		value, err := slots.Evaluate(expression)
+		if err != nil {
+			return evaluatedMsg{err: err}
+		}
+		return evaluatedMsg{lines: value.Lines()}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case contractLoadedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.storageClient = msg.storageClient
+		m.transport = msg.transport
+		m.contract = msg.contract
+		m.proxy = msg.proxy
+		m.layoutSource = msg.layoutSource
+		m.layout = msg.layout
+		m.currentStep = stepInspect
+		return m, tea.Batch(m.readVariables, textinput.Blink)
+
+	case variablesReadMsg:
+		m.inspector = msg.inspector
+		m.variables = msg.variables
+		m.readErr = ""
+		if msg.err != nil {
+			m.readErr = msg.err.Error()
+		}
+		return m, nil
+
+	case evaluatedMsg:
+		m.evaluating = false
+		m.resultLines = msg.lines
+		m.errorMsg = ""
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepInspect:
+			return m.handleInspect(msg)
+		case stepError:
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/contract", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleInspect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		expression := strings.TrimSpace(m.input.Value())
+		if expression == "" || m.inspector == nil || m.evaluating {
+			return m, nil
+		}
+		m.evaluating = true
+		m.errorMsg = ""
+		return m, m.evaluate(expression)
+	case "ctrl+r":
+		m.inspector = nil
+		m.variables = nil
+		m.resultLines = nil
+		m.errorMsg = ""
+		return m, m.readVariables
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepInspect:
+		return "enter: read expression • ctrl+r: refresh • esc: back", view.HelpDisplayOptionOverride
+	case stepError:
+		return "Press any key to return to contract list", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	title := component.T("Contract Storage").Bold(true).Primary()
+
+	switch m.currentStep {
+	case stepInspect:
+		return m.renderInspect()
+	case stepError:
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Loading contract and detecting proxy...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) renderHeader() component.Component {
+	lines := []component.Component{
+		component.T("Contract: " + m.contract.Name),
+		component.T("Address: " + m.contract.Address),
+		component.T(fmt.Sprintf("Network: %s (%s)", m.contract.Endpoint.Name, m.contract.Endpoint.Url)),
+	}
+	if m.proxy != nil && m.proxy.IsProxy() {
+		lines = append(lines,
+			component.T("Proxy: "+m.proxy.Description()),
+			component.T("Implementation: "+m.proxy.Implementation.Hex()),
+		)
+	}
+	if m.layoutSource != "" {
+		source := "Storage layout: " + m.layoutSource
+		if m.layout.Derived {
+			// solc-go does not return solc's storageLayout output, so compiled contracts carry a layout
+			// built from their AST, while imported artifacts carry the one emitted by solc
+			source += " (derived from the compiler AST)"
+		}
+		lines = append(lines, component.T(source).Muted())
+	}
+	return component.VStackC(lines...)
+}
+
+func (m Model) renderVariables() component.Component {
+	if m.layout.IsEmpty() {
+		message := "⚠ No storage layout is stored for this contract. Compile it from source or import an artifact with a storage layout to decode state variables; enter a slot number to read raw storage."
+		if m.proxy != nil && m.proxy.IsProxy() {
+			message = "⚠ No stored contract at the implementation address has a storage layout. Add the implementation as a contract to decode state variables; enter a slot number to read raw storage."
+		}
+		return component.T(message).Warning()
+	}
+
+	rows := []component.Component{component.T("State variables").Bold(true)}
+	switch {
+	case m.readErr != "":
+		rows = append(rows, component.T("Error: "+m.readErr).Error())
+	case m.inspector == nil:
+		rows = append(rows, component.T("Reading storage...").Muted())
+	case len(m.variables) == 0:
+		rows = append(rows, component.T("The contract has no state variables.").Muted())
+	}
+	for _, variable := range m.variables {
+		for _, line := range variable.Lines() {
+			rows = append(rows, component.T(line))
+		}
+	}
+	return component.VStackC(rows...)
+}
+
+func (m Model) renderInspect() string {
+	result := component.Empty()
+	switch {
+	case m.evaluating:
+		result = component.T("Reading...").Muted()
+	case m.errorMsg != "":
+		result = component.T("Error: " + m.errorMsg).Error()
+	case len(m.resultLines) > 0:
+		lines := make([]component.Component, 0, len(m.resultLines))
+		for _, line := range m.resultLines {
+			lines = append(lines, component.T(line))
+		}
+		result = component.VStackC(lines...)
+	}
+
+	prompt := "Read a variable, mapping entry, array element or raw slot"
+	if m.layout.IsEmpty() {
+		prompt = "Read a raw slot (decimal or 0x-prefixed)"
+	}
+
+	return component.VStackC(
+		component.T("Contract Storage").Bold(true).Primary(),
+		component.SpacerV(1),
+		m.renderHeader(),
+		component.SpacerV(1),
+		m.renderVariables(),
+		component.SpacerV(1),
+		component.T(prompt).Bold(true),
+		component.T(m.input.View()),
+		component.SpacerV(1),
+		result,
+	).Render()
+}
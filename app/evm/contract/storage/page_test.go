@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"math/big"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/proxy"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storagelayout"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	contractAddress       = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	implementationAddress = "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512"
+	holderAddress         = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+)
+
+// fakeTransport serves the storage of the contract and reverts every call.
+type fakeTransport struct {
+	transport.Transport
+	storage map[common.Hash]common.Hash
+}
+
+func (f *fakeTransport) GetStorageAt(address common.Address, slot common.Hash) (common.Hash, error) {
+	if address != common.HexToAddress(contractAddress) {
+		return common.Hash{}, nil
+	}
+	return f.storage[slot], nil
+}
+
+func (f *fakeTransport) GetCode(common.Address) ([]byte, error) {
+	return common.FromHex("0x6080"), nil
+}
+
+func (f *fakeTransport) CallContract(common.Address, abi.ABI, string, ...any) ([]byte, error) {
+	return nil, errors.NewTransportError(errors.ErrCodeCallReverted, "execution reverted")
+}
+
+// tokenLayout is the layout of a contract with `uint256 totalSupply; mapping(address => uint256) balances;`.
+func tokenLayout() *storagelayout.Layout {
+	return &storagelayout.Layout{
+		Storage: []storagelayout.Variable{
+			{Label: "totalSupply", Slot: "0", Type: "t_uint256"},
+			{Label: "balances", Slot: "1", Type: "t_mapping(t_address,t_uint256)"},
+		},
+		Types: map[string]storagelayout.Type{
+			"t_uint256": {Encoding: storagelayout.EncodingInplace, Label: "uint256", NumberOfBytes: "32"},
+			"t_address": {Encoding: storagelayout.EncodingInplace, Label: "address", NumberOfBytes: "20"},
+			"t_mapping(t_address,t_uint256)": {
+				Encoding: storagelayout.EncodingMapping, Label: "mapping(address => uint256)", NumberOfBytes: "32",
+				Key: "t_address", Value: "t_uint256",
+			},
+		},
+	}
+}
+
+type StoragePageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	transport   *fakeTransport
+	model       Model
+}
+
+func TestStoragePageTestSuite(t *testing.T) {
+	suite.Run(t, new(StoragePageTestSuite))
+}
+
+func (s *StoragePageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.transport = &fakeTransport{storage: map[common.Hash]common.Hash{}}
+	s.model = NewPageWithService(s.mockRouter, storage.NewSharedMemory(), s.mockStorage, s.transport).(Model)
+
+	s.transport.storage[common.BigToHash(big.NewInt(0))] = common.BigToHash(big.NewInt(1000))
+	balance := crypto.Keccak256Hash(common.LeftPadBytes(common.HexToAddress(holderAddress).Bytes(), 32), common.BigToHash(big.NewInt(1)).Bytes())
+	s.transport.storage[balance] = common.BigToHash(big.NewInt(250))
+}
+
+func (s *StoragePageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *StoragePageTestSuite) contract(layout *storagelayout.Layout) models.EVMContract {
+	return models.EVMContract{
+		ID:            3,
+		Name:          "Token",
+		Address:       contractAddress,
+		Status:        models.DeploymentStatusDeployed,
+		EndpointId:    1,
+		Endpoint:      &models.EVMEndpoint{Name: "Anvil", Url: "http://localhost:8545"},
+		StorageLayout: models.StorageLayoutType{Layout: layout},
+	}
+}
+
+// load loads the contract and reads its state variables.
+func (s *StoragePageTestSuite) load(contract models.EVMContract) {
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("3")
+	s.mockStorage.EXPECT().GetContractByID(uint(3)).Return(contract, nil)
+
+	s.model = s.update(s.model.loadContract())
+	if s.model.currentStep == stepInspect {
+		s.model = s.update(s.model.readVariables())
+	}
+}
+
+func (s *StoragePageTestSuite) update(msg tea.Msg) Model {
+	updated, _ := s.model.Update(msg)
+	return updated.(Model)
+}
+
+// evaluate enters an expression and runs the read.
+func (s *StoragePageTestSuite) evaluate(expression string) {
+	s.model.input.SetValue(expression)
+	updated, cmd := s.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model = updated.(Model)
+	s.Require().NotNil(cmd)
+	s.True(s.model.evaluating)
+	s.model = s.update(cmd())
+}
+
+func (s *StoragePageTestSuite) TestDecodesStateVariablesAndMappingEntries() {
+	s.load(s.contract(tokenLayout()))
+
+	s.Require().Equal(stepInspect, s.model.currentStep)
+	output := s.model.View()
+	s.Contains(output, "Storage layout: Token")
+	s.Contains(output, "totalSupply (uint256): 1000")
+	s.Contains(output, "balances (mapping(address => uint256)): (mapping, enter balances[key] to read an entry)")
+
+	s.evaluate("balances[" + holderAddress + "]")
+	s.Empty(s.model.errorMsg)
+	s.Contains(s.model.View(), "balances["+holderAddress+"] (uint256): 250")
+
+	s.evaluate("missing")
+	s.Contains(s.model.View(), "state variable missing not found")
+}
+
+// TestDerivedLayoutIsLabelled tests that a layout built from the AST is told apart from solc's output.
+func (s *StoragePageTestSuite) TestDerivedLayoutIsLabelled() {
+	layout := tokenLayout()
+	layout.Derived = true
+	s.load(s.contract(layout))
+
+	s.Contains(s.model.View(), "Storage layout: Token (derived from the compiler AST)")
+}
+
+func (s *StoragePageTestSuite) TestWithoutLayoutReadsRawSlots() {
+	s.load(s.contract(nil))
+
+	output := s.model.View()
+	s.Contains(output, "No storage layout is stored for this contract")
+	s.Contains(output, "Read a raw slot")
+	s.NotContains(output, "State variables")
+
+	s.evaluate("0")
+	s.Contains(s.model.View(), "uint256 (uint256): 1000")
+}
+
+func (s *StoragePageTestSuite) TestProxyUsesImplementationLayout() {
+	s.transport.storage[proxy.ImplementationSlot] = common.BytesToHash(common.HexToAddress(implementationAddress).Bytes())
+	implementation := models.EVMContract{
+		ID:            4,
+		Name:          "TokenV1",
+		Address:       implementationAddress,
+		EndpointId:    1,
+		StorageLayout: models.StorageLayoutType{Layout: tokenLayout()},
+	}
+	s.mockStorage.EXPECT().SearchContracts(common.HexToAddress(implementationAddress).Hex()).Return(types.Pagination[models.EVMContract]{
+		Items: []models.EVMContract{implementation},
+	}, nil)
+
+	s.load(s.contract(nil))
+
+	output := s.model.View()
+	s.Contains(output, "Proxy: EIP-1967 proxy")
+	s.Contains(output, "Storage layout: TokenV1")
+	s.Contains(output, "totalSupply (uint256): 1000")
+}
+
+func (s *StoragePageTestSuite) TestRefreshRereadsStorage() {
+	s.load(s.contract(tokenLayout()))
+	s.transport.storage[common.BigToHash(big.NewInt(0))] = common.BigToHash(big.NewInt(2000))
+
+	updated, cmd := s.model.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	s.model = updated.(Model)
+	s.Contains(s.model.View(), "Reading storage...")
+	s.model = s.update(cmd())
+
+	s.Contains(s.model.View(), "totalSupply (uint256): 2000")
+}
+
+func (s *StoragePageTestSuite) TestUndeployedContractShowsError() {
+	contract := s.contract(nil)
+	contract.Status = models.DeploymentStatusPending
+	s.load(contract)
+
+	s.Equal(stepError, s.model.currentStep)
+	s.Contains(s.model.View(), "not deployed")
+}
@@ -0,0 +1,256 @@
+package decode
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/endpointgroup"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/signatures"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/decode.log")
+
+type decodeStep int
+
+const (
+	stepEnterInput decodeStep = iota
+	stepDecoding
+	stepResult
+)
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+
+	currentStep decodeStep
+	input       textinput.Model
+
+	transaction *decoder.Transaction
+	calls       []decoder.Call
+	errorMsg    string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithStorage(router, sharedMemory, nil)
+}
+
+// NewPageWithStorage creates a new calldata decoder page with an optional storage client (for testing).
+func NewPageWithStorage(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage) view.View {
+	input := textinput.New()
+	input.Placeholder = "0x calldata or transaction hash"
+	input.Width = 66
+	input.Focus()
+
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		currentStep:   stepEnterInput,
+		input:         input,
+	}
+}
+
+type decodedMsg struct {
+	transaction *decoder.Transaction
+	calls       []decoder.Call
+	err         error
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) getStorageClient() (sql.Storage, error) {
+	if m.storageClient != nil {
+		return m.storageClient, nil
+	}
+
+	sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+	if err != nil {
+		logger.Error("Failed to get storage client from shared memory: %v", err)
+		return nil, fmt.Errorf("failed to get storage client from shared memory: %w", err)
+	}
+	return sqlStorage, nil
+}
+
+func (m Model) decode() tea.Msg {
+	input, err := decoder.ParseInput(m.input.Value())
+	if err != nil {
+		return decodedMsg{err: err}
+	}
+
+	storageClient, err := m.getStorageClient()
+	if err != nil {
+		return decodedMsg{err: err}
+	}
+
+	callDecoder := decoder.New()
+	if err := callDecoder.AddStoredABIs(storageClient); err != nil {
+		logger.Error("Failed to load stored ABIs: %v", err)
+		return decodedMsg{err: fmt.Errorf("failed to load stored ABIs: %w", err)}
+	}
+	callDecoder.UseSignatures(signatures.NewDatabase(storageClient))
+
+	if !input.IsTransaction {
+		calls, err := callDecoder.Decode(input.Calldata)
+		return decodedMsg{calls: calls, err: err}
+	}
+
+	config, err := storageClient.GetCurrentConfig()
+	if err != nil {
+		logger.Error("Failed to get current config: %v", err)
+		return decodedMsg{err: fmt.Errorf("failed to get current config: %w", err)}
+	}
+	if config.Endpoint == nil {
+		return decodedMsg{err: fmt.Errorf("select an endpoint to fetch transactions by hash")}
+	}
+
+	tr, err := endpointgroup.NewTransport(storageClient, *config.Endpoint, 30*time.Second)
+	if err != nil {
+		logger.Error("Failed to create transport: %v", err)
+		return decodedMsg{err: err}
+	}
+
+	transaction, err := callDecoder.DecodeTransaction(tr, input.TxHash)
+	if transaction == nil {
+		return decodedMsg{err: err}
+	}
+	return decodedMsg{transaction: transaction, calls: transaction.Calls, err: err}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case decodedMsg:
+		m.transaction = msg.transaction
+		m.calls = msg.calls
+		m.errorMsg = ""
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+		}
+		if msg.transaction == nil && msg.err != nil {
+			m.currentStep = stepEnterInput
+			return m, textinput.Blink
+		}
+		m.currentStep = stepResult
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepEnterInput:
+			if msg.String() == "enter" {
+				m.currentStep = stepDecoding
+				return m, m.decode
+			}
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+
+		case stepResult:
+			if msg.String() == "n" {
+				m.input.SetValue("")
+				m.transaction = nil
+				m.calls = nil
+				m.errorMsg = ""
+				m.currentStep = stepEnterInput
+				return m, textinput.Blink
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepEnterInput:
+		return "enter: decode • esc: back", view.HelpDisplayOptionOverride
+	case stepResult:
+		return "n: decode another • esc: back", view.HelpDisplayOptionOverride
+	default:
+		return "Decoding...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	title := component.T("Calldata Decoder").Bold(true).Primary()
+
+	switch m.currentStep {
+	case stepEnterInput:
+		errorLine := component.Empty()
+		if m.errorMsg != "" {
+			errorLine = component.T("Error: " + m.errorMsg).Error()
+		}
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Paste calldata or a transaction hash").Bold(true),
+			component.T("Selectors are matched against the stored ABIs, well-known functions and the signature database").Muted(),
+			component.SpacerV(1),
+			component.T(m.input.View()),
+			component.SpacerV(1),
+			errorLine,
+		).Render()
+	case stepDecoding:
+		return component.VStackC(title, component.SpacerV(1), component.T("Decoding...").Muted()).Render()
+	default:
+		return m.renderResult()
+	}
+}
+
+func (m Model) renderResult() string {
+	rows := []component.Component{
+		component.T("Calldata Decoder").Bold(true).Primary(),
+		component.SpacerV(1),
+	}
+
+	if m.transaction != nil {
+		status := "mined"
+		if m.transaction.Pending {
+			status = "pending"
+		}
+		rows = append(rows, component.T(fmt.Sprintf("Transaction %s (%s)", m.transaction.Hash.Hex(), status)).Bold(true))
+		switch {
+		case m.transaction.To == nil:
+			rows = append(rows, component.T("Contract creation").Muted())
+		default:
+			rows = append(rows,
+				component.T("To: "+m.transaction.To.Hex()),
+				component.T(fmt.Sprintf("Value: %s wei", m.transaction.Value)),
+			)
+			if len(m.calls) == 0 && m.errorMsg == "" {
+				rows = append(rows, component.T("No calldata").Muted())
+			}
+		}
+		rows = append(rows, component.SpacerV(1))
+	}
+
+	if m.errorMsg != "" {
+		rows = append(rows, component.T("Error: "+m.errorMsg).Error())
+	}
+
+	if len(m.calls) > 1 {
+		rows = append(rows, component.T(fmt.Sprintf("%d functions match selector %s", len(m.calls), m.calls[0].Selector)).Warning(), component.SpacerV(1))
+	}
+	for index, call := range m.calls {
+		if index > 0 {
+			rows = append(rows, component.SpacerV(1))
+		}
+		lines := call.Lines()
+		rows = append(rows, component.T(lines[0]).Bold(true))
+		for _, line := range lines[1:] {
+			rows = append(rows, component.T(line))
+		}
+	}
+
+	return component.VStackC(rows...).Render()
+}
@@ -0,0 +1,111 @@
+package decode
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const transferCalldata = "0xa9059cbb00000000000000000000000070997970c51812dc3a010c7d01b50e0d17dc79c800000000000000000000000000000000000000000000000000000000000003e8"
+
+type DecodePageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	model       Model
+}
+
+func TestDecodePageTestSuite(t *testing.T) {
+	suite.Run(t, new(DecodePageTestSuite))
+}
+
+func (s *DecodePageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.model = NewPageWithStorage(s.mockRouter, storage.NewSharedMemory(), s.mockStorage).(Model)
+}
+
+func (s *DecodePageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *DecodePageTestSuite) decode(input string) {
+	s.model.input.SetValue(input)
+	updated, cmd := s.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model = updated.(Model)
+	s.Equal(stepDecoding, s.model.currentStep)
+	s.Require().NotNil(cmd)
+
+	updated, _ = s.model.Update(cmd())
+	s.model = updated.(Model)
+}
+
+func (s *DecodePageTestSuite) TestDecodeWithStoredABI() {
+	elements, err := abi.ParseHumanReadable([]string{"function transfer(address recipient, uint256 amount) returns (bool)"})
+	s.Require().NoError(err)
+	s.mockStorage.EXPECT().ListABIs(int64(1), gomock.Any()).Return(types.Pagination[models.EvmAbi]{
+		Items:      []models.EvmAbi{{ID: 1, Name: "MyToken", Abi: models.AbiArrayType{AbiArray: elements}}},
+		TotalPages: 1,
+	}, nil)
+
+	s.decode(transferCalldata)
+
+	output := s.model.View()
+	s.Equal(stepResult, s.model.currentStep)
+	s.Contains(output, "transfer(address,uint256)  [0xa9059cbb, MyToken]")
+	s.Contains(output, "recipient (address): 0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	s.Contains(output, "amount (uint256): 1000")
+}
+
+func (s *DecodePageTestSuite) TestUnknownSelector() {
+	s.mockStorage.EXPECT().ListABIs(int64(1), gomock.Any()).Return(types.Pagination[models.EvmAbi]{TotalPages: 1}, nil)
+	s.mockStorage.EXPECT().ListSignaturesByHash("0xdeadbeef").Return(nil, nil)
+
+	s.decode("0xdeadbeef")
+
+	s.Equal(stepEnterInput, s.model.currentStep)
+	s.Contains(s.model.View(), "no function found for selector 0xdeadbeef")
+}
+
+func (s *DecodePageTestSuite) TestDecodeWithSignatureDatabase() {
+	s.mockStorage.EXPECT().ListABIs(int64(1), gomock.Any()).Return(types.Pagination[models.EvmAbi]{TotalPages: 1}, nil)
+	s.mockStorage.EXPECT().ListSignaturesByHash("0xdeadbeef").Return([]models.EvmSignature{
+		{Kind: models.SignatureKindFunction, Hash: "0xdeadbeef", Signature: "unknownFn()", Source: "dump.txt"},
+	}, nil)
+
+	s.decode("0xdeadbeef")
+
+	s.Equal(stepResult, s.model.currentStep)
+	s.Contains(s.model.View(), "unknownFn()  [0xdeadbeef, signatures]")
+}
+
+func (s *DecodePageTestSuite) TestTransactionHashRequiresEndpoint() {
+	s.mockStorage.EXPECT().ListABIs(int64(1), gomock.Any()).Return(types.Pagination[models.EvmAbi]{TotalPages: 1}, nil)
+	s.mockStorage.EXPECT().GetCurrentConfig().Return(models.EVMConfig{}, nil)
+
+	s.decode("0x00000000000000000000000000000000000000000000000000000000000000aa")
+
+	s.Equal(stepEnterInput, s.model.currentStep)
+	s.Contains(s.model.View(), "select an endpoint")
+}
+
+func (s *DecodePageTestSuite) TestDecodeAnother() {
+	s.mockStorage.EXPECT().ListABIs(int64(1), gomock.Any()).Return(types.Pagination[models.EvmAbi]{TotalPages: 1}, nil)
+	s.decode(transferCalldata)
+	s.Equal(stepResult, s.model.currentStep)
+
+	updated, _ := s.model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	s.model = updated.(Model)
+	s.Equal(stepEnterInput, s.model.currentStep)
+	s.Empty(s.model.input.Value())
+}
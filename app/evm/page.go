@@ -23,9 +23,12 @@ type Option struct {
 var options = []Option{
 	{Label: "Storage Client", Value: "storage-client", Route: "/evm/storage", Description: "Manage the storage of the contract"},
 	{Label: "Abi Management", Value: "abi-management", Route: "/evm/abi", Description: "Manage the ABI of the contract"},
-	{Label: "Contract Management", Value: "contract-management", Route: "/evm/contract-management", Description: "Manage the contract of the contract"},
+	{Label: "Contract Management", Value: "contract-management", Route: "/evm/contract", Description: "Manage the contract of the contract"},
 	{Label: "Endpoint Management", Value: "endpoint-management", Route: "/evm/endpoint-management", Description: "Manage the endpoint of the contract"},
 	{Label: "Wallet Management", Value: "wallet-management", Route: "/evm/wallet", Description: "Manage your wallets and private keys"},
+	{Label: "Calldata Decoder", Value: "calldata-decoder", Route: "/evm/decode", Description: "Decode calldata or a transaction input"},
+	{Label: "Transaction Tracer", Value: "transaction-tracer", Route: "/evm/trace", Description: "Show the call tree of a mined transaction"},
+	{Label: "Safe Proposals", Value: "safe-proposals", Route: "/evm/safe", Description: "Sign and execute Safe multisig transactions"},
 }
 
 func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
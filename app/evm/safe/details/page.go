@@ -0,0 +1,482 @@
+package details
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/endpointgroup"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/safe"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/safe/details.log")
+
+type detailsStep int
+
+const (
+	stepLoading detailsStep = iota
+	stepDetails
+	stepSelectWallet
+	stepWorking
+	stepError
+)
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+	walletService wallet.WalletService
+	transport     transport.Transport
+
+	currentStep detailsStep
+	proposal    *models.SafeProposal
+	safe        *safe.Safe
+	tx          safe.Transaction
+	// signatures are the stored signatures still valid for the current owners of the Safe.
+	signatures map[common.Address]string
+	// ownerWallets are the stored wallets of Safe owners, by owner address.
+	ownerWallets map[common.Address]models.EVMWallet
+	signers      []models.EVMWallet
+	cursor       int
+
+	working  string
+	notice   string
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithService(router, sharedMemory, nil, nil, nil)
+}
+
+// NewPageWithService creates a new Safe proposal page with an optional storage client, wallet service
+// and transport (for testing).
+func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage, walletService wallet.WalletService, tr transport.Transport) view.View {
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		walletService: walletService,
+		transport:     tr,
+		currentStep:   stepLoading,
+	}
+}
+
+type proposalLoadedMsg struct {
+	storageClient sql.Storage
+	transport     transport.Transport
+	proposal      *models.SafeProposal
+	safe          *safe.Safe
+	tx            safe.Transaction
+	ownerWallets  map[common.Address]models.EVMWallet
+	err           error
+}
+
+type proposalActionMsg struct {
+	notice string
+	err    error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadProposal
+}
+
+func (m Model) loadProposal() tea.Msg {
+	proposalID, err := strconv.ParseUint(m.router.GetQueryParam("id"), 10, 64)
+	if err != nil {
+		return proposalLoadedMsg{err: fmt.Errorf("invalid proposal ID: %w", err)}
+	}
+
+	storageClient := m.storageClient
+	if storageClient == nil {
+		sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get storage client from shared memory: %v", err)
+			return proposalLoadedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+		}
+		storageClient = sqlStorage
+	}
+
+	proposal, err := storageClient.GetSafeProposalByID(uint(proposalID))
+	if err != nil {
+		logger.Error("Failed to load Safe proposal %d: %v", proposalID, err)
+		return proposalLoadedMsg{err: fmt.Errorf("failed to load Safe proposal: %w", err)}
+	}
+	if proposal.Endpoint == nil {
+		return proposalLoadedMsg{err: fmt.Errorf("Safe proposal #%d has no network endpoint", proposal.ID)}
+	}
+
+	tr := m.transport
+	if tr == nil {
+		tr, err = endpointgroup.NewTransport(storageClient, *proposal.Endpoint, 30*time.Second)
+		if err != nil {
+			return proposalLoadedMsg{err: err}
+		}
+	}
+
+	safeState, err := safe.Load(tr, common.HexToAddress(proposal.SafeAddress))
+	if err != nil {
+		logger.Error("Failed to load Safe %s: %v", proposal.SafeAddress, err)
+		return proposalLoadedMsg{err: fmt.Errorf("failed to load Safe: %w", err)}
+	}
+	tx, err := safe.TransactionFromProposal(proposal)
+	if err != nil {
+		return proposalLoadedMsg{err: err}
+	}
+
+	ownerWallets := map[common.Address]models.EVMWallet{}
+	for _, owner := range safeState.Owners {
+		if ownerWallet, err := storageClient.GetWalletByAddress(owner.Hex()); err == nil {
+			ownerWallets[owner] = ownerWallet
+		}
+	}
+
+	return proposalLoadedMsg{
+		storageClient: storageClient,
+		transport:     tr,
+		proposal:      &proposal,
+		safe:          safeState,
+		tx:            tx,
+		ownerWallets:  ownerWallets,
+	}
+}
+
+func (m Model) getWalletService() (wallet.WalletService, error) {
+	if m.walletService != nil {
+		return m.walletService, nil
+	}
+
+	secureStorage, _, err := utils.GetSecureStorageFromSharedMemory(m.sharedMemory)
+	if err != nil {
+		logger.Error("Failed to get secure storage from shared memory: %v", err)
+		return nil, fmt.Errorf("failed to get secure storage from shared memory: %w", err)
+	}
+	return wallet.NewWalletService(m.storageClient, secureStorage), nil
+}
+
+// sign signs the SafeTx hash with a stored owner wallet and stores the signature.
+func (m Model) sign(ownerWallet models.EVMWallet) tea.Cmd {
+	return func() tea.Msg {
+		walletService, err := m.getWalletService()
+		if err != nil {
+			return proposalActionMsg{err: err}
+		}
+		accountSigner, err := wallet.NewAccountSigner(walletService, &ownerWallet)
+		if err != nil {
+			return proposalActionMsg{err: err}
+		}
+		signature, err := m.safe.Sign(accountSigner, m.tx)
+		if err != nil {
+			logger.Error("Failed to sign Safe proposal %d with wallet %d: %v", m.proposal.ID, ownerWallet.ID, err)
+			return proposalActionMsg{err: err}
+		}
+
+		if err := m.storageClient.AddSafeSignature(models.SafeSignature{
+			ProposalID: m.proposal.ID,
+			Owner:      accountSigner.GetAddress().Hex(),
+			Signature:  signature,
+		}); err != nil {
+			logger.Error("Failed to store Safe signature: %v", err)
+			return proposalActionMsg{err: err}
+		}
+		return proposalActionMsg{notice: fmt.Sprintf("✓ Signed by %s", ownerWallet.Alias)}
+	}
+}
+
+// execute sends execTransaction from the selected wallet, which pays the gas and does not have to be an owner.
+func (m Model) execute() tea.Msg {
+	config, err := m.storageClient.GetCurrentConfig()
+	if err != nil {
+		logger.Error("Failed to get current config: %v", err)
+		return proposalActionMsg{err: fmt.Errorf("failed to get current config: %w", err)}
+	}
+	if config.SelectedWalletID == nil {
+		return proposalActionMsg{err: fmt.Errorf("no wallet selected. Please select a wallet to pay for the execution")}
+	}
+
+	walletService, err := m.getWalletService()
+	if err != nil {
+		return proposalActionMsg{err: err}
+	}
+	executor, err := wallet.NewSigner(walletService, *config.SelectedWalletID, m.transport)
+	if err != nil {
+		return proposalActionMsg{err: err}
+	}
+
+	txHash, err := m.safe.Execute(executor, m.tx, m.signatures)
+	if err != nil {
+		logger.Error("Failed to execute Safe proposal %d: %v", m.proposal.ID, err)
+		return proposalActionMsg{err: err}
+	}
+	if err := m.storageClient.MarkSafeProposalExecuted(m.proposal.ID, txHash); err != nil {
+		logger.Error("Failed to mark Safe proposal %d as executed: %v", m.proposal.ID, err)
+		return proposalActionMsg{err: fmt.Errorf("executed in %s but failed to update the proposal: %w", txHash, err)}
+	}
+	return proposalActionMsg{notice: "✓ Executed in transaction " + txHash}
+}
+
+// pendingSigners returns the stored owner wallets that have not signed yet.
+func (m Model) pendingSigners() []models.EVMWallet {
+	signers := []models.EVMWallet{}
+	for _, owner := range m.safe.Owners {
+		ownerWallet, ok := m.ownerWallets[owner]
+		if !ok {
+			continue
+		}
+		if _, signed := m.signatures[owner]; !signed {
+			signers = append(signers, ownerWallet)
+		}
+	}
+	return signers
+}
+
+func (m Model) isStale() bool {
+	return !m.proposal.IsExecuted() && m.safe.Nonce.Uint64() > m.proposal.Nonce
+}
+
+func (m Model) canExecute() bool {
+	return !m.proposal.IsExecuted() && !m.isStale() && uint64(len(m.signatures)) >= m.safe.Threshold
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case proposalLoadedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.storageClient = msg.storageClient
+		m.transport = msg.transport
+		m.proposal = msg.proposal
+		m.safe = msg.safe
+		m.tx = msg.tx
+		m.ownerWallets = msg.ownerWallets
+		m.signatures = m.safe.ValidSignatures(m.tx, safe.SignaturesOf(*m.proposal))
+		m.currentStep = stepDetails
+		return m, nil
+
+	case proposalActionMsg:
+		m.currentStep = stepDetails
+		m.notice = msg.notice
+		m.errorMsg = ""
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadProposal
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepDetails:
+			return m.handleDetails(msg)
+		case stepSelectWallet:
+			return m.handleSelectWallet(msg)
+		case stepError:
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/safe", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "s":
+		if m.proposal.IsExecuted() || m.isStale() {
+			return m, nil
+		}
+		m.signers = m.pendingSigners()
+		if len(m.signers) == 0 {
+			m.notice = ""
+			m.errorMsg = "no stored wallet of an owner is left to sign"
+			return m, nil
+		}
+		m.cursor = 0
+		m.errorMsg = ""
+		m.currentStep = stepSelectWallet
+	case "x":
+		if !m.canExecute() {
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.notice = ""
+		m.working = "Executing the Safe transaction and waiting for the receipt..."
+		m.currentStep = stepWorking
+		return m, m.execute
+	case "r":
+		m.notice = ""
+		m.errorMsg = ""
+		return m, m.loadProposal
+	}
+	return m, nil
+}
+
+func (m Model) handleSelectWallet(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.signers)-1 {
+			m.cursor++
+		}
+	case "ctrl+b":
+		m.currentStep = stepDetails
+	case "enter":
+		m.notice = ""
+		m.working = "Signing with " + m.signers[m.cursor].Alias + "..."
+		m.currentStep = stepWorking
+		return m, m.sign(m.signers[m.cursor])
+	}
+	return m, nil
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepDetails:
+		if m.proposal.IsExecuted() || m.isStale() {
+			return "r: refresh • esc/q: back", view.HelpDisplayOptionAppend
+		}
+		if m.canExecute() {
+			return "s: sign with an owner wallet • x: execute • r: refresh • esc/q: back", view.HelpDisplayOptionAppend
+		}
+		return "s: sign with an owner wallet • r: refresh • esc/q: back", view.HelpDisplayOptionAppend
+	case stepSelectWallet:
+		return "↑/k: up • ↓/j: down • enter: sign • ctrl+b: back to proposal", view.HelpDisplayOptionOverride
+	case stepWorking:
+		return "Working...", view.HelpDisplayOptionOverride
+	case stepError:
+		return "Press any key to return to Safe proposals", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	switch m.currentStep {
+	case stepDetails:
+		return m.renderDetails()
+	case stepSelectWallet:
+		return m.renderSelectWallet()
+	case stepWorking:
+		return component.VStackC(
+			component.T(m.title()).Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T(m.working).Muted(),
+		).Render()
+	case stepError:
+		return component.VStackC(
+			component.T("Safe Proposal").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			component.T("Safe Proposal").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Loading proposal and Safe owners...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) title() string {
+	return fmt.Sprintf("Safe Proposal #%d", m.proposal.ID)
+}
+
+func (m Model) renderStatus() component.Component {
+	switch {
+	case m.proposal.IsExecuted():
+		executedTx := ""
+		if m.proposal.ExecutedTxHash != nil {
+			executedTx = " in " + *m.proposal.ExecutedTxHash
+		}
+		return component.T("Status: executed" + executedTx).Success()
+	case m.isStale():
+		return component.T(fmt.Sprintf("Status: stale, the Safe nonce is already %s", m.safe.Nonce)).Warning()
+	case m.canExecute():
+		return component.T("Status: ready to execute").Success()
+	default:
+		return component.T("Status: pending signatures")
+	}
+}
+
+func (m Model) renderDetails() string {
+	proposal := m.proposal
+	owners := make([]component.Component, 0, len(m.safe.Owners))
+	for _, owner := range m.safe.Owners {
+		label := owner.Hex()
+		if ownerWallet, ok := m.ownerWallets[owner]; ok {
+			label += " (" + ownerWallet.Alias + ")"
+		}
+		if _, signed := m.signatures[owner]; signed {
+			owners = append(owners, component.T("  ✓ "+label).Success())
+		} else {
+			owners = append(owners, component.T("  · "+label).Muted())
+		}
+	}
+
+	footer := component.Empty()
+	if m.errorMsg != "" {
+		footer = component.T("Error: " + m.errorMsg).Error()
+	} else if m.notice != "" {
+		footer = component.T(m.notice).Success()
+	}
+
+	return component.VStackC(
+		component.T(m.title()).Bold(true).Primary(),
+		component.SpacerV(1),
+		component.IfC(proposal.Description != "", component.T("Call: "+proposal.Description), component.Empty()),
+		component.T(fmt.Sprintf("Safe: %s (v%s)", proposal.SafeAddress, m.safe.Version)),
+		component.T(fmt.Sprintf("Network: %s (%s)", proposal.Endpoint.Name, proposal.Endpoint.Url)),
+		component.T("To: "+proposal.To),
+		component.T("Value: "+proposal.Value+" wei"),
+		component.T("Data: "+proposal.Data),
+		component.T("Operation: "+m.tx.Operation.String()),
+		component.T(fmt.Sprintf("Nonce: %d", proposal.Nonce)),
+		component.T("SafeTx hash: "+proposal.SafeTxHash),
+		m.renderStatus(),
+		component.SpacerV(1),
+		component.T(fmt.Sprintf("Signatures: %d of %d required", len(m.signatures), m.safe.Threshold)).Bold(true),
+		component.VStackC(owners...),
+		component.SpacerV(1),
+		footer,
+	).Render()
+}
+
+func (m Model) renderSelectWallet() string {
+	items := make([]component.Component, 0, len(m.signers))
+	for index, signer := range m.signers {
+		prefix := "  "
+		if index == m.cursor {
+			prefix = "> "
+		}
+		item := component.T(fmt.Sprintf("%s%s (%s)", prefix, signer.Alias, signer.Address))
+		if index == m.cursor {
+			item = item.Bold(true)
+		}
+		items = append(items, item)
+	}
+
+	return component.VStackC(
+		component.T(m.title()+" - Sign").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Sign the SafeTx hash "+m.proposal.SafeTxHash+" with:").Muted(),
+		component.SpacerV(1),
+		component.VStackC(items...),
+	).Render()
+}
@@ -0,0 +1,294 @@
+package safe
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/safe/page.log")
+
+const pageSize = 5
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+
+	proposals     []models.SafeProposal
+	selectedIndex int
+	currentPage   int64
+	totalPages    int64
+	totalItems    int64
+
+	confirmDelete bool
+
+	loading  bool
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithStorage(router, sharedMemory, nil)
+}
+
+// NewPageWithStorage creates a new Safe proposals page with an optional storage client (for testing).
+func NewPageWithStorage(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage) view.View {
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		currentPage:   1,
+		loading:       true,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadProposals
+}
+
+type proposalsLoadedMsg struct {
+	proposals     []models.SafeProposal
+	storageClient sql.Storage
+	totalPages    int64
+	totalItems    int64
+	err           error
+}
+
+type proposalDeletedMsg struct {
+	err error
+}
+
+func (m Model) getStorageClient() (sql.Storage, error) {
+	if m.storageClient != nil {
+		return m.storageClient, nil
+	}
+
+	sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+	if err != nil {
+		logger.Error("Failed to get storage client from shared memory: %v", err)
+		return nil, fmt.Errorf("failed to get storage client from shared memory: %w", err)
+	}
+	return sqlStorage, nil
+}
+
+func (m Model) loadProposals() tea.Msg {
+	storageClient, err := m.getStorageClient()
+	if err != nil {
+		return proposalsLoadedMsg{err: err}
+	}
+
+	result, err := storageClient.ListSafeProposals(m.currentPage, pageSize)
+	if err != nil {
+		logger.Error("Failed to list Safe proposals: %v", err)
+		return proposalsLoadedMsg{err: err}
+	}
+
+	return proposalsLoadedMsg{
+		proposals:     result.Items,
+		storageClient: storageClient,
+		totalPages:    result.TotalPages,
+		totalItems:    result.TotalItems,
+	}
+}
+
+func (m Model) deleteProposal() tea.Msg {
+	proposal := m.proposals[m.selectedIndex]
+	if err := m.storageClient.DeleteSafeProposal(proposal.ID); err != nil {
+		logger.Error("Failed to delete Safe proposal %d: %v", proposal.ID, err)
+		return proposalDeletedMsg{err: err}
+	}
+	return proposalDeletedMsg{}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case proposalsLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+
+		m.errorMsg = ""
+		m.proposals = msg.proposals
+		m.storageClient = msg.storageClient
+		m.totalPages = msg.totalPages
+		m.totalItems = msg.totalItems
+		if m.selectedIndex >= len(m.proposals) {
+			m.selectedIndex = max(len(m.proposals)-1, 0)
+		}
+		return m, nil
+
+	case proposalDeletedMsg:
+		m.confirmDelete = false
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.loading = true
+		return m, m.loadProposals
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+
+		if m.confirmDelete {
+			switch msg.String() {
+			case "y":
+				return m, m.deleteProposal
+			case "n":
+				m.confirmDelete = false
+			}
+			return m, nil
+		}
+
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.selectedIndex > 0 {
+			m.selectedIndex--
+		}
+	case "down", "j":
+		if m.selectedIndex < len(m.proposals)-1 {
+			m.selectedIndex++
+		}
+	case "n":
+		if m.currentPage < m.totalPages {
+			m.currentPage++
+			m.selectedIndex = 0
+			m.loading = true
+			return m, m.loadProposals
+		}
+	case "p":
+		if m.currentPage > 1 {
+			m.currentPage--
+			m.selectedIndex = 0
+			m.loading = true
+			return m, m.loadProposals
+		}
+	case "enter":
+		proposal, ok := m.selectedProposal()
+		if !ok {
+			return m, nil
+		}
+		if err := m.router.NavigateTo("/evm/safe/details", map[string]string{
+			"id": strconv.FormatUint(uint64(proposal.ID), 10),
+		}); err != nil {
+			logger.Error("Failed to navigate to Safe proposal page: %v", err)
+		}
+	case "d":
+		if _, ok := m.selectedProposal(); ok {
+			m.confirmDelete = true
+		}
+	case "r":
+		m.loading = true
+		return m, m.loadProposals
+	}
+
+	return m, nil
+}
+
+func (m Model) selectedProposal() (models.SafeProposal, bool) {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.proposals) {
+		return models.SafeProposal{}, false
+	}
+	return m.proposals[m.selectedIndex], true
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	if m.loading {
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+	if m.confirmDelete {
+		return "y: delete • n: cancel", view.HelpDisplayOptionOverride
+	}
+	if len(m.proposals) == 0 {
+		return "esc/q: back", view.HelpDisplayOptionAppend
+	}
+
+	return "↑/k: up • ↓/j: down • enter: sign or execute • d: delete • n: next page • p: previous page • r: refresh • esc/q: back", view.HelpDisplayOptionAppend
+}
+
+func (m Model) View() string {
+	if m.loading {
+		return component.VStackC(
+			component.T("Safe Proposals").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.T("Loading proposals...").Muted(),
+		).Render()
+	}
+
+	if len(m.proposals) == 0 {
+		return component.VStackC(
+			component.T("Safe Proposals").Bold(true).Primary(),
+			component.SpacerV(1),
+			component.IfC(m.errorMsg != "", component.T("Error: "+m.errorMsg).Error(), component.Empty()),
+			component.T("No Safe proposals found").Bold(true),
+			component.SpacerV(1),
+			component.T("Propose a transaction from the contract runner: fill in the arguments of a"),
+			component.T("write function and press ctrl+p to have a Safe make the call."),
+		).Render()
+	}
+
+	items := make([]component.Component, 0, len(m.proposals))
+	for index, proposal := range m.proposals {
+		items = append(items, renderProposal(proposal, index == m.selectedIndex))
+	}
+
+	footer := component.Empty()
+	if m.confirmDelete {
+		proposal, _ := m.selectedProposal()
+		footer = component.T(fmt.Sprintf("Delete Safe proposal #%d and its signatures? (y/n)", proposal.ID)).Warning()
+	} else if m.errorMsg != "" {
+		footer = component.T("Error: " + m.errorMsg).Error()
+	}
+
+	return component.VStackC(
+		component.T("Safe Proposals").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Collect owner signatures and execute Safe transactions").Muted(),
+		component.SpacerV(1),
+		component.VStackC(items...),
+		component.T(fmt.Sprintf("Page %d of %d • Showing %d of %d proposals", m.currentPage, max(m.totalPages, 1), len(m.proposals), m.totalItems)).Muted(),
+		component.SpacerV(1),
+		footer,
+	).Render()
+}
+
+func renderProposal(proposal models.SafeProposal, isCursor bool) component.Component {
+	prefix := "  "
+	if isCursor {
+		prefix = "> "
+	}
+
+	description := proposal.Description
+	if description == "" {
+		description = "call " + proposal.To
+	}
+	title := component.T(fmt.Sprintf("%s#%d %s", prefix, proposal.ID, description))
+	if isCursor {
+		title = title.Bold(true)
+	}
+
+	return component.VStackC(
+		title,
+		component.T(fmt.Sprintf("    Safe: %s • Nonce: %d", proposal.SafeAddress, proposal.Nonce)).Muted(),
+		component.T(fmt.Sprintf("    Signatures: %d • Status: %s", len(proposal.Signatures), proposal.Status)).Muted(),
+		component.SpacerV(1),
+	)
+}
@@ -0,0 +1,123 @@
+package safe
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const safeAddress = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+
+type SafePageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	model       Model
+}
+
+func TestSafePageTestSuite(t *testing.T) {
+	suite.Run(t, new(SafePageTestSuite))
+}
+
+func (s *SafePageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.model = NewPageWithStorage(s.mockRouter, storage.NewSharedMemory(), s.mockStorage).(Model)
+}
+
+func (s *SafePageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *SafePageTestSuite) load(proposals ...models.SafeProposal) {
+	s.mockStorage.EXPECT().ListSafeProposals(int64(1), int64(pageSize)).Return(types.Pagination[models.SafeProposal]{
+		Items:      proposals,
+		TotalPages: 1,
+		TotalItems: int64(len(proposals)),
+	}, nil)
+
+	updated, _ := s.model.Update(s.model.loadProposals())
+	s.model = updated.(Model)
+}
+
+func (s *SafePageTestSuite) press(msg tea.KeyMsg) tea.Cmd {
+	updated, cmd := s.model.Update(msg)
+	s.model = updated.(Model)
+	return cmd
+}
+
+func proposal(id uint, status models.SafeProposalStatus, signatures int) models.SafeProposal {
+	p := models.SafeProposal{
+		ID:          id,
+		SafeAddress: safeAddress,
+		Description: "function setValue(uint256 newValue)",
+		Nonce:       uint64(id),
+		Status:      status,
+	}
+	for index := 0; index < signatures; index++ {
+		p.Signatures = append(p.Signatures, models.SafeSignature{ProposalID: id})
+	}
+	return p
+}
+
+func (s *SafePageTestSuite) TestEmptyList() {
+	s.load()
+
+	output := s.model.View()
+	s.Contains(output, "No Safe proposals found")
+	s.Contains(output, "ctrl+p")
+}
+
+func (s *SafePageTestSuite) TestListProposals() {
+	s.load(proposal(2, models.SafeProposalStatusPending, 1), proposal(1, models.SafeProposalStatusExecuted, 2))
+
+	output := s.model.View()
+	s.Contains(output, "> #2 function setValue(uint256 newValue)")
+	s.Contains(output, "Safe: "+safeAddress+" • Nonce: 2")
+	s.Contains(output, "Signatures: 1 • Status: pending")
+	s.Contains(output, "Signatures: 2 • Status: executed")
+	s.Contains(output, "Page 1 of 1 • Showing 2 of 2 proposals")
+}
+
+func (s *SafePageTestSuite) TestOpenProposal() {
+	s.load(proposal(2, models.SafeProposalStatusPending, 0), proposal(1, models.SafeProposalStatusPending, 0))
+
+	s.press(tea.KeyMsg{Type: tea.KeyDown})
+	s.mockRouter.EXPECT().NavigateTo("/evm/safe/details", map[string]string{"id": "1"}).Return(nil)
+	s.press(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func (s *SafePageTestSuite) TestDeleteProposal() {
+	s.load(proposal(2, models.SafeProposalStatusPending, 0))
+
+	s.press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	s.Require().True(s.model.confirmDelete)
+	s.Contains(s.model.View(), "Delete Safe proposal #2 and its signatures? (y/n)")
+
+	s.mockStorage.EXPECT().DeleteSafeProposal(uint(2)).Return(nil)
+	cmd := s.press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	s.Require().NotNil(cmd)
+
+	updated, cmd := s.model.Update(cmd())
+	s.model = updated.(Model)
+	s.False(s.model.confirmDelete)
+	s.True(s.model.loading)
+	s.Require().NotNil(cmd)
+}
+
+func (s *SafePageTestSuite) TestCancelDelete() {
+	s.load(proposal(2, models.SafeProposalStatusPending, 0))
+
+	s.press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	s.press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	s.False(s.model.confirmDelete)
+}
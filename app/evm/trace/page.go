@@ -0,0 +1,369 @@
+package trace
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/endpointgroup"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/signatures"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/trace"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/trace.log")
+
+const listPageSize = 100
+
+type traceStep int
+
+const (
+	stepEnterInput traceStep = iota
+	stepTracing
+	stepResult
+)
+
+// row is a visible call of the tree. The path is the index of the call in each level, e.g. "0.2.1".
+type row struct {
+	call  *trace.Call
+	depth int
+	path  string
+}
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+	transport     transport.Transport
+
+	currentStep traceStep
+	input       textinput.Model
+
+	root      *trace.Call
+	collapsed map[string]bool
+	cursor    int
+	errorMsg  string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithService(router, sharedMemory, nil, nil)
+}
+
+// NewPageWithService creates a new transaction trace page with an optional storage client and
+// transport (for testing).
+func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage, tr transport.Transport) view.View {
+	input := textinput.New()
+	input.Placeholder = "0x transaction hash"
+	input.Width = 66
+	input.Focus()
+
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		storageClient: storageClient,
+		transport:     tr,
+		currentStep:   stepEnterInput,
+		input:         input,
+		collapsed:     map[string]bool{},
+	}
+}
+
+type tracedMsg struct {
+	root *trace.Call
+	err  error
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) getStorageClient() (sql.Storage, error) {
+	if m.storageClient != nil {
+		return m.storageClient, nil
+	}
+
+	sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+	if err != nil {
+		logger.Error("Failed to get storage client from shared memory: %v", err)
+		return nil, fmt.Errorf("failed to get storage client from shared memory: %w", err)
+	}
+	return sqlStorage, nil
+}
+
+func (m Model) getTransport(storageClient sql.Storage) (transport.Transport, error) {
+	if m.transport != nil {
+		return m.transport, nil
+	}
+
+	config, err := storageClient.GetCurrentConfig()
+	if err != nil {
+		logger.Error("Failed to get current config: %v", err)
+		return nil, fmt.Errorf("failed to get current config: %w", err)
+	}
+	if config.Endpoint == nil {
+		return nil, fmt.Errorf("select an endpoint to trace transactions")
+	}
+
+	tr, err := endpointgroup.NewTransport(storageClient, *config.Endpoint, 30*time.Second)
+	if err != nil {
+		logger.Error("Failed to create transport: %v", err)
+		return nil, err
+	}
+	return tr, nil
+}
+
+// loadABIs registers the stored ABIs with the decoder and returns all of their elements, which
+// decode the custom errors and events of the trace.
+func loadABIs(storageClient sql.Storage, callDecoder *decoder.Decoder) (abi.AbiArray, error) {
+	elements := abi.AbiArray{}
+	for page := int64(1); ; page++ {
+		result, err := storageClient.ListABIs(page, listPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, stored := range result.Items {
+			callDecoder.AddABI(stored.Name, stored.Abi.AbiArray)
+			elements = append(elements, stored.Abi.AbiArray...)
+		}
+		if page >= result.TotalPages {
+			return elements, nil
+		}
+	}
+}
+
+func (m Model) trace() tea.Msg {
+	input := strings.TrimSpace(m.input.Value())
+	hash, err := hexutil.Decode(input)
+	if err != nil || len(hash) != common.HashLength {
+		return tracedMsg{err: fmt.Errorf("enter a 0x-prefixed 32 byte transaction hash")}
+	}
+
+	storageClient, err := m.getStorageClient()
+	if err != nil {
+		return tracedMsg{err: err}
+	}
+
+	tr, err := m.getTransport(storageClient)
+	if err != nil {
+		return tracedMsg{err: err}
+	}
+
+	frame, err := trace.Transaction(tr, common.BytesToHash(hash))
+	if err != nil {
+		logger.Error("Failed to trace transaction: %v", err)
+		return tracedMsg{err: fmt.Errorf("failed to trace transaction, the endpoint must support debug_traceTransaction: %w", err)}
+	}
+
+	callDecoder := decoder.New()
+	elements, err := loadABIs(storageClient, callDecoder)
+	if err != nil {
+		logger.Error("Failed to load stored ABIs: %v", err)
+		return tracedMsg{err: fmt.Errorf("failed to load stored ABIs: %w", err)}
+	}
+	callDecoder.UseSignatures(signatures.NewDatabase(storageClient))
+
+	return tracedMsg{root: trace.Decode(frame, callDecoder, elements)}
+}
+
+// rows flattens the tree into the calls that are visible, skipping the subcalls of collapsed calls.
+func (m Model) rows() []row {
+	if m.root == nil {
+		return nil
+	}
+	return m.appendRows(nil, m.root, 0, "0")
+}
+
+func (m Model) appendRows(rows []row, call *trace.Call, depth int, path string) []row {
+	rows = append(rows, row{call: call, depth: depth, path: path})
+	if m.collapsed[path] {
+		return rows
+	}
+	for index, subcall := range call.Calls {
+		rows = m.appendRows(rows, subcall, depth+1, path+"."+strconv.Itoa(index))
+	}
+	return rows
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tracedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			m.currentStep = stepEnterInput
+			return m, textinput.Blink
+		}
+		m.errorMsg = ""
+		m.root = msg.root
+		m.cursor = 0
+		m.collapsed = map[string]bool{}
+		m.currentStep = stepResult
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepEnterInput:
+			if msg.String() == "enter" {
+				m.currentStep = stepTracing
+				return m, m.trace
+			}
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+
+		case stepResult:
+			return m.handleResult(msg)
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleResult(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := m.rows()
+	switch msg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(rows)-1 {
+			m.cursor++
+		}
+	case "enter", " ":
+		selected := rows[m.cursor]
+		if len(selected.call.Calls) > 0 {
+			m.collapsed[selected.path] = !m.collapsed[selected.path]
+		}
+	case "f":
+		// Jump to the call where the transaction failed.
+		for index, visible := range m.expandFailure() {
+			if visible.call.FailedHere() {
+				m.cursor = index
+				break
+			}
+		}
+	case "n":
+		m.input.SetValue("")
+		m.root = nil
+		m.errorMsg = ""
+		m.currentStep = stepEnterInput
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+// expandFailure expands the calls on the path to the failing call and returns the visible rows.
+func (m Model) expandFailure() []row {
+	var expand func(call *trace.Call, path string)
+	expand = func(call *trace.Call, path string) {
+		for index, subcall := range call.Calls {
+			if subcall.Frame.Failed() {
+				delete(m.collapsed, path)
+				expand(subcall, path+"."+strconv.Itoa(index))
+			}
+		}
+	}
+	if m.root != nil {
+		expand(m.root, "0")
+	}
+	return m.rows()
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepEnterInput:
+		return "enter: trace • esc: back", view.HelpDisplayOptionOverride
+	case stepResult:
+		return "↑/k: up • ↓/j: down • enter: expand/collapse • f: go to failure • n: trace another • esc: back", view.HelpDisplayOptionOverride
+	default:
+		return "Tracing...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	title := component.T("Transaction Tracer").Bold(true).Primary()
+
+	switch m.currentStep {
+	case stepEnterInput:
+		errorLine := component.Empty()
+		if m.errorMsg != "" {
+			errorLine = component.T("Error: " + m.errorMsg).Error()
+		}
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Paste a transaction hash").Bold(true),
+			component.T("The call tree is traced with debug_traceTransaction on the current endpoint").Muted(),
+			component.SpacerV(1),
+			component.T(m.input.View()),
+			component.SpacerV(1),
+			errorLine,
+		).Render()
+	case stepTracing:
+		return component.VStackC(title, component.SpacerV(1), component.T("Tracing...").Muted()).Render()
+	default:
+		return m.renderResult()
+	}
+}
+
+func (m Model) renderResult() string {
+	rootFrame := m.root.Frame
+	status := component.T("Status: success").Success()
+	if rootFrame.Failed() {
+		status = component.T("Status: reverted - " + m.root.RevertReason).Error()
+	}
+
+	items := []component.Component{
+		component.T("Transaction Tracer").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Transaction " + strings.TrimSpace(m.input.Value())).Bold(true),
+		status,
+		component.T(fmt.Sprintf("Gas used: %d", uint64(rootFrame.GasUsed))),
+		component.SpacerV(1),
+	}
+
+	rows := m.rows()
+	for index, visible := range rows {
+		marker := "  "
+		switch {
+		case len(visible.call.Calls) > 0 && m.collapsed[visible.path]:
+			marker = "▸ "
+		case len(visible.call.Calls) > 0:
+			marker = "▾ "
+		}
+		prefix := "  "
+		if index == m.cursor {
+			prefix = "> "
+		}
+		item := component.T(prefix + strings.Repeat("  ", visible.depth) + marker + visible.call.Label())
+		switch {
+		case index == m.cursor:
+			item = item.Bold(true)
+		case visible.call.FailedHere():
+			item = item.Error()
+		case visible.call.Frame.Failed():
+			item = item.Warning()
+		}
+		items = append(items, item)
+	}
+
+	items = append(items, component.SpacerV(1), component.T("Selected call").Bold(true))
+	for _, line := range rows[m.cursor].call.Details() {
+		items = append(items, component.T(line))
+	}
+
+	return component.VStackC(items...).Render()
+}
@@ -0,0 +1,179 @@
+package trace
+
+import (
+	"encoding/json"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const txHash = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+
+// routerTrace is a swap through a router that reads a pool and fails in the token transfer.
+const routerTrace = `{
+	"type": "CALL",
+	"from": "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266",
+	"to": "0xe7f1725e7734ce288f8367e1bb143e90bb3f0512",
+	"gas": "0x30000",
+	"gasUsed": "0xa410",
+	"input": "0x94b918de00000000000000000000000000000000000000000000000000000000000003e8",
+	"error": "execution reverted",
+	"revertReason": "insufficient balance",
+	"calls": [
+		{
+			"type": "STATICCALL",
+			"from": "0xe7f1725e7734ce288f8367e1bb143e90bb3f0512",
+			"to": "0x9fe46736679d2d9a65f0992f2272de9f3c7fa6e0",
+			"gas": "0x20000",
+			"gasUsed": "0xa28",
+			"input": "0x0902f1ac"
+		},
+		{
+			"type": "CALL",
+			"from": "0xe7f1725e7734ce288f8367e1bb143e90bb3f0512",
+			"to": "0x5fbdb2315678afecb367f032d93f642f64180aa3",
+			"gas": "0x10000",
+			"gasUsed": "0xbb8",
+			"input": "0xa9059cbb00000000000000000000000070997970c51812dc3a010c7d01b50e0d17dc79c800000000000000000000000000000000000000000000000000000000000003e8",
+			"error": "execution reverted",
+			"revertReason": "insufficient balance"
+		}
+	]
+}`
+
+type fakeTransport struct {
+	transport.Transport
+	err error
+}
+
+func (f *fakeTransport) TraceTransaction(common.Hash, string, map[string]any) (json.RawMessage, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return json.RawMessage(routerTrace), nil
+}
+
+type TracePageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	transport   *fakeTransport
+	model       Model
+}
+
+func TestTracePageTestSuite(t *testing.T) {
+	suite.Run(t, new(TracePageTestSuite))
+}
+
+func (s *TracePageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.transport = &fakeTransport{}
+	s.model = NewPageWithService(s.mockRouter, storage.NewSharedMemory(), s.mockStorage, s.transport).(Model)
+}
+
+func (s *TracePageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *TracePageTestSuite) trace(input string) {
+	s.model.input.SetValue(input)
+	updated, cmd := s.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model = updated.(Model)
+	s.Equal(stepTracing, s.model.currentStep)
+	s.Require().NotNil(cmd)
+
+	updated, _ = s.model.Update(cmd())
+	s.model = updated.(Model)
+}
+
+func (s *TracePageTestSuite) press(key string) {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	if key == "enter" {
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	updated, _ := s.model.Update(msg)
+	s.model = updated.(Model)
+}
+
+func (s *TracePageTestSuite) expectStoredABIs() {
+	elements, err := abi.ParseHumanReadable([]string{
+		"function swap(uint256 amountIn)",
+		"function getReserves() view returns (uint112 reserve0, uint112 reserve1, uint32 blockTimestampLast)",
+	})
+	s.Require().NoError(err)
+	s.mockStorage.EXPECT().ListABIs(int64(1), gomock.Any()).Return(types.Pagination[models.EvmAbi]{
+		Items:      []models.EvmAbi{{ID: 1, Name: "Router", Abi: models.AbiArrayType{AbiArray: elements}}},
+		TotalPages: 1,
+	}, nil)
+}
+
+func (s *TracePageTestSuite) TestShowsDecodedCallTree() {
+	s.expectStoredABIs()
+	s.trace(txHash)
+	s.Require().Equal(stepResult, s.model.currentStep)
+
+	output := s.model.View()
+	s.Contains(output, "Status: reverted - insufficient balance")
+	s.Contains(output, "Gas used: 42000")
+	s.Contains(output, "▾ CALL 0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512 Router.swap(amountIn=1000)")
+	s.Contains(output, "STATICCALL 0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0 Router.getReserves() • gas 2600")
+	s.Contains(output, "CALL 0x5FbDB2315678afecb367f032d93F642f64180aa3 transfer(to=0x70997970C51812dc3A010C7d01b50e0d17dc79C8, value=1000) • gas 3000 ✗ insufficient balance")
+	s.Contains(output, "Gas used: 42000 of 196608")
+}
+
+func (s *TracePageTestSuite) TestCollapseAndJumpToFailure() {
+	s.expectStoredABIs()
+	s.trace(txHash)
+
+	s.press("enter")
+	s.Len(s.model.rows(), 1)
+	s.NotContains(s.model.View(), "STATICCALL")
+	s.Contains(s.model.View(), "▸ CALL")
+
+	s.press("f")
+	s.Len(s.model.rows(), 3)
+	s.Equal(2, s.model.cursor)
+	s.Contains(s.model.View(), "Error: insufficient balance (failed here)")
+
+	s.press("k")
+	s.Equal(1, s.model.cursor)
+	s.Contains(s.model.View(), "Function: getReserves() [Router]")
+}
+
+func (s *TracePageTestSuite) TestInvalidHash() {
+	s.trace("0x1234")
+
+	s.Equal(stepEnterInput, s.model.currentStep)
+	s.Contains(s.model.View(), "enter a 0x-prefixed 32 byte transaction hash")
+}
+
+func (s *TracePageTestSuite) TestEndpointWithoutDebugAPI() {
+	s.transport.err = errors.NewTransportError(errors.ErrCodeTraceFailed, "the method debug_traceTransaction does not exist")
+	s.trace(txHash)
+
+	s.Equal(stepEnterInput, s.model.currentStep)
+	s.Contains(s.model.View(), "the endpoint must support debug_traceTransaction")
+}
+
+func (s *TracePageTestSuite) TestTraceAnother() {
+	s.expectStoredABIs()
+	s.trace(txHash)
+
+	s.press("n")
+	s.Equal(stepEnterInput, s.model.currentStep)
+	s.Empty(s.model.input.Value())
+}
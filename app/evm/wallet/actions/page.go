@@ -237,6 +237,21 @@ func (m Model) buildActionOptions(isSelected bool) []actionOption {
 			description: "Update wallet alias or private key",
 			route:       "/evm/wallet/update",
 		},
+		{
+			label:       "Sign message",
+			description: "Sign text, hex bytes or a raw hash with this wallet",
+			route:       "/evm/wallet/sign",
+		},
+		{
+			label:       "Verify signature",
+			description: "Recover the signer of a message and match it against your wallets",
+			route:       "/evm/wallet/verify",
+		},
+		{
+			label:       "Sign typed data",
+			description: "Sign EIP-712 typed data such as permits, orders and meta-transactions",
+			route:       "/evm/wallet/typeddata",
+		},
 		{
 			label:       "Delete wallet",
 			description: "Remove this wallet from the system",
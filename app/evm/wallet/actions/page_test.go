@@ -688,9 +688,10 @@ func (s *ActionsPageTestSuite) TestDeleteWalletNavigation() {
 	// Wait for wallet loading
 	time.Sleep(300 * time.Millisecond)
 
-	// Navigate to "Delete wallet" option (third option when wallet is selected)
-	testModel.Send(tea.KeyMsg{Type: tea.KeyDown})
-	testModel.Send(tea.KeyMsg{Type: tea.KeyDown})
+	// Navigate to "Delete wallet" option (last option when wallet is selected)
+	for range 5 {
+		testModel.Send(tea.KeyMsg{Type: tea.KeyDown})
+	}
 	time.Sleep(100 * time.Millisecond)
 
 	output := s.getOutput(testModel)
@@ -26,6 +26,8 @@ const (
 	stepEnterPrivateKey
 	stepEnterMnemonic
 	stepSelectDerivationPath
+	stepEnterSignerURL
+	stepEnterRemoteAddress
 	stepGenerating
 	stepShowBackup
 	stepConfirm
@@ -39,6 +41,7 @@ const (
 	methodPrivateKey importMethod = iota
 	methodMnemonic
 	methodGenerate
+	methodRemote
 )
 
 type methodOption struct {
@@ -79,6 +82,10 @@ type Model struct {
 	pkeyInput     textinput.Model
 	mnemonicInput textarea.Model
 
+	// Remote signer inputs
+	signerURLInput     textinput.Model
+	remoteAddressInput textinput.Model
+
 	// Generated wallet data
 	generatedMnemonic string
 	generatedPKey     string
@@ -113,6 +120,14 @@ func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, w
 	mnemonicInput.SetWidth(76)
 	mnemonicInput.SetHeight(5)
 
+	signerURLInput := textinput.New()
+	signerURLInput.Placeholder = "http://localhost:8550 or /path/to/clef.ipc"
+	signerURLInput.Width = 66
+
+	remoteAddressInput := textinput.New()
+	remoteAddressInput.Placeholder = "0x..."
+	remoteAddressInput.Width = 66
+
 	customPathInput := textinput.New()
 	customPathInput.Placeholder = "m/44'/60'/0'/0/0"
 	customPathInput.Width = 40
@@ -127,10 +142,14 @@ func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, w
 		pkeyInput:       pkeyInput,
 		mnemonicInput:   mnemonicInput,
 		customPathInput: customPathInput,
+
+		signerURLInput:     signerURLInput,
+		remoteAddressInput: remoteAddressInput,
 		methodOptions: []methodOption{
 			{label: "Import from private key", description: "Import wallet using a private key (hex format)", method: methodPrivateKey},
 			{label: "Import from mnemonic phrase", description: "Import wallet using a 12 or 24 word mnemonic phrase", method: methodMnemonic},
 			{label: "Generate new wallet", description: "Create a new random wallet with private key", method: methodGenerate},
+			{label: "Connect remote signer", description: "Use an account held by Clef or another signer speaking its JSON-RPC API", method: methodRemote},
 		},
 		derivationOptions: []derivationPathOption{
 			{label: "m/44'/60'/0'/0/0", description: "Ethereum standard (default)", path: "m/44'/60'/0'/0/0"},
@@ -355,6 +374,60 @@ func (m Model) importMnemonic() tea.Msg {
 	return walletImportedMsg{wallet: walletWithBalance, rpcEndpoint: rpcEndpoint}
 }
 
+func (m Model) importRemoteWallet() tea.Msg {
+	alias := m.aliasInput.Value()
+	signerURL := strings.TrimSpace(m.signerURLInput.Value())
+	address := strings.TrimSpace(m.remoteAddressInput.Value())
+
+	if alias == "" {
+		return walletImportedMsg{err: fmt.Errorf("alias cannot be empty")}
+	}
+
+	if signerURL == "" {
+		return walletImportedMsg{err: fmt.Errorf("remote signer URL cannot be empty")}
+	}
+
+	if address == "" {
+		return walletImportedMsg{err: fmt.Errorf("address cannot be empty")}
+	}
+
+	// Import wallet, the service checks the address and duplicates
+	walletData, err := m.walletService.ImportRemoteWallet(alias, signerURL, address)
+	if err != nil {
+		return walletImportedMsg{err: err}
+	}
+
+	// Get RPC endpoint from database
+	sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+	if err != nil {
+		logger.Error("Failed to get storage client from shared memory: %v", err)
+		return walletImportedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+	}
+
+	// Get the current config
+	config, err := sqlStorage.GetCurrentConfig()
+	if err != nil {
+		logger.Error("Failed to get current config: %v", err)
+		return walletImportedMsg{err: fmt.Errorf("failed to get current config: %w", err)}
+	}
+	if config.Endpoint == nil {
+		logger.Error("No RPC endpoint configured")
+		return walletImportedMsg{err: fmt.Errorf("no RPC endpoint configured. Please configure an endpoint first")}
+	}
+	rpcEndpoint := config.Endpoint.Url
+
+	// Load with balance
+	walletWithBalance, err := m.walletService.GetWalletWithBalance(walletData.ID, rpcEndpoint)
+	if err != nil {
+		logger.Warn("Failed to load balance: %v", err)
+		walletWithBalance = &wallet.WalletWithBalance{
+			Wallet: *walletData,
+		}
+	}
+
+	return walletImportedMsg{wallet: walletWithBalance, rpcEndpoint: rpcEndpoint}
+}
+
 func (m Model) generateWallet() tea.Msg {
 	alias := m.aliasInput.Value()
 
@@ -472,6 +545,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case stepSelectDerivationPath:
 			return m.handleSelectDerivationPath(msg)
 
+		case stepEnterSignerURL:
+			return m.handleEnterSignerURL(msg)
+
+		case stepEnterRemoteAddress:
+			return m.handleEnterRemoteAddress(msg)
+
 		case stepShowBackup:
 			return m.handleShowBackup(msg)
 
@@ -540,6 +619,11 @@ func (m Model) handleEnterAlias(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case methodGenerate:
 			m.currentStep = stepGenerating
 			return m, m.generateWallet
+
+		case methodRemote:
+			m.currentStep = stepEnterSignerURL
+			m.signerURLInput.Focus()
+			return m, textinput.Blink
 		}
 
 	case "esc":
@@ -565,6 +649,39 @@ func (m Model) handleEnterPrivateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) handleEnterSignerURL(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.signerURLInput, cmd = m.signerURLInput.Update(msg)
+
+	switch msg.String() {
+	case "enter":
+		m.signerURLInput.Blur()
+		m.currentStep = stepEnterRemoteAddress
+		m.remoteAddressInput.Focus()
+		return m, textinput.Blink
+
+	case "esc":
+		m.currentStep = stepEnterAlias
+	}
+
+	return m, cmd
+}
+
+func (m Model) handleEnterRemoteAddress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.remoteAddressInput, cmd = m.remoteAddressInput.Update(msg)
+
+	switch msg.String() {
+	case "enter":
+		return m, m.importRemoteWallet
+
+	case "esc":
+		m.currentStep = stepEnterSignerURL
+	}
+
+	return m, cmd
+}
+
 func (m Model) handleEnterMnemonic(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	m.mnemonicInput, cmd = m.mnemonicInput.Update(msg)
@@ -686,7 +803,7 @@ func (m Model) handleConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) Help() (string, view.HelpDisplayOption) {
 	switch m.currentStep {
-	case stepEnterAlias, stepEnterPrivateKey:
+	case stepEnterAlias, stepEnterPrivateKey, stepEnterSignerURL, stepEnterRemoteAddress:
 		return "enter: next • esc: cancel", view.HelpDisplayOptionOverride
 	case stepEnterMnemonic:
 		return "ctrl+s: next • esc: cancel", view.HelpDisplayOptionOverride
@@ -716,6 +833,10 @@ func (m Model) View() string {
 		return m.renderEnterMnemonic()
 	case stepSelectDerivationPath:
 		return m.renderSelectDerivationPath()
+	case stepEnterSignerURL:
+		return m.renderEnterSignerURL()
+	case stepEnterRemoteAddress:
+		return m.renderEnterRemoteAddress()
 	case stepGenerating:
 		return m.renderGenerating()
 	case stepShowBackup:
@@ -774,6 +895,8 @@ func (m Model) renderEnterAlias() string {
 		methodName = "Mnemonic Import"
 	case methodGenerate:
 		methodName = "Generate New"
+	case methodRemote:
+		methodName = "Remote Signer"
 	default:
 		methodName = "Private Key Import"
 	}
@@ -804,6 +927,32 @@ func (m Model) renderEnterPrivateKey() string {
 	).Render()
 }
 
+func (m Model) renderEnterSignerURL() string {
+	return component.VStackC(
+		component.T("Add New Wallet - Remote Signer").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Step 2/3: Enter Signer Endpoint").Bold(true),
+		component.SpacerV(1),
+		component.T("Enter the HTTP URL or IPC socket path of your Clef-compatible signer:"),
+		component.SpacerV(1),
+		component.T("Signer: "+m.signerURLInput.View()),
+		component.SpacerV(1),
+		component.T("The private key stays in the signer, every signature must be approved there.").Muted(),
+	).Render()
+}
+
+func (m Model) renderEnterRemoteAddress() string {
+	return component.VStackC(
+		component.T("Add New Wallet - Remote Signer").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Step 3/3: Enter Account Address").Bold(true),
+		component.SpacerV(1),
+		component.T("Enter the address of the signer account to use:"),
+		component.SpacerV(1),
+		component.T("Address: "+m.remoteAddressInput.View()),
+	).Render()
+}
+
 func (m Model) renderEnterMnemonic() string {
 	mnemonic := m.mnemonicInput.Value()
 	wordCount := len(strings.Fields(strings.TrimSpace(mnemonic)))
@@ -995,6 +1144,11 @@ func (m Model) renderConfirm() string {
 		title = "successfully imported from mnemonic!"
 	}
 
+	privateKeyInfo := "• Private Key: Available (hidden for security)"
+	if m.confirmedWallet.Wallet.IsRemote && m.confirmedWallet.Wallet.RemoteSignerURL != nil {
+		privateKeyInfo = "• Private Key: Held by remote signer at " + *m.confirmedWallet.Wallet.RemoteSignerURL
+	}
+
 	return component.VStackC(
 		component.T("Add New Wallet - Confirmation").Bold(true).Primary(),
 		component.SpacerV(1),
@@ -1007,7 +1161,7 @@ func (m Model) renderConfirm() string {
 		component.T("• Balance: "+balanceStr+" (on "+m.rpcEndpoint+")").Muted(),
 		component.SpacerV(1),
 		component.T("Derived Information:").Bold(true),
-		component.T("• Private Key: Available (hidden for security)").Muted(),
+		component.T(privateKeyInfo).Muted(),
 		component.T("• Checksum Address: ✓ Valid").Muted(),
 		component.SpacerV(1),
 		component.VStackC(optionComponents...),
@@ -259,6 +259,79 @@ func (suite *WalletAddPageTestSuite) TestGenerateNewWallet() {
 	suite.Equal(stepSuccess, suite.model.currentStep)
 }
 
+// TestConnectRemoteSigner tests the full flow of adding a wallet held by a remote signer.
+func (suite *WalletAddPageTestSuite) TestConnectRemoteSigner() {
+	// Step 1: Select "Connect remote signer" (fourth option, index 3)
+	suite.model.selectedIndex = 3
+	updatedModel, _ := suite.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	suite.model = updatedModel.(Model)
+	suite.Equal(stepEnterAlias, suite.model.currentStep)
+	suite.Equal(methodRemote, suite.model.method)
+	suite.Contains(suite.model.View(), "Add New Wallet - Remote Signer")
+
+	// Step 2: Enter alias
+	suite.model.aliasInput.SetValue("clef-wallet")
+	updatedModel, _ = suite.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	suite.model = updatedModel.(Model)
+	suite.Equal(stepEnterSignerURL, suite.model.currentStep)
+
+	// Step 3: Enter signer URL
+	suite.model.signerURLInput.SetValue("http://localhost:8550")
+	updatedModel, _ = suite.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	suite.model = updatedModel.(Model)
+	suite.Equal(stepEnterRemoteAddress, suite.model.currentStep)
+
+	// Step 4: Enter address and mock the service
+	address := "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	suite.model.remoteAddressInput.SetValue(address)
+
+	signerURL := "http://localhost:8550"
+	expectedWallet := &models.EVMWallet{
+		ID:              4,
+		Alias:           "clef-wallet",
+		Address:         address,
+		IsRemote:        true,
+		RemoteSignerURL: &signerURL,
+	}
+	suite.walletService.EXPECT().ImportRemoteWallet("clef-wallet", signerURL, address).Return(expectedWallet, nil)
+	suite.walletService.EXPECT().GetWalletWithBalance(uint(4), "http://localhost:8545").Return(&wallet.WalletWithBalance{Wallet: *expectedWallet, Balance: big.NewInt(0)}, nil)
+
+	updatedModel, cmd := suite.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	suite.model = updatedModel.(Model)
+	suite.Require().NotNil(cmd)
+	updatedModel, _ = suite.model.Update(cmd())
+	suite.model = updatedModel.(Model)
+
+	suite.Equal(stepConfirm, suite.model.currentStep)
+	view := suite.model.View()
+	suite.Contains(view, "Private Key: Held by remote signer at http://localhost:8550")
+	suite.NotContains(view, "Available (hidden for security)")
+
+	updatedModel, _ = suite.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	suite.model = updatedModel.(Model)
+	suite.Equal(stepSuccess, suite.model.currentStep)
+}
+
+// TestRemoteSignerImportError tests that a rejected remote wallet shows the error.
+func (suite *WalletAddPageTestSuite) TestRemoteSignerImportError() {
+	suite.model.method = methodRemote
+	suite.model.aliasInput.SetValue("clef-wallet")
+	suite.model.signerURLInput.SetValue("http://localhost:8550")
+
+	msg := suite.model.importRemoteWallet()
+	updatedModel, _ := suite.model.Update(msg)
+	suite.model = updatedModel.(Model)
+	suite.Equal(stepError, suite.model.currentStep)
+	suite.Contains(suite.model.errorMsg, "address cannot be empty")
+
+	suite.model.remoteAddressInput.SetValue("0x1234")
+	suite.walletService.EXPECT().ImportRemoteWallet("clef-wallet", "http://localhost:8550", "0x1234").Return(nil, fmt.Errorf("invalid address: 0x1234"))
+	msg = suite.model.importRemoteWallet()
+	updatedModel, _ = suite.model.Update(msg)
+	suite.model = updatedModel.(Model)
+	suite.Contains(suite.model.errorMsg, "invalid address: 0x1234")
+}
+
 // TestPrivateKeyValidationError tests error handling when private key is invalid.
 func (suite *WalletAddPageTestSuite) TestPrivateKeyValidationError() {
 	// Navigate to private key entry
@@ -598,9 +671,17 @@ func (suite *WalletAddPageTestSuite) TestNavigationKeys() {
 	suite.model = updatedModel.(Model)
 	suite.Equal(2, suite.model.selectedIndex)
 
+	updatedModel, _ = suite.model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	suite.model = updatedModel.(Model)
+	suite.Equal(3, suite.model.selectedIndex)
+
 	// Can't go down past last option
 	updatedModel, _ = suite.model.Update(tea.KeyMsg{Type: tea.KeyDown})
 	suite.model = updatedModel.(Model)
+	suite.Equal(3, suite.model.selectedIndex)
+
+	updatedModel, _ = suite.model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	suite.model = updatedModel.(Model)
 	suite.Equal(2, suite.model.selectedIndex)
 
 	updatedModel, _ = suite.model.Update(tea.KeyMsg{Type: tea.KeyUp})
@@ -119,6 +119,14 @@ func (m *MockWalletService) ImportMnemonic(alias, mnemonic, derivationPath strin
 	return args.Get(0).(*models.EVMWallet), args.Error(1) //nolint:wrapcheck // Mock method
 }
 
+func (m *MockWalletService) ImportRemoteWallet(alias, signerURL, address string) (*models.EVMWallet, error) {
+	args := m.Called(alias, signerURL, address)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:wrapcheck // Mock method
+	}
+	return args.Get(0).(*models.EVMWallet), args.Error(1) //nolint:wrapcheck // Mock method
+}
+
 func (m *MockWalletService) GenerateWallet(alias string) (*models.EVMWallet, string, string, error) {
 	args := m.Called(alias)
 	if args.Get(0) == nil {
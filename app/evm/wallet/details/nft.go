@@ -0,0 +1,296 @@
+package details
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/endpointgroup"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/nft"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+)
+
+// maxNFTContracts limits how many registered contracts are scanned for NFTs.
+const maxNFTContracts = 100
+
+type nftsLoadedMsg struct {
+	collections []nft.Collection
+	err         error
+}
+
+type nftTransferredMsg struct {
+	txHash string
+	err    error
+}
+
+// nftItem is a single selectable token in the NFT section.
+type nftItem struct {
+	collection nft.Collection
+	token      nft.OwnedToken
+}
+
+// transportFactory creates the transports of the NFT contracts, failing over between the stored
+// endpoints of their chain.
+func transportFactory(storageClient sql.Storage) nft.TransportFactory {
+	return func(endpoint string) (transport.Transport, error) {
+		return endpointgroup.NewTransportForURL(storageClient, endpoint, 30*time.Second)
+	}
+}
+
+// loadNFTs scans the registered contracts for ERC-721/ERC-1155 tokens owned by the wallet.
+func (m Model) loadNFTs(owner string) tea.Cmd {
+	return func() tea.Msg {
+		storageClient, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			return nftsLoadedMsg{err: err}
+		}
+
+		contracts, err := storageClient.ListContracts(1, maxNFTContracts)
+		if err != nil {
+			return nftsLoadedMsg{err: fmt.Errorf("failed to list contracts: %w", err)}
+		}
+
+		collections := nft.LoadCollections(contracts.Items, common.HexToAddress(owner), transportFactory(storageClient))
+		return nftsLoadedMsg{collections: collections}
+	}
+}
+
+func (m Model) nftItems() []nftItem {
+	items := make([]nftItem, 0)
+	for _, collection := range m.collections {
+		for _, token := range collection.Tokens {
+			items = append(items, nftItem{collection: collection, token: token})
+		}
+	}
+	return items
+}
+
+func (m Model) selectedNFT() (nftItem, bool) {
+	items := m.nftItems()
+	if m.nftCursor < 0 || m.nftCursor >= len(items) {
+		return nftItem{}, false
+	}
+	return items[m.nftCursor], true
+}
+
+func (m Model) startNFTTransfer() (tea.Model, tea.Cmd) {
+	if _, ok := m.selectedNFT(); !ok {
+		return m, nil
+	}
+
+	m.mode = modeTransferNFT
+	m.transferError = ""
+	m.transferTxHash = ""
+	m.recipientInput.SetValue("")
+	m.amountInput.SetValue("1")
+	m.amountInput.Blur()
+	m.recipientInput.Focus()
+	return m, textinput.Blink
+}
+
+func (m Model) updateNFTTransfer(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.transferring {
+		return m, nil
+	}
+
+	item, _ := m.selectedNFT()
+	isERC1155 := item.token.Standard == nft.StandardERC1155
+
+	switch msg.String() {
+	case "esc":
+		m.mode = modeNormal
+		m.recipientInput.Blur()
+		m.amountInput.Blur()
+		return m, nil
+	case "tab", "shift+tab":
+		if isERC1155 {
+			if m.recipientInput.Focused() {
+				m.recipientInput.Blur()
+				m.amountInput.Focus()
+			} else {
+				m.amountInput.Blur()
+				m.recipientInput.Focus()
+			}
+		}
+		return m, nil
+	case "enter":
+		if m.transferTxHash != "" {
+			m.mode = modeNormal
+			return m, m.loadNFTs(m.wallet.Wallet.Address)
+		}
+		return m.submitNFTTransfer(item)
+	}
+
+	var cmd tea.Cmd
+	if m.amountInput.Focused() {
+		m.amountInput, cmd = m.amountInput.Update(msg)
+	} else {
+		m.recipientInput, cmd = m.recipientInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) submitNFTTransfer(item nftItem) (tea.Model, tea.Cmd) {
+	recipient := strings.TrimSpace(m.recipientInput.Value())
+	if !common.IsHexAddress(recipient) {
+		m.transferError = "Invalid recipient address"
+		return m, nil
+	}
+
+	amount := big.NewInt(1)
+	if item.token.Standard == nft.StandardERC1155 {
+		parsed, ok := new(big.Int).SetString(strings.TrimSpace(m.amountInput.Value()), 10)
+		if !ok || parsed.Sign() <= 0 {
+			m.transferError = "Amount must be a positive integer"
+			return m, nil
+		}
+		if parsed.Cmp(item.token.Balance) > 0 {
+			m.transferError = fmt.Sprintf("Amount exceeds balance of %s", item.token.Balance)
+			return m, nil
+		}
+		amount = parsed
+	}
+
+	m.transferError = ""
+	m.transferring = true
+	return m, m.transferNFT(item, common.HexToAddress(recipient), amount)
+}
+
+func (m Model) transferNFT(item nftItem, recipient common.Address, amount *big.Int) tea.Cmd {
+	return func() tea.Msg {
+		storageClient, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			return nftTransferredMsg{err: err}
+		}
+		tr, err := transportFactory(storageClient)(item.collection.EndpointURL)
+		if err != nil {
+			return nftTransferredMsg{err: err}
+		}
+
+		walletSigner, err := wallet.NewSigner(m.walletService, m.walletID, tr)
+		if err != nil {
+			return nftTransferredMsg{err: err}
+		}
+
+		txHash, err := nft.SafeTransfer(tr, walletSigner, item.token.Contract, recipient, item.token.TokenID, amount)
+		if err != nil {
+			logger.Error("NFT transfer failed: %v", err)
+			return nftTransferredMsg{err: err}
+		}
+
+		logger.Info("Transferred token %s of %s in tx %s", item.token.TokenID, item.token.Contract.Hex(), txHash)
+		return nftTransferredMsg{txHash: txHash}
+	}
+}
+
+func tokenLabel(item nftItem) string {
+	label := fmt.Sprintf("%s #%s", item.collection.ContractName, item.token.TokenID)
+	if item.token.Standard == nft.StandardERC1155 {
+		label += fmt.Sprintf(" ×%s", item.token.Balance)
+	}
+	if item.token.Metadata != nil && item.token.Metadata.Name != "" {
+		label += " - " + item.token.Metadata.Name
+	}
+	return label
+}
+
+func (m Model) renderNFTs() component.Component {
+	if m.nftLoading {
+		return component.VStackC(
+			component.T("NFTs:").Bold(true),
+			component.T("• Scanning registered contracts...").Muted(),
+		)
+	}
+
+	if m.nftErrorMsg != "" {
+		return component.VStackC(
+			component.T("NFTs:").Bold(true),
+			component.T("• unavailable: "+m.nftErrorMsg).Muted(),
+		)
+	}
+
+	rows := []component.Component{component.T("NFTs:").Bold(true)}
+	for _, collection := range m.collections {
+		if collection.Err != nil {
+			rows = append(rows, component.T(fmt.Sprintf("• %s (%s): %v", collection.ContractName, collection.Info.Standard, collection.Err)).Error())
+		}
+		if collection.Truncated {
+			rows = append(rows, component.T(fmt.Sprintf("• %s (%s): only the first %d tokens are listed", collection.ContractName, collection.Info.Standard, nft.MaxEnumeratedTokens)).Muted())
+		}
+	}
+
+	items := m.nftItems()
+	if len(items) == 0 {
+		rows = append(rows, component.T("• No tokens owned in registered ERC-721/ERC-1155 contracts").Muted())
+		return component.VStackC(rows...)
+	}
+
+	for index, item := range items {
+		label := "  " + tokenLabel(item) + " (" + string(item.token.Standard) + ")"
+		if index == m.nftCursor {
+			rows = append(rows, component.T("> "+strings.TrimPrefix(label, "  ")).Bold(true))
+		} else {
+			rows = append(rows, component.T(label).Muted())
+		}
+	}
+
+	if selected, ok := m.selectedNFT(); ok && selected.token.Metadata != nil {
+		metadata := selected.token.Metadata
+		rows = append(rows, component.SpacerV(1))
+		rows = append(rows, component.IfC(metadata.Description != "", component.T("  Description: "+metadata.Description).Muted(), component.Empty()))
+		rows = append(rows, component.IfC(metadata.Image != "", component.T("  Image: "+truncate(metadata.Image, 60)).Muted(), component.Empty()))
+		for _, attribute := range metadata.Attributes {
+			rows = append(rows, component.T(fmt.Sprintf("  %s: %v", attribute.TraitType, attribute.Value)).Muted())
+		}
+	}
+
+	return component.VStackC(rows...)
+}
+
+func (m Model) renderNFTTransfer() string {
+	item, _ := m.selectedNFT()
+	isERC1155 := item.token.Standard == nft.StandardERC1155
+
+	status := component.Empty()
+	switch {
+	case m.transferring:
+		status = component.T("Sending safeTransferFrom transaction...").Muted()
+	case m.transferTxHash != "":
+		status = component.VStackC(
+			component.T("✓ Transfer confirmed").Primary(),
+			component.T("Transaction: "+m.transferTxHash).Muted(),
+			component.T("Press enter to return").Muted(),
+		)
+	case m.transferError != "":
+		status = component.T("Error: " + m.transferError).Error()
+	}
+
+	return component.VStackC(
+		component.T("Transfer NFT").Bold(true).Primary(),
+		component.SpacerV(1),
+		component.T("Token: "+tokenLabel(item)),
+		component.T("Standard: "+string(item.token.Standard)).Muted(),
+		component.T("Contract: "+item.token.Contract.Hex()).Muted(),
+		component.T("From: "+m.wallet.Wallet.Address).Muted(),
+		component.SpacerV(1),
+		component.T("Recipient: "+m.recipientInput.View()),
+		component.IfC(isERC1155, component.T("Amount:    "+m.amountInput.View()), component.Empty()),
+		component.SpacerV(1),
+		status,
+	).Render()
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length] + "..."
+}
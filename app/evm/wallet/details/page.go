@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rxtech-lab/smart-contract-cli/internal/config"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/nft"
 	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
 	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
 	"github.com/rxtech-lab/smart-contract-cli/internal/log"
@@ -26,6 +27,7 @@ const (
 	modeNormal viewMode = iota
 	modeShowPrivateKeyPrompt
 	modeShowPrivateKey
+	modeTransferNFT
 )
 
 type Model struct {
@@ -42,6 +44,16 @@ type Model struct {
 	confirmationInput textinput.Model
 	autoCloseCounter  int
 
+	collections    []nft.Collection
+	nftCursor      int
+	nftLoading     bool
+	nftErrorMsg    string
+	recipientInput textinput.Model
+	amountInput    textinput.Model
+	transferring   bool
+	transferTxHash string
+	transferError  string
+
 	loading  bool
 	errorMsg string
 }
@@ -56,6 +68,14 @@ func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, w
 	confirmInput.Placeholder = "Type 'SHOW' to confirm"
 	confirmInput.Width = 30
 
+	recipientInput := textinput.New()
+	recipientInput.Placeholder = "0x..."
+	recipientInput.Width = 44
+
+	amountInput := textinput.New()
+	amountInput.Placeholder = "1"
+	amountInput.Width = 20
+
 	return Model{
 		router:            router,
 		sharedMemory:      sharedMemory,
@@ -63,6 +83,8 @@ func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, w
 		loading:           true,
 		mode:              modeNormal,
 		confirmationInput: confirmInput,
+		recipientInput:    recipientInput,
+		amountInput:       amountInput,
 	}
 }
 
@@ -177,6 +199,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.wallet = msg.wallet
 		m.walletService = msg.walletService
 		m.selectedWalletID = msg.selectedWalletID
+		m.nftLoading = true
+		m.nftErrorMsg = ""
+		return m, m.loadNFTs(msg.wallet.Wallet.Address)
+
+	case nftsLoadedMsg:
+		m.nftLoading = false
+		if msg.err != nil {
+			logger.Error("Failed to load NFTs: %v", msg.err)
+			m.nftErrorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.collections = msg.collections
+		if m.nftCursor >= len(m.nftItems()) {
+			m.nftCursor = 0
+		}
+		return m, nil
+
+	case nftTransferredMsg:
+		m.transferring = false
+		if msg.err != nil {
+			m.transferError = msg.err.Error()
+			return m, nil
+		}
+		m.transferTxHash = msg.txHash
 		return m, nil
 
 	case privateKeyLoadedMsg:
@@ -229,6 +275,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, cmd
 
+		case modeTransferNFT:
+			return m.updateNFTTransfer(msg)
+
 		case modeShowPrivateKey:
 			switch msg.String() {
 			case "c":
@@ -243,6 +292,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case modeNormal:
 			switch msg.String() {
 			case "p":
+				// Remote wallets have no local private key to show
+				if m.wallet != nil && m.wallet.Wallet.IsRemote {
+					return m, nil
+				}
 				m.mode = modeShowPrivateKeyPrompt
 				m.confirmationInput.Focus()
 				return m, textinput.Blink
@@ -250,6 +303,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Refresh balance
 				m.loading = true
 				return m, m.loadWallet
+			case "up", "k":
+				if m.nftCursor > 0 {
+					m.nftCursor--
+				}
+			case "down", "j":
+				if m.nftCursor < len(m.nftItems())-1 {
+					m.nftCursor++
+				}
+			case "t":
+				return m.startNFTTransfer()
 			}
 		}
 	}
@@ -267,8 +330,17 @@ func (m Model) Help() (string, view.HelpDisplayOption) {
 		return "enter: confirm • esc: cancel", view.HelpDisplayOptionOverride
 	case modeShowPrivateKey:
 		return "c: copy to clipboard • esc/q: close immediately", view.HelpDisplayOptionOverride
+	case modeTransferNFT:
+		return "tab: switch field • enter: send • esc: cancel", view.HelpDisplayOptionOverride
 	default:
-		return "r: refresh balance • p: show private key • esc/q: back", view.HelpDisplayOptionAppend
+		keys := "r: refresh balance • p: show private key"
+		if m.wallet != nil && m.wallet.Wallet.IsRemote {
+			keys = "r: refresh balance"
+		}
+		if len(m.nftItems()) > 0 {
+			return keys + " • ↑/↓: select NFT • t: transfer NFT • esc/q: back", view.HelpDisplayOptionAppend
+		}
+		return keys + " • esc/q: back", view.HelpDisplayOptionAppend
 	}
 }
 
@@ -305,6 +377,8 @@ func (m Model) View() string {
 		return m.renderPrivateKeyPrompt()
 	case modeShowPrivateKey:
 		return m.renderPrivateKey()
+	case modeTransferNFT:
+		return m.renderNFTTransfer()
 	default:
 		return m.renderDetails()
 	}
@@ -332,6 +406,17 @@ func (m Model) renderDetails() string {
 		statusStr = "★ Currently Selected"
 	}
 
+	// Imported private key wallets have no derivation path
+	derivationPath := ""
+	if m.wallet.Wallet.DerivationPath != nil {
+		derivationPath = *m.wallet.Wallet.DerivationPath
+	}
+
+	privateKeyStr := "******** (hidden)"
+	if m.wallet.Wallet.IsRemote && m.wallet.Wallet.RemoteSignerURL != nil {
+		privateKeyStr = "Held by remote signer at " + *m.wallet.Wallet.RemoteSignerURL
+	}
+
 	title := "Wallet Details - " + m.wallet.Wallet.Alias
 
 	return component.VStackC(
@@ -345,8 +430,8 @@ func (m Model) renderDetails() string {
 		component.T("• Address: "+m.wallet.Wallet.Address).Muted(),
 		component.T("• Checksum: ✓ Valid Ethereum address").Muted(),
 		component.IfC(
-			m.wallet.Wallet.DerivationPath != nil && *m.wallet.Wallet.DerivationPath != "",
-			component.T("• Derivation Path: "+*m.wallet.Wallet.DerivationPath).Muted(),
+			derivationPath != "",
+			component.T("• Derivation Path: "+derivationPath).Muted(),
 			component.Empty(),
 		),
 		component.SpacerV(1),
@@ -359,7 +444,7 @@ func (m Model) renderDetails() string {
 		component.SpacerV(1),
 
 		component.T("Security:").Bold(true),
-		component.T("• Private Key: ******** (hidden)").Muted(),
+		component.T("• Private Key: "+privateKeyStr).Muted(),
 		component.T("• Created: "+m.wallet.Wallet.CreatedAt.Format("2006-01-02 3:04 PM")).Muted(),
 		component.T("• Last Modified: "+m.wallet.Wallet.UpdatedAt.Format("2006-01-02 3:04 PM")).Muted(),
 		component.IfC(
@@ -367,6 +452,9 @@ func (m Model) renderDetails() string {
 			component.T("• Mnemonic: Available (use 'm' to show)").Muted(),
 			component.Empty(),
 		),
+		component.SpacerV(1),
+
+		m.renderNFTs(),
 	).Render()
 }
 
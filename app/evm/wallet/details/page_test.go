@@ -7,7 +7,9 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rxtech-lab/smart-contract-cli/internal/config"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/nft"
 	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
 	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
 	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
@@ -120,6 +122,14 @@ func (m *MockWalletService) ImportMnemonic(alias, mnemonic, derivationPath strin
 	return args.Get(0).(*models.EVMWallet), args.Error(1) //nolint:wrapcheck,forcetypeassert // Mock method
 }
 
+func (m *MockWalletService) ImportRemoteWallet(alias, signerURL, address string) (*models.EVMWallet, error) {
+	args := m.Called(alias, signerURL, address)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:wrapcheck // Mock method
+	}
+	return args.Get(0).(*models.EVMWallet), args.Error(1) //nolint:wrapcheck,forcetypeassert // Mock method
+}
+
 func (m *MockWalletService) GenerateWallet(alias string) (*models.EVMWallet, string, string, error) {
 	args := m.Called(alias)
 	if args.Get(0) == nil {
@@ -353,6 +363,36 @@ func (suite *WalletDetailsPageTestSuite) TestShowPrivateKeyPrompt() {
 	suite.Contains(view, "Type \"SHOW\" to reveal")
 }
 
+// TestRemoteWalletHasNoPrivateKey tests that a remote wallet shows its signer instead of the private key.
+func (suite *WalletDetailsPageTestSuite) TestRemoteWalletHasNoPrivateKey() {
+	suite.router.On("GetQueryParam", "id").Return("1")
+	signerURL := "http://localhost:8550"
+	testWallet := &wallet.WalletWithBalance{
+		Wallet: models.EVMWallet{
+			ID:              1,
+			Alias:           "clef-wallet",
+			Address:         "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+			IsRemote:        true,
+			RemoteSignerURL: &signerURL,
+		},
+		Balance: big.NewInt(0),
+	}
+	suite.walletService.On("GetWalletWithBalance", uint(1), "http://localhost:8545").Return(testWallet, nil)
+
+	loadMsg := suite.model.loadWallet()
+	updatedModel, _ := suite.model.Update(loadMsg)
+	suite.model = updatedModel.(Model)
+
+	suite.Contains(suite.model.View(), "Private Key: Held by remote signer at http://localhost:8550")
+	helpText, _ := suite.model.Help()
+	suite.NotContains(helpText, "show private key")
+
+	// Pressing 'p' does nothing
+	updatedModel, _ = suite.model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	suite.model = updatedModel.(Model)
+	suite.Equal(modeNormal, suite.model.mode)
+}
+
 // TestCancelPrivateKeyPrompt tests canceling private key prompt.
 func (suite *WalletDetailsPageTestSuite) TestCancelPrivateKeyPrompt() {
 	// Setup wallet and enter prompt mode
@@ -694,3 +734,116 @@ func (suite *WalletDetailsPageTestSuite) TestIgnoreKeysWhileLoading() {
 	// Verify nothing changed
 	suite.Equal(modeNormal, suite.model.mode)
 }
+
+func (suite *WalletDetailsPageTestSuite) setupNFTs() {
+	suite.model.loading = false
+	suite.model.walletID = 1
+	suite.model.wallet = &wallet.WalletWithBalance{
+		Wallet: models.EVMWallet{
+			ID:      1,
+			Alias:   "nft-wallet",
+			Address: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		},
+		Balance: big.NewInt(0),
+	}
+
+	contract := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	updatedModel, _ := suite.model.Update(nftsLoadedMsg{collections: []nft.Collection{
+		{
+			ContractName: "Punks",
+			EndpointURL:  "http://localhost:8545",
+			Info:         &nft.ContractInfo{Address: contract, Standard: nft.StandardERC721},
+			Truncated:    true,
+			Tokens: []nft.OwnedToken{
+				{
+					Token:    nft.Token{Contract: contract, Standard: nft.StandardERC721, TokenID: big.NewInt(7), Balance: big.NewInt(1)},
+					Metadata: &nft.Metadata{Name: "Punk Seven", Description: "A rare punk"},
+				},
+			},
+		},
+		{
+			ContractName: "Items",
+			EndpointURL:  "http://localhost:8545",
+			Info:         &nft.ContractInfo{Address: contract, Standard: nft.StandardERC1155},
+			Tokens: []nft.OwnedToken{
+				{Token: nft.Token{Contract: contract, Standard: nft.StandardERC1155, TokenID: big.NewInt(3), Balance: big.NewInt(5)}},
+			},
+		},
+	}})
+	suite.model = updatedModel.(Model)
+}
+
+// TestNFTSection tests listing owned NFTs and moving the cursor.
+func (suite *WalletDetailsPageTestSuite) TestNFTSection() {
+	suite.setupNFTs()
+
+	view := suite.model.View()
+	suite.Contains(view, "NFTs:")
+	suite.Contains(view, "Punks #7 - Punk Seven (ERC-721)")
+	suite.Contains(view, "Items #3 ×5 (ERC-1155)")
+	suite.Contains(view, "A rare punk")
+	suite.Contains(view, "Punks (ERC-721): only the first 1000 tokens are listed")
+
+	updatedModel, _ := suite.model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	suite.model = updatedModel.(Model)
+	suite.Equal(1, suite.model.nftCursor)
+
+	helpText, _ := suite.model.Help()
+	suite.Contains(helpText, "transfer NFT")
+}
+
+// TestNFTLoadError tests that NFT errors do not hide wallet details.
+func (suite *WalletDetailsPageTestSuite) TestNFTLoadError() {
+	suite.setupNFTs()
+
+	updatedModel, _ := suite.model.Update(nftsLoadedMsg{err: fmt.Errorf("storage client not initialized")})
+	suite.model = updatedModel.(Model)
+
+	view := suite.model.View()
+	suite.Contains(view, "nft-wallet")
+	suite.Contains(view, "unavailable: storage client not initialized")
+}
+
+// TestNFTTransferValidation tests the transfer form validation.
+func (suite *WalletDetailsPageTestSuite) TestNFTTransferValidation() {
+	suite.setupNFTs()
+
+	updatedModel, _ := suite.model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	suite.model = updatedModel.(Model)
+	suite.Equal(modeTransferNFT, suite.model.mode)
+	suite.Contains(suite.model.View(), "Transfer NFT")
+
+	suite.model.recipientInput.SetValue("not-an-address")
+	updatedModel, cmd := suite.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	suite.model = updatedModel.(Model)
+	suite.Nil(cmd)
+	suite.Contains(suite.model.View(), "Invalid recipient address")
+
+	// ERC-1155 amount cannot exceed the owned balance
+	suite.model.nftCursor = 1
+	suite.model.recipientInput.SetValue("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	suite.model.amountInput.SetValue("6")
+	updatedModel, cmd = suite.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	suite.model = updatedModel.(Model)
+	suite.Nil(cmd)
+	suite.Contains(suite.model.View(), "Amount exceeds balance of 5")
+
+	updatedModel, _ = suite.model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	suite.model = updatedModel.(Model)
+	suite.Equal(modeNormal, suite.model.mode)
+}
+
+// TestNFTTransferResult tests rendering the transfer outcome.
+func (suite *WalletDetailsPageTestSuite) TestNFTTransferResult() {
+	suite.setupNFTs()
+	suite.model.mode = modeTransferNFT
+	suite.model.transferring = true
+
+	updatedModel, _ := suite.model.Update(nftTransferredMsg{txHash: "0xdeadbeef"})
+	suite.model = updatedModel.(Model)
+
+	suite.False(suite.model.transferring)
+	view := suite.model.View()
+	suite.Contains(view, "Transfer confirmed")
+	suite.Contains(view, "0xdeadbeef")
+}
@@ -0,0 +1,364 @@
+package sign
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/config"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/wallet/sign.log")
+
+type signStep int
+
+const (
+	stepLoading signStep = iota
+	stepInput
+	stepSigning
+	stepSigned
+	stepError
+)
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	walletService wallet.WalletService
+
+	currentStep signStep
+	wallet      *models.EVMWallet
+
+	mode  signer.MessageMode
+	input textarea.Model
+
+	hash      common.Hash
+	signature string
+	message   string
+
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithService(router, sharedMemory, nil)
+}
+
+// NewPageWithService creates a new sign message page with an optional wallet service (for testing).
+func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, walletService wallet.WalletService) view.View {
+	input := textarea.New()
+	input.Placeholder = "Text to sign, or 0x-prefixed hex bytes"
+	input.SetWidth(76)
+	input.SetHeight(5)
+	input.Focus()
+
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		walletService: walletService,
+		currentStep:   stepLoading,
+		mode:          signer.MessageModePersonal,
+		input:         input,
+	}
+}
+
+type walletLoadedMsg struct {
+	wallet        *models.EVMWallet
+	walletService wallet.WalletService
+	err           error
+}
+
+type signedMsg struct {
+	hash      common.Hash
+	signature string
+	err       error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadWallet
+}
+
+func (m Model) createWalletService() (wallet.WalletService, error) {
+	storageClient, err := m.sharedMemory.Get(config.StorageClientKey)
+	if err != nil || storageClient == nil {
+		logger.Error("Failed to get storage client from shared memory: %v", err)
+		return nil, fmt.Errorf("storage client not initialized")
+	}
+
+	sqlStorage, isValidStorage := storageClient.(sql.Storage)
+	if !isValidStorage {
+		logger.Error("Invalid storage client type")
+		return nil, fmt.Errorf("invalid storage client type")
+	}
+
+	secureStorage, _, err := utils.GetSecureStorageFromSharedMemory(m.sharedMemory)
+	if err != nil {
+		logger.Error("Failed to get secure storage from shared memory: %v", err)
+		return nil, fmt.Errorf("failed to get secure storage from shared memory: %w", err)
+	}
+
+	return wallet.NewWalletService(sqlStorage, secureStorage), nil
+}
+
+func (m Model) loadWallet() tea.Msg {
+	walletID, err := strconv.ParseUint(m.router.GetQueryParam("id"), 10, 32)
+	if err != nil {
+		return walletLoadedMsg{err: fmt.Errorf("invalid wallet ID: %w", err)}
+	}
+
+	// Use injected wallet service if available (for testing)
+	walletService := m.walletService
+	if walletService == nil {
+		svc, err := m.createWalletService()
+		if err != nil {
+			return walletLoadedMsg{err: err}
+		}
+		walletService = svc
+	}
+
+	walletData, err := walletService.GetWallet(uint(walletID))
+	if err != nil {
+		logger.Error("Failed to load wallet %d: %v", walletID, err)
+		return walletLoadedMsg{err: fmt.Errorf("failed to load wallet: %w", err)}
+	}
+
+	return walletLoadedMsg{wallet: walletData, walletService: walletService}
+}
+
+func (m Model) sign(input string, mode signer.MessageMode) tea.Cmd {
+	walletService, walletData := m.walletService, m.wallet
+	return func() tea.Msg {
+		hash, err := signer.MessageHash(input, mode)
+		if err != nil {
+			return signedMsg{err: err}
+		}
+		// Remote signers refuse raw hashes, only a local key can sign them
+		if mode == signer.MessageModeHash && walletData.IsRemote {
+			return signedMsg{err: fmt.Errorf("remote signers cannot sign raw hashes")}
+		}
+		accountSigner, err := wallet.NewAccountSigner(walletService, walletData)
+		if err != nil {
+			logger.Error("Failed to create signer for wallet %d: %v", walletData.ID, err)
+			return signedMsg{err: err}
+		}
+
+		var signature string
+		if mode == signer.MessageModeHash {
+			keySigner, ok := accountSigner.(*signer.PrivateKeySigner)
+			if !ok {
+				return signedMsg{err: fmt.Errorf("unsupported signer type %T", accountSigner)}
+			}
+			signature, err = keySigner.SignHash(hash)
+		} else {
+			message, _ := signer.DecodeMessage(input)
+			signature, err = accountSigner.SignMessageString(string(message))
+		}
+		if err != nil {
+			return signedMsg{err: err}
+		}
+		return signedMsg{hash: hash, signature: signature}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case walletLoadedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.wallet = msg.wallet
+		m.walletService = msg.walletService
+		m.currentStep = stepInput
+		return m, textarea.Blink
+
+	case signedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			m.currentStep = stepInput
+			return m, nil
+		}
+		m.hash = msg.hash
+		m.signature = msg.signature
+		m.currentStep = stepSigned
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepInput:
+			return m.handleInput(msg)
+		case stepSigned:
+			if msg.String() == "n" {
+				m.input.Reset()
+				m.signature = ""
+				m.currentStep = stepInput
+				return m, textarea.Blink
+			}
+		case stepError:
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/wallet", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+t":
+		m.mode = toggleMode(m.mode)
+		m.errorMsg = ""
+		return m, nil
+	case "ctrl+s": // Enter adds a newline in the textarea
+		m.message = m.input.Value()
+		if strings.TrimSpace(m.message) == "" {
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.currentStep = stepSigning
+		return m, m.sign(m.message, m.mode)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func toggleMode(mode signer.MessageMode) signer.MessageMode {
+	if mode == signer.MessageModePersonal {
+		return signer.MessageModeHash
+	}
+	return signer.MessageModePersonal
+}
+
+// modeLabel describes a message mode for display.
+func modeLabel(mode signer.MessageMode) string {
+	if mode == signer.MessageModeHash {
+		return "raw hash (signs 32 bytes as is)"
+	}
+	return "personal_sign (EIP-191)"
+}
+
+// describeMessage describes how the message input is interpreted.
+func describeMessage(input string, mode signer.MessageMode) string {
+	if mode == signer.MessageModeHash {
+		return "32 byte hash"
+	}
+	message, isHex := signer.DecodeMessage(input)
+	if isHex {
+		return fmt.Sprintf("hex, %d bytes", len(message))
+	}
+	return fmt.Sprintf("text, %d bytes", len(message))
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepInput:
+		return "ctrl+s: sign • ctrl+t: switch mode • esc: back", view.HelpDisplayOptionOverride
+	case stepSigned:
+		return "n: sign another message • esc: back", view.HelpDisplayOptionOverride
+	case stepError:
+		return "Press any key to return to wallet list", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	title := component.T("Sign Message").Bold(true).Primary()
+
+	switch m.currentStep {
+	case stepInput, stepSigning:
+		return m.renderInput(title)
+	case stepSigned:
+		return m.renderSigned(title)
+	case stepError:
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Loading wallet...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) renderWallet() component.Component {
+	return component.VStackC(
+		component.T("Wallet: "+m.wallet.Alias),
+		component.T("Address: "+m.wallet.Address).Muted(),
+	)
+}
+
+func (m Model) renderInput(title component.Component) string {
+	status := component.Empty()
+	switch {
+	case m.currentStep == stepSigning:
+		status = component.T("Signing...").Muted()
+	case m.errorMsg != "":
+		status = component.T("Error: " + m.errorMsg).Error()
+	}
+
+	warning := component.Empty()
+	if m.mode == signer.MessageModeHash {
+		warning = component.T("⚠ A raw hash can be a transaction or permit digest. Only sign hashes whose content you know.").Warning()
+	}
+
+	return component.VStackC(
+		title,
+		component.SpacerV(1),
+		m.renderWallet(),
+		component.SpacerV(1),
+		component.T("Mode: "+modeLabel(m.mode)).Bold(true),
+		warning,
+		component.SpacerV(1),
+		component.T("Message").Bold(true),
+		component.T(m.input.View()),
+		component.SpacerV(1),
+		status,
+	).Render()
+}
+
+func (m Model) renderSigned(title component.Component) string {
+	signature := common.FromHex(m.signature)
+	components := component.Empty()
+	if len(signature) == 65 {
+		components = component.VStackC(
+			component.T("r: "+common.BytesToHash(signature[:32]).Hex()).Muted(),
+			component.T("s: "+common.BytesToHash(signature[32:64]).Hex()).Muted(),
+			component.T(fmt.Sprintf("v: %d", signature[64])).Muted(),
+		)
+	}
+
+	return component.VStackC(
+		title,
+		component.SpacerV(1),
+		component.T("✓ Message signed").Success(),
+		component.SpacerV(1),
+		component.T("Signer: "+m.wallet.Address),
+		component.T("Mode: "+modeLabel(m.mode)),
+		component.T("Message: "+describeMessage(m.message, m.mode)),
+		component.T("Hash: "+m.hash.Hex()),
+		component.SpacerV(1),
+		component.T("Signature").Bold(true),
+		component.T(m.signature),
+		components,
+	).Render()
+}
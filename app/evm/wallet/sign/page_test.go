@@ -0,0 +1,132 @@
+package sign
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	walletsvc "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	// Anvil default test account.
+	testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	testAddress    = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	// helloHash is the EIP-191 personal message hash of "hello".
+	helloHash = "0x50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750"
+)
+
+type SignPageTestSuite struct {
+	suite.Suite
+	mockCtrl          *gomock.Controller
+	mockRouter        *view.MockRouter
+	mockWalletService *walletsvc.MockWalletService
+	model             Model
+}
+
+func TestSignPageTestSuite(t *testing.T) {
+	suite.Run(t, new(SignPageTestSuite))
+}
+
+func (s *SignPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockWalletService = walletsvc.NewMockWalletService(s.mockCtrl)
+	s.model = NewPageWithService(s.mockRouter, storage.NewSharedMemory(), s.mockWalletService).(Model)
+
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("1")
+	s.mockWalletService.EXPECT().GetWallet(uint(1)).Return(&models.EVMWallet{ID: 1, Alias: "Main Wallet", Address: testAddress}, nil)
+	s.update(s.model.loadWallet())
+	s.Require().Equal(stepInput, s.model.currentStep)
+}
+
+func (s *SignPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *SignPageTestSuite) update(msg tea.Msg) {
+	updated, _ := s.model.Update(msg)
+	s.model = updated.(Model)
+}
+
+// sign enters the message and runs the signing command.
+func (s *SignPageTestSuite) sign(message string) {
+	s.model.input.SetValue(message)
+	updated, cmd := s.model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	s.model = updated.(Model)
+	s.Require().NotNil(cmd)
+	s.Equal(stepSigning, s.model.currentStep)
+	s.update(cmd())
+}
+
+// recover returns the address that signed the hash shown on the page.
+func (s *SignPageTestSuite) recover() common.Address {
+	recovered, err := signer.RecoverAddress(s.model.hash, s.model.signature)
+	s.Require().NoError(err)
+	return recovered
+}
+
+func (s *SignPageTestSuite) TestSignPersonalMessage() {
+	s.Contains(s.model.View(), "Mode: personal_sign (EIP-191)")
+	s.mockWalletService.EXPECT().GetPrivateKey(uint(1)).Return(testPrivateKey, nil)
+
+	s.sign("hello")
+
+	s.Require().Equal(stepSigned, s.model.currentStep, s.model.errorMsg)
+	output := s.model.View()
+	s.Contains(output, "✓ Message signed")
+	s.Contains(output, "Message: text, 5 bytes")
+	s.Contains(output, "Hash: "+helloHash)
+	s.Contains(output, s.model.signature)
+	s.Equal(common.HexToAddress(testAddress), s.recover())
+}
+
+func (s *SignPageTestSuite) TestSignHexBytes() {
+	s.mockWalletService.EXPECT().GetPrivateKey(uint(1)).Return(testPrivateKey, nil)
+
+	s.sign("0x68656c6c6f")
+
+	s.Require().Equal(stepSigned, s.model.currentStep, s.model.errorMsg)
+	s.Contains(s.model.View(), "Message: hex, 5 bytes")
+	s.Equal(helloHash, s.model.hash.Hex())
+}
+
+func (s *SignPageTestSuite) TestSignRawHash() {
+	s.update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	output := s.model.View()
+	s.Contains(output, "Mode: raw hash")
+	s.Contains(output, "Only sign hashes whose content you know")
+
+	s.sign("hello")
+	s.Equal(stepInput, s.model.currentStep)
+	s.Contains(s.model.View(), "invalid hash")
+
+	s.mockWalletService.EXPECT().GetPrivateKey(uint(1)).Return(testPrivateKey, nil)
+	s.sign(helloHash)
+
+	s.Require().Equal(stepSigned, s.model.currentStep, s.model.errorMsg)
+	s.Contains(s.model.View(), "Message: 32 byte hash")
+	s.Equal(helloHash, s.model.hash.Hex())
+	s.Equal(common.HexToAddress(testAddress), s.recover())
+
+	s.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	s.Equal(stepInput, s.model.currentStep)
+	s.Empty(s.model.input.Value())
+}
+
+func (s *SignPageTestSuite) TestRemoteWalletCannotSignRawHash() {
+	signerURL := "http://localhost:8550"
+	s.model.wallet = &models.EVMWallet{ID: 1, Alias: "Clef Wallet", Address: testAddress, IsRemote: true, RemoteSignerURL: &signerURL}
+	s.update(tea.KeyMsg{Type: tea.KeyCtrlT})
+
+	s.sign(helloHash)
+
+	s.Equal(stepInput, s.model.currentStep)
+	s.Contains(s.model.View(), "remote signers cannot sign raw hashes")
+}
@@ -0,0 +1,480 @@
+package typeddata
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/rxtech-lab/smart-contract-cli/internal/config"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/wallet/typeddata.log")
+
+type typedDataStep int
+
+const (
+	stepLoading typedDataStep = iota
+	stepInput
+	stepReview
+	stepSigning
+	stepSigned
+	stepError
+)
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	walletService wallet.WalletService
+
+	currentStep typedDataStep
+	walletID    uint
+	wallet      *models.EVMWallet
+
+	input     textarea.Model
+	typedData string
+	parsed    *apitypes.TypedData
+	digest    common.Hash
+	domain    []decoder.Value
+	message   decoder.Value
+
+	signature string
+
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithService(router, sharedMemory, nil)
+}
+
+// NewPageWithService creates a new typed data signing page with an optional wallet service (for testing).
+func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, walletService wallet.WalletService) view.View {
+	input := textarea.New()
+	input.Placeholder = `Paste eth_signTypedData_v4 JSON ({"types": …, "primaryType": …, "domain": …, "message": …}) or a file path`
+	input.SetWidth(76)
+	input.SetHeight(10)
+	input.CharLimit = 0
+	input.Focus()
+
+	return Model{
+		router:        router,
+		sharedMemory:  sharedMemory,
+		walletService: walletService,
+		currentStep:   stepLoading,
+		input:         input,
+	}
+}
+
+type walletLoadedMsg struct {
+	walletID      uint
+	wallet        *models.EVMWallet
+	walletService wallet.WalletService
+	err           error
+}
+
+type parsedMsg struct {
+	typedData string
+	parsed    *apitypes.TypedData
+	digest    common.Hash
+	err       error
+}
+
+type signedMsg struct {
+	signature string
+	err       error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadWallet
+}
+
+func (m Model) createWalletService() (wallet.WalletService, error) {
+	storageClient, err := m.sharedMemory.Get(config.StorageClientKey)
+	if err != nil || storageClient == nil {
+		logger.Error("Failed to get storage client from shared memory: %v", err)
+		return nil, fmt.Errorf("storage client not initialized")
+	}
+
+	sqlStorage, isValidStorage := storageClient.(sql.Storage)
+	if !isValidStorage {
+		logger.Error("Invalid storage client type")
+		return nil, fmt.Errorf("invalid storage client type")
+	}
+
+	secureStorage, _, err := utils.GetSecureStorageFromSharedMemory(m.sharedMemory)
+	if err != nil {
+		logger.Error("Failed to get secure storage from shared memory: %v", err)
+		return nil, fmt.Errorf("failed to get secure storage from shared memory: %w", err)
+	}
+
+	return wallet.NewWalletService(sqlStorage, secureStorage), nil
+}
+
+func (m Model) loadWallet() tea.Msg {
+	walletID, err := strconv.ParseUint(m.router.GetQueryParam("id"), 10, 32)
+	if err != nil {
+		return walletLoadedMsg{err: fmt.Errorf("invalid wallet ID: %w", err)}
+	}
+
+	// Use injected wallet service if available (for testing)
+	walletService := m.walletService
+	if walletService == nil {
+		svc, err := m.createWalletService()
+		if err != nil {
+			return walletLoadedMsg{err: err}
+		}
+		walletService = svc
+	}
+
+	walletData, err := walletService.GetWallet(uint(walletID))
+	if err != nil {
+		logger.Error("Failed to load wallet %d: %v", walletID, err)
+		return walletLoadedMsg{err: fmt.Errorf("failed to load wallet: %w", err)}
+	}
+
+	return walletLoadedMsg{walletID: uint(walletID), wallet: walletData, walletService: walletService}
+}
+
+// parse reads the typed data from the input, which is either the JSON itself or the path of a file containing it.
+func (m Model) parse(input string) tea.Cmd {
+	return func() tea.Msg {
+		typedData := input
+		if !strings.HasPrefix(input, "{") {
+			data, err := os.ReadFile(input)
+			if err != nil {
+				return parsedMsg{err: fmt.Errorf("input is neither typed data JSON nor a readable file: %w", err)}
+			}
+			typedData = string(data)
+		}
+
+		parsed, err := signer.ParseTypedData(typedData)
+		if err != nil {
+			return parsedMsg{err: err}
+		}
+		digest, err := signer.HashTypedData(parsed)
+		if err != nil {
+			return parsedMsg{err: err}
+		}
+		return parsedMsg{typedData: typedData, parsed: parsed, digest: digest}
+	}
+}
+
+func (m Model) sign() tea.Msg {
+	keySigner, err := wallet.NewAccountSigner(m.walletService, m.wallet)
+	if err != nil {
+		logger.Error("Failed to create signer for wallet %d: %v", m.walletID, err)
+		return signedMsg{err: err}
+	}
+	signature, err := keySigner.SignTypedData(m.typedData)
+	if err != nil {
+		logger.Error("Failed to sign typed data with wallet %d: %v", m.walletID, err)
+		return signedMsg{err: err}
+	}
+	return signedMsg{signature: signature}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case walletLoadedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.walletID = msg.walletID
+		m.wallet = msg.wallet
+		m.walletService = msg.walletService
+		m.currentStep = stepInput
+		return m, textarea.Blink
+
+	case parsedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.typedData = msg.typedData
+		m.parsed = msg.parsed
+		m.digest = msg.digest
+		m.domain = typedValues(msg.parsed, "EIP712Domain", msg.parsed.Domain.Map())
+		m.message = decoder.Value{
+			Name:       "message",
+			Type:       msg.parsed.PrimaryType,
+			Components: typedValues(msg.parsed, msg.parsed.PrimaryType, msg.parsed.Message),
+		}
+		m.errorMsg = ""
+		m.currentStep = stepReview
+		return m, nil
+
+	case signedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			m.currentStep = stepReview
+			return m, nil
+		}
+		m.signature = msg.signature
+		m.currentStep = stepSigned
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepInput:
+			return m.handleInput(msg)
+		case stepReview:
+			return m.handleReview(msg)
+		case stepSigned:
+			if msg.String() == "n" {
+				m.input.Reset()
+				m.signature = ""
+				m.currentStep = stepInput
+				return m, textarea.Blink
+			}
+		case stepError:
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/wallet", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+s" { // Enter adds a newline in the textarea
+		input := strings.TrimSpace(m.input.Value())
+		if input == "" {
+			return m, nil
+		}
+		m.errorMsg = ""
+		return m, m.parse(input)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		m.errorMsg = ""
+		m.currentStep = stepSigning
+		return m, m.sign
+	case "e":
+		m.errorMsg = ""
+		m.currentStep = stepInput
+		return m, textarea.Blink
+	}
+	return m, nil
+}
+
+// typedValues converts the fields of a struct in the typed data to values for display, resolving
+// nested structs and arrays through the declared types.
+func typedValues(typedData *apitypes.TypedData, typeName string, data map[string]any) []decoder.Value {
+	fields := typedData.Types[typeName]
+	values := make([]decoder.Value, 0, len(fields))
+	for _, field := range fields {
+		values = append(values, typedValue(typedData, field.Name, field.Type, data[field.Name]))
+	}
+	return values
+}
+
+func typedValue(typedData *apitypes.TypedData, name string, typeName string, data any) decoder.Value {
+	value := decoder.Value{Name: name, Type: typeName}
+
+	if strings.HasSuffix(typeName, "]") {
+		elementType := typeName[:strings.LastIndex(typeName, "[")]
+		elements, _ := data.([]any)
+		for index, element := range elements {
+			value.Components = append(value.Components, typedValue(typedData, fmt.Sprintf("[%d]", index), elementType, element))
+		}
+		if len(elements) == 0 {
+			value.Value = "[]"
+		}
+		return value
+	}
+
+	if _, ok := typedData.Types[typeName]; ok {
+		fields, ok := data.(map[string]any)
+		if !ok {
+			value.Value = "<missing>"
+			return value
+		}
+		value.Components = typedValues(typedData, typeName, fields)
+		return value
+	}
+
+	switch data := data.(type) {
+	case nil:
+		value.Value = "<missing>"
+	case string:
+		value.Value = data
+		if typeName == "string" {
+			value.Value = strconv.Quote(data)
+		}
+	case float64:
+		value.Value = strconv.FormatFloat(data, 'f', -1, 64)
+	case *math.HexOrDecimal256:
+		value.Value = (*big.Int)(data).String()
+	default:
+		value.Value = fmt.Sprint(data)
+	}
+	return value
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepInput:
+		return "ctrl+s: review • esc: back", view.HelpDisplayOptionOverride
+	case stepReview:
+		return "enter/y: sign • e: edit • esc: back", view.HelpDisplayOptionOverride
+	case stepSigned:
+		return "n: sign other data • esc: back", view.HelpDisplayOptionOverride
+	case stepError:
+		return "Press any key to return to wallet list", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	title := component.T("Sign Typed Data (EIP-712)").Bold(true).Primary()
+
+	switch m.currentStep {
+	case stepInput:
+		return m.renderInput(title)
+	case stepReview, stepSigning:
+		return m.renderReview(title)
+	case stepSigned:
+		return m.renderSigned(title)
+	case stepError:
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Loading wallet...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) renderWallet() component.Component {
+	return component.VStackC(
+		component.T("Wallet: "+m.wallet.Alias),
+		component.T("Address: "+m.wallet.Address).Muted(),
+	)
+}
+
+func (m Model) renderError() component.Component {
+	if m.errorMsg == "" {
+		return component.Empty()
+	}
+	return component.VStackC(
+		component.SpacerV(1),
+		component.T("Error: "+m.errorMsg).Error(),
+	)
+}
+
+func (m Model) renderInput(title component.Component) string {
+	return component.VStackC(
+		title,
+		component.SpacerV(1),
+		m.renderWallet(),
+		component.SpacerV(1),
+		component.T("Typed data").Bold(true),
+		component.T(m.input.View()),
+		m.renderError(),
+	).Render()
+}
+
+func renderValues(values []decoder.Value) component.Component {
+	rows := []component.Component{}
+	for _, value := range values {
+		for _, line := range value.Lines() {
+			rows = append(rows, component.T("  "+line))
+		}
+	}
+	return component.VStackC(rows...)
+}
+
+func (m Model) renderReview(title component.Component) string {
+	status := component.T("Review the domain and message below before signing.").Muted()
+	if m.currentStep == stepSigning {
+		status = component.T("Signing...").Muted()
+	}
+
+	warning := component.Empty()
+	if m.parsed.Domain.VerifyingContract == "" {
+		warning = component.T("⚠ The domain has no verifying contract; the signature is not bound to a contract.").Warning()
+	}
+
+	return component.VStackC(
+		title,
+		component.SpacerV(1),
+		m.renderWallet(),
+		component.SpacerV(1),
+		component.T("Domain").Bold(true),
+		renderValues(m.domain),
+		warning,
+		component.SpacerV(1),
+		component.T("Message ("+m.parsed.PrimaryType+")").Bold(true),
+		renderValues(m.message.Components),
+		component.SpacerV(1),
+		component.T("Digest: "+m.digest.Hex()).Muted(),
+		component.SpacerV(1),
+		status,
+		m.renderError(),
+	).Render()
+}
+
+func (m Model) renderSigned(title component.Component) string {
+	signature := common.FromHex(m.signature)
+	components := component.Empty()
+	if len(signature) == 65 {
+		components = component.VStackC(
+			component.T("r: "+common.BytesToHash(signature[:32]).Hex()).Muted(),
+			component.T("s: "+common.BytesToHash(signature[32:64]).Hex()).Muted(),
+			component.T(fmt.Sprintf("v: %d", signature[64])).Muted(),
+		)
+	}
+
+	return component.VStackC(
+		title,
+		component.SpacerV(1),
+		component.T("✓ Signed "+m.parsed.PrimaryType+" for "+m.domainName()).Success(),
+		component.SpacerV(1),
+		component.T("Signer: "+m.wallet.Address),
+		component.T("Digest: "+m.digest.Hex()),
+		component.SpacerV(1),
+		component.T("Signature").Bold(true),
+		component.T(m.signature),
+		components,
+	).Render()
+}
+
+func (m Model) domainName() string {
+	if m.parsed.Domain.Name == "" {
+		return "unnamed domain"
+	}
+	return m.parsed.Domain.Name
+}
@@ -0,0 +1,186 @@
+package typeddata
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/crypto"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	walletsvc "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// permitTypedData is an EIP-2612 permit.
+const permitTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Permit": [
+      {"name": "owner", "type": "address"},
+      {"name": "spender", "type": "address"},
+      {"name": "value", "type": "uint256"},
+      {"name": "nonce", "type": "uint256"},
+      {"name": "deadline", "type": "uint256"}
+    ]
+  },
+  "primaryType": "Permit",
+  "domain": {"name": "USD Coin", "version": "2", "chainId": 1, "verifyingContract": "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"},
+  "message": {
+    "owner": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+    "spender": "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+    "value": "1000000",
+    "nonce": 0,
+    "deadline": "1893456000"
+  }
+}`
+
+// ordersTypedData has nested structs and arrays.
+const ordersTypedData = `{
+  "types": {
+    "EIP712Domain": [{"name": "name", "type": "string"}],
+    "Item": [{"name": "token", "type": "address"}, {"name": "amount", "type": "uint256"}],
+    "Order": [{"name": "maker", "type": "address"}, {"name": "items", "type": "Item[]"}, {"name": "tags", "type": "string[]"}]
+  },
+  "primaryType": "Order",
+  "domain": {"name": "Exchange"},
+  "message": {
+    "maker": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+    "items": [{"token": "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", "amount": 5}],
+    "tags": ["limit"]
+  }
+}`
+
+type TypedDataPageTestSuite struct {
+	suite.Suite
+	mockCtrl          *gomock.Controller
+	mockRouter        *view.MockRouter
+	mockWalletService *walletsvc.MockWalletService
+	model             Model
+	privateKey        string
+}
+
+func TestTypedDataPageTestSuite(t *testing.T) {
+	suite.Run(t, new(TypedDataPageTestSuite))
+}
+
+func (s *TypedDataPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockWalletService = walletsvc.NewMockWalletService(s.mockCtrl)
+	s.model = NewPageWithService(s.mockRouter, storage.NewSharedMemory(), s.mockWalletService).(Model)
+	s.privateKey = hex.EncodeToString(crypto.Keccak256([]byte("cow")))
+}
+
+func (s *TypedDataPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *TypedDataPageTestSuite) update(msg tea.Msg) {
+	updated, _ := s.model.Update(msg)
+	s.model = updated.(Model)
+}
+
+// load loads the wallet and submits the typed data for review.
+func (s *TypedDataPageTestSuite) load(typedData string) {
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("1")
+	s.mockWalletService.EXPECT().GetWallet(uint(1)).Return(&models.EVMWallet{
+		ID:      1,
+		Alias:   "Cold Wallet",
+		Address: "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+	}, nil)
+	s.update(s.model.loadWallet())
+	s.Require().Equal(stepInput, s.model.currentStep)
+
+	s.model.input.SetValue(typedData)
+	updated, cmd := s.model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	s.model = updated.(Model)
+	s.Require().NotNil(cmd)
+	s.update(cmd())
+}
+
+func (s *TypedDataPageTestSuite) TestReviewAndSignPermit() {
+	s.load(permitTypedData)
+
+	s.Require().Equal(stepReview, s.model.currentStep, s.model.errorMsg)
+	output := s.model.View()
+	s.Contains(output, "Wallet: Cold Wallet")
+	s.Contains(output, `name (string): "USD Coin"`)
+	s.Contains(output, "chainId (uint256): 1")
+	s.Contains(output, "verifyingContract (address): 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	s.Contains(output, "Message (Permit)")
+	s.Contains(output, "spender (address): 0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	s.Contains(output, "value (uint256): 1000000")
+	s.Contains(output, "nonce (uint256): 0")
+	s.Contains(output, "Digest: 0x")
+	s.NotContains(output, "no verifying contract")
+
+	s.mockWalletService.EXPECT().GetPrivateKey(uint(1)).Return(s.privateKey, nil)
+	updated, cmd := s.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model = updated.(Model)
+	s.Equal(stepSigning, s.model.currentStep)
+	s.Contains(s.model.View(), "Signing...")
+	s.update(cmd())
+
+	s.Require().Equal(stepSigned, s.model.currentStep, s.model.errorMsg)
+	output = s.model.View()
+	s.Contains(output, "✓ Signed Permit for USD Coin")
+	s.Contains(output, s.model.signature)
+	s.Regexp(`v: 2[78]`, output)
+	s.Len(s.model.signature, 132)
+}
+
+func (s *TypedDataPageTestSuite) TestNestedStructsAndArrays() {
+	s.load(ordersTypedData)
+
+	s.Require().Equal(stepReview, s.model.currentStep, s.model.errorMsg)
+	output := s.model.View()
+	s.Contains(output, "items (Item[]):")
+	s.Contains(output, "[0] (Item):")
+	s.Contains(output, "amount (uint256): 5")
+	s.Contains(output, `[0] (string): "limit"`)
+	s.Contains(output, "no verifying contract")
+}
+
+func (s *TypedDataPageTestSuite) TestReadsTypedDataFromFile() {
+	path := filepath.Join(s.T().TempDir(), "permit.json")
+	s.Require().NoError(os.WriteFile(path, []byte(permitTypedData), 0o600))
+
+	s.load(path)
+
+	s.Require().Equal(stepReview, s.model.currentStep, s.model.errorMsg)
+	s.Contains(s.model.View(), "Message (Permit)")
+}
+
+func (s *TypedDataPageTestSuite) TestInvalidTypedDataStaysOnInput() {
+	s.load(`{"types": {}, "domain": {}, "message": {}}`)
+
+	s.Equal(stepInput, s.model.currentStep)
+	s.Contains(s.model.View(), "typed data has no primaryType")
+}
+
+func (s *TypedDataPageTestSuite) TestSigningErrorReturnsToReview() {
+	s.load(permitTypedData)
+
+	s.mockWalletService.EXPECT().GetPrivateKey(uint(1)).Return("", fmt.Errorf("secure storage locked"))
+	updated, cmd := s.model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	s.model = updated.(Model)
+	s.update(cmd())
+
+	s.Equal(stepReview, s.model.currentStep)
+	s.Contains(s.model.View(), "secure storage locked")
+
+	updated, _ = s.model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	s.model = updated.(Model)
+	s.Equal(stepInput, s.model.currentStep)
+}
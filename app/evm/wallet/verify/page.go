@@ -0,0 +1,333 @@
+package verify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/rxtech-lab/smart-contract-cli/internal/log"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/ui/component"
+	"github.com/rxtech-lab/smart-contract-cli/internal/utils"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+)
+
+var logger, _ = log.NewFileLogger("./logs/evm/wallet/verify.log")
+
+type verifyStep int
+
+const (
+	stepLoading verifyStep = iota
+	stepInput
+	stepResult
+	stepError
+)
+
+type inputField int
+
+const (
+	fieldMessage inputField = iota
+	fieldSignature
+)
+
+type Model struct {
+	router        view.Router
+	sharedMemory  storage.SharedMemory
+	storageClient sql.Storage
+
+	currentStep verifyStep
+	wallet      *models.EVMWallet
+
+	mode           signer.MessageMode
+	focus          inputField
+	messageInput   textarea.Model
+	signatureInput textinput.Model
+
+	hash      common.Hash
+	recovered common.Address
+	match     *models.EVMWallet
+
+	errorMsg string
+}
+
+func NewPage(router view.Router, sharedMemory storage.SharedMemory) view.View {
+	return NewPageWithService(router, sharedMemory, nil)
+}
+
+// NewPageWithService creates a new verify signature page with an optional storage client (for testing).
+func NewPageWithService(router view.Router, sharedMemory storage.SharedMemory, storageClient sql.Storage) view.View {
+	messageInput := textarea.New()
+	messageInput.Placeholder = "Signed text, 0x-prefixed hex bytes or a 32 byte hash"
+	messageInput.SetWidth(76)
+	messageInput.SetHeight(5)
+	messageInput.Focus()
+
+	signatureInput := textinput.New()
+	signatureInput.Placeholder = "0x… (65 bytes)"
+	signatureInput.Width = 76
+
+	return Model{
+		router:         router,
+		sharedMemory:   sharedMemory,
+		storageClient:  storageClient,
+		currentStep:    stepLoading,
+		mode:           signer.MessageModePersonal,
+		focus:          fieldMessage,
+		messageInput:   messageInput,
+		signatureInput: signatureInput,
+	}
+}
+
+type walletLoadedMsg struct {
+	storageClient sql.Storage
+	wallet        *models.EVMWallet
+	err           error
+}
+
+type verifiedMsg struct {
+	hash      common.Hash
+	recovered common.Address
+	match     *models.EVMWallet
+	err       error
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadWallet
+}
+
+func (m Model) loadWallet() tea.Msg {
+	walletID, err := strconv.ParseUint(m.router.GetQueryParam("id"), 10, 32)
+	if err != nil {
+		return walletLoadedMsg{err: fmt.Errorf("invalid wallet ID: %w", err)}
+	}
+
+	storageClient := m.storageClient
+	if storageClient == nil {
+		sqlStorage, err := utils.GetStorageClientFromSharedMemory(m.sharedMemory)
+		if err != nil {
+			logger.Error("Failed to get storage client from shared memory: %v", err)
+			return walletLoadedMsg{err: fmt.Errorf("failed to get storage client from shared memory: %w", err)}
+		}
+		storageClient = sqlStorage
+	}
+
+	walletData, err := storageClient.GetWalletByID(uint(walletID))
+	if err != nil {
+		logger.Error("Failed to load wallet %d: %v", walletID, err)
+		return walletLoadedMsg{err: fmt.Errorf("failed to load wallet: %w", err)}
+	}
+
+	return walletLoadedMsg{storageClient: storageClient, wallet: &walletData}
+}
+
+// verify recovers the signer of the message and looks it up among the stored wallets.
+func (m Model) verify(message string, signature string, mode signer.MessageMode) tea.Cmd {
+	storageClient := m.storageClient
+	return func() tea.Msg {
+		hash, err := signer.MessageHash(message, mode)
+		if err != nil {
+			return verifiedMsg{err: err}
+		}
+		recovered, err := signer.RecoverAddress(hash, signature)
+		if err != nil {
+			return verifiedMsg{err: err}
+		}
+
+		stored, err := storageClient.GetWalletByAddress(recovered.Hex())
+		if err != nil {
+			if !errors.HasCode(err, errors.ErrCodeRecordNotFound) {
+				logger.Error("Failed to look up wallet %s: %v", recovered.Hex(), err)
+			}
+			return verifiedMsg{hash: hash, recovered: recovered}
+		}
+		return verifiedMsg{hash: hash, recovered: recovered, match: &stored}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case walletLoadedMsg:
+		if msg.err != nil {
+			m.currentStep = stepError
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.storageClient = msg.storageClient
+		m.wallet = msg.wallet
+		m.currentStep = stepInput
+		return m, textarea.Blink
+
+	case verifiedMsg:
+		if msg.err != nil {
+			m.errorMsg = msg.err.Error()
+			return m, nil
+		}
+		m.hash = msg.hash
+		m.recovered = msg.recovered
+		m.match = msg.match
+		m.currentStep = stepResult
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentStep {
+		case stepInput:
+			return m.handleInput(msg)
+		case stepResult:
+			if msg.String() == "n" {
+				m.messageInput.Reset()
+				m.signatureInput.Reset()
+				m.currentStep = stepInput
+				cmd := m.focusField(fieldMessage)
+				return m, cmd
+			}
+		case stepError:
+			return m, func() tea.Msg {
+				_ = m.router.NavigateTo("/evm/wallet", nil)
+				return nil
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "shift+tab":
+		next := fieldSignature
+		if m.focus == fieldSignature {
+			next = fieldMessage
+		}
+		cmd := m.focusField(next)
+		return m, cmd
+	case "ctrl+t":
+		if m.mode == signer.MessageModePersonal {
+			m.mode = signer.MessageModeHash
+		} else {
+			m.mode = signer.MessageModePersonal
+		}
+		m.errorMsg = ""
+		return m, nil
+	case "ctrl+s":
+		message := m.messageInput.Value()
+		signature := strings.TrimSpace(m.signatureInput.Value())
+		if strings.TrimSpace(message) == "" || signature == "" {
+			m.errorMsg = "Enter both the message and the signature"
+			return m, nil
+		}
+		m.errorMsg = ""
+		return m, m.verify(message, signature, m.mode)
+	}
+
+	var cmd tea.Cmd
+	if m.focus == fieldSignature {
+		m.signatureInput, cmd = m.signatureInput.Update(msg)
+	} else {
+		m.messageInput, cmd = m.messageInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// focusField moves the focus between the message and signature inputs.
+func (m *Model) focusField(field inputField) tea.Cmd {
+	m.focus = field
+	if field == fieldSignature {
+		m.messageInput.Blur()
+		return m.signatureInput.Focus()
+	}
+	m.signatureInput.Blur()
+	return m.messageInput.Focus()
+}
+
+func (m Model) Help() (string, view.HelpDisplayOption) {
+	switch m.currentStep {
+	case stepInput:
+		return "tab: next field • ctrl+s: verify • ctrl+t: switch mode • esc: back", view.HelpDisplayOptionOverride
+	case stepResult:
+		return "n: verify another signature • esc: back", view.HelpDisplayOptionOverride
+	case stepError:
+		return "Press any key to return to wallet list", view.HelpDisplayOptionOverride
+	default:
+		return "Loading...", view.HelpDisplayOptionOverride
+	}
+}
+
+func (m Model) View() string {
+	title := component.T("Verify Signature").Bold(true).Primary()
+
+	switch m.currentStep {
+	case stepInput:
+		return m.renderInput(title)
+	case stepResult:
+		return m.renderResult(title)
+	case stepError:
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Error: "+m.errorMsg).Error(),
+		).Render()
+	default:
+		return component.VStackC(
+			title,
+			component.SpacerV(1),
+			component.T("Loading wallet...").Muted(),
+		).Render()
+	}
+}
+
+func (m Model) modeLabel() string {
+	if m.mode == signer.MessageModeHash {
+		return "raw hash"
+	}
+	return "personal_sign (EIP-191)"
+}
+
+func (m Model) renderInput(title component.Component) string {
+	errorLine := component.Empty()
+	if m.errorMsg != "" {
+		errorLine = component.T("Error: " + m.errorMsg).Error()
+	}
+
+	return component.VStackC(
+		title,
+		component.SpacerV(1),
+		component.T("Mode: "+m.modeLabel()).Bold(true),
+		component.SpacerV(1),
+		component.T("Message").Bold(true),
+		component.T(m.messageInput.View()),
+		component.SpacerV(1),
+		component.T("Signature").Bold(true),
+		component.T(m.signatureInput.View()),
+		component.SpacerV(1),
+		errorLine,
+	).Render()
+}
+
+func (m Model) renderResult(title component.Component) string {
+	match := component.T("⚠ The signer is not one of your stored wallets").Warning()
+	if m.match != nil {
+		label := "✓ Signed by stored wallet " + m.match.Alias
+		if m.wallet != nil && m.match.ID == m.wallet.ID {
+			label += " (this wallet)"
+		}
+		match = component.T(label).Success()
+	}
+
+	return component.VStackC(
+		title,
+		component.SpacerV(1),
+		component.T("Mode: "+m.modeLabel()),
+		component.T("Hash: "+m.hash.Hex()).Muted(),
+		component.SpacerV(1),
+		component.T("Recovered signer: "+m.recovered.Hex()).Bold(true),
+		match,
+	).Render()
+}
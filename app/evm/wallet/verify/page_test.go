@@ -0,0 +1,130 @@
+package verify
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
+	"github.com/rxtech-lab/smart-contract-cli/internal/view"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	// Anvil default test account.
+	testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	testAddress    = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+)
+
+type VerifyPageTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockRouter  *view.MockRouter
+	mockStorage *sql.MockStorage
+	signer      *signer.PrivateKeySigner
+	model       Model
+}
+
+func TestVerifyPageTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyPageTestSuite))
+}
+
+func (s *VerifyPageTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRouter = view.NewMockRouter(s.mockCtrl)
+	s.mockStorage = sql.NewMockStorage(s.mockCtrl)
+	s.model = NewPageWithService(s.mockRouter, storage.NewSharedMemory(), s.mockStorage).(Model)
+
+	keySigner, err := signer.NewPrivateKeySigner(testPrivateKey)
+	s.Require().NoError(err)
+	s.signer = keySigner.(*signer.PrivateKeySigner)
+
+	s.mockRouter.EXPECT().GetQueryParam("id").Return("2")
+	s.mockStorage.EXPECT().GetWalletByID(uint(2)).Return(models.EVMWallet{ID: 2, Alias: "Dev Wallet", Address: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"}, nil)
+	s.update(s.model.loadWallet())
+	s.Require().Equal(stepInput, s.model.currentStep)
+}
+
+func (s *VerifyPageTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *VerifyPageTestSuite) update(msg tea.Msg) {
+	updated, _ := s.model.Update(msg)
+	s.model = updated.(Model)
+}
+
+// verify enters the message and signature, switching fields with tab, and runs the verification.
+func (s *VerifyPageTestSuite) verify(message string, signature string) {
+	s.model.messageInput.SetValue(message)
+	s.update(tea.KeyMsg{Type: tea.KeyTab})
+	s.Equal(fieldSignature, s.model.focus)
+	s.model.signatureInput.SetValue(signature)
+
+	updated, cmd := s.model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	s.model = updated.(Model)
+	s.Require().NotNil(cmd)
+	s.update(cmd())
+}
+
+func (s *VerifyPageTestSuite) TestRecoversStoredWallet() {
+	signature, err := s.signer.SignMessageString("hello")
+	s.Require().NoError(err)
+	s.mockStorage.EXPECT().GetWalletByAddress(testAddress).Return(models.EVMWallet{ID: 1, Alias: "Main Wallet", Address: testAddress}, nil)
+
+	s.verify("hello", signature)
+
+	s.Require().Equal(stepResult, s.model.currentStep, s.model.errorMsg)
+	output := s.model.View()
+	s.Contains(output, "Recovered signer: "+testAddress)
+	s.Contains(output, "✓ Signed by stored wallet Main Wallet")
+	s.NotContains(output, "(this wallet)")
+}
+
+func (s *VerifyPageTestSuite) TestMetaMaskRecoveryIDAndThisWallet() {
+	signature, err := s.signer.SignMessageString("hello")
+	s.Require().NoError(err)
+	raw := common.FromHex(signature)
+	raw[64] += 27
+	s.mockStorage.EXPECT().GetWalletByAddress(testAddress).Return(models.EVMWallet{ID: 2, Alias: "Dev Wallet", Address: testAddress}, nil)
+
+	s.verify("hello", common.Bytes2Hex(raw))
+
+	s.Require().Equal(stepResult, s.model.currentStep, s.model.errorMsg)
+	s.Contains(s.model.View(), "✓ Signed by stored wallet Dev Wallet (this wallet)")
+}
+
+func (s *VerifyPageTestSuite) TestUnknownSignerAndRawHash() {
+	hash := signer.PersonalMessageHash([]byte("order"))
+	signature, err := s.signer.SignHash(hash)
+	s.Require().NoError(err)
+	s.mockStorage.EXPECT().GetWalletByAddress(testAddress).Return(models.EVMWallet{}, errors.NewDatabaseError(errors.ErrCodeRecordNotFound, "wallet not found"))
+
+	s.update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	s.Contains(s.model.View(), "Mode: raw hash")
+	s.verify(hash.Hex(), signature)
+
+	s.Require().Equal(stepResult, s.model.currentStep, s.model.errorMsg)
+	output := s.model.View()
+	s.Contains(output, "Hash: "+hash.Hex())
+	s.Contains(output, "Recovered signer: "+testAddress)
+	s.Contains(output, "not one of your stored wallets")
+
+	s.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	s.Equal(stepInput, s.model.currentStep)
+	s.Equal(fieldMessage, s.model.focus)
+}
+
+func (s *VerifyPageTestSuite) TestInvalidInput() {
+	s.update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	s.Contains(s.model.View(), "Enter both the message and the signature")
+
+	s.verify("hello", "0x1234")
+	s.Equal(stepInput, s.model.currentStep)
+	s.Contains(s.model.View(), "invalid signature length")
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+)
+
+// storedABIPrefix marks an operand naming a stored ABI by id, e.g. "id:3".
+const storedABIPrefix = "id:"
+
+func runABI(args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "Usage: smart-contract-cli abi <diff> [flags]")
+		return fmt.Errorf("expected a subcommand")
+	}
+
+	switch args[0] {
+	case "diff":
+		return runABIDiff(args[1:], stdout, stderr)
+	default:
+		return fmt.Errorf("unknown subcommand %q, expected diff", args[0])
+	}
+}
+
+func runABIDiff(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("abi diff", "[flags] <old ABI> <new ABI>\n\nEach ABI is a file, a URL or id:<n> for an ABI stored in -db", stderr)
+	dbPath := flags.String("db", "", "SQLite database holding the ABIs referenced as id:<n>")
+	asJSON := flags.Bool("json", false, "print the changes as JSON")
+	failOnBreaking := flags.Bool("fail-on-breaking", false, "exit with an error when a change breaks existing callers")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return fmt.Errorf("expected the old and the new ABI")
+	}
+
+	var storage sql.Storage
+	openStorage := func() (sql.Storage, error) {
+		if storage != nil {
+			return storage, nil
+		}
+		if *dbPath == "" {
+			return nil, fmt.Errorf("-db is required to read stored ABIs")
+		}
+		opened, err := openDatabase(*dbPath)
+		if err != nil {
+			return nil, err
+		}
+		storage = opened
+		return storage, nil
+	}
+	defer func() {
+		if storage != nil {
+			_ = storage.Close()
+		}
+	}()
+
+	oldABI, err := readABIOperand(flags.Arg(0), openStorage)
+	if err != nil {
+		return err
+	}
+	newABI, err := readABIOperand(flags.Arg(1), openStorage)
+	if err != nil {
+		return err
+	}
+
+	diff := abi.Diff(oldABI, newABI)
+	if *asJSON {
+		if err := writeJSON(stdout, diff); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintln(stdout, diff.Summary())
+		if !diff.IsEmpty() {
+			fmt.Fprintln(stdout, "")
+		}
+		for _, line := range diff.Lines() {
+			fmt.Fprintln(stdout, line)
+		}
+	}
+
+	if *failOnBreaking && diff.HasBreakingChanges() {
+		return fmt.Errorf("%d breaking changes", len(diff.Breaking()))
+	}
+	return nil
+}
+
+// readABIOperand reads an ABI from a file or URL, or from storage for operands like "id:3".
+func readABIOperand(operand string, openStorage func() (sql.Storage, error)) (abi.AbiArray, error) {
+	if !strings.HasPrefix(operand, storedABIPrefix) {
+		return abi.ReadAbi(operand)
+	}
+
+	id, err := strconv.ParseUint(strings.TrimPrefix(operand, storedABIPrefix), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ABI id %q", operand)
+	}
+	storage, err := openStorage()
+	if err != nil {
+		return nil, err
+	}
+	stored, err := storage.GetABIByID(uint(id))
+	if err != nil {
+		return nil, err
+	}
+	return stored.Abi.AbiArray, nil
+}
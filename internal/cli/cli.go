@@ -0,0 +1,79 @@
+// Package cli implements the headless commands, run as "smart-contract-cli <command> [flags]"
+// instead of the interactive interface.
+package cli
+
+import (
+	goerrors "errors"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// Command is a headless command.
+type Command struct {
+	Name    string
+	Summary string
+	Run     func(args []string, stdout io.Writer, stderr io.Writer) error
+}
+
+// Commands returns every headless command.
+func Commands() []Command {
+	return []Command{
+		{Name: "abi", Summary: "Compare two versions of an ABI", Run: runABI},
+		{Name: "decode", Summary: "Decode calldata or the input of a transaction", Run: runDecode},
+		{Name: "endpoints", Summary: "List endpoints by chain, set their failover priority and check their health", Run: runEndpoints},
+		{Name: "signatures", Summary: "Import and look up selector and event topic signatures", Run: runSignatures},
+		{Name: "tx", Summary: "Build, sign and broadcast transactions offline", Run: runTx},
+	}
+}
+
+// Run runs the command named by the first argument and returns the process exit code.
+func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stdout)
+		return 0
+	}
+
+	for _, command := range Commands() {
+		if command.Name != args[0] {
+			continue
+		}
+		err := command.Run(args[1:], stdout, stderr)
+		if goerrors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(stderr, "Unknown command %q\n\n", args[0])
+	printUsage(stderr)
+	return 2
+}
+
+func printUsage(writer io.Writer) {
+	fmt.Fprintln(writer, "Usage: smart-contract-cli [command] [flags]")
+	fmt.Fprintln(writer, "")
+	fmt.Fprintln(writer, "Without a command the interactive interface is started.")
+	fmt.Fprintln(writer, "")
+	fmt.Fprintln(writer, "Commands:")
+	for _, command := range Commands() {
+		fmt.Fprintf(writer, "  %-10s %s\n", command.Name, command.Summary)
+	}
+	fmt.Fprintln(writer, "")
+	fmt.Fprintln(writer, "Run \"smart-contract-cli <command> -h\" for the flags of a command.")
+}
+
+// newFlagSet creates the flag set of a command, printing usage and errors to stderr.
+func newFlagSet(name string, usage string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: smart-contract-cli %s %s\n\n", name, usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
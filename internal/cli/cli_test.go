@@ -0,0 +1,363 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transferCalldata = "0xa9059cbb00000000000000000000000070997970c51812dc3a010c7d01b50e0d17dc79c800000000000000000000000000000000000000000000000000000000000003e8"
+
+func run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := Run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunUsage(t *testing.T) {
+	code, stdout, _ := run()
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "decode")
+
+	code, _, stderr := run("unknown")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `Unknown command "unknown"`)
+}
+
+func TestDecodeCalldata(t *testing.T) {
+	code, stdout, stderr := run("decode", transferCalldata)
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "transfer(address,uint256)  [0xa9059cbb, builtin]\n"+
+		"  to (address): 0x70997970C51812dc3A010C7d01b50e0d17dc79C8\n"+
+		"  value (uint256): 1000\n", stdout)
+}
+
+func TestDecodeWithABIFileAsJSON(t *testing.T) {
+	abiPath := filepath.Join(t.TempDir(), "token.json")
+	require.NoError(t, os.WriteFile(abiPath, []byte(`["function transfer(address recipient, uint256 amount)"]`), 0o600))
+
+	code, stdout, stderr := run("decode", "-abi", abiPath, "-json", transferCalldata)
+	require.Equal(t, 0, code, stderr)
+
+	var calls []decoder.Call
+	require.NoError(t, json.Unmarshal([]byte(stdout), &calls))
+	require.Len(t, calls, 1)
+	assert.Equal(t, abiPath, calls[0].Source)
+	assert.Equal(t, "recipient", calls[0].Arguments[0].Name)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	code, _, stderr := run("decode")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "expected calldata or a transaction hash")
+
+	code, _, stderr = run("decode", "0xdeadbeef")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "no function found for selector 0xdeadbeef")
+
+	code, _, stderr = run("decode", "0x"+"00000000000000000000000000000000000000000000000000000000000000aa")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "-rpc is required")
+
+	code, _, stderr = run("decode", "-db", filepath.Join(t.TempDir(), "missing.db"), transferCalldata)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "missing.db")
+}
+
+func TestSignaturesImportAndLookup(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	dumpPath := filepath.Join(t.TempDir(), "dump.txt")
+	require.NoError(t, os.WriteFile(dumpPath, []byte("0xdeadbeef ownerOfPlanet(uint256)\n"), 0o600))
+
+	// A mistyped database path is not created empty
+	code, _, stderr := run("signatures", "import", "-db", dbPath, "-bundled")
+	require.Equal(t, 1, code)
+	assert.Contains(t, stderr, "test.db")
+	assert.NoFileExists(t, dbPath)
+
+	storage, err := sql.NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, storage.Close())
+
+	code, _, stderr = run("signatures", "import", "-db", dbPath, "-bundled", dumpPath)
+	require.Equal(t, 1, code)
+	assert.Contains(t, stderr, "does not match")
+
+	require.NoError(t, os.WriteFile(dumpPath, []byte("0x30edd1a1 setPlanet(uint256)\n"), 0o600))
+	code, stdout, stderr := run("signatures", "import", "-db", dbPath, "-bundled", dumpPath)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Imported 1 signatures from "+dumpPath)
+
+	code, stdout, stderr = run("signatures", "lookup", "-db", dbPath, "0xa9059cbb")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "function transfer(address to, uint256 value) returns (bool)  [bundled]\n", stdout)
+
+	code, _, stderr = run("signatures", "lookup", "-db", dbPath, "0x12345678")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "no signature found")
+
+	code, _, stderr = run("signatures", "export")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "unknown subcommand")
+}
+
+func TestABIDiff(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "v1.json")
+	newPath := filepath.Join(dir, "v2.json")
+	require.NoError(t, os.WriteFile(oldPath, []byte(`["function balanceOf(address owner) view returns (uint256)", "function burn(uint256 amount)"]`), 0o600))
+	require.NoError(t, os.WriteFile(newPath, []byte(`["function balanceOf(address owner) view returns (uint256)", "function mint(address to, uint256 amount)"]`), 0o600))
+
+	code, stdout, stderr := run("abi", "diff", oldPath, newPath)
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "1 added, 1 removed (1 breaking)\n\n"+
+		"- removed function burn(uint256 amount)  [breaking]\n"+
+		"+ added function mint(address to, uint256 amount)\n", stdout)
+
+	code, _, stderr = run("abi", "diff", "-fail-on-breaking", oldPath, newPath)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "1 breaking changes")
+
+	code, stdout, stderr = run("abi", "diff", "-json", newPath, newPath)
+	require.Equal(t, 0, code, stderr)
+	var diff abi.ABIDiff
+	require.NoError(t, json.Unmarshal([]byte(stdout), &diff))
+	assert.Empty(t, diff.Changes)
+}
+
+func TestABIDiffStoredABI(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	storage, err := sql.NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	elements, err := abi.ParseHumanReadable([]string{"function burn(uint256 amount)"})
+	require.NoError(t, err)
+	id, err := storage.CreateABI(models.EvmAbi{Name: "Token", Abi: models.AbiArrayType{AbiArray: elements}})
+	require.NoError(t, err)
+
+	newPath := filepath.Join(t.TempDir(), "v2.json")
+	require.NoError(t, os.WriteFile(newPath, []byte(`["function burn(uint128 amount)"]`), 0o600))
+
+	code, stdout, stderr := run("abi", "diff", "-db", dbPath, fmt.Sprintf("id:%d", id), newPath)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "~ modified function burn(uint128 amount)  [breaking]")
+
+	code, _, stderr = run("abi", "diff", "id:1", newPath)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "-db is required")
+
+	missing := filepath.Join(t.TempDir(), "missing.db")
+	code, _, stderr = run("abi", "diff", "-db", missing, "id:1", newPath)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "missing.db")
+	assert.NoFileExists(t, missing)
+}
+
+// fakeNode answers the JSON-RPC calls of the tx command and mines every submitted transaction.
+type fakeNode struct {
+	sent []*types.Transaction
+}
+
+func (n *fakeNode) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	var call struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	_ = json.Unmarshal(body, &call)
+
+	var result any
+	switch call.Method {
+	case "eth_chainId":
+		result = "0x7a69"
+	case "eth_getTransactionCount":
+		result = "0x3"
+	case "eth_estimateGas":
+		result = "0x5208"
+	case "eth_sendRawTransaction":
+		var raw hexutil.Bytes
+		_ = json.Unmarshal(call.Params[0], &raw)
+		transaction := &types.Transaction{}
+		_ = transaction.UnmarshalBinary(raw)
+		n.sent = append(n.sent, transaction)
+		result = transaction.Hash()
+	case "eth_getTransactionReceipt":
+		result = map[string]any{
+			"transactionHash":   n.sent[0].Hash(),
+			"blockHash":         common.Hash{1},
+			"blockNumber":       "0x5",
+			"transactionIndex":  "0x0",
+			"type":              "0x2",
+			"status":            "0x1",
+			"cumulativeGasUsed": "0x5208",
+			"gasUsed":           "0x5208",
+			"effectiveGasPrice": "0x3b9aca00",
+			"logs":              []any{},
+			"logsBloom":         types.Bloom{},
+		}
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(map[string]any{"jsonrpc": "2.0", "id": call.ID, "result": result})
+}
+
+func TestTxBuildSignAndBroadcast(t *testing.T) {
+	node := &fakeNode{}
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	dir := t.TempDir()
+	unsignedPath := filepath.Join(dir, "unsigned.json")
+	signedPath := filepath.Join(dir, "signed.txt")
+	keyPath := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(keyPath, []byte("0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80\n"), 0o600))
+
+	code, stdout, stderr := run("tx", "build", "-rpc", server.URL, "-from", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		"-to", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", "-value", "1000", "-out", unsignedPath)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "nonce 3, gas 31500, chain 31337")
+
+	// Signing works without an RPC endpoint.
+	code, stdout, stderr = run("tx", "sign", "-key-file", keyPath, "-out", signedPath, unsignedPath)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Wrote signed transaction 0x")
+
+	code, stdout, stderr = run("tx", "broadcast", "-rpc", server.URL, signedPath)
+	require.Equal(t, 0, code, stderr)
+	require.Len(t, node.sent, 1)
+	assert.Equal(t, "Submitted "+node.sent[0].Hash().Hex()+"\nStatus: success\nBlock: 5\nGas used: 21000\n", stdout)
+	assert.Equal(t, uint64(3), node.sent[0].Nonce())
+}
+
+// fakeClef signs transactions like a Clef instance that approves every request.
+type fakeClef struct {
+	key *ecdsa.PrivateKey
+}
+
+func (c *fakeClef) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	var call struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	_ = json.Unmarshal(body, &call)
+
+	var result any
+	switch call.Method {
+	case "account_version":
+		result = "6.1.0"
+	case "account_signTransaction":
+		var args apitypes.SendTxArgs
+		_ = json.Unmarshal(call.Params[0], &args)
+		transaction, _ := args.ToTransaction()
+		signed, _ := types.SignTx(transaction, types.LatestSignerForChainID(args.ChainID.ToInt()), c.key)
+		raw, _ := signed.MarshalBinary()
+		result = map[string]any{"raw": hexutil.Bytes(raw)}
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(map[string]any{"jsonrpc": "2.0", "id": call.ID, "result": result})
+}
+
+func TestTxSignWithRemoteSigner(t *testing.T) {
+	key, err := crypto.HexToECDSA("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	require.NoError(t, err)
+	clef := httptest.NewServer(&fakeClef{key: key})
+	defer clef.Close()
+
+	unsignedPath := filepath.Join(t.TempDir(), "unsigned.json")
+	require.NoError(t, os.WriteFile(unsignedPath, []byte(`{"type":"eip1559","chainId":"31337","from":"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",`+
+		`"to":"0x70997970C51812dc3A010C7d01b50e0d17dc79C8","nonce":4,"value":"1000","data":"0x","gas":21000,"maxFeePerGas":"2","maxPriorityFeePerGas":"1"}`), 0o600))
+
+	code, stdout, stderr := run("tx", "sign", "-signer", clef.URL, unsignedPath)
+	require.Equal(t, 0, code, stderr)
+
+	transaction, sender, err := signer.DecodeSignedTransaction(strings.TrimSpace(stdout))
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), sender)
+	assert.Equal(t, uint64(4), transaction.Nonce())
+
+	code, _, stderr = run("tx", "sign", "-signer", clef.URL, "-key-file", "key", unsignedPath)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "expected one of -key-file or -signer")
+}
+
+func TestTxErrors(t *testing.T) {
+	code, _, stderr := run("tx", "build", "-from", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "expected -rpc and -from")
+
+	dir := t.TempDir()
+	unsignedPath := filepath.Join(dir, "unsigned.json")
+	keyPath := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(unsignedPath, []byte(`{"type":"eip1559","chainId":"31337","from":"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",`+
+		`"to":"0x70997970C51812dc3A010C7d01b50e0d17dc79C8","nonce":0,"value":"0","data":"0x","gas":21000,"maxFeePerGas":"2","maxPriorityFeePerGas":"1"}`), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, []byte("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"), 0o600))
+
+	code, _, stderr = run("tx", "sign", "-key-file", keyPath, unsignedPath)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "key belongs to 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	code, _, stderr = run("tx", "broadcast", "-rpc", "http://localhost:1", "0x1234")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "failed to decode raw transaction")
+
+	code, _, stderr = run("tx", "send")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "unknown subcommand")
+}
+
+func TestEndpointsSetListAndHealth(t *testing.T) {
+	server := httptest.NewServer(&fakeNode{})
+	defer server.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	storage, err := sql.NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	_, err = storage.CreateEndpoint(models.EVMEndpoint{Name: "anvil", Url: server.URL, ChainId: "31337"})
+	require.NoError(t, err)
+	_, err = storage.CreateEndpoint(models.EVMEndpoint{Name: "anvil-backup", Url: down.URL, ChainId: "0x7a69"})
+	require.NoError(t, err)
+
+	code, stdout, stderr := run("endpoints", "set", "-db", dbPath, "-priority", "1", "-confirmations", "2", "anvil")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "Updated anvil: priority 1, confirmations 2\n", stdout)
+
+	code, stdout, stderr = run("endpoints", "list", "-db", dbPath)
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "Chain 31337\n"+
+		"  priority 0  anvil-backup  "+down.URL+"  confirmations 0\n"+
+		"  priority 1  anvil  "+server.URL+"  confirmations 2\n", stdout)
+
+	code, stdout, stderr = run("endpoints", "health", "-db", dbPath)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, "  down  "+down.URL)
+	assert.Contains(t, stdout, "  ok    "+server.URL)
+	assert.Contains(t, stderr, "1 of 2 endpoints are unhealthy")
+
+	code, _, stderr = run("endpoints", "set", "-db", dbPath, "mainnet")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, `no endpoint named "mainnet"`)
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/signatures"
+)
+
+func runDecode(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("decode", "[flags] <calldata | transaction hash>", stderr)
+	dbPath := flags.String("db", "", "SQLite database whose stored ABIs and signatures are used for decoding")
+	abiPath := flags.String("abi", "", "ABI file or URL used for decoding")
+	rpcURL := flags.String("rpc", "", "RPC endpoint used to fetch transactions by hash")
+	asJSON := flags.Bool("json", false, "print the result as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected calldata or a transaction hash")
+	}
+
+	input, err := decoder.ParseInput(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	callDecoder := decoder.New()
+	if *dbPath != "" {
+		storage, err := openDatabase(*dbPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = storage.Close() }()
+		if err := callDecoder.AddStoredABIs(storage); err != nil {
+			return err
+		}
+		callDecoder.UseSignatures(signatures.NewDatabase(storage))
+	}
+	if *abiPath != "" {
+		elements, err := abi.ReadAbi(*abiPath)
+		if err != nil {
+			return err
+		}
+		callDecoder.AddABI(*abiPath, elements)
+	}
+
+	if !input.IsTransaction {
+		calls, err := callDecoder.Decode(input.Calldata)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return writeJSON(stdout, calls)
+		}
+		writeCalls(stdout, calls)
+		return nil
+	}
+
+	if *rpcURL == "" {
+		return fmt.Errorf("-rpc is required to decode a transaction hash")
+	}
+	tr, err := transport.New(*rpcURL, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	transaction, err := callDecoder.DecodeTransaction(tr, input.TxHash)
+	if transaction == nil {
+		return err
+	}
+	if *asJSON {
+		if jsonErr := writeJSON(stdout, transaction); jsonErr != nil {
+			return jsonErr
+		}
+		return err
+	}
+
+	writeTransaction(stdout, transaction)
+	if err != nil {
+		return err
+	}
+	writeCalls(stdout, transaction.Calls)
+	return nil
+}
+
+func writeJSON(stdout io.Writer, value any) error {
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func writeTransaction(stdout io.Writer, transaction *decoder.Transaction) {
+	status := "mined"
+	if transaction.Pending {
+		status = "pending"
+	}
+	fmt.Fprintf(stdout, "Transaction %s (%s)\n", transaction.Hash.Hex(), status)
+	if transaction.To == nil {
+		fmt.Fprintln(stdout, "Contract creation")
+		return
+	}
+	fmt.Fprintf(stdout, "To: %s\n", transaction.To.Hex())
+	fmt.Fprintf(stdout, "Value: %s wei\n", transaction.Value)
+	if len(transaction.Calls) == 0 {
+		fmt.Fprintln(stdout, "No calldata")
+	}
+	fmt.Fprintln(stdout, "")
+}
+
+func writeCalls(stdout io.Writer, calls []decoder.Call) {
+	if len(calls) > 1 {
+		fmt.Fprintf(stdout, "%d functions match selector %s\n\n", len(calls), calls[0].Selector)
+	}
+	for index, call := range calls {
+		if index > 0 {
+			fmt.Fprintln(stdout, "")
+		}
+		for _, line := range call.Lines() {
+			fmt.Fprintln(stdout, line)
+		}
+	}
+}
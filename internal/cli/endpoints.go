@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/endpointgroup"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+)
+
+func runEndpoints(args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "Usage: smart-contract-cli endpoints <list | set | health> [flags]")
+		return fmt.Errorf("expected a subcommand")
+	}
+
+	switch args[0] {
+	case "list":
+		return runEndpointsList(args[1:], stdout, stderr)
+	case "set":
+		return runEndpointsSet(args[1:], stdout, stderr)
+	case "health":
+		return runEndpointsHealth(args[1:], stdout, stderr)
+	default:
+		return fmt.Errorf("unknown subcommand %q, expected list, set or health", args[0])
+	}
+}
+
+// openDatabase opens an existing SQLite database, so a mistyped path isn't created empty.
+func openDatabase(dbPath string) (sql.Storage, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("database %s: %w", dbPath, err)
+	}
+	return sql.NewSQLiteDB(dbPath)
+}
+
+func runEndpointsList(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("endpoints list", "[flags]", stderr)
+	dbPath := flags.String("db", "", "SQLite database of the endpoints (required)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *dbPath == "" || flags.NArg() != 0 {
+		flags.Usage()
+		return fmt.Errorf("expected -db")
+	}
+
+	storage, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = storage.Close() }()
+	groups, err := endpointgroup.List(storage)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		fmt.Fprintf(stdout, "Chain %s\n", group.ChainID)
+		for _, endpoint := range group.Endpoints {
+			fmt.Fprintf(stdout, "  priority %d  %s  %s  confirmations %d\n", endpoint.Priority, endpoint.Name, endpoint.Url, endpoint.Confirmations)
+		}
+	}
+	return nil
+}
+
+func runEndpointsSet(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("endpoints set", "[flags] <endpoint name>", stderr)
+	dbPath := flags.String("db", "", "SQLite database of the endpoints (required)")
+	priority := flags.Int("priority", 0, "failover priority, lower endpoints of a chain are used first")
+	confirmations := flags.Uint("confirmations", 0, "blocks to wait for before a receipt is final")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *dbPath == "" || flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected -db and an endpoint name")
+	}
+
+	storage, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = storage.Close() }()
+	endpoint, err := findEndpoint(storage, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	flags.Visit(func(set *flag.Flag) {
+		switch set.Name {
+		case "priority":
+			endpoint.Priority = *priority
+		case "confirmations":
+			endpoint.Confirmations = *confirmations
+		}
+	})
+	if err := storage.UpdateEndpoint(endpoint.ID, endpoint); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Updated %s: priority %d, confirmations %d\n", endpoint.Name, endpoint.Priority, endpoint.Confirmations)
+	return nil
+}
+
+func findEndpoint(storage sql.Storage, name string) (models.EVMEndpoint, error) {
+	result, err := storage.SearchEndpoints(name)
+	if err != nil {
+		return models.EVMEndpoint{}, err
+	}
+	for _, endpoint := range result.Items {
+		if endpoint.Name == name {
+			return endpoint, nil
+		}
+	}
+	return models.EVMEndpoint{}, fmt.Errorf("no endpoint named %q", name)
+}
+
+func runEndpointsHealth(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("endpoints health", "[flags]", stderr)
+	dbPath := flags.String("db", "", "SQLite database of the endpoints (required)")
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of each health check")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *dbPath == "" || flags.NArg() != 0 {
+		flags.Usage()
+		return fmt.Errorf("expected -db")
+	}
+
+	storage, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = storage.Close() }()
+	groups, err := endpointgroup.List(storage)
+	if err != nil {
+		return err
+	}
+
+	total, unhealthy := 0, 0
+	for _, group := range groups {
+		failover, err := group.Transport(*timeout)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Chain %s\n", group.ChainID)
+		for _, member := range failover.CheckHealth() {
+			total++
+			if member.Healthy {
+				fmt.Fprintf(stdout, "  ok    %s  %s\n", member.URL, member.Latency.Round(time.Millisecond))
+				continue
+			}
+			unhealthy++
+			fmt.Fprintf(stdout, "  down  %s  %s\n", member.URL, member.LastError)
+		}
+	}
+	if unhealthy > 0 {
+		return fmt.Errorf("%d of %d endpoints are unhealthy", unhealthy, total)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/signatures"
+)
+
+func runSignatures(args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "Usage: smart-contract-cli signatures <import | lookup> [flags]")
+		return fmt.Errorf("expected a subcommand")
+	}
+
+	switch args[0] {
+	case "import":
+		return runSignaturesImport(args[1:], stdout, stderr)
+	case "lookup":
+		return runSignaturesLookup(args[1:], stdout, stderr)
+	default:
+		return fmt.Errorf("unknown subcommand %q, expected import or lookup", args[0])
+	}
+}
+
+func runSignaturesImport(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("signatures import", "[flags] [dump file | -]", stderr)
+	dbPath := flags.String("db", "", "SQLite database to import into (required)")
+	importBundled := flags.Bool("bundled", false, "import the signatures bundled with the CLI")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *dbPath == "" || (flags.NArg() == 0 && !*importBundled) || flags.NArg() > 1 {
+		flags.Usage()
+		return fmt.Errorf("expected -db and a dump file or -bundled")
+	}
+
+	storage, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = storage.Close() }()
+
+	if *importBundled {
+		count, err := signatures.ImportBundled(storage)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Imported %d bundled signatures\n", count)
+	}
+
+	if flags.NArg() == 1 {
+		path := flags.Arg(0)
+		var count int
+		if path == "-" {
+			count, err = signatures.ImportReader(storage, os.Stdin, "stdin")
+		} else {
+			count, err = signatures.ImportFile(storage, path)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Imported %d signatures from %s\n", count, path)
+	}
+
+	total, err := storage.CountSignatures()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%d signatures in the database\n", total)
+	return nil
+}
+
+func runSignaturesLookup(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("signatures lookup", "[flags] <selector | topic>", stderr)
+	dbPath := flags.String("db", "", "SQLite database to look up in (required)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *dbPath == "" || flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected -db and a selector or topic")
+	}
+	storage, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = storage.Close() }()
+	found, err := signatures.NewDatabase(storage).Lookup(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if len(found) == 0 {
+		return fmt.Errorf("no signature found for %s", flags.Arg(0))
+	}
+
+	for _, signature := range found {
+		text := string(signature.Kind) + " " + signature.Signature
+		if signature.HumanReadable != "" {
+			text = signature.HumanReadable
+		}
+		fmt.Fprintf(stdout, "%s  [%s]\n", text, signature.Source)
+	}
+	return nil
+}
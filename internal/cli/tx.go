@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+)
+
+func runTx(args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "Usage: smart-contract-cli tx <build | sign | broadcast> [flags]")
+		return fmt.Errorf("expected a subcommand")
+	}
+
+	switch args[0] {
+	case "build":
+		return runTxBuild(args[1:], stdout, stderr)
+	case "sign":
+		return runTxSign(args[1:], stdout, stderr)
+	case "broadcast":
+		return runTxBroadcast(args[1:], stdout, stderr)
+	default:
+		return fmt.Errorf("unknown subcommand %q, expected build, sign or broadcast", args[0])
+	}
+}
+
+func runTxBuild(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("tx build", "[flags]", stderr)
+	rpcURL := flags.String("rpc", "", "RPC endpoint used for the chain ID, nonce and gas estimate (required)")
+	from := flags.String("from", "", "address that will sign the transaction (required)")
+	to := flags.String("to", "", "recipient, omit to deploy the contract in -data")
+	value := flags.String("value", "0", "value in wei")
+	data := flags.String("data", "", "calldata or deployment bytecode as hex")
+	nonce := flags.Int64("nonce", -1, "nonce, defaults to the pending nonce of -from")
+	gas := flags.Uint64("gas", 0, "gas limit, defaults to the estimate plus 50%")
+	gasPrice := flags.String("gas-price", "", "gas price in wei, builds a legacy transaction")
+	tip := flags.String("tip", "", "max priority fee per gas in wei, defaults to 1 gwei")
+	maxFee := flags.String("max-fee", "", "max fee per gas in wei, defaults to twice the tip")
+	out := flags.String("out", "", "file to write the unsigned transaction to, printed if omitted")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *rpcURL == "" || *from == "" || flags.NArg() != 0 {
+		flags.Usage()
+		return fmt.Errorf("expected -rpc and -from")
+	}
+	if !common.IsHexAddress(*from) {
+		return fmt.Errorf("invalid -from address %s", *from)
+	}
+
+	request := signer.TransactionRequest{From: common.HexToAddress(*from), GasLimit: *gas}
+	if *to != "" {
+		if !common.IsHexAddress(*to) {
+			return fmt.Errorf("invalid -to address %s", *to)
+		}
+		recipient := common.HexToAddress(*to)
+		request.To = &recipient
+	}
+	if *data != "" {
+		calldata, err := hexutil.Decode(ensureHexPrefix(*data))
+		if err != nil {
+			return fmt.Errorf("invalid -data: %w", err)
+		}
+		request.Data = calldata
+	}
+	if request.To == nil && len(request.Data) == 0 {
+		return fmt.Errorf("expected -to or the deployment bytecode in -data")
+	}
+	if *nonce >= 0 {
+		pinned := uint64(*nonce)
+		request.Nonce = &pinned
+	}
+
+	var err error
+	if request.Value, err = parseWei("value", *value); err != nil {
+		return err
+	}
+	if request.GasPrice, err = parseWei("gas-price", *gasPrice); err != nil {
+		return err
+	}
+	if request.GasTipCap, err = parseWei("tip", *tip); err != nil {
+		return err
+	}
+	if request.GasFeeCap, err = parseWei("max-fee", *maxFee); err != nil {
+		return err
+	}
+
+	tr, err := transport.New(*rpcURL, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	unsigned, err := signer.BuildUnsignedTransaction(tr, request)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return writeJSON(stdout, unsigned)
+	}
+	if err := signer.WriteUnsignedTransaction(*out, unsigned); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Wrote unsigned transaction to %s (nonce %d, gas %d, chain %s)\n", *out, unsigned.Nonce, unsigned.Gas, unsigned.ChainID)
+	return nil
+}
+
+func runTxSign(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("tx sign", "[flags] <unsigned transaction file>", stderr)
+	keyFile := flags.String("key-file", "", "file containing the private key as hex")
+	remoteSigner := flags.String("signer", "", "URL or IPC path of a Clef-compatible signer holding the key of the sender")
+	out := flags.String("out", "", "file to write the signed raw transaction to, printed if omitted")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if (*keyFile == "") == (*remoteSigner == "") || flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected one of -key-file or -signer and an unsigned transaction file")
+	}
+
+	unsigned, err := signer.ReadUnsignedTransaction(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var txSigner signer.Signer
+	if *remoteSigner != "" {
+		if !common.IsHexAddress(unsigned.From) {
+			return fmt.Errorf("the unsigned transaction has no from address to sign with -signer")
+		}
+		clefSigner, err := signer.NewClefSigner(*remoteSigner, common.HexToAddress(unsigned.From), 0)
+		if err != nil {
+			return err
+		}
+		defer clefSigner.Close()
+		txSigner = clefSigner
+	} else {
+		key, err := os.ReadFile(*keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read key file: %w", err)
+		}
+		txSigner, err = signer.NewPrivateKeySigner(strings.TrimSpace(string(key)))
+		if err != nil {
+			return err
+		}
+	}
+
+	transaction, err := signer.SignOffline(txSigner, unsigned)
+	if err != nil {
+		return err
+	}
+	raw, err := signer.EncodeSignedTransaction(transaction)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		fmt.Fprintln(stdout, raw)
+		return nil
+	}
+	if err := os.WriteFile(*out, []byte(raw+"\n"), 0o600); err != nil {
+		return fmt.Errorf("failed to write signed transaction: %w", err)
+	}
+	fmt.Fprintf(stdout, "Wrote signed transaction %s to %s\n", transaction.Hash().Hex(), *out)
+	return nil
+}
+
+func runTxBroadcast(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("tx broadcast", "[flags] <raw transaction | file | ->", stderr)
+	rpcURL := flags.String("rpc", "", "RPC endpoint to submit the transaction to (required)")
+	timeout := flags.Duration("timeout", 2*time.Minute, "how long to wait for the receipt or the next confirmation")
+	confirmations := flags.Uint64("confirmations", 1, "blocks to wait for, the block of the transaction included")
+	noWait := flags.Bool("no-wait", false, "exit after submitting without waiting for the receipt")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *rpcURL == "" || flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected -rpc and a signed raw transaction")
+	}
+
+	raw, err := readRawTransaction(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	// Reject malformed input before connecting to the endpoint
+	if _, _, err := signer.DecodeSignedTransaction(raw); err != nil {
+		return err
+	}
+	tr, err := transport.New(*rpcURL, *timeout, transport.WithConfirmations(*confirmations))
+	if err != nil {
+		return err
+	}
+
+	transaction, err := signer.BroadcastSignedTransaction(tr, raw)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Submitted %s\n", transaction.Hash().Hex())
+	if *noWait {
+		return nil
+	}
+
+	receipt, err := tr.WaitForTransactionReceipt(transaction.Hash())
+	if err != nil {
+		return err
+	}
+	status := "success"
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		status = "reverted"
+	}
+	fmt.Fprintf(stdout, "Status: %s\nBlock: %s\nGas used: %d\n", status, receipt.BlockNumber, receipt.GasUsed)
+	if receipt.ContractAddress != (common.Address{}) {
+		fmt.Fprintf(stdout, "Contract: %s\n", receipt.ContractAddress.Hex())
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction %s reverted", transaction.Hash().Hex())
+	}
+	return nil
+}
+
+// readRawTransaction reads a raw transaction given as hex, as a file containing the hex or from stdin.
+func readRawTransaction(operand string) (string, error) {
+	if operand == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	if _, err := hexutil.Decode(ensureHexPrefix(operand)); err == nil {
+		return operand, nil
+	}
+	data, err := os.ReadFile(operand)
+	if err != nil {
+		return "", fmt.Errorf("%s is neither a raw transaction nor a readable file: %w", operand, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func parseWei(name string, value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("invalid -%s %q, expected an amount in wei", name, value)
+	}
+	return amount, nil
+}
+
+func ensureHexPrefix(value string) string {
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		return value
+	}
+	return "0x" + value
+}
@@ -22,11 +22,16 @@ type AbiObject struct {
 }
 
 // ParseAbi parse an abi string which can be in array or object format or
-// Abi object format and returns an AbiArray.
+// Abi object format and returns an AbiArray. Human-readable signatures, one per
+// line or as a JSON array of strings, are also accepted.
 func ParseAbi(abi string) (AbiArray, error) {
 	var abiArray AbiArray
 	var abiObject AbiObject
 
+	if isHumanReadable(abi) {
+		return ParseHumanReadableString(abi)
+	}
+
 	err := json.Unmarshal([]byte(abi), &abiArray)
 	// check if error is not nil, try to unmarshal as an object
 	if err != nil {
@@ -40,6 +45,20 @@ func ParseAbi(abi string) (AbiArray, error) {
 	return abiArray, nil
 }
 
+// isHumanReadable returns true if the text is not a JSON ABI: either plain signatures or a JSON array of strings.
+func isHumanReadable(text string) bool {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return false
+	}
+	if !strings.HasPrefix(trimmed, "[") && !strings.HasPrefix(trimmed, "{") {
+		return true
+	}
+
+	var signatures []string
+	return json.Unmarshal([]byte(trimmed), &signatures) == nil && len(signatures) > 0
+}
+
 // ReadAbi reads an ABI from a local file path or download it from a remote source.
 func ReadAbi(filepath string) (AbiArray, error) {
 	// Check if filepath is a URL
@@ -0,0 +1,453 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// decimalPattern matches a decimal number with an optional fraction and exponent, e.g. "1.5e18".
+var decimalPattern = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE]([+-]?[0-9]+))?$`)
+
+// maxExponent bounds the exponent of scientific notation, no ABI integer needs more than 78 digits.
+const maxExponent = 100
+
+// units maps the denomination suffixes accepted for integers to their power of ten.
+var units = map[string]int64{
+	"wei":        0,
+	"kwei":       3,
+	"babbage":    3,
+	"mwei":       6,
+	"lovelace":   6,
+	"gwei":       9,
+	"shannon":    9,
+	"szabo":      12,
+	"microether": 12,
+	"finney":     15,
+	"milliether": 15,
+	"ether":      18,
+	"eth":        18,
+}
+
+// ArgumentError describes why the input for a single field could not be converted.
+type ArgumentError struct {
+	// Field is the path of the field, e.g. "order.calls[1].value".
+	Field string
+	Type  string
+	Input string
+	// Reason explains what is wrong with the input.
+	Reason string
+}
+
+func (e *ArgumentError) Error() string {
+	return fmt.Sprintf("%s (%s): %s", e.Field, e.Type, e.Reason)
+}
+
+// ArgumentErrors collects the errors of every invalid field.
+type ArgumentErrors []*ArgumentError
+
+func (e ArgumentErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ParseArguments converts one input string per parameter into the Go values go-ethereum expects
+// when packing them. Every invalid field is reported; the returned error is then ArgumentErrors.
+func ParseArguments(params []ABIParam, inputs []string) ([]any, error) {
+	if len(inputs) != len(params) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(params), len(inputs))
+	}
+
+	values := make([]any, 0, len(params))
+	var errs ArgumentErrors
+	for index, param := range params {
+		value, err := parseArgument(param, inputs[index], ArgumentName(param, index))
+		if err != nil {
+			errs = append(errs, err...)
+			continue
+		}
+		values = append(values, value)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return values, nil
+}
+
+// ParseArgument converts the input string for a parameter into the Go value go-ethereum expects:
+//
+//   - intN/uintN: decimal, 0x hex, scientific ("1.5e18") and unit suffixes ("1.5 ether", "20 gwei");
+//     *big.Int or the sized Go integer, range checked
+//   - address: 0x-prefixed, with the EIP-55 checksum validated for mixed-case input
+//   - bool: true/false or 1/0
+//   - bytes and bytesN: 0x-prefixed hex, bytesN requiring exactly N bytes
+//   - string: the input as-is
+//   - arrays and tuples: JSON, e.g. ["0x..", "1 ether"], {"to": "0x..", "amount": 5} or [1, true]
+func ParseArgument(param ABIParam, input string) (any, error) {
+	value, errs := parseArgument(param, input, ArgumentName(param, 0))
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return value, nil
+}
+
+// ArgumentName returns the parameter name, or argN for unnamed parameters.
+func ArgumentName(param ABIParam, index int) string {
+	if param.Name != "" {
+		return param.Name
+	}
+	return fmt.Sprintf("arg%d", index)
+}
+
+func parseArgument(param ABIParam, input string, field string) (any, ArgumentErrors) {
+	abiType, err := newType(param)
+	if err != nil {
+		return nil, ArgumentErrors{{Field: field, Type: param.Type, Input: input, Reason: fmt.Sprintf("unsupported type: %v", err)}}
+	}
+
+	converter := &argumentConverter{}
+	var value reflect.Value
+	switch abiType.T {
+	case ethabi.SliceTy, ethabi.ArrayTy, ethabi.TupleTy:
+		decoded, err := decodeJSON(input)
+		if err != nil {
+			return nil, ArgumentErrors{{Field: field, Type: abiType.String(), Input: input, Reason: fmt.Sprintf("expected JSON: %v", err)}}
+		}
+		value = converter.convert(abiType, decoded, field)
+	default:
+		value = converter.convertScalar(abiType, input, field)
+	}
+
+	if len(converter.errs) > 0 {
+		return nil, converter.errs
+	}
+	return value.Interface(), nil
+}
+
+// newType builds the go-ethereum type of the parameter, accepting aliases such as uint and validating sizes.
+func newType(param ABIParam) (ethabi.Type, error) {
+	components, err := toArgumentMarshaling(param.Components)
+	if err != nil {
+		return ethabi.Type{}, err
+	}
+	typeName, err := normalizeParamType(param.Type)
+	if err != nil {
+		return ethabi.Type{}, err
+	}
+	return ethabi.NewType(typeName, param.InternalType, components)
+}
+
+func normalizeParamType(typeName string) (string, error) {
+	if strings.HasPrefix(typeName, "tuple") {
+		return typeName, nil
+	}
+	return normalizeType(typeName)
+}
+
+func toArgumentMarshaling(params []ABIParam) ([]ethabi.ArgumentMarshaling, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	marshaling := make([]ethabi.ArgumentMarshaling, 0, len(params))
+	for index, param := range params {
+		typeName, err := normalizeParamType(param.Type)
+		if err != nil {
+			return nil, err
+		}
+		components, err := toArgumentMarshaling(param.Components)
+		if err != nil {
+			return nil, err
+		}
+		// go-ethereum rejects unnamed tuple components, so they get the argN name shown to the user
+		marshaling = append(marshaling, ethabi.ArgumentMarshaling{
+			Name:         ArgumentName(param, index),
+			Type:         typeName,
+			InternalType: param.InternalType,
+			Components:   components,
+			Indexed:      param.Indexed,
+		})
+	}
+	return marshaling, nil
+}
+
+func decodeJSON(input string) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(input)))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return decoded, nil
+}
+
+// argumentConverter converts decoded input into reflect values, collecting every field error.
+type argumentConverter struct {
+	errs ArgumentErrors
+}
+
+func (c *argumentConverter) fail(abiType ethabi.Type, input any, field string, format string, args ...any) reflect.Value {
+	c.errs = append(c.errs, &ArgumentError{
+		Field:  field,
+		Type:   abiType.String(),
+		Input:  fmt.Sprint(input),
+		Reason: fmt.Sprintf(format, args...),
+	})
+	return reflect.New(abiType.GetType()).Elem()
+}
+
+// convert converts a decoded JSON value.
+func (c *argumentConverter) convert(abiType ethabi.Type, input any, field string) reflect.Value {
+	switch abiType.T {
+	case ethabi.SliceTy, ethabi.ArrayTy:
+		items, ok := input.([]any)
+		if !ok {
+			return c.fail(abiType, input, field, "expected a JSON array")
+		}
+		if abiType.T == ethabi.ArrayTy && len(items) != abiType.Size {
+			return c.fail(abiType, input, field, "expected %d elements, got %d", abiType.Size, len(items))
+		}
+
+		var value reflect.Value
+		if abiType.T == ethabi.ArrayTy {
+			value = reflect.New(abiType.GetType()).Elem()
+		} else {
+			value = reflect.MakeSlice(abiType.GetType(), len(items), len(items))
+		}
+		for index, item := range items {
+			value.Index(index).Set(c.convert(*abiType.Elem, item, fmt.Sprintf("%s[%d]", field, index)))
+		}
+		return value
+
+	case ethabi.TupleTy:
+		return c.convertTuple(abiType, input, field)
+
+	default:
+		text, ok := scalarText(input)
+		if !ok {
+			return c.fail(abiType, input, field, "expected a single value")
+		}
+		return c.convertScalar(abiType, text, field)
+	}
+}
+
+// convertTuple converts a JSON object keyed by component name, or a JSON array of components in order.
+func (c *argumentConverter) convertTuple(abiType ethabi.Type, input any, field string) reflect.Value {
+	value := reflect.New(abiType.GetType()).Elem()
+
+	switch typed := input.(type) {
+	case map[string]any:
+		known := map[string]bool{}
+		for index, name := range abiType.TupleRawNames {
+			known[name] = true
+			item, ok := typed[name]
+			if !ok {
+				c.fail(*abiType.TupleElems[index], nil, field+"."+name, "missing field")
+				continue
+			}
+			value.Field(index).Set(c.convert(*abiType.TupleElems[index], item, field+"."+name))
+		}
+		for name := range typed {
+			if !known[name] {
+				c.fail(abiType, input, field+"."+name, "unknown field")
+			}
+		}
+	case []any:
+		if len(typed) != len(abiType.TupleElems) {
+			return c.fail(abiType, input, field, "expected %d components, got %d", len(abiType.TupleElems), len(typed))
+		}
+		for index, item := range typed {
+			value.Field(index).Set(c.convert(*abiType.TupleElems[index], item, field+"."+abiType.TupleRawNames[index]))
+		}
+	default:
+		return c.fail(abiType, input, field, "expected a JSON object or array")
+	}
+	return value
+}
+
+// scalarText returns the text of a JSON scalar.
+func scalarText(input any) (string, bool) {
+	switch typed := input.(type) {
+	case string:
+		return typed, true
+	case json.Number:
+		return typed.String(), true
+	case bool:
+		if typed {
+			return "true", true
+		}
+		return "false", true
+	default:
+		return "", false
+	}
+}
+
+// convertScalar converts the text of an elementary type.
+func (c *argumentConverter) convertScalar(abiType ethabi.Type, input string, field string) reflect.Value {
+	trimmed := strings.TrimSpace(input)
+
+	switch abiType.T {
+	case ethabi.IntTy, ethabi.UintTy:
+		number, err := parseInteger(trimmed)
+		if err != nil {
+			return c.fail(abiType, input, field, "%v", err)
+		}
+		return c.integerValue(abiType, number, input, field)
+
+	case ethabi.AddressTy:
+		if !common.IsHexAddress(trimmed) || !strings.HasPrefix(trimmed, "0x") {
+			return c.fail(abiType, input, field, "expected a 0x-prefixed 20 byte hex address")
+		}
+		hexPart := trimmed[2:]
+		if hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) && common.HexToAddress(trimmed).Hex() != trimmed {
+			return c.fail(abiType, input, field, "invalid EIP-55 checksum (expected %s)", common.HexToAddress(trimmed).Hex())
+		}
+		return reflect.ValueOf(common.HexToAddress(trimmed))
+
+	case ethabi.BoolTy:
+		switch strings.ToLower(trimmed) {
+		case "true", "1":
+			return reflect.ValueOf(true)
+		case "false", "0":
+			return reflect.ValueOf(false)
+		}
+		return c.fail(abiType, input, field, "expected true or false")
+
+	case ethabi.StringTy:
+		return reflect.ValueOf(input)
+
+	case ethabi.BytesTy:
+		data, err := parseHexBytes(trimmed)
+		if err != nil {
+			return c.fail(abiType, input, field, "%v", err)
+		}
+		return reflect.ValueOf(data)
+
+	case ethabi.FixedBytesTy, ethabi.FunctionTy:
+		data, err := parseHexBytes(trimmed)
+		if err != nil {
+			return c.fail(abiType, input, field, "%v", err)
+		}
+		value := reflect.New(abiType.GetType()).Elem()
+		if len(data) != value.Len() {
+			return c.fail(abiType, input, field, "expected %d bytes, got %d", value.Len(), len(data))
+		}
+		reflect.Copy(value, reflect.ValueOf(data))
+		return value
+
+	default:
+		return c.fail(abiType, input, field, "type is not supported")
+	}
+}
+
+func (c *argumentConverter) integerValue(abiType ethabi.Type, number *big.Int, input string, field string) reflect.Value {
+	var minimum, maximum *big.Int
+	if abiType.T == ethabi.UintTy {
+		minimum = big.NewInt(0)
+		maximum = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(abiType.Size)), big.NewInt(1))
+	} else {
+		maximum = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(abiType.Size-1)), big.NewInt(1))
+		minimum = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), uint(abiType.Size-1)))
+	}
+	if number.Cmp(minimum) < 0 || number.Cmp(maximum) > 0 {
+		return c.fail(abiType, input, field, "%s is out of range [%s, %s]", number, minimum, maximum)
+	}
+
+	goType := abiType.GetType()
+	if goType == reflect.TypeOf(&big.Int{}) {
+		return reflect.ValueOf(number)
+	}
+	value := reflect.New(goType).Elem()
+	if abiType.T == ethabi.UintTy {
+		value.SetUint(number.Uint64())
+	} else {
+		value.SetInt(number.Int64())
+	}
+	return value
+}
+
+// parseInteger parses decimal, 0x hex and scientific notation, with an optional unit suffix.
+func parseInteger(input string) (*big.Int, error) {
+	text := strings.ReplaceAll(input, "_", "")
+	if text == "" {
+		return nil, fmt.Errorf("value is empty")
+	}
+
+	negative := strings.HasPrefix(text, "-")
+	digits := strings.TrimPrefix(text, "-")
+	if strings.HasPrefix(strings.ToLower(digits), "0x") {
+		hexPart := digits[2:]
+		if hexPart == "" || strings.ContainsAny(hexPart, "+-") {
+			return nil, fmt.Errorf("%q is not a valid hex integer", input)
+		}
+		number, ok := new(big.Int).SetString(hexPart, 16)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a valid hex integer", input)
+		}
+		if negative {
+			number.Neg(number)
+		}
+		return number, nil
+	}
+
+	exponent := int64(0)
+	unitStart := strings.LastIndexFunc(text, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z')
+	}) + 1
+	if unit := strings.ToLower(text[unitStart:]); unit != "" {
+		power, ok := units[unit]
+		if !ok {
+			return nil, fmt.Errorf("unknown unit %q", text[unitStart:])
+		}
+		text, exponent = strings.TrimSpace(text[:unitStart]), power
+	}
+
+	match := decimalPattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil, fmt.Errorf("%q is not a valid integer", input)
+	}
+	if match[2] != "" {
+		if power, err := strconv.Atoi(match[2]); err != nil || power > maxExponent || power < -maxExponent {
+			return nil, fmt.Errorf("exponent of %q is out of range [-%d, %d]", input, maxExponent, maxExponent)
+		}
+	}
+	rat, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a valid integer", input)
+	}
+	rat.Mul(rat, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(exponent), nil)))
+	if !rat.IsInt() {
+		return nil, fmt.Errorf("%q is not a whole number", input)
+	}
+	return new(big.Int).Set(rat.Num()), nil
+}
+
+func parseHexBytes(input string) ([]byte, error) {
+	if !strings.HasPrefix(input, "0x") && !strings.HasPrefix(input, "0X") {
+		return nil, fmt.Errorf("expected 0x-prefixed hex")
+	}
+	hexPart := input[2:]
+	if len(hexPart)%2 != 0 {
+		return nil, fmt.Errorf("hex has an odd number of digits")
+	}
+	data, err := hex.DecodeString(hexPart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %v", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,221 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustBigInt(t *testing.T, text string) *big.Int {
+	t.Helper()
+	value, ok := new(big.Int).SetString(text, 10)
+	require.True(t, ok)
+	return value
+}
+
+func TestParseArgument(t *testing.T) {
+	tests := []struct {
+		name  string
+		param ABIParam
+		input string
+		want  any
+	}{
+		{name: "uint256 decimal", param: ABIParam{Type: "uint256"}, input: "1000", want: big.NewInt(1000)},
+		{name: "uint256 hex", param: ABIParam{Type: "uint256"}, input: "0xff", want: big.NewInt(255)},
+		{name: "uint256 scientific", param: ABIParam{Type: "uint256"}, input: "1.5e18", want: mustBigInt(t, "1500000000000000000")},
+		{name: "uint256 ether", param: ABIParam{Type: "uint256"}, input: "2.5 ether", want: mustBigInt(t, "2500000000000000000")},
+		{name: "uint256 gwei", param: ABIParam{Type: "uint256"}, input: "20gwei", want: big.NewInt(20_000_000_000)},
+		{name: "uint256 underscores", param: ABIParam{Type: "uint256"}, input: "1_000_000", want: big.NewInt(1_000_000)},
+		{name: "uint alias", param: ABIParam{Type: "uint"}, input: "7", want: big.NewInt(7)},
+		{name: "uint8", param: ABIParam{Type: "uint8"}, input: "255", want: uint8(255)},
+		{name: "uint24 uses big.Int", param: ABIParam{Type: "uint24"}, input: "16777215", want: big.NewInt(16777215)},
+		{name: "int64 negative", param: ABIParam{Type: "int64"}, input: "-5", want: int64(-5)},
+		{name: "int256 negative hex", param: ABIParam{Type: "int256"}, input: "-0x10", want: big.NewInt(-16)},
+		{name: "address lowercase", param: ABIParam{Type: "address"}, input: "0x5fbdb2315678afecb367f032d93f642f64180aa3", want: common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")},
+		{name: "address checksummed", param: ABIParam{Type: "address"}, input: "0x5FbDB2315678afecb367f032d93F642f64180aa3", want: common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")},
+		{name: "bool", param: ABIParam{Type: "bool"}, input: "TRUE", want: true},
+		{name: "bool numeric", param: ABIParam{Type: "bool"}, input: "0", want: false},
+		{name: "string kept as-is", param: ABIParam{Type: "string"}, input: " hello, world ", want: " hello, world "},
+		{name: "bytes", param: ABIParam{Type: "bytes"}, input: "0x0102", want: []byte{1, 2}},
+		{name: "empty bytes", param: ABIParam{Type: "bytes"}, input: "0x", want: []byte{}},
+		{name: "bytes4", param: ABIParam{Type: "bytes4"}, input: "0xa9059cbb", want: [4]byte{0xa9, 0x05, 0x9c, 0xbb}},
+		{name: "dynamic array", param: ABIParam{Type: "uint256[]"}, input: `[1, "2", "1 gwei"]`, want: []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(1_000_000_000)}},
+		{name: "fixed array", param: ABIParam{Type: "bool[2]"}, input: `[true, "false"]`, want: [2]bool{true, false}},
+		{name: "nested array", param: ABIParam{Type: "uint8[][]"}, input: `[[1, 2], []]`, want: [][]uint8{{1, 2}, {}}},
+		{name: "string array", param: ABIParam{Type: "string[]"}, input: `["a", "b,c"]`, want: []string{"a", "b,c"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseArgument(test.param, test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestParseArgumentErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		param  ABIParam
+		input  string
+		field  string
+		reason string
+	}{
+		{name: "uint negative", param: ABIParam{Name: "amount", Type: "uint256"}, input: "-1", field: "amount", reason: "out of range"},
+		{name: "uint8 overflow", param: ABIParam{Name: "decimals", Type: "uint8"}, input: "256", field: "decimals", reason: "out of range"},
+		{name: "int8 overflow", param: ABIParam{Type: "int8"}, input: "128", field: "arg0", reason: "out of range"},
+		{name: "fractional wei", param: ABIParam{Type: "uint256"}, input: "1.5", reason: "not a whole number"},
+		{name: "fraction", param: ABIParam{Type: "uint256"}, input: "3/1", reason: "not a valid integer"},
+		{name: "signed hex digits", param: ABIParam{Type: "int256"}, input: "-0x-5", reason: "not a valid hex integer"},
+		{name: "empty hex", param: ABIParam{Type: "uint256"}, input: "0x", reason: "not a valid hex integer"},
+		{name: "huge exponent", param: ABIParam{Type: "uint256"}, input: "1e1000000000", reason: "exponent"},
+		{name: "unknown unit", param: ABIParam{Type: "uint256"}, input: "1 dollar", reason: "unknown unit"},
+		{name: "not a number", param: ABIParam{Type: "uint256"}, input: "abc", reason: "unknown unit"},
+		{name: "empty number", param: ABIParam{Type: "uint256"}, input: "", reason: "empty"},
+		{name: "short address", param: ABIParam{Type: "address"}, input: "0x12", reason: "20 byte hex address"},
+		{name: "bad checksum", param: ABIParam{Type: "address"}, input: "0x5FBdB2315678afecb367f032d93F642f64180aa3", reason: "checksum"},
+		{name: "bool", param: ABIParam{Type: "bool"}, input: "maybe", reason: "true or false"},
+		{name: "bytes without prefix", param: ABIParam{Type: "bytes"}, input: "0102", reason: "0x-prefixed"},
+		{name: "bytes odd length", param: ABIParam{Type: "bytes"}, input: "0x012", reason: "odd number"},
+		{name: "bytes4 wrong size", param: ABIParam{Type: "bytes4"}, input: "0x01", reason: "expected 4 bytes, got 1"},
+		{name: "array not json", param: ABIParam{Name: "ids", Type: "uint256[]"}, input: "1,2", field: "ids", reason: "expected JSON"},
+		{name: "fixed array length", param: ABIParam{Type: "uint256[3]"}, input: "[1, 2]", reason: "expected 3 elements, got 2"},
+		{name: "array element", param: ABIParam{Name: "ids", Type: "uint8[]"}, input: "[1, 300]", field: "ids[1]", reason: "out of range"},
+		{name: "unsupported type", param: ABIParam{Type: "uint7"}, input: "1", reason: "unsupported type"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseArgument(test.param, test.input)
+			require.Error(t, err)
+
+			var errs ArgumentErrors
+			require.ErrorAs(t, err, &errs)
+			require.Len(t, errs, 1)
+			if test.field != "" {
+				assert.Equal(t, test.field, errs[0].Field)
+			}
+			assert.Contains(t, errs[0].Reason, test.reason)
+		})
+	}
+}
+
+func orderParam() ABIParam {
+	return ABIParam{
+		Name: "order",
+		Type: "tuple",
+		Components: []ABIParam{
+			{Name: "maker", Type: "address"},
+			{
+				Name: "calls",
+				Type: "tuple[]",
+				Components: []ABIParam{
+					{Name: "target", Type: "address"},
+					{Name: "value", Type: "uint256"},
+					{Name: "data", Type: "bytes"},
+				},
+			},
+		},
+	}
+}
+
+func TestParseArgumentTuple(t *testing.T) {
+	input := `{
+		"maker": "0x5fbdb2315678afecb367f032d93f642f64180aa3",
+		"calls": [
+			{"target": "0x5fbdb2315678afecb367f032d93f642f64180aa3", "value": "1 ether", "data": "0x"},
+			["0x5fbdb2315678afecb367f032d93f642f64180aa3", 5, "0x01"]
+		]
+	}`
+
+	value, err := ParseArgument(orderParam(), input)
+	require.NoError(t, err)
+
+	// The converted value must be accepted by the go-ethereum encoder.
+	abiType, err := newType(orderParam())
+	require.NoError(t, err)
+	packed, err := ethabi.Arguments{{Type: abiType}}.Pack(value)
+	require.NoError(t, err)
+
+	unpacked, err := ethabi.Arguments{{Type: abiType}}.Unpack(packed)
+	require.NoError(t, err)
+	assert.Equal(t, value, unpacked[0])
+}
+
+func TestParseArgumentUnnamedTuple(t *testing.T) {
+	elements, err := ParseHumanReadable([]string{"function fill((address, uint256) order)"})
+	require.NoError(t, err)
+	param := elements[0].Inputs[0]
+
+	value, err := ParseArgument(param, `["0x5fbdb2315678afecb367f032d93f642f64180aa3", "2 gwei"]`)
+	require.NoError(t, err)
+	byName, err := ParseArgument(param, `{"arg0": "0x5fbdb2315678afecb367f032d93f642f64180aa3", "arg1": "2 gwei"}`)
+	require.NoError(t, err)
+	assert.Equal(t, value, byName)
+
+	arguments, err := Arguments(elements[0].Inputs)
+	require.NoError(t, err)
+	packed, err := arguments.Pack(value)
+	require.NoError(t, err)
+	unpacked, err := arguments.Unpack(packed)
+	require.NoError(t, err)
+	assert.Equal(t, value, unpacked[0])
+
+	_, err = ParseArgument(param, `["0x5fbdb2315678afecb367f032d93f642f64180aa3", -1]`)
+	var errs ArgumentErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "order.arg1", errs[0].Field)
+}
+
+func TestParseArgumentTupleErrors(t *testing.T) {
+	input := `{
+		"maker": "0x12",
+		"calls": [
+			{"target": "0x5fbdb2315678afecb367f032d93f642f64180aa3", "value": "1 ether", "data": "0x"},
+			{"target": "0x5fbdb2315678afecb367f032d93f642f64180aa3", "value": "-1"}
+		],
+		"extra": 1
+	}`
+
+	_, err := ParseArgument(orderParam(), input)
+	require.Error(t, err)
+
+	var errs ArgumentErrors
+	require.ErrorAs(t, err, &errs)
+	fields := make([]string, 0, len(errs))
+	for _, fieldErr := range errs {
+		fields = append(fields, fieldErr.Field)
+	}
+	assert.ElementsMatch(t, []string{"order.maker", "order.calls[1].value", "order.calls[1].data", "order.extra"}, fields)
+	assert.Contains(t, err.Error(), "order.calls[1].data (bytes): missing field")
+}
+
+func TestParseArguments(t *testing.T) {
+	params := []ABIParam{
+		{Name: "to", Type: "address"},
+		{Name: "amount", Type: "uint256"},
+		{Type: "bool"},
+	}
+
+	values, err := ParseArguments(params, []string{"0x5fbdb2315678afecb367f032d93f642f64180aa3", "1 ether", "true"})
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+	assert.Equal(t, mustBigInt(t, "1000000000000000000"), values[1])
+
+	_, err = ParseArguments(params, []string{"nope", "x", "maybe"})
+	var errs ArgumentErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 3)
+	assert.Equal(t, "to", errs[0].Field)
+	assert.Equal(t, "amount", errs[1].Field)
+	assert.Equal(t, "arg2", errs[2].Field)
+
+	_, err = ParseArguments(params, []string{"1"})
+	assert.ErrorContains(t, err, "expected 3 arguments, got 1")
+}
@@ -0,0 +1,320 @@
+package abi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeKind describes how an ABI element changed between two versions.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change is a function, event, error, constructor, fallback or receive function that differs
+// between two ABI versions.
+type Change struct {
+	Kind        ChangeKind `json:"kind"`
+	ElementType string     `json:"elementType"`
+	Name        string     `json:"name,omitempty"`
+	// Old and New are the human-readable signatures, Old is empty for added elements and New for removed ones.
+	Old     string   `json:"old,omitempty"`
+	New     string   `json:"new,omitempty"`
+	Details []string `json:"details,omitempty"`
+	// Breaking is set when existing callers, listeners or senders stop working with the new ABI.
+	Breaking bool `json:"breaking"`
+}
+
+// ABIDiff is the list of changes from one ABI version to another.
+type ABIDiff struct {
+	Changes []Change `json:"changes"`
+}
+
+// elementTypeOrder sorts changes by element type.
+var elementTypeOrder = map[string]int{
+	"constructor": 0,
+	"function":    1,
+	"event":       2,
+	"error":       3,
+	"fallback":    4,
+	"receive":     5,
+}
+
+// Diff compares two versions of an ABI. Elements are matched by type and name; overloads are matched by
+// their signature, and a single remaining overload on each side is reported as modified.
+func Diff(oldABI AbiArray, newABI AbiArray) ABIDiff {
+	oldGroups, oldKeys := groupElements(oldABI)
+	newGroups, newKeys := groupElements(newABI)
+
+	keys := append([]string{}, oldKeys...)
+	for _, key := range newKeys {
+		if _, ok := oldGroups[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	diff := ABIDiff{Changes: []Change{}}
+	for _, key := range keys {
+		diff.Changes = append(diff.Changes, diffGroup(oldGroups[key], newGroups[key])...)
+	}
+
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		left, right := diff.Changes[i], diff.Changes[j]
+		if left.ElementType != right.ElementType {
+			return elementTypeOrder[left.ElementType] < elementTypeOrder[right.ElementType]
+		}
+		return left.Name < right.Name
+	})
+	return diff
+}
+
+// HasBreakingChanges reports whether any change breaks existing callers.
+func (d ABIDiff) HasBreakingChanges() bool {
+	return len(d.Breaking()) > 0
+}
+
+// Breaking returns the changes that break existing callers.
+func (d ABIDiff) Breaking() []Change {
+	breaking := []Change{}
+	for _, change := range d.Changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+// IsEmpty reports whether both versions are the same.
+func (d ABIDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// Summary counts the changes, e.g. "1 added, 2 modified, 1 removed (2 breaking)".
+func (d ABIDiff) Summary() string {
+	if d.IsEmpty() {
+		return "No changes"
+	}
+
+	counts := map[ChangeKind]int{}
+	for _, change := range d.Changes {
+		counts[change.Kind]++
+	}
+	parts := []string{}
+	for _, kind := range []ChangeKind{ChangeAdded, ChangeModified, ChangeRemoved} {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+	summary := strings.Join(parts, ", ")
+	if breaking := len(d.Breaking()); breaking > 0 {
+		summary += fmt.Sprintf(" (%d breaking)", breaking)
+	}
+	return summary
+}
+
+// Lines renders every change, with the details of modified elements indented below them.
+func (d ABIDiff) Lines() []string {
+	lines := []string{}
+	for _, change := range d.Changes {
+		lines = append(lines, change.Lines()...)
+	}
+	return lines
+}
+
+// Lines renders the change, e.g. "- removed function balanceOf(address) view returns (uint256) [breaking]".
+func (c Change) Lines() []string {
+	suffix := ""
+	if c.Breaking {
+		suffix = "  [breaking]"
+	}
+
+	switch c.Kind {
+	case ChangeAdded:
+		return []string{"+ added " + c.New + suffix}
+	case ChangeRemoved:
+		return []string{"- removed " + c.Old + suffix}
+	}
+
+	lines := []string{"~ modified " + c.New + suffix}
+	for _, detail := range c.Details {
+		lines = append(lines, "    "+detail)
+	}
+	return lines
+}
+
+func elementKey(element ABIElement) string {
+	switch element.Type {
+	case "constructor", "fallback", "receive":
+		return element.Type
+	}
+	return element.Type + " " + element.Name
+}
+
+// groupElements groups elements by key, returning the keys in ABI order.
+func groupElements(elements AbiArray) (map[string][]ABIElement, []string) {
+	groups := map[string][]ABIElement{}
+	keys := []string{}
+	for _, element := range elements {
+		key := elementKey(element)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], element)
+	}
+	return groups, keys
+}
+
+func diffGroup(oldElements []ABIElement, newElements []ABIElement) []Change {
+	changes := []Change{}
+	remainingNew := append([]ABIElement{}, newElements...)
+	unmatchedOld := []ABIElement{}
+
+	for _, oldElement := range oldElements {
+		matched := -1
+		for index, newElement := range remainingNew {
+			if inputTypes(oldElement) == inputTypes(newElement) {
+				matched = index
+				break
+			}
+		}
+		if matched < 0 {
+			unmatchedOld = append(unmatchedOld, oldElement)
+			continue
+		}
+		if change, ok := compareElements(oldElement, remainingNew[matched]); ok {
+			changes = append(changes, change)
+		}
+		remainingNew = append(remainingNew[:matched], remainingNew[matched+1:]...)
+	}
+
+	if len(unmatchedOld) == 1 && len(remainingNew) == 1 {
+		change, _ := compareElements(unmatchedOld[0], remainingNew[0])
+		return append(changes, change)
+	}
+
+	for _, element := range unmatchedOld {
+		changes = append(changes, Change{
+			Kind:        ChangeRemoved,
+			ElementType: element.Type,
+			Name:        element.Name,
+			Old:         element.HumanReadable(),
+			Breaking:    removalBreaks(element),
+		})
+	}
+	for _, element := range remainingNew {
+		changes = append(changes, Change{
+			Kind:        ChangeAdded,
+			ElementType: element.Type,
+			Name:        element.Name,
+			New:         element.HumanReadable(),
+		})
+	}
+	return changes
+}
+
+// removalBreaks reports whether removing the element breaks existing users. Removed errors are
+// never raised, and constructors only matter to new deployments.
+func removalBreaks(element ABIElement) bool {
+	return element.Type != "error" && element.Type != "constructor"
+}
+
+// compareElements compares two versions of an element, returning false when nothing changed.
+func compareElements(oldElement ABIElement, newElement ABIElement) (Change, bool) {
+	change := Change{
+		Kind:        ChangeModified,
+		ElementType: newElement.Type,
+		Name:        newElement.Name,
+		Old:         oldElement.HumanReadable(),
+		New:         newElement.HumanReadable(),
+	}
+	// Only deployments use the constructor, changing it never breaks existing callers
+	canBreak := newElement.Type != "constructor"
+	addDetail := func(breaking bool, format string, args ...any) {
+		change.Details = append(change.Details, fmt.Sprintf(format, args...))
+		change.Breaking = change.Breaking || (breaking && canBreak)
+	}
+
+	if oldTypes, newTypes := inputTypes(oldElement), inputTypes(newElement); oldTypes != newTypes {
+		detail := "parameter types changed from (%s) to (%s)"
+		if newElement.Type == "function" || newElement.Type == "error" {
+			detail += ", changing the selector"
+		}
+		if newElement.Type == "event" {
+			detail += ", changing the topic"
+		}
+		addDetail(true, detail, oldTypes, newTypes)
+	} else {
+		compareParams(oldElement.Inputs, newElement.Inputs, "parameter", addDetail)
+		for index, input := range newElement.Inputs {
+			if newElement.Type == "event" && oldElement.Inputs[index].Indexed != input.Indexed {
+				state := "no longer indexed"
+				if input.Indexed {
+					state = "now indexed"
+				}
+				addDetail(true, "parameter %s is %s", ArgumentName(input, index), state)
+			}
+		}
+	}
+
+	if oldTypes, newTypes := paramTypes(oldElement.Outputs), paramTypes(newElement.Outputs); oldTypes != newTypes {
+		addDetail(true, "return types changed from (%s) to (%s)", oldTypes, newTypes)
+	} else {
+		compareParams(oldElement.Outputs, newElement.Outputs, "return value", addDetail)
+	}
+
+	if oldElement.Anonymous != newElement.Anonymous {
+		addDetail(true, "anonymous changed from %t to %t", oldElement.Anonymous, newElement.Anonymous)
+	}
+
+	oldMutability, newMutability := oldElement.EffectiveStateMutability(), newElement.EffectiveStateMutability()
+	if oldMutability != newMutability {
+		breaking := (isReadOnly(oldMutability) && !isReadOnly(newMutability)) ||
+			(oldMutability == StateMutabilityPayable && newMutability != StateMutabilityPayable)
+		addDetail(breaking, "state mutability changed from %s to %s", oldMutability, newMutability)
+	}
+
+	return change, len(change.Details) > 0
+}
+
+// compareParams reports renamed parameters of two parameter lists with the same types.
+func compareParams(oldParams []ABIParam, newParams []ABIParam, label string, addDetail func(bool, string, ...any)) {
+	for index, param := range newParams {
+		if oldParams[index].Name != param.Name {
+			addDetail(false, "%s %d renamed from %q to %q", label, index, oldParams[index].Name, param.Name)
+		}
+	}
+}
+
+func inputTypes(element ABIElement) string {
+	return paramTypes(element.Inputs)
+}
+
+// paramTypes returns the canonical parameter types, e.g. "address,(uint256,bytes)[]".
+func paramTypes(params []ABIParam) string {
+	types := make([]string, 0, len(params))
+	for _, param := range params {
+		types = append(types, canonicalType(param))
+	}
+	return strings.Join(types, ",")
+}
+
+// canonicalType expands tuples from their components and normalizes aliases such as uint, keeping
+// the declared type when it is unknown.
+func canonicalType(param ABIParam) string {
+	if strings.HasPrefix(param.Type, "tuple") {
+		return "(" + paramTypes(param.Components) + ")" + strings.TrimPrefix(param.Type, "tuple")
+	}
+	typeName, err := normalizeType(param.Type)
+	if err != nil {
+		return param.Type
+	}
+	return typeName
+}
+
+func isReadOnly(mutability StateMutability) bool {
+	return mutability == StateMutabilityView || mutability == StateMutabilityPure
+}
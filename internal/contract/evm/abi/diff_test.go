@@ -0,0 +1,173 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseSignatures(t *testing.T, signatures ...string) AbiArray {
+	t.Helper()
+	if len(signatures) == 0 {
+		return AbiArray{}
+	}
+	elements, err := ParseHumanReadable(signatures)
+	require.NoError(t, err)
+	return elements
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      []string
+		new      []string
+		kinds    []ChangeKind
+		breaking []bool
+		details  []string
+	}{
+		{
+			name: "identical",
+			old:  []string{"function balanceOf(address owner) view returns (uint256)"},
+			new:  []string{"function balanceOf(address owner) view returns (uint256)"},
+		},
+		{
+			name:     "added function",
+			old:      []string{},
+			new:      []string{"function mint(address to, uint256 amount)"},
+			kinds:    []ChangeKind{ChangeAdded},
+			breaking: []bool{false},
+		},
+		{
+			name:     "removed function",
+			old:      []string{"function mint(address to, uint256 amount)"},
+			new:      []string{},
+			kinds:    []ChangeKind{ChangeRemoved},
+			breaking: []bool{true},
+		},
+		{
+			name:     "removed error",
+			old:      []string{"error Unauthorized()"},
+			new:      []string{},
+			kinds:    []ChangeKind{ChangeRemoved},
+			breaking: []bool{false},
+		},
+		{
+			name:     "changed parameter type",
+			old:      []string{"function mint(address to, uint256 amount)"},
+			new:      []string{"function mint(address to, uint128 amount)"},
+			kinds:    []ChangeKind{ChangeModified},
+			breaking: []bool{true},
+			details:  []string{"parameter types changed from (address,uint256) to (address,uint128), changing the selector"},
+		},
+		{
+			name:     "changed tuple component type",
+			old:      []string{"function fill((address, uint256)[] orders) returns ((bool, bytes))"},
+			new:      []string{"function fill((address, uint128)[] orders) returns ((bool, bytes))"},
+			kinds:    []ChangeKind{ChangeModified},
+			breaking: []bool{true},
+			details:  []string{"parameter types changed from ((address,uint256)[]) to ((address,uint128)[]), changing the selector"},
+		},
+		{
+			name:     "renamed parameter",
+			old:      []string{"function mint(address to, uint256 amount)"},
+			new:      []string{"function mint(address recipient, uint amount)"},
+			kinds:    []ChangeKind{ChangeModified},
+			breaking: []bool{false},
+			details:  []string{`parameter 0 renamed from "to" to "recipient"`},
+		},
+		{
+			name:     "view became nonpayable",
+			old:      []string{"function total() view returns (uint256)"},
+			new:      []string{"function total() returns (uint256)"},
+			kinds:    []ChangeKind{ChangeModified},
+			breaking: []bool{true},
+			details:  []string{"state mutability changed from view to nonpayable"},
+		},
+		{
+			name:     "nonpayable became payable",
+			old:      []string{"function deposit()"},
+			new:      []string{"function deposit() payable"},
+			kinds:    []ChangeKind{ChangeModified},
+			breaking: []bool{false},
+			details:  []string{"state mutability changed from nonpayable to payable"},
+		},
+		{
+			name:     "payable became nonpayable",
+			old:      []string{"function deposit() payable"},
+			new:      []string{"function deposit()"},
+			kinds:    []ChangeKind{ChangeModified},
+			breaking: []bool{true},
+		},
+		{
+			name:     "changed return type",
+			old:      []string{"function total() view returns (uint256)"},
+			new:      []string{"function total() view returns (uint256, uint256)"},
+			kinds:    []ChangeKind{ChangeModified},
+			breaking: []bool{true},
+			details:  []string{"return types changed from (uint256) to (uint256,uint256)"},
+		},
+		{
+			name:     "event indexing",
+			old:      []string{"event Transfer(address indexed from, address indexed to, uint256 value)"},
+			new:      []string{"event Transfer(address indexed from, address to, uint256 value)"},
+			kinds:    []ChangeKind{ChangeModified},
+			breaking: []bool{true},
+			details:  []string{"parameter to is no longer indexed"},
+		},
+		{
+			name:     "constructor changes are not breaking",
+			old:      []string{"constructor(address owner)"},
+			new:      []string{"constructor(address owner, uint256 supply)"},
+			kinds:    []ChangeKind{ChangeModified},
+			breaking: []bool{false},
+		},
+		{
+			name:     "new overload",
+			old:      []string{"function safeTransferFrom(address from, address to, uint256 id)"},
+			new:      []string{"function safeTransferFrom(address from, address to, uint256 id)", "function safeTransferFrom(address from, address to, uint256 id, bytes data)"},
+			kinds:    []ChangeKind{ChangeAdded},
+			breaking: []bool{false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diff := Diff(parseSignatures(t, test.old...), parseSignatures(t, test.new...))
+			require.Len(t, diff.Changes, len(test.kinds))
+			for index, change := range diff.Changes {
+				assert.Equal(t, test.kinds[index], change.Kind)
+				assert.Equal(t, test.breaking[index], change.Breaking)
+			}
+			if test.details != nil {
+				assert.Equal(t, test.details, diff.Changes[0].Details)
+			}
+		})
+	}
+}
+
+func TestDiffLegacyMutability(t *testing.T) {
+	oldABI, err := ParseAbi(`[{"type":"function","name":"total","inputs":[],"outputs":[{"name":"","type":"uint256"}],"constant":true}]`)
+	require.NoError(t, err)
+	newABI := parseSignatures(t, "function total() view returns (uint256)")
+
+	assert.True(t, Diff(oldABI, newABI).IsEmpty())
+}
+
+func TestDiffSummaryAndLines(t *testing.T) {
+	diff := Diff(
+		parseSignatures(t, "function balanceOf(address owner) view returns (uint256)", "event Paused()", "error Unauthorized()"),
+		parseSignatures(t, "function balanceOf(address owner) returns (uint256)", "function pause()", "error Unauthorized()"),
+	)
+
+	assert.True(t, diff.HasBreakingChanges())
+	assert.Len(t, diff.Breaking(), 2)
+	assert.Equal(t, "1 added, 1 modified, 1 removed (2 breaking)", diff.Summary())
+	assert.Equal(t, []string{
+		"~ modified function balanceOf(address owner) returns (uint256)  [breaking]",
+		"    state mutability changed from view to nonpayable",
+		"+ added function pause()",
+		"- removed event Paused()  [breaking]",
+	}, diff.Lines())
+	assert.Equal(t, "No changes", Diff(nil, nil).Summary())
+}
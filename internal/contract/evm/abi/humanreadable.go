@@ -0,0 +1,410 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+var (
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	arraySuffixes     = regexp.MustCompile(`^(\[[0-9]*\])*$`)
+)
+
+// modifiers are the keywords allowed after a parameter list.
+var modifiers = map[string]bool{
+	"external":   true,
+	"public":     true,
+	"view":       true,
+	"pure":       true,
+	"payable":    true,
+	"nonpayable": true,
+	"constant":   true,
+	"virtual":    true,
+	"override":   true,
+	"anonymous":  true,
+}
+
+// dataLocations are the keywords allowed between a parameter type and its name.
+var dataLocations = map[string]bool{
+	"memory":   true,
+	"calldata": true,
+	"storage":  true,
+}
+
+// ParseHumanReadable parses human-readable ABI signatures such as
+// "function transfer(address to, uint256 amount) returns (bool)",
+// "event Transfer(address indexed from, address indexed to, uint256 value)" or
+// "error InsufficientBalance(uint256 available, uint256 required)". Signatures without a keyword
+// are treated as functions. Blank lines, "//" comments and trailing semicolons are ignored.
+func ParseHumanReadable(signatures []string) (AbiArray, error) {
+	elements := AbiArray{}
+	for index, signature := range signatures {
+		signature = strings.TrimSpace(signature)
+		if comment := strings.Index(signature, "//"); comment >= 0 {
+			signature = strings.TrimSpace(signature[:comment])
+		}
+		signature = strings.TrimSpace(strings.TrimSuffix(signature, ";"))
+		if signature == "" {
+			continue
+		}
+
+		element, err := parseSignature(signature)
+		if err != nil {
+			return nil, errors.WrapABIError(err, errors.ErrCodeInvalidABIFormat, fmt.Sprintf("invalid signature on line %d: %s", index+1, signature))
+		}
+		elements = append(elements, element)
+	}
+
+	if len(elements) == 0 {
+		return nil, errors.NewABIError(errors.ErrCodeInvalidABIFormat, "no signatures found")
+	}
+	return elements, nil
+}
+
+// ParseHumanReadableString parses human-readable signatures given one per line, or as a JSON
+// array of strings (the format used by ethers).
+func ParseHumanReadableString(text string) (AbiArray, error) {
+	var signatures []string
+	if err := json.Unmarshal([]byte(text), &signatures); err == nil {
+		return ParseHumanReadable(signatures)
+	}
+	return ParseHumanReadable(strings.Split(text, "\n"))
+}
+
+func parseSignature(signature string) (ABIElement, error) {
+	keyword, rest := "function", signature
+	if fields := strings.Fields(signature); len(fields) > 0 {
+		first := fields[0]
+		if open := strings.Index(first, "("); open >= 0 {
+			first = first[:open]
+		}
+		switch first {
+		case "function", "event", "error", "constructor", "fallback", "receive":
+			keyword = first
+			rest = strings.TrimSpace(strings.TrimPrefix(signature, first))
+		}
+	}
+
+	open := strings.Index(rest, "(")
+	if open < 0 {
+		return ABIElement{}, fmt.Errorf("missing parameter list")
+	}
+	name := strings.TrimSpace(rest[:open])
+	paramsText, tail, err := splitGroup(rest[open:])
+	if err != nil {
+		return ABIElement{}, err
+	}
+
+	element := ABIElement{Type: keyword, Name: name}
+	switch keyword {
+	case "function", "event", "error":
+		if !identifierPattern.MatchString(name) {
+			return ABIElement{}, fmt.Errorf("invalid name %q", name)
+		}
+	default:
+		if name != "" {
+			return ABIElement{}, fmt.Errorf("%s cannot have a name", keyword)
+		}
+	}
+
+	element.Inputs, err = parseParams(paramsText, keyword == "event")
+	if err != nil {
+		return ABIElement{}, err
+	}
+
+	if err := parseTail(&element, tail); err != nil {
+		return ABIElement{}, err
+	}
+	return element, nil
+}
+
+// parseTail reads the modifiers and returns clause that follow the parameter list.
+func parseTail(element *ABIElement, tail string) error {
+	mutability := ""
+	for tail = strings.TrimSpace(tail); tail != ""; tail = strings.TrimSpace(tail) {
+		if strings.HasPrefix(tail, "returns") {
+			if element.Type != "function" {
+				return fmt.Errorf("%s cannot return values", element.Type)
+			}
+			outputsText, rest, err := splitGroup(strings.TrimSpace(strings.TrimPrefix(tail, "returns")))
+			if err != nil {
+				return fmt.Errorf("invalid returns clause: %w", err)
+			}
+			outputs, err := parseParams(outputsText, false)
+			if err != nil {
+				return err
+			}
+			element.Outputs = outputs
+			tail = rest
+			continue
+		}
+
+		word := strings.Fields(tail)[0]
+		if !modifiers[word] {
+			return fmt.Errorf("unexpected %q", word)
+		}
+		tail = strings.TrimPrefix(tail, word)
+
+		switch word {
+		case "anonymous":
+			if element.Type != "event" {
+				return fmt.Errorf("only events can be anonymous")
+			}
+			element.Anonymous = true
+		case "view", "pure", "payable", "nonpayable":
+			mutability = word
+		case "constant":
+			mutability = string(StateMutabilityView)
+		}
+	}
+
+	switch element.Type {
+	case "function", "constructor", "fallback", "receive":
+		if mutability == "" {
+			mutability = string(StateMutabilityNonPayable)
+		}
+		if element.Type == "receive" {
+			mutability = string(StateMutabilityPayable)
+		}
+		element.StateMutability = mutability
+		element.Payable = mutability == string(StateMutabilityPayable)
+		element.Constant = mutability == string(StateMutabilityView) || mutability == string(StateMutabilityPure)
+	default:
+		if mutability != "" {
+			return fmt.Errorf("%s cannot be %s", element.Type, mutability)
+		}
+	}
+	return nil
+}
+
+// splitGroup splits "(...)rest" into the text inside the balanced parentheses and the rest.
+func splitGroup(text string) (string, string, error) {
+	if !strings.HasPrefix(text, "(") {
+		return "", "", fmt.Errorf("expected '('")
+	}
+	depth := 0
+	for index, char := range text {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return text[1:index], text[index+1:], nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("unbalanced parentheses")
+}
+
+// splitParams splits a parameter list on the commas that are not nested in a tuple.
+func splitParams(text string) []string {
+	params := []string{}
+	depth, start := 0, 0
+	for index, char := range text {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				params = append(params, text[start:index])
+				start = index + 1
+			}
+		}
+	}
+	return append(params, text[start:])
+}
+
+func parseParams(text string, allowIndexed bool) ([]ABIParam, error) {
+	if strings.TrimSpace(text) == "" {
+		return []ABIParam{}, nil
+	}
+
+	params := []ABIParam{}
+	for _, paramText := range splitParams(text) {
+		param, err := parseParam(strings.TrimSpace(paramText), allowIndexed)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+func parseParam(text string, allowIndexed bool) (ABIParam, error) {
+	if text == "" {
+		return ABIParam{}, fmt.Errorf("empty parameter")
+	}
+
+	param := ABIParam{}
+	var rest string
+	if strings.HasPrefix(text, "tuple(") || strings.HasPrefix(text, "(") {
+		groupText := strings.TrimPrefix(text, "tuple")
+		componentsText, tail, err := splitGroup(groupText)
+		if err != nil {
+			return ABIParam{}, fmt.Errorf("invalid tuple %q: %w", text, err)
+		}
+		components, err := parseParams(componentsText, false)
+		if err != nil {
+			return ABIParam{}, err
+		}
+
+		suffixEnd := strings.IndexFunc(tail, func(char rune) bool { return char == ' ' || char == '\t' })
+		if suffixEnd < 0 {
+			suffixEnd = len(tail)
+		}
+		suffix := tail[:suffixEnd]
+		if !arraySuffixes.MatchString(suffix) {
+			return ABIParam{}, fmt.Errorf("invalid tuple suffix %q", suffix)
+		}
+		param.Type = "tuple" + suffix
+		param.Components = components
+		rest = tail[suffixEnd:]
+	} else {
+		fields := strings.Fields(text)
+		typeName, err := normalizeType(fields[0])
+		if err != nil {
+			return ABIParam{}, err
+		}
+		param.Type = typeName
+		rest = strings.Join(fields[1:], " ")
+	}
+
+	for _, word := range strings.Fields(rest) {
+		switch {
+		case word == "indexed":
+			if !allowIndexed {
+				return ABIParam{}, fmt.Errorf("only event parameters can be indexed")
+			}
+			param.Indexed = true
+		case dataLocations[word]:
+			continue
+		case param.Name == "" && identifierPattern.MatchString(word):
+			param.Name = word
+		default:
+			return ABIParam{}, fmt.Errorf("unexpected %q in parameter %q", word, text)
+		}
+	}
+	return param, nil
+}
+
+// normalizeType validates an elementary type, expanding the uint/int/ufixed/fixed aliases.
+func normalizeType(typeName string) (string, error) {
+	base := typeName
+	suffix := ""
+	if bracket := strings.Index(typeName, "["); bracket >= 0 {
+		base, suffix = typeName[:bracket], typeName[bracket:]
+	}
+	if !arraySuffixes.MatchString(suffix) {
+		return "", fmt.Errorf("invalid array type %q", typeName)
+	}
+
+	switch base {
+	case "uint", "int":
+		base += "256"
+	case "ufixed", "fixed":
+		base += "128x18"
+	case "address", "bool", "string", "bytes", "function":
+	default:
+		if !isSizedType(base) {
+			return "", fmt.Errorf("unknown type %q", typeName)
+		}
+	}
+	return base + suffix, nil
+}
+
+var sizedTypePattern = regexp.MustCompile(`^(uint|int)([0-9]+)$|^bytes([0-9]+)$|^(ufixed|fixed)([0-9]+)x([0-9]+)$`)
+
+func isSizedType(base string) bool {
+	match := sizedTypePattern.FindStringSubmatch(base)
+	if match == nil {
+		return false
+	}
+
+	var size int
+	switch {
+	case match[2] != "":
+		_, _ = fmt.Sscanf(match[2], "%d", &size)
+		return size > 0 && size <= 256 && size%8 == 0
+	case match[3] != "":
+		_, _ = fmt.Sscanf(match[3], "%d", &size)
+		return size > 0 && size <= 32
+	default:
+		_, _ = fmt.Sscanf(match[5], "%d", &size)
+		return size > 0 && size <= 256 && size%8 == 0
+	}
+}
+
+// FormatHumanReadable renders every element of the ABI as a human-readable signature.
+func FormatHumanReadable(elements AbiArray) []string {
+	signatures := make([]string, 0, len(elements))
+	for _, element := range elements {
+		signatures = append(signatures, element.HumanReadable())
+	}
+	return signatures
+}
+
+// HumanReadable renders the element as a human-readable signature, e.g.
+// "function balanceOf(address owner) view returns (uint256)".
+func (a ABIElement) HumanReadable() string {
+	var builder strings.Builder
+	builder.WriteString(a.Type)
+	if a.Name != "" {
+		builder.WriteString(" " + a.Name)
+	}
+	builder.WriteString("(" + formatParams(a.Inputs) + ")")
+
+	if a.Type == "event" && a.Anonymous {
+		builder.WriteString(" anonymous")
+	}
+
+	mutability := a.StateMutability
+	if mutability == "" {
+		switch {
+		case a.Payable:
+			mutability = string(StateMutabilityPayable)
+		case a.Constant:
+			mutability = string(StateMutabilityView)
+		}
+	}
+	switch a.Type {
+	case "fallback", "receive":
+		builder.WriteString(" external")
+	}
+	if mutability != "" && mutability != string(StateMutabilityNonPayable) {
+		builder.WriteString(" " + mutability)
+	}
+
+	if len(a.Outputs) > 0 {
+		builder.WriteString(" returns (" + formatParams(a.Outputs) + ")")
+	}
+	return builder.String()
+}
+
+func formatParams(params []ABIParam) string {
+	parts := make([]string, 0, len(params))
+	for _, param := range params {
+		parts = append(parts, formatParam(param))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatParam(param ABIParam) string {
+	typeName := param.Type
+	if strings.HasPrefix(typeName, "tuple") {
+		typeName = "tuple(" + formatParams(param.Components) + ")" + strings.TrimPrefix(typeName, "tuple")
+	}
+	if param.Indexed {
+		typeName += " indexed"
+	}
+	if param.Name != "" {
+		typeName += " " + param.Name
+	}
+	return typeName
+}
@@ -0,0 +1,190 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHumanReadable(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   bool
+		validate  func(t *testing.T, element ABIElement)
+	}{
+		{
+			name:      "function with returns",
+			signature: "function transfer(address to, uint256 amount) returns (bool)",
+			validate: func(t *testing.T, element ABIElement) {
+				assert.Equal(t, "function", element.Type)
+				assert.Equal(t, "transfer", element.Name)
+				assert.Equal(t, []ABIParam{{Name: "to", Type: "address"}, {Name: "amount", Type: "uint256"}}, element.Inputs)
+				assert.Equal(t, []ABIParam{{Type: "bool"}}, element.Outputs)
+				assert.Equal(t, "nonpayable", element.StateMutability)
+			},
+		},
+		{
+			name:      "function without keyword and unnamed params",
+			signature: "balanceOf(address) view returns (uint)",
+			validate: func(t *testing.T, element ABIElement) {
+				assert.Equal(t, "function", element.Type)
+				assert.Equal(t, "balanceOf", element.Name)
+				assert.Equal(t, []ABIParam{{Type: "address"}}, element.Inputs)
+				assert.Equal(t, []ABIParam{{Type: "uint256"}}, element.Outputs)
+				assert.True(t, element.IsReadOnly())
+			},
+		},
+		{
+			name:      "function with modifiers and data locations",
+			signature: "function deposit(string calldata memo, bytes32[2] memory proof) external payable;",
+			validate: func(t *testing.T, element ABIElement) {
+				assert.Equal(t, []ABIParam{{Name: "memo", Type: "string"}, {Name: "proof", Type: "bytes32[2]"}}, element.Inputs)
+				assert.Equal(t, "payable", element.StateMutability)
+				assert.True(t, element.Payable)
+			},
+		},
+		{
+			name:      "event with indexed params",
+			signature: "event Transfer(address indexed from, address indexed to, uint256 value)",
+			validate: func(t *testing.T, element ABIElement) {
+				assert.Equal(t, "event", element.Type)
+				assert.True(t, element.Inputs[0].Indexed)
+				assert.True(t, element.Inputs[1].Indexed)
+				assert.False(t, element.Inputs[2].Indexed)
+				assert.Empty(t, element.StateMutability)
+			},
+		},
+		{
+			name:      "anonymous event",
+			signature: "event Log(bytes data) anonymous",
+			validate: func(t *testing.T, element ABIElement) {
+				assert.True(t, element.Anonymous)
+			},
+		},
+		{
+			name:      "error",
+			signature: "error InsufficientBalance(uint256 available, uint256 required)",
+			validate: func(t *testing.T, element ABIElement) {
+				assert.Equal(t, "error", element.Type)
+				assert.Len(t, element.Inputs, 2)
+			},
+		},
+		{
+			name:      "nested tuples and tuple arrays",
+			signature: "function execute(tuple(address target, (uint256 value, bytes data)[] calls) order, (bool, string)[2] flags) returns (tuple(bool success, bytes result)[] results)",
+			validate: func(t *testing.T, element ABIElement) {
+				order := element.Inputs[0]
+				assert.Equal(t, "tuple", order.Type)
+				assert.Equal(t, "order", order.Name)
+				require.Len(t, order.Components, 2)
+				assert.Equal(t, "tuple[]", order.Components[1].Type)
+				assert.Equal(t, "calls", order.Components[1].Name)
+				assert.Equal(t, []ABIParam{{Name: "value", Type: "uint256"}, {Name: "data", Type: "bytes"}}, order.Components[1].Components)
+
+				assert.Equal(t, "tuple[2]", element.Inputs[1].Type)
+				assert.Equal(t, []ABIParam{{Type: "bool"}, {Type: "string"}}, element.Inputs[1].Components)
+
+				assert.Equal(t, "tuple[]", element.Outputs[0].Type)
+				assert.Equal(t, "results", element.Outputs[0].Name)
+			},
+		},
+		{
+			name:      "constructor",
+			signature: "constructor(string name, string symbol) payable",
+			validate: func(t *testing.T, element ABIElement) {
+				assert.Equal(t, "constructor", element.Type)
+				assert.Empty(t, element.Name)
+				assert.True(t, element.IsPayable())
+			},
+		},
+		{
+			name:      "receive and fallback",
+			signature: "receive() external payable",
+			validate: func(t *testing.T, element ABIElement) {
+				assert.Equal(t, "receive", element.Type)
+				assert.Equal(t, "payable", element.StateMutability)
+			},
+		},
+		{name: "unknown type", signature: "function f(uint7 a)", wantErr: true},
+		{name: "indexed function param", signature: "function f(address indexed a)", wantErr: true},
+		{name: "event with returns", signature: "event E() returns (bool)", wantErr: true},
+		{name: "unbalanced parentheses", signature: "function f(address a", wantErr: true},
+		{name: "unknown modifier", signature: "function f() internal", wantErr: true},
+		{name: "missing parameter list", signature: "function f", wantErr: true},
+		{name: "named constructor", signature: "constructor init()", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseHumanReadable([]string{tt.signature})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result, 1)
+			tt.validate(t, result[0])
+		})
+	}
+}
+
+func TestParseHumanReadableString(t *testing.T) {
+	text := `
+// ERC-20
+function transfer(address to, uint256 amount) returns (bool);
+event Transfer(address indexed from, address indexed to, uint256 value);
+
+error Unauthorized();
+`
+	result, err := ParseHumanReadableString(text)
+	require.NoError(t, err)
+	assert.Len(t, result, 3)
+
+	result, err = ParseHumanReadableString(`["function name() view returns (string)", "function decimals() view returns (uint8)"]`)
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	_, err = ParseHumanReadableString("\n// nothing here\n")
+	assert.Error(t, err)
+
+	_, err = ParseHumanReadableString("function ok()\nfunction bad(")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestParseAbi_HumanReadable(t *testing.T) {
+	result, err := ParseAbi("function totalSupply() view returns (uint256)\nevent Approval(address indexed owner, address indexed spender, uint256 value)")
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	result, err = ParseAbi(`["function totalSupply() view returns (uint256)"]`)
+	require.NoError(t, err)
+	assert.Equal(t, "totalSupply", result[0].Name)
+}
+
+func TestFormatHumanReadable(t *testing.T) {
+	signatures := []string{
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"function balanceOf(address owner) view returns (uint256)",
+		"function deposit() payable",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Log(bytes data) anonymous",
+		"error InsufficientBalance(uint256 available, uint256 required)",
+		"function execute(tuple(address target, tuple(uint256 value, bytes data)[] calls) order) returns (tuple(bool success, bytes result)[] results)",
+		"constructor(string name)",
+		"receive() external payable",
+		"fallback() external",
+	}
+
+	elements, err := ParseHumanReadable(signatures)
+	require.NoError(t, err)
+	assert.Equal(t, signatures, FormatHumanReadable(elements), "Rendering should round-trip")
+}
+
+func TestFormatHumanReadable_FromJSON(t *testing.T) {
+	elements, err := ParseAbi(`[{"type": "function", "name": "getReserves", "inputs": [], "outputs": [{"name": "reserve0", "type": "uint112"}, {"name": "reserve1", "type": "uint112"}], "constant": true}]`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"function getReserves() view returns (uint112 reserve0, uint112 reserve1)"}, FormatHumanReadable(elements))
+}
@@ -0,0 +1,74 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Arguments converts parameters into go-ethereum arguments, used to pack and unpack their values.
+func Arguments(params []ABIParam) (ethabi.Arguments, error) {
+	arguments := make(ethabi.Arguments, 0, len(params))
+	for index, param := range params {
+		abiType, err := newType(param)
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s): %w", ArgumentName(param, index), param.Type, err)
+		}
+		arguments = append(arguments, ethabi.Argument{Name: param.Name, Type: abiType, Indexed: param.Indexed})
+	}
+	return arguments, nil
+}
+
+// Signature returns the canonical signature of a function, event or error, e.g. "transfer(address,uint256)".
+func (a ABIElement) Signature() (string, error) {
+	arguments, err := Arguments(a.Inputs)
+	if err != nil {
+		return "", err
+	}
+
+	types := make([]string, 0, len(arguments))
+	for _, argument := range arguments {
+		types = append(types, argument.Type.String())
+	}
+	return fmt.Sprintf("%s(%s)", a.Name, strings.Join(types, ",")), nil
+}
+
+// Selector returns the 4-byte selector of a function or error, the first bytes of the signature hash.
+func (a ABIElement) Selector() ([4]byte, error) {
+	var selector [4]byte
+	topic, err := a.Topic()
+	if err != nil {
+		return selector, err
+	}
+	copy(selector[:], topic[:4])
+	return selector, nil
+}
+
+// Topic returns the keccak256 hash of the signature, which is the first topic of an event log.
+func (a ABIElement) Topic() (common.Hash, error) {
+	signature, err := a.Signature()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash([]byte(signature)), nil
+}
+
+// EncodeCall returns the calldata of a function call, the selector followed by the ABI-encoded arguments.
+func (a ABIElement) EncodeCall(args ...any) ([]byte, error) {
+	selector, err := a.Selector()
+	if err != nil {
+		return nil, err
+	}
+	arguments, err := Arguments(a.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := arguments.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode arguments of %s: %w", a.Name, err)
+	}
+	return append(selector[:], encoded...), nil
+}
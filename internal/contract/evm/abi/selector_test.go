@@ -0,0 +1,60 @@
+package abi
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureAndSelector(t *testing.T) {
+	tests := []struct {
+		signature string
+		canonical string
+		selector  [4]byte
+	}{
+		{signature: "function transfer(address to, uint256 amount)", canonical: "transfer(address,uint256)", selector: [4]byte{0xa9, 0x05, 0x9c, 0xbb}},
+		{signature: "function balanceOf(address)", canonical: "balanceOf(address)", selector: [4]byte{0x70, 0xa0, 0x82, 0x31}},
+		{signature: "function aggregate3((address target, bool allowFailure, bytes callData)[] calls)", canonical: "aggregate3((address,bool,bytes)[])", selector: [4]byte{0x82, 0xad, 0x56, 0xcb}},
+		{signature: "error InsufficientBalance(uint available, uint required)", canonical: "InsufficientBalance(uint256,uint256)", selector: [4]byte{0xcf, 0x47, 0x91, 0x81}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.canonical, func(t *testing.T) {
+			elements, err := ParseHumanReadable([]string{test.signature})
+			require.NoError(t, err)
+
+			signature, err := elements[0].Signature()
+			require.NoError(t, err)
+			assert.Equal(t, test.canonical, signature)
+
+			selector, err := elements[0].Selector()
+			require.NoError(t, err)
+			assert.Equal(t, test.selector, selector)
+		})
+	}
+}
+
+func TestEventTopic(t *testing.T) {
+	elements, err := ParseHumanReadable([]string{"event Transfer(address indexed from, address indexed to, uint256 value)"})
+	require.NoError(t, err)
+
+	topic, err := elements[0].Topic()
+	require.NoError(t, err)
+	assert.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", topic.Hex())
+}
+
+func TestEncodeCall(t *testing.T) {
+	elements, err := ParseHumanReadable([]string{"function setValue(uint256 newValue)"})
+	require.NoError(t, err)
+
+	args, err := ParseArguments(elements[0].Inputs, []string{"42"})
+	require.NoError(t, err)
+	data, err := elements[0].EncodeCall(args...)
+	require.NoError(t, err)
+	assert.Equal(t, "55241077000000000000000000000000000000000000000000000000000000000000002a", hex.EncodeToString(data))
+
+	_, err = elements[0].EncodeCall("not a number")
+	assert.Error(t, err)
+}
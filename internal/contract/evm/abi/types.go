@@ -95,6 +95,23 @@ func (a *ABIElement) GetStateMutability() StateMutability {
 	return StateMutability(a.StateMutability)
 }
 
+// EffectiveStateMutability returns the state mutability, derived from the legacy constant and payable
+// fields for ABIs that predate stateMutability. Events and errors have none.
+func (a ABIElement) EffectiveStateMutability() StateMutability {
+	switch {
+	case a.Type == "event" || a.Type == "error":
+		return ""
+	case a.StateMutability != "":
+		return StateMutability(a.StateMutability)
+	case a.Payable:
+		return StateMutabilityPayable
+	case a.Constant:
+		return StateMutabilityView
+	default:
+		return StateMutabilityNonPayable
+	}
+}
+
 // IsReadOnly returns true if the function is view or pure.
 func (a *ABIElement) IsReadOnly() bool {
 	sm := a.GetStateMutability()
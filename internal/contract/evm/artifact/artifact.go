@@ -0,0 +1,289 @@
+package artifact
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storagelayout"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Format identifies the tool that produced an artifact.
+type Format string
+
+const (
+	FormatFoundry Format = "foundry"
+	FormatHardhat Format = "hardhat"
+)
+
+// hardhatArtifactFormat is the _format marker of Hardhat artifacts.
+const hardhatArtifactFormat = "hh-sol-artifact-1"
+
+// Artifact is a compiled contract read from a build output file.
+type Artifact struct {
+	Name       string
+	SourceName string
+	ABI        abi.AbiArray
+	Bytecode   string
+	Format     Format
+	Path       string
+	// StorageLayout is set for Foundry artifacts built with extra_output = ["storageLayout"].
+	StorageLayout *storagelayout.Layout
+}
+
+// QualifiedName returns the fully qualified name of the contract, "<source path>:<name>", which tells
+// apart contracts with the same name declared in different files.
+func (a Artifact) QualifiedName() string {
+	if a.SourceName == "" {
+		return a.Name
+	}
+	return a.SourceName + ":" + a.Name
+}
+
+// IsDeployable returns true if the artifact has creation bytecode (i.e. it is not an interface or abstract contract).
+func (a Artifact) IsDeployable() bool {
+	return a.Bytecode != "" && a.Bytecode != "0x"
+}
+
+// Deployment is a contract deployment recorded in a Foundry broadcast file, a hardhat-deploy
+// deployment file or a Hardhat Ignition deployment.
+type Deployment struct {
+	ContractName string
+	// SourceName is the source path of the contract when the deployment records it, which picks the
+	// artifact among several with the same contract name
+	SourceName string
+	Address    string
+	ChainID    uint64
+	// TxHash is empty for Ignition deployments, which only record the deployed addresses
+	TxHash string
+	Path   string
+}
+
+// foundryBytecode is the bytecode object of a Foundry artifact.
+type foundryBytecode struct {
+	Object string `json:"object"`
+}
+
+// rawArtifact holds the fields shared by Foundry and Hardhat artifacts. Bytecode is a string
+// in Hardhat artifacts and an object in Foundry artifacts.
+type rawArtifact struct {
+	Format        string                `json:"_format"`
+	ContractName  string                `json:"contractName"`
+	SourceName    string                `json:"sourceName"`
+	ABI           abi.AbiArray          `json:"abi"`
+	Bytecode      json.RawMessage       `json:"bytecode"`
+	Metadata      json.RawMessage       `json:"metadata"`
+	StorageLayout *storagelayout.Layout `json:"storageLayout"`
+	AST           struct {
+		AbsolutePath string `json:"absolutePath"`
+	} `json:"ast"`
+}
+
+type foundryMetadata struct {
+	Settings struct {
+		CompilationTarget map[string]string `json:"compilationTarget"`
+	} `json:"settings"`
+}
+
+// ParseArtifact parses a Foundry (out/<File>.sol/<Name>.json) or Hardhat (artifacts/**/<Name>.json)
+// artifact. The contract name falls back to the file name when the artifact does not record it.
+func ParseArtifact(data []byte, path string) (*Artifact, error) {
+	var raw rawArtifact
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.WrapContractError(err, errors.ErrCodeInvalidArtifact, fmt.Sprintf("failed to parse artifact %s", path))
+	}
+	if raw.ABI == nil {
+		return nil, errors.NewContractError(errors.ErrCodeInvalidArtifact, fmt.Sprintf("artifact %s has no ABI", path))
+	}
+
+	artifact := &Artifact{
+		Name:          raw.ContractName,
+		SourceName:    raw.SourceName,
+		ABI:           raw.ABI,
+		Path:          path,
+		StorageLayout: raw.StorageLayout,
+	}
+	if artifact.Name == "" {
+		artifact.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	bytecode, format, err := parseBytecode(raw.Bytecode)
+	if err != nil {
+		return nil, errors.WrapContractError(err, errors.ErrCodeInvalidArtifact, fmt.Sprintf("artifact %s has invalid bytecode", path))
+	}
+	if raw.Format == hardhatArtifactFormat {
+		format = FormatHardhat
+	}
+	artifact.Format = format
+	if bytecode != "" {
+		artifact.Bytecode = "0x" + strings.TrimPrefix(bytecode, "0x")
+	}
+
+	if artifact.SourceName == "" {
+		artifact.SourceName = foundrySourceName(raw, artifact.Name)
+	}
+	return artifact, nil
+}
+
+// parseBytecode reads the bytecode as either a hex string (Hardhat) or a {object} (Foundry).
+func parseBytecode(raw json.RawMessage) (string, Format, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", FormatFoundry, nil
+	}
+
+	var hexString string
+	if err := json.Unmarshal(raw, &hexString); err == nil {
+		return hexString, FormatHardhat, nil
+	}
+
+	var object foundryBytecode
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return "", "", err
+	}
+	return object.Object, FormatFoundry, nil
+}
+
+// foundrySourceName finds the source path of a Foundry artifact from its compilation target or AST.
+func foundrySourceName(raw rawArtifact, name string) string {
+	for source, target := range compilationTarget(raw.Metadata) {
+		if target == name {
+			return source
+		}
+	}
+	return raw.AST.AbsolutePath
+}
+
+// compilationTarget returns the source paths and contract names compiled into the solc metadata, which
+// Foundry and hardhat-deploy store either as an object or as a JSON string.
+func compilationTarget(raw json.RawMessage) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var metadata foundryMetadata
+	var metadataString string
+	if err := json.Unmarshal(raw, &metadataString); err == nil {
+		_ = json.Unmarshal([]byte(metadataString), &metadata)
+	} else {
+		_ = json.Unmarshal(raw, &metadata)
+	}
+	return metadata.Settings.CompilationTarget
+}
+
+// broadcastFile is the subset of a Foundry broadcast/<Script>/<chainId>/run-latest.json file used for imports.
+type broadcastFile struct {
+	Chain        uint64 `json:"chain"`
+	Transactions []struct {
+		Hash            string `json:"hash"`
+		TransactionType string `json:"transactionType"`
+		ContractName    string `json:"contractName"`
+		ContractAddress string `json:"contractAddress"`
+	} `json:"transactions"`
+	Receipts []struct {
+		TransactionHash string `json:"transactionHash"`
+		Status          string `json:"status"`
+	} `json:"receipts"`
+}
+
+// ParseBroadcast parses a Foundry broadcast file and returns the contracts it created. Transactions
+// whose receipt reports a failure are skipped. When the file does not record the chain ID, it is
+// taken from the parent directory name.
+func ParseBroadcast(data []byte, path string) ([]Deployment, error) {
+	var broadcast broadcastFile
+	if err := json.Unmarshal(data, &broadcast); err != nil {
+		return nil, errors.WrapContractError(err, errors.ErrCodeInvalidArtifact, fmt.Sprintf("failed to parse broadcast file %s", path))
+	}
+
+	chainID := broadcast.Chain
+	if chainID == 0 {
+		if _, err := fmt.Sscanf(filepath.Base(filepath.Dir(path)), "%d", &chainID); err != nil {
+			return nil, errors.NewContractError(errors.ErrCodeInvalidArtifact, fmt.Sprintf("broadcast file %s has no chain ID", path))
+		}
+	}
+
+	failed := map[string]bool{}
+	for _, receipt := range broadcast.Receipts {
+		if receipt.Status == "0x0" || receipt.Status == "0" {
+			failed[strings.ToLower(receipt.TransactionHash)] = true
+		}
+	}
+
+	deployments := []Deployment{}
+	for _, transaction := range broadcast.Transactions {
+		if transaction.TransactionType != "CREATE" && transaction.TransactionType != "CREATE2" {
+			continue
+		}
+		if transaction.ContractAddress == "" || failed[strings.ToLower(transaction.Hash)] {
+			continue
+		}
+		deployments = append(deployments, Deployment{
+			ContractName: transaction.ContractName,
+			Address:      transaction.ContractAddress,
+			ChainID:      chainID,
+			TxHash:       transaction.Hash,
+			Path:         path,
+		})
+	}
+	return deployments, nil
+}
+
+// hardhatDeployment is the subset of a hardhat-deploy deployments/<network>/<Name>.json file used for imports.
+type hardhatDeployment struct {
+	Address         string          `json:"address"`
+	TransactionHash string          `json:"transactionHash"`
+	Metadata        json.RawMessage `json:"metadata"`
+}
+
+// ParseHardhatDeployment parses a hardhat-deploy deployment file of the network with the chain ID. The
+// contract is taken from the compilation target of its metadata, falling back to the file name, which
+// is the deployment name.
+func ParseHardhatDeployment(data []byte, path string, chainID uint64) (Deployment, error) {
+	var raw hardhatDeployment
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Deployment{}, errors.WrapContractError(err, errors.ErrCodeInvalidArtifact, fmt.Sprintf("failed to parse deployment %s", path))
+	}
+	if raw.Address == "" {
+		return Deployment{}, errors.NewContractError(errors.ErrCodeInvalidArtifact, fmt.Sprintf("deployment %s has no address", path))
+	}
+
+	deployment := Deployment{
+		ContractName: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Address:      raw.Address,
+		ChainID:      chainID,
+		TxHash:       raw.TransactionHash,
+		Path:         path,
+	}
+	if target := compilationTarget(raw.Metadata); len(target) == 1 {
+		for source, name := range target {
+			deployment.SourceName, deployment.ContractName = source, name
+		}
+	}
+	return deployment, nil
+}
+
+// ParseIgnitionDeployments parses the deployed_addresses.json file of a Hardhat Ignition deployment,
+// which maps future IDs like "TokenModule#Token" to addresses. The chain ID is taken from the
+// chain-<id> name of the deployment directory.
+func ParseIgnitionDeployments(data []byte, path string) ([]Deployment, error) {
+	var addresses map[string]string
+	if err := json.Unmarshal(data, &addresses); err != nil {
+		return nil, errors.WrapContractError(err, errors.ErrCodeInvalidArtifact, fmt.Sprintf("failed to parse Ignition deployment %s", path))
+	}
+	var chainID uint64
+	if _, err := fmt.Sscanf(filepath.Base(filepath.Dir(path)), "chain-%d", &chainID); err != nil {
+		return nil, errors.NewContractError(errors.ErrCodeInvalidArtifact, fmt.Sprintf("Ignition deployment %s has no chain ID", path))
+	}
+
+	deployments := make([]Deployment, 0, len(addresses))
+	for futureID, address := range addresses {
+		name := futureID[strings.LastIndex(futureID, "#")+1:]
+		deployments = append(deployments, Deployment{ContractName: name, Address: address, ChainID: chainID, Path: path})
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].ContractName < deployments[j].ContractName
+	})
+	return deployments, nil
+}
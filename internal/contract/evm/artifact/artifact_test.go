@@ -0,0 +1,346 @@
+package artifact
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/stretchr/testify/suite"
+)
+
+const foundryCounter = `{
+  "abi": [{"type": "function", "name": "increment", "inputs": [], "outputs": [], "stateMutability": "nonpayable"}],
+  "bytecode": {"object": "0x6080604052", "sourceMap": "", "linkReferences": {}},
+  "deployedBytecode": {"object": "0x6080", "sourceMap": "", "linkReferences": {}},
+  "metadata": {"settings": {"compilationTarget": {"src/Counter.sol": "Counter"}}},
+  "storageLayout": {
+    "storage": [{"astId": 3, "contract": "src/Counter.sol:Counter", "label": "number", "offset": 0, "slot": "0", "type": "t_uint256"}],
+    "types": {"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"}}
+  },
+  "ast": {"absolutePath": "src/Counter.sol"}
+}`
+
+const foundryInterface = `{
+  "abi": [{"type": "function", "name": "count", "inputs": [], "outputs": [{"name": "", "type": "uint256"}], "stateMutability": "view"}],
+  "bytecode": {"object": "0x"},
+  "ast": {"absolutePath": "src/ICounter.sol"}
+}`
+
+const foundryTest = `{
+  "abi": [],
+  "bytecode": {"object": "0x6080"},
+  "ast": {"absolutePath": "test/Counter.t.sol"}
+}`
+
+const hardhatToken = `{
+  "_format": "hh-sol-artifact-1",
+  "contractName": "Token",
+  "sourceName": "contracts/Token.sol",
+  "abi": [{"type": "constructor", "inputs": [{"name": "supply", "type": "uint256"}], "stateMutability": "nonpayable"}],
+  "bytecode": "0x60806040",
+  "deployedBytecode": "0x6080",
+  "linkReferences": {},
+  "deployedLinkReferences": {}
+}`
+
+const hardhatDependency = `{
+  "_format": "hh-sol-artifact-1",
+  "contractName": "ERC20",
+  "sourceName": "@openzeppelin/contracts/token/ERC20/ERC20.sol",
+  "abi": [],
+  "bytecode": "0x6080"
+}`
+
+const broadcast = `{
+  "transactions": [
+    {"hash": "0xaa", "transactionType": "CREATE", "contractName": "Counter", "contractAddress": "0x5fbdb2315678afecb367f032d93f642f64180aa3"},
+    {"hash": "0xbb", "transactionType": "CALL", "contractName": "Counter", "contractAddress": "0x5fbdb2315678afecb367f032d93f642f64180aa3"},
+    {"hash": "0xcc", "transactionType": "CREATE", "contractName": "Token", "contractAddress": "0xe7f1725e7734ce288f8367e1bb143e90bb3f0512"}
+  ],
+  "receipts": [
+    {"transactionHash": "0xaa", "status": "0x1"},
+    {"transactionHash": "0xcc", "status": "0x0"}
+  ],
+  "chain": 31337
+}`
+
+const hardhatCounterV2 = `{
+  "_format": "hh-sol-artifact-1",
+  "contractName": "Counter",
+  "sourceName": "contracts/v2/Counter.sol",
+  "abi": [{"type": "function", "name": "decrement", "inputs": [], "outputs": [], "stateMutability": "nonpayable"}],
+  "bytecode": "0x60806041"
+}`
+
+const hardhatDeploymentFile = `{
+  "address": "0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0",
+  "abi": [],
+  "transactionHash": "0xdd",
+  "metadata": "{\"settings\":{\"compilationTarget\":{\"contracts/Token.sol\":\"Token\"}}}"
+}`
+
+const ignitionAddresses = `{
+  "TokenModule#Token": "0xCf7Ed3AccA5a467e9e704C703E8D87F634fB0Fc9",
+  "TokenModule#Counter": "0xDc64a140Aa3E981100a9becA4E685f962f0cF6C9"
+}`
+
+type ArtifactTestSuite struct {
+	suite.Suite
+	projectDir string
+}
+
+func TestArtifactTestSuite(t *testing.T) {
+	suite.Run(t, new(ArtifactTestSuite))
+}
+
+func (s *ArtifactTestSuite) SetupTest() {
+	s.projectDir = s.T().TempDir()
+	s.writeFile("out/Counter.sol/Counter.json", foundryCounter)
+	s.writeFile("out/ICounter.sol/ICounter.json", foundryInterface)
+	s.writeFile("out/Counter.t.sol/CounterTest.json", foundryTest)
+	s.writeFile("out/build-info/abc.json", `{"id": "abc"}`)
+	s.writeFile("artifacts/contracts/Token.sol/Token.json", hardhatToken)
+	s.writeFile("artifacts/contracts/Token.sol/Token.dbg.json", `{"_format": "hh-sol-dbg-1"}`)
+	s.writeFile("artifacts/@openzeppelin/contracts/token/ERC20/ERC20.sol/ERC20.json", hardhatDependency)
+	s.writeFile("broadcast/Deploy.s.sol/31337/run-latest.json", broadcast)
+	s.writeFile("broadcast/Deploy.s.sol/31337/run-1700000000.json", broadcast)
+}
+
+func (s *ArtifactTestSuite) writeFile(relative, content string) {
+	path := filepath.Join(s.projectDir, filepath.FromSlash(relative))
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+}
+
+func (s *ArtifactTestSuite) TestParseFoundryArtifact() {
+	artifact, err := ParseArtifact([]byte(foundryCounter), "out/Counter.sol/Counter.json")
+	s.Require().NoError(err)
+	s.Equal("Counter", artifact.Name)
+	s.Equal("src/Counter.sol", artifact.SourceName)
+	s.Equal(FormatFoundry, artifact.Format)
+	s.Equal("0x6080604052", artifact.Bytecode)
+	s.Len(artifact.ABI, 1)
+	s.True(artifact.IsDeployable())
+	s.Require().NotNil(artifact.StorageLayout)
+	s.Equal("number", artifact.StorageLayout.Storage[0].Label)
+
+	iface, err := ParseArtifact([]byte(foundryInterface), "out/ICounter.sol/ICounter.json")
+	s.Require().NoError(err)
+	s.False(iface.IsDeployable())
+}
+
+func (s *ArtifactTestSuite) TestParseHardhatArtifact() {
+	artifact, err := ParseArtifact([]byte(hardhatToken), "artifacts/contracts/Token.sol/Token.json")
+	s.Require().NoError(err)
+	s.Equal("Token", artifact.Name)
+	s.Equal("contracts/Token.sol", artifact.SourceName)
+	s.Equal(FormatHardhat, artifact.Format)
+	s.Equal("0x60806040", artifact.Bytecode)
+}
+
+func (s *ArtifactTestSuite) TestParseInvalidArtifact() {
+	_, err := ParseArtifact([]byte(`{"id": "abc"}`), "build-info/abc.json")
+	s.Error(err)
+
+	_, err = ParseArtifact([]byte(`not json`), "bad.json")
+	s.Error(err)
+}
+
+func (s *ArtifactTestSuite) TestParseBroadcast() {
+	deployments, err := ParseBroadcast([]byte(broadcast), "broadcast/Deploy.s.sol/31337/run-latest.json")
+	s.Require().NoError(err)
+	s.Require().Len(deployments, 1, "Should skip calls and reverted deployments")
+	s.Equal("Counter", deployments[0].ContractName)
+	s.Equal(uint64(31337), deployments[0].ChainID)
+	s.Equal("0xaa", deployments[0].TxHash)
+
+	deployments, err = ParseBroadcast([]byte(`{"transactions": [{"transactionType": "CREATE", "contractName": "A", "contractAddress": "0x01"}]}`), "broadcast/Deploy.s.sol/11155111/run-latest.json")
+	s.Require().NoError(err)
+	s.Equal(uint64(11155111), deployments[0].ChainID, "Should take the chain ID from the directory name")
+}
+
+func (s *ArtifactTestSuite) TestParseHardhatDeployment() {
+	deployment, err := ParseHardhatDeployment([]byte(hardhatDeploymentFile), "deployments/localhost/MyToken.json", 31337)
+	s.Require().NoError(err)
+	s.Equal("Token", deployment.ContractName, "Should take the contract from the metadata")
+	s.Equal("contracts/Token.sol", deployment.SourceName)
+	s.Equal("0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0", deployment.Address)
+	s.Equal(uint64(31337), deployment.ChainID)
+	s.Equal("0xdd", deployment.TxHash)
+
+	deployment, err = ParseHardhatDeployment([]byte(`{"address": "0x01"}`), "deployments/localhost/MyToken.json", 31337)
+	s.Require().NoError(err)
+	s.Equal("MyToken", deployment.ContractName, "Should fall back to the deployment name")
+
+	_, err = ParseHardhatDeployment([]byte(`{"abi": []}`), "deployments/localhost/MyToken.json", 31337)
+	s.Error(err)
+}
+
+func (s *ArtifactTestSuite) TestParseIgnitionDeployments() {
+	deployments, err := ParseIgnitionDeployments([]byte(ignitionAddresses), "ignition/deployments/chain-11155111/deployed_addresses.json")
+	s.Require().NoError(err)
+	s.Require().Len(deployments, 2)
+	s.Equal("Counter", deployments[0].ContractName)
+	s.Equal("0xDc64a140Aa3E981100a9becA4E685f962f0cF6C9", deployments[0].Address)
+	s.Equal(uint64(11155111), deployments[0].ChainID)
+	s.Equal("Token", deployments[1].ContractName)
+
+	_, err = ParseIgnitionDeployments([]byte(ignitionAddresses), "ignition/deployments/local/deployed_addresses.json")
+	s.Error(err, "Should require the chain ID in the directory name")
+}
+
+func (s *ArtifactTestSuite) TestScanHardhatDeployments() {
+	s.writeFile("deployments/localhost/.chainId", "31337")
+	s.writeFile("deployments/localhost/.migrations.json", `{}`)
+	s.writeFile("deployments/localhost/MyToken.json", hardhatDeploymentFile)
+	s.writeFile("deployments/localhost/solcInputs/abc.json", `{"language": "Solidity"}`)
+	s.writeFile("deployments/unknown/MyToken.json", hardhatDeploymentFile)
+	s.writeFile("ignition/deployments/chain-11155111/deployed_addresses.json", ignitionAddresses)
+	s.writeFile("ignition/deployments/chain-11155111/artifacts/TokenModule#Token.json", hardhatToken)
+
+	project, err := ScanProject(s.projectDir, ScanOptions{})
+	s.Require().NoError(err)
+
+	deployments := []string{}
+	for _, deployment := range project.Deployments {
+		deployments = append(deployments, fmt.Sprintf("%s@%d", deployment.ContractName, deployment.ChainID))
+	}
+	s.Equal([]string{"Counter@31337", "Token@31337", "Counter@11155111", "Token@11155111"}, deployments)
+	s.Equal([]string{filepath.Join(s.projectDir, "deployments", "unknown", "MyToken.json")}, project.Skipped, "Should skip networks without a chain ID")
+}
+
+func (s *ArtifactTestSuite) TestScanProject() {
+	project, err := ScanProject(s.projectDir, ScanOptions{})
+	s.Require().NoError(err)
+
+	names := []string{}
+	for _, artifact := range project.Artifacts {
+		names = append(names, artifact.Name)
+	}
+	s.Equal([]string{"Counter", "Token"}, names, "Should skip interfaces, tests, dependencies and debug files")
+	s.Len(project.Deployments, 1, "Should only read run-latest.json")
+
+	project, err = ScanProject(s.projectDir, ScanOptions{ExcludedSources: []string{}, IncludeNonDeployable: true})
+	s.Require().NoError(err)
+	s.Len(project.Artifacts, 5)
+}
+
+func (s *ArtifactTestSuite) TestScanMissingDirectory() {
+	_, err := ScanProject(filepath.Join(s.projectDir, "missing"), ScanOptions{})
+	s.Error(err)
+}
+
+func (s *ArtifactTestSuite) TestImport() {
+	storage, err := sql.NewSQLiteDB(filepath.Join(s.T().TempDir(), "test.db"))
+	s.Require().NoError(err)
+
+	anvilID, err := storage.CreateEndpoint(models.EVMEndpoint{Name: "Anvil", Url: "http://localhost:8545", ChainId: "31337"})
+	s.Require().NoError(err)
+	sepoliaID, err := storage.CreateEndpoint(models.EVMEndpoint{Name: "Sepolia", Url: "https://sepolia", ChainId: "0xaa36a7"})
+	s.Require().NoError(err)
+
+	project, err := ScanProject(s.projectDir, ScanOptions{})
+	s.Require().NoError(err)
+	project.Deployments = append(project.Deployments,
+		Deployment{ContractName: "Token", Address: "0xe7f1725e7734ce288f8367e1bb143e90bb3f0512", ChainID: 11155111},
+		Deployment{ContractName: "Counter", Address: "0x0000000000000000000000000000000000000001", ChainID: 1},
+	)
+
+	result, err := Import(storage, project, anvilID)
+	s.Require().NoError(err)
+	s.Len(result.ABIs, 2)
+	s.Require().Len(result.Skipped, 1)
+	s.Contains(result.Skipped[0], "no endpoint configured for chain 1")
+
+	contracts, err := storage.ListContracts(1, 10)
+	s.Require().NoError(err)
+	s.Require().Len(contracts.Items, 3)
+
+	byKey := map[string]models.EVMContract{}
+	for _, contract := range contracts.Items {
+		byKey[contract.Name+"/"+string(contract.Status)] = contract
+	}
+
+	counter := byKey["Counter/deployed"]
+	s.Equal("0x5fbdb2315678afecb367f032d93f642f64180aa3", counter.Address)
+	s.Equal(anvilID, counter.EndpointId)
+	s.Require().NotNil(counter.AbiId)
+	s.Require().NotNil(counter.StorageLayout.Layout, "Storage layout should be imported from the artifact")
+	s.Equal("t_uint256", counter.StorageLayout.Storage[0].Type)
+
+	sepoliaToken := byKey["Token/deployed"]
+	s.Equal(sepoliaID, sepoliaToken.EndpointId)
+
+	pendingToken := byKey["Token/pending"]
+	s.Equal(anvilID, pendingToken.EndpointId)
+	s.True(pendingToken.IsDeployable())
+	s.Require().NotNil(pendingToken.AbiId)
+
+	// Importing again reuses the ABIs and skips existing contracts
+	result, err = Import(storage, project, anvilID)
+	s.Require().NoError(err)
+	s.Len(result.ABIs, 2)
+	s.Empty(result.Contracts)
+
+	abis, err := storage.ListABIs(1, 10)
+	s.Require().NoError(err)
+	s.Len(abis.Items, 2)
+
+	// A different ABI with the same name is not overwritten
+	project.Artifacts[0].ABI = project.Artifacts[0].ABI[:0]
+	result, err = Import(storage, project, 0)
+	s.Require().NoError(err)
+	s.Len(result.ABIs, 1)
+	s.Contains(result.Skipped[0], "a different ABI named Counter is already stored")
+
+	stored, err := storage.GetABIByID(*counter.AbiId)
+	s.Require().NoError(err)
+	s.Len(stored.Abi.AbiArray, 1)
+}
+
+// TestSameNameInDifferentFiles tests that contracts with the same name declared in different files are
+// all imported under their qualified names.
+func (s *ArtifactTestSuite) TestSameNameInDifferentFiles() {
+	s.writeFile("artifacts/contracts/v2/Counter.sol/Counter.json", hardhatCounterV2)
+
+	project, err := ScanProject(s.projectDir, ScanOptions{})
+	s.Require().NoError(err)
+
+	names := []string{}
+	for _, artifact := range project.Artifacts {
+		names = append(names, project.StoredName(artifact))
+	}
+	s.Equal([]string{"contracts/v2/Counter.sol:Counter", "src/Counter.sol:Counter", "Token"}, names)
+	s.Equal([]string{"Counter is declared in contracts/v2/Counter.sol, src/Counter.sol"}, project.Collisions)
+
+	_, ok := project.Artifact("", "Counter")
+	s.False(ok, "An ambiguous name should not match")
+	artifact, ok := project.Artifact("src/Counter.sol", "Counter")
+	s.Require().True(ok)
+	s.Equal("0x6080604052", artifact.Bytecode)
+
+	storage, err := sql.NewSQLiteDB(filepath.Join(s.T().TempDir(), "test.db"))
+	s.Require().NoError(err)
+	anvilID, err := storage.CreateEndpoint(models.EVMEndpoint{Name: "Anvil", Url: "http://localhost:8545", ChainId: "31337"})
+	s.Require().NoError(err)
+
+	result, err := Import(storage, project, anvilID)
+	s.Require().NoError(err)
+	s.Len(result.ABIs, 3)
+	s.Require().Len(result.Skipped, 1)
+	s.Contains(result.Skipped[0], "several contracts are named Counter")
+
+	contracts := []string{}
+	for _, contract := range result.Contracts {
+		contracts = append(contracts, fmt.Sprintf("%s/%s/%t", contract.Name, contract.Status, contract.AbiId != nil))
+	}
+	s.Equal([]string{
+		"Counter/deployed/false",
+		"contracts/v2/Counter.sol:Counter/pending/true",
+		"src/Counter.sol:Counter/pending/true",
+		"Token/pending/true",
+	}, contracts)
+}
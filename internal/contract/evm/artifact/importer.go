@@ -0,0 +1,174 @@
+package artifact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+)
+
+// maxEndpoints is the number of endpoints considered when matching broadcast chain IDs.
+const maxEndpoints = 1000
+
+// ImportResult summarizes an import.
+type ImportResult struct {
+	ABIs      []models.EvmAbi
+	Contracts []models.EVMContract
+	// Skipped describes artifacts and deployments that were not imported, and why.
+	Skipped []string
+}
+
+// Import stores the project's artifacts and deployments. Every artifact's ABI is created under the
+// stored name of the artifact, see Project.StoredName; an ABI with the same name is reused if it has
+// the same content and is never overwritten. Deployments are registered as deployed contracts on the
+// endpoint whose chain ID matches. When endpointID is non-zero, every deployable artifact that was not
+// deployed to that endpoint is also added there as a pending contract.
+func Import(storage sql.Storage, project *Project, endpointID uint) (*ImportResult, error) {
+	endpoints, err := storage.ListEndpoints(1, maxEndpoints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+
+	result := &ImportResult{}
+	abiIDs := map[string]uint{}
+	for _, artifact := range project.Artifacts {
+		name := project.StoredName(artifact)
+		abi, err := saveABI(storage, name, artifact.ABI)
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("ABI %s: %v", name, err))
+			continue
+		}
+		abiIDs[artifact.QualifiedName()] = abi.ID
+		result.ABIs = append(result.ABIs, abi)
+	}
+
+	deployed := map[string]bool{}
+	for _, deployment := range project.Deployments {
+		endpoint, ok := endpointForChain(endpoints.Items, deployment.ChainID)
+		if !ok {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s at %s: no endpoint configured for chain %d", deployment.ContractName, deployment.Address, deployment.ChainID))
+			continue
+		}
+
+		contract := models.EVMContract{
+			Name:       deployment.ContractName,
+			Address:    deployment.Address,
+			Status:     models.DeploymentStatusDeployed,
+			EndpointId: endpoint.ID,
+		}
+		artifact, ok := project.Artifact(deployment.SourceName, deployment.ContractName)
+		if !ok {
+			if deployment.SourceName == "" && project.hasArtifact(deployment.ContractName) {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("ABI of %s at %s: several contracts are named %s, the deployment doesn't tell which one", deployment.ContractName, deployment.Address, deployment.ContractName))
+			}
+			result.addContract(storage, contract, 0)
+			continue
+		}
+
+		contract.Name = project.StoredName(*artifact)
+		deployed[contractKey(contract.Name, endpoint.ID)] = true
+		if artifact.IsDeployable() {
+			bytecode := artifact.Bytecode
+			contract.Bytecode = &bytecode
+			contract.StorageLayout = models.StorageLayoutType{Layout: artifact.StorageLayout}
+		}
+		result.addContract(storage, contract, abiIDs[artifact.QualifiedName()])
+	}
+
+	if endpointID == 0 {
+		return result, nil
+	}
+	for _, artifact := range project.Artifacts {
+		name := project.StoredName(artifact)
+		if !artifact.IsDeployable() || deployed[contractKey(name, endpointID)] {
+			continue
+		}
+		bytecode := artifact.Bytecode
+		result.addContract(storage, models.EVMContract{
+			Name:          name,
+			Status:        models.DeploymentStatusPending,
+			Bytecode:      &bytecode,
+			StorageLayout: models.StorageLayoutType{Layout: artifact.StorageLayout},
+			EndpointId:    endpointID,
+		}, abiIDs[artifact.QualifiedName()])
+	}
+	return result, nil
+}
+
+// addContract stores the contract, linked to the ABI with the ID unless it is zero.
+func (r *ImportResult) addContract(storage sql.Storage, contract models.EVMContract, abiID uint) {
+	if abiID != 0 {
+		contract.AbiId = &abiID
+	}
+
+	id, err := storage.CreateContract(contract)
+	if err != nil {
+		label := contract.Name
+		if contract.Address != "" {
+			label += " at " + contract.Address
+		}
+		r.Skipped = append(r.Skipped, fmt.Sprintf("%s: %v", label, err))
+		return
+	}
+	contract.ID = id
+	r.Contracts = append(r.Contracts, contract)
+}
+
+// saveABI creates the ABI with the name. An existing ABI with the same name is reused when its content
+// is the same; one with a different content belongs to another contract or an older build and is
+// left as it is.
+func saveABI(storage sql.Storage, name string, elements abi.AbiArray) (models.EvmAbi, error) {
+	stored := models.EvmAbi{Name: name, Abi: models.AbiArrayType{AbiArray: elements}}
+
+	existing, err := storage.SearchABIs(name)
+	if err != nil {
+		return stored, fmt.Errorf("failed to search ABIs: %w", err)
+	}
+	for _, item := range existing.Items {
+		if item.Name != name {
+			continue
+		}
+		if !sameABI(item.Abi.AbiArray, elements) {
+			return stored, fmt.Errorf("a different ABI named %s is already stored, it is not overwritten", name)
+		}
+		return item, nil
+	}
+
+	id, err := storage.CreateABI(stored)
+	if err != nil {
+		return stored, fmt.Errorf("failed to create ABI: %w", err)
+	}
+	stored.ID = id
+	return stored, nil
+}
+
+// sameABI reports whether two ABIs have the same elements.
+func sameABI(a, b abi.AbiArray) bool {
+	left, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	right, err := json.Marshal(b)
+	return err == nil && bytes.Equal(left, right)
+}
+
+// endpointForChain returns the endpoint whose chain ID matches. Endpoint chain IDs may be stored
+// in decimal or 0x-prefixed hex.
+func endpointForChain(endpoints []models.EVMEndpoint, chainID uint64) (models.EVMEndpoint, bool) {
+	for _, endpoint := range endpoints {
+		value, ok := new(big.Int).SetString(strings.TrimSpace(endpoint.ChainId), 0)
+		if ok && value.IsUint64() && value.Uint64() == chainID {
+			return endpoint, true
+		}
+	}
+	return models.EVMEndpoint{}, false
+}
+
+func contractKey(name string, endpointID uint) string {
+	return fmt.Sprintf("%s@%d", name, endpointID)
+}
@@ -0,0 +1,297 @@
+package artifact
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// DefaultExcludedSources are source path prefixes whose artifacts are skipped by default:
+// dependencies, tests and scripts rather than the project's own contracts.
+var DefaultExcludedSources = []string{"lib/", "test/", "script/", "node_modules/", "@", "hardhat/", "forge-std/"}
+
+// skippedDirs are directories never descended into while scanning.
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	".git":         true,
+	"cache":        true,
+	"build-info":   true,
+	"lib":          true,
+	"solcInputs":   true,
+}
+
+// hardhatChainIDFile is the file hardhat-deploy writes the chain ID of a network into, next to its
+// deployments.
+const hardhatChainIDFile = ".chainId"
+
+// ScanOptions configures a project scan.
+type ScanOptions struct {
+	// ExcludedSources are source path prefixes to skip; nil uses DefaultExcludedSources.
+	ExcludedSources []string
+	// IncludeNonDeployable keeps interfaces and abstract contracts, which have an ABI but no bytecode.
+	IncludeNonDeployable bool
+}
+
+// Project holds the artifacts and deployments found in a Foundry or Hardhat project.
+type Project struct {
+	Dir         string
+	Artifacts   []Artifact
+	Deployments []Deployment
+	// Skipped lists files that looked like artifacts but could not be parsed.
+	Skipped []string
+	// Collisions describes the contract names declared in several source files. Their artifacts are
+	// all kept and stored under their qualified names.
+	Collisions []string
+}
+
+// Artifact returns the artifact of a contract. An empty sourceName matches any source, as long as only
+// one artifact has the name.
+func (p *Project) Artifact(sourceName, name string) (*Artifact, bool) {
+	var found *Artifact
+	for index := range p.Artifacts {
+		candidate := &p.Artifacts[index]
+		if candidate.Name != name || (sourceName != "" && candidate.SourceName != sourceName) {
+			continue
+		}
+		if found != nil {
+			return nil, false
+		}
+		found = candidate
+	}
+	return found, found != nil
+}
+
+// hasArtifact reports whether any artifact has the contract name.
+func (p *Project) hasArtifact(name string) bool {
+	for _, artifact := range p.Artifacts {
+		if artifact.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// StoredName returns the name the artifact is stored under: its contract name, or its qualified name
+// when another artifact has the same contract name.
+func (p *Project) StoredName(artifact Artifact) string {
+	for _, other := range p.Artifacts {
+		if other.Name == artifact.Name && other.SourceName != artifact.SourceName {
+			return artifact.QualifiedName()
+		}
+	}
+	return artifact.Name
+}
+
+// ScanProject scans a project directory for Foundry (out/) and Hardhat (artifacts/) artifacts, and for
+// the deployments of Foundry broadcasts (broadcast/**/run-latest.json), hardhat-deploy (deployments/)
+// and Hardhat Ignition (ignition/deployments/). Artifacts are identified by their source path and
+// contract name.
+func ScanProject(dir string, options ScanOptions) (*Project, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, errors.WrapContractError(err, errors.ErrCodeProjectScanFailed, fmt.Sprintf("failed to read project directory %s", dir))
+	}
+	if !info.IsDir() {
+		return nil, errors.NewContractError(errors.ErrCodeProjectScanFailed, fmt.Sprintf("%s is not a directory", dir))
+	}
+
+	excluded := options.ExcludedSources
+	if excluded == nil {
+		excluded = DefaultExcludedSources
+	}
+
+	project := &Project{Dir: dir}
+	seen := map[string]bool{}
+	for _, outputDir := range []string{"out", "artifacts"} {
+		if err := project.scanArtifacts(filepath.Join(dir, outputDir), excluded, options.IncludeNonDeployable, seen); err != nil {
+			return nil, err
+		}
+	}
+	if err := project.scanBroadcasts(filepath.Join(dir, "broadcast")); err != nil {
+		return nil, err
+	}
+	if err := project.scanHardhatDeployments(filepath.Join(dir, "deployments")); err != nil {
+		return nil, err
+	}
+	if err := project.scanIgnitionDeployments(filepath.Join(dir, "ignition", "deployments")); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(project.Artifacts, func(i, j int) bool {
+		left, right := project.Artifacts[i], project.Artifacts[j]
+		if left.Name != right.Name {
+			return left.Name < right.Name
+		}
+		return left.SourceName < right.SourceName
+	})
+	project.findCollisions()
+	return project, nil
+}
+
+func (p *Project) scanArtifacts(root string, excluded []string, includeNonDeployable bool, seen map[string]bool) error {
+	return walk(root, func(path string) error {
+		if filepath.Ext(path) != ".json" || strings.HasSuffix(path, ".dbg.json") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.WrapContractError(err, errors.ErrCodeProjectScanFailed, fmt.Sprintf("failed to read %s", path))
+		}
+		artifact, err := ParseArtifact(data, path)
+		if err != nil {
+			p.Skipped = append(p.Skipped, path)
+			return nil
+		}
+
+		if isExcluded(artifact.SourceName, excluded) || (!includeNonDeployable && !artifact.IsDeployable()) {
+			return nil
+		}
+		// The same contract may be produced for several compiler profiles; keep the first
+		if seen[artifact.QualifiedName()] {
+			return nil
+		}
+		seen[artifact.QualifiedName()] = true
+		p.Artifacts = append(p.Artifacts, *artifact)
+		return nil
+	})
+}
+
+func (p *Project) scanBroadcasts(root string) error {
+	return walk(root, func(path string) error {
+		if filepath.Base(path) != "run-latest.json" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.WrapContractError(err, errors.ErrCodeProjectScanFailed, fmt.Sprintf("failed to read %s", path))
+		}
+		deployments, err := ParseBroadcast(data, path)
+		if err != nil {
+			p.Skipped = append(p.Skipped, path)
+			return nil
+		}
+		p.Deployments = append(p.Deployments, deployments...)
+		return nil
+	})
+}
+
+// scanHardhatDeployments reads the hardhat-deploy deployments/<network>/<Name>.json files of the networks
+// that record their chain ID.
+func (p *Project) scanHardhatDeployments(root string) error {
+	chainIDs := map[string]uint64{}
+	return walk(root, func(path string) error {
+		// Hidden files like .migrations.json are bookkeeping of hardhat-deploy
+		if filepath.Ext(path) != ".json" || strings.HasPrefix(filepath.Base(path), ".") {
+			return nil
+		}
+
+		networkDir := filepath.Dir(path)
+		chainID, ok := chainIDs[networkDir]
+		if !ok {
+			data, err := os.ReadFile(filepath.Join(networkDir, hardhatChainIDFile))
+			if err == nil {
+				_, err = fmt.Sscanf(strings.TrimSpace(string(data)), "%d", &chainID)
+			}
+			if err != nil {
+				chainID = 0
+			}
+			chainIDs[networkDir] = chainID
+		}
+		if chainID == 0 {
+			p.Skipped = append(p.Skipped, path)
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.WrapContractError(err, errors.ErrCodeProjectScanFailed, fmt.Sprintf("failed to read %s", path))
+		}
+		deployment, err := ParseHardhatDeployment(data, path, chainID)
+		if err != nil {
+			p.Skipped = append(p.Skipped, path)
+			return nil
+		}
+		p.Deployments = append(p.Deployments, deployment)
+		return nil
+	})
+}
+
+// scanIgnitionDeployments reads the ignition/deployments/chain-<id>/deployed_addresses.json files.
+func (p *Project) scanIgnitionDeployments(root string) error {
+	return walk(root, func(path string) error {
+		if filepath.Base(path) != "deployed_addresses.json" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.WrapContractError(err, errors.ErrCodeProjectScanFailed, fmt.Sprintf("failed to read %s", path))
+		}
+		deployments, err := ParseIgnitionDeployments(data, path)
+		if err != nil {
+			p.Skipped = append(p.Skipped, path)
+			return nil
+		}
+		p.Deployments = append(p.Deployments, deployments...)
+		return nil
+	})
+}
+
+// findCollisions reports the contract names shared by artifacts of different source files.
+func (p *Project) findCollisions() {
+	sources := map[string][]string{}
+	names := []string{}
+	for _, artifact := range p.Artifacts {
+		if len(sources[artifact.Name]) == 0 {
+			names = append(names, artifact.Name)
+		}
+		sources[artifact.Name] = append(sources[artifact.Name], artifact.SourceName)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if len(sources[name]) > 1 {
+			p.Collisions = append(p.Collisions, fmt.Sprintf("%s is declared in %s", name, strings.Join(sources[name], ", ")))
+		}
+	}
+}
+
+// walk calls visit for every file under root, skipping dependency and cache directories. A
+// missing root is not an error.
+func walk(root string, visit func(path string) error) error {
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
+	}
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && skippedDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		return visit(path)
+	})
+	if err != nil {
+		return errors.WrapContractError(err, errors.ErrCodeProjectScanFailed, fmt.Sprintf("failed to scan %s", root))
+	}
+	return nil
+}
+
+func isExcluded(sourceName string, excluded []string) bool {
+	for _, prefix := range excluded {
+		if strings.HasPrefix(sourceName, prefix) {
+			return true
+		}
+	}
+	return false
+}
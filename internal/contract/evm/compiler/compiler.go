@@ -0,0 +1,373 @@
+package compiler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storagelayout"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	solc "github.com/rxtech-lab/solc-go"
+)
+
+// DefaultVersion is the compiler version used when none is selected.
+const DefaultVersion = "0.8.30"
+
+// DefaultOptimizerRuns is the optimizer runs setting used when the optimizer is enabled without runs.
+const DefaultOptimizerRuns = 200
+
+// DefaultFileName is the name given to the main source file when none is provided.
+const DefaultFileName = "Contract.sol"
+
+// Options configures a compilation.
+type Options struct {
+	// Version is the solc version, e.g. "0.8.30". Versions that are not embedded are downloaded.
+	Version string
+	// OptimizerEnabled turns the solc optimizer on.
+	OptimizerEnabled bool
+	// OptimizerRuns is the optimizer runs setting; defaults to DefaultOptimizerRuns.
+	OptimizerRuns int
+	// EVMVersion optionally selects the target EVM version, e.g. "paris".
+	EVMVersion string
+	// FileName is the name of the main source file, used to resolve relative imports.
+	FileName string
+	// ImportDir is the local directory imports are resolved from.
+	ImportDir string
+}
+
+// Severity is the severity of a compiler diagnostic.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Diagnostic is an error, warning or info message reported by the compiler.
+type Diagnostic struct {
+	Severity Severity
+	Type     string
+	Message  string
+	File     string
+	// Line and Column are 1-based, or 0 when the diagnostic has no source location.
+	Line   int
+	Column int
+}
+
+// Location returns the "file:line:column" location of the diagnostic, or an empty string if unknown.
+func (d Diagnostic) Location() string {
+	if d.File == "" {
+		return ""
+	}
+	if d.Line == 0 {
+		return d.File
+	}
+	return fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+}
+
+// String formats the diagnostic on a single line.
+func (d Diagnostic) String() string {
+	if location := d.Location(); location != "" {
+		return fmt.Sprintf("%s: %s: %s", location, d.Type, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.Type, d.Message)
+}
+
+// Contract is a compiled contract.
+type Contract struct {
+	Name     string
+	File     string
+	ABI      abi.AbiArray
+	Bytecode string
+	// StorageLayout is where the contract keeps its state variables, or nil for interfaces and libraries.
+	StorageLayout *storagelayout.Layout
+}
+
+// IsDeployable returns true if the contract has creation bytecode (i.e. it is not abstract or an interface).
+func (c Contract) IsDeployable() bool {
+	return c.Bytecode != "" && c.Bytecode != "0x"
+}
+
+// Result is the output of a compilation.
+type Result struct {
+	Version     string
+	Contracts   []Contract
+	Diagnostics []Diagnostic
+}
+
+// Errors returns the error diagnostics.
+func (r *Result) Errors() []Diagnostic {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the warning diagnostics.
+func (r *Result) Warnings() []Diagnostic {
+	return r.filter(SeverityWarning)
+}
+
+func (r *Result) filter(severity Severity) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for _, diagnostic := range r.Diagnostics {
+		if diagnostic.Severity == severity {
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	return diagnostics
+}
+
+// Contract returns the compiled contract to store for the given main file. When name is empty,
+// the single deployable contract declared in the main file is returned.
+func (r *Result) Contract(fileName, name string) (*Contract, error) {
+	candidates := []Contract{}
+	for _, contract := range r.Contracts {
+		if name != "" {
+			if contract.Name == name {
+				return &contract, nil
+			}
+			continue
+		}
+		if contract.File == fileName && contract.IsDeployable() {
+			candidates = append(candidates, contract)
+		}
+	}
+
+	if name != "" {
+		return nil, errors.NewContractError(errors.ErrCodeContractNotFound, fmt.Sprintf("contract %s not found in compilation output", name))
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, errors.NewContractError(errors.ErrCodeContractNotFound, fmt.Sprintf("no deployable contract found in %s", fileName))
+	case 1:
+		return &candidates[0], nil
+	default:
+		names := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			names = append(names, candidate.Name)
+		}
+		return nil, errors.NewContractError(errors.ErrCodeContractNotFound, fmt.Sprintf("multiple contracts found in %s (%s); specify a contract name", fileName, strings.Join(names, ", ")))
+	}
+}
+
+// AvailableVersions returns the compiler versions that are available offline, newest first.
+func AvailableVersions() []string {
+	versions := solc.GetEmbeddedVersions()
+	sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+	return versions
+}
+
+// cachedCompiler is a loaded compiler. The instance is not safe for concurrent use, so it is locked
+// for the whole compilation.
+type cachedCompiler struct {
+	mu       sync.Mutex
+	instance solc.Solc
+}
+
+var (
+	compilersMu sync.Mutex
+	compilers   = map[string]*cachedCompiler{}
+)
+
+// loadCompiler returns a cached compiler instance for the version, loading it on first use.
+func loadCompiler(version string) (*cachedCompiler, error) {
+	compilersMu.Lock()
+	defer compilersMu.Unlock()
+
+	if compiler, ok := compilers[version]; ok {
+		return compiler, nil
+	}
+
+	instance, err := solc.NewWithVersion(version)
+	if err != nil {
+		return nil, errors.WrapContractError(err, errors.ErrCodeCompilerLoadFailed, fmt.Sprintf("failed to load solc %s", version))
+	}
+	compiler := &cachedCompiler{instance: instance}
+	compilers[version] = compiler
+	return compiler, nil
+}
+
+// compile runs a compilation while holding the lock of the instance.
+func (c *cachedCompiler) compile(input *solc.Input, options *solc.CompileOptions) (*solc.Output, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.instance == nil {
+		return nil, fmt.Errorf("compiler was closed")
+	}
+	return c.instance.CompileWithOptions(input, options)
+}
+
+// Close releases the cached compiler instances, waiting for running compilations to finish.
+func Close() error {
+	compilersMu.Lock()
+	defer compilersMu.Unlock()
+
+	var firstErr error
+	for version, compiler := range compilers {
+		compiler.mu.Lock()
+		if err := compiler.instance.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		compiler.instance = nil
+		compiler.mu.Unlock()
+		delete(compilers, version)
+	}
+	return firstErr
+}
+
+// Compile compiles Solidity source code. Imports are resolved from options.ImportDir, falling
+// back to its node_modules and lib directories. The returned result carries every diagnostic;
+// if the compiler reports errors, an ErrCodeContractCompileFailed error is returned along with it.
+func Compile(source string, options Options) (*Result, error) {
+	if strings.TrimSpace(source) == "" {
+		return nil, errors.NewContractError(errors.ErrCodeContractCodeRequired, "contract code is required")
+	}
+
+	version := options.Version
+	if version == "" {
+		version = DefaultVersion
+	}
+	fileName := options.FileName
+	if fileName == "" {
+		fileName = DefaultFileName
+	}
+
+	compiler, err := loadCompiler(version)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := solc.Settings{
+		EVMVersion: options.EVMVersion,
+		OutputSelection: map[string]map[string][]string{
+			"*": {"*": {"abi", "evm.bytecode.object"}, "": {"ast"}},
+		},
+	}
+	if options.OptimizerEnabled {
+		runs := options.OptimizerRuns
+		if runs <= 0 {
+			runs = DefaultOptimizerRuns
+		}
+		settings.Optimizer = solc.Optimizer{Enabled: true, Runs: runs}
+	}
+
+	sources := map[string]solc.SourceIn{fileName: {Content: source}}
+	input := &solc.Input{Language: "Solidity", Sources: sources, Settings: settings}
+
+	output, err := compiler.compile(input, &solc.CompileOptions{ImportCallback: importCallback(options.ImportDir)})
+	if err != nil {
+		return nil, errors.WrapContractError(err, errors.ErrCodeContractCompileFailed, "failed to compile contract")
+	}
+
+	result := &Result{Version: version}
+	for _, compilerError := range output.Errors {
+		result.Diagnostics = append(result.Diagnostics, toDiagnostic(compilerError, sources))
+	}
+
+	contracts, err := toContracts(output)
+	if err != nil {
+		return result, err
+	}
+	result.Contracts = contracts
+
+	if errs := result.Errors(); len(errs) > 0 {
+		return result, errors.NewContractError(errors.ErrCodeContractCompileFailed, fmt.Sprintf("compilation failed with %d error(s): %s", len(errs), errs[0]))
+	}
+	return result, nil
+}
+
+// importCallback resolves imports from the local import directory. Absolute imports and imports
+// climbing out of the directory with ".." are rejected, so a source can't read arbitrary files.
+func importCallback(importDir string) solc.ImportCallback {
+	return func(url string) solc.ImportResult {
+		if importDir == "" {
+			return solc.ImportResult{Error: fmt.Sprintf("cannot resolve import %s: no import directory set", url)}
+		}
+		path := filepath.FromSlash(url)
+		if !filepath.IsLocal(path) {
+			return solc.ImportResult{Error: errors.NewContractError(errors.ErrCodeImportNotFound, fmt.Sprintf("import %s resolves outside %s", url, importDir)).Error()}
+		}
+
+		for _, root := range []string{importDir, filepath.Join(importDir, "node_modules"), filepath.Join(importDir, "lib")} {
+			content, err := os.ReadFile(filepath.Join(root, path))
+			if err == nil {
+				return solc.ImportResult{Contents: string(content)}
+			}
+		}
+		return solc.ImportResult{Error: errors.NewContractError(errors.ErrCodeImportNotFound, fmt.Sprintf("import %s not found in %s", url, importDir)).Error()}
+	}
+}
+
+func toDiagnostic(compilerError solc.Error, sources map[string]solc.SourceIn) Diagnostic {
+	diagnostic := Diagnostic{
+		Severity: Severity(compilerError.Severity),
+		Type:     compilerError.Type,
+		Message:  compilerError.Message,
+		File:     compilerError.SourceLocation.File,
+	}
+	if source, ok := sources[diagnostic.File]; ok {
+		diagnostic.Line, diagnostic.Column = lineColumn(source.Content, compilerError.SourceLocation.Start)
+	}
+	return diagnostic
+}
+
+// lineColumn converts a byte offset into a 1-based line and column.
+func lineColumn(content string, offset int) (int, int) {
+	if offset < 0 || offset > len(content) {
+		return 0, 0
+	}
+	before := content[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := offset - strings.LastIndex(before, "\n")
+	return line, column
+}
+
+func toContracts(output *solc.Output) ([]Contract, error) {
+	asts := make(map[string]json.RawMessage, len(output.Sources))
+	for file, source := range output.Sources {
+		asts[file] = source.AST
+	}
+	layouts, err := storagelayout.FromAST(asts)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := []Contract{}
+	for file, fileContracts := range output.Contracts {
+		for name, compiled := range fileContracts {
+			abiJSON, err := json.Marshal(compiled.ABI)
+			if err != nil {
+				return nil, errors.WrapContractError(err, errors.ErrCodeContractCompileFailed, "failed to encode compiled ABI")
+			}
+			var contractABI abi.AbiArray
+			if err := json.Unmarshal(abiJSON, &contractABI); err != nil {
+				return nil, errors.WrapContractError(err, errors.ErrCodeContractCompileFailed, "failed to decode compiled ABI")
+			}
+
+			bytecode := ""
+			if compiled.EVM.Bytecode.Object != "" {
+				bytecode = "0x" + strings.TrimPrefix(compiled.EVM.Bytecode.Object, "0x")
+			}
+			contracts = append(contracts, Contract{
+				Name:          name,
+				File:          file,
+				ABI:           contractABI,
+				Bytecode:      bytecode,
+				StorageLayout: layouts[file+":"+name],
+			})
+		}
+	}
+
+	sort.Slice(contracts, func(i, j int) bool {
+		if contracts[i].File != contracts[j].File {
+			return contracts[i].File < contracts[j].File
+		}
+		return contracts[i].Name < contracts[j].Name
+	})
+	return contracts, nil
+}
@@ -0,0 +1,341 @@
+package compiler
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storagelayout"
+	customerrors "github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+const ownableSource = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Ownable {
+    address public owner;
+
+    constructor() {
+        owner = msg.sender;
+    }
+}
+`
+
+const tokenSource = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./access/Ownable.sol";
+
+contract Token is Ownable {
+    uint256 public totalSupply;
+
+    constructor(uint256 supply) {
+        totalSupply = supply;
+    }
+
+    function mint(uint256 amount) public {
+        uint256 unused;
+        totalSupply += amount;
+    }
+}
+`
+
+type CompilerTestSuite struct {
+	suite.Suite
+	importDir string
+}
+
+func TestCompilerTestSuite(t *testing.T) {
+	suite.Run(t, new(CompilerTestSuite))
+}
+
+func (s *CompilerTestSuite) SetupTest() {
+	s.importDir = s.T().TempDir()
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.importDir, "access"), 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.importDir, "access", "Ownable.sol"), []byte(ownableSource), 0o600))
+}
+
+func (s *CompilerTestSuite) TestCompileWithImportsAndOptimizer() {
+	result, err := Compile(tokenSource, Options{
+		Version:          DefaultVersion,
+		OptimizerEnabled: true,
+		FileName:         "Token.sol",
+		ImportDir:        s.importDir,
+	})
+	s.Require().NoError(err)
+	s.Equal(DefaultVersion, result.Version)
+	s.Len(result.Contracts, 2)
+	s.Empty(result.Errors())
+
+	warnings := result.Warnings()
+	s.Require().NotEmpty(warnings)
+	s.Equal("Token.sol", warnings[0].File)
+	s.Equal(14, warnings[0].Line)
+	s.Equal(9, warnings[0].Column)
+	s.Contains(warnings[0].String(), "Token.sol:14:9: Warning: Unused local variable.")
+
+	contract, err := result.Contract("Token.sol", "")
+	s.Require().NoError(err)
+	s.Equal("Token", contract.Name)
+	s.Contains(contract.Bytecode, "0x6080")
+
+	var hasConstructor bool
+	for _, element := range contract.ABI {
+		if element.Type == "constructor" {
+			hasConstructor = true
+			s.Equal("uint256", element.Inputs[0].Type)
+		}
+	}
+	s.True(hasConstructor)
+
+	ownable, err := result.Contract("Token.sol", "Ownable")
+	s.Require().NoError(err)
+	s.Equal("access/Ownable.sol", ownable.File)
+}
+
+// TestCompileContract tests compiling a stored contract into its bytecode, ABI and storage layout.
+func (s *CompilerTestSuite) TestCompileContract() {
+	contract := models.EVMContract{Name: "Counter", Status: models.DeploymentStatusFailed}
+	_, err := CompileContract(&contract, Options{}, "")
+	s.True(customerrors.HasCode(err, customerrors.ErrCodeContractCodeRequired), "Should require contract code")
+
+	source := "// SPDX-License-Identifier: MIT\npragma solidity ^0.8.0;\n\ncontract Counter {\n    uint256 public count;\n\n    function increment() public {\n        count++;\n    }\n}\n"
+	contract.ContractCode = &source
+
+	result, err := CompileContract(&contract, Options{Version: DefaultVersion}, "")
+	s.Require().NoError(err)
+	s.Empty(result.Errors())
+	s.Require().NotNil(contract.Bytecode)
+	s.Contains(*contract.Bytecode, "0x6080")
+	s.Require().NotNil(contract.Abi)
+	s.Equal("Counter", contract.Abi.Name)
+	s.NotEmpty(contract.Abi.Abi.AbiArray)
+	s.True(contract.IsDeployable(), "Compiled contract should be pending deployment")
+
+	s.Require().NotNil(contract.StorageLayout.Layout)
+	s.Equal("count", contract.StorageLayout.Storage[0].Label)
+}
+
+func (s *CompilerTestSuite) TestCompileErrorsHaveLineNumbers() {
+	source := "pragma solidity ^0.8.0;\n\ncontract Broken {\n    function f() public {\n        undefinedCall();\n    }\n}\n"
+
+	result, err := Compile(source, Options{FileName: "Broken.sol"})
+	s.Require().Error(err)
+	s.True(customerrors.HasCode(err, customerrors.ErrCodeContractCompileFailed))
+	s.Require().NotNil(result)
+
+	errs := result.Errors()
+	s.Require().Len(errs, 1)
+	s.Equal("DeclarationError", errs[0].Type)
+	s.Equal("Broken.sol:5:9", errs[0].Location())
+	s.Contains(err.Error(), "Broken.sol:5:9")
+}
+
+func (s *CompilerTestSuite) TestConcurrentCompile() {
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for index := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[index] = Compile(ownableSource, Options{})
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		s.NoError(err)
+	}
+}
+
+func (s *CompilerTestSuite) TestCompileAfterClose() {
+	_, err := Compile(ownableSource, Options{})
+	s.Require().NoError(err)
+	s.Require().NoError(Close())
+
+	// The compiler is loaded again on the next compilation
+	result, err := Compile(ownableSource, Options{})
+	s.Require().NoError(err)
+	s.Len(result.Contracts, 1)
+}
+
+func (s *CompilerTestSuite) TestMissingImport() {
+	_, err := Compile(tokenSource, Options{FileName: "Token.sol", ImportDir: s.T().TempDir()})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "access/Ownable.sol")
+}
+
+// TestImportsOutsideImportDir tests that imports can't read files outside the import directory.
+func (s *CompilerTestSuite) TestImportsOutsideImportDir() {
+	parent := s.T().TempDir()
+	importDir := filepath.Join(parent, "project")
+	s.Require().NoError(os.MkdirAll(importDir, 0o755))
+	secret := filepath.Join(parent, "Secret.sol")
+	s.Require().NoError(os.WriteFile(secret, []byte(ownableSource), 0o600))
+
+	resolve := importCallback(importDir)
+	for _, url := range []string{"../Secret.sol", "lib/../../Secret.sol", filepath.ToSlash(secret)} {
+		result := resolve(url)
+		s.Empty(result.Contents, url)
+		s.Contains(result.Error, "resolves outside", url)
+	}
+
+	s.Require().NoError(os.WriteFile(filepath.Join(importDir, "Secret.sol"), []byte(ownableSource), 0o600))
+	s.Equal(ownableSource, resolve("access/../Secret.sol").Contents)
+
+	_, err := Compile("pragma solidity ^0.8.0;\nimport \"../Secret.sol\";\n", Options{FileName: "Token.sol", ImportDir: importDir})
+	s.Require().Error(err)
+	s.NotContains(err.Error(), "Ownable")
+}
+
+func (s *CompilerTestSuite) TestContractSelection() {
+	result, err := Compile(ownableSource+"\ncontract Second {}\n", Options{})
+	s.Require().NoError(err)
+
+	_, err = result.Contract(DefaultFileName, "")
+	s.Require().Error(err)
+	s.Contains(err.Error(), "Ownable, Second")
+
+	contract, err := result.Contract(DefaultFileName, "Second")
+	s.Require().NoError(err)
+	s.Equal("Second", contract.Name)
+
+	_, err = result.Contract(DefaultFileName, "Missing")
+	s.True(customerrors.HasCode(err, customerrors.ErrCodeContractNotFound))
+}
+
+func (s *CompilerTestSuite) TestEmptySource() {
+	_, err := Compile("  ", Options{})
+	s.True(customerrors.HasCode(err, customerrors.ErrCodeContractCodeRequired))
+}
+
+func (s *CompilerTestSuite) TestAvailableVersions() {
+	s.Contains(AvailableVersions(), DefaultVersion)
+}
+
+func (s *CompilerTestSuite) TestLineColumn() {
+	line, column := lineColumn("ab\ncd\nef", 4)
+	s.Equal(2, line)
+	s.Equal(2, column)
+
+	line, column = lineColumn("ab", 10)
+	s.Zero(line)
+	s.Zero(column)
+}
+
+const layoutSource = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Base {
+    uint8 internal version;
+    address internal owner;
+}
+
+contract Vault is Base {
+    enum State { Open, Closed }
+    struct Position { uint128 amount; bool active; uint256 opened; }
+
+    bool internal paused;
+    uint256 internal constant FEE = 1;
+    address internal immutable deployer;
+    mapping(address => uint256) internal balances;
+    mapping(string => Position) internal positions;
+    uint16[] internal history;
+    uint64[3] internal limits;
+    string internal name;
+    bytes internal data;
+    State internal state;
+    Position internal current;
+    Vault internal self;
+    function() external internal hook;
+
+    constructor() {
+        deployer = msg.sender;
+    }
+}
+
+interface IVault {
+    function deposit() external;
+}
+`
+
+func (s *CompilerTestSuite) TestStorageLayoutFromAST() {
+	result, err := Compile(layoutSource, Options{FileName: "Vault.sol"})
+	s.Require().NoError(err)
+
+	contract, err := result.Contract("Vault.sol", "Vault")
+	s.Require().NoError(err)
+	layout := contract.StorageLayout
+	s.Require().NotNil(layout)
+
+	type placement struct {
+		name     string
+		slot     string
+		offset   int
+		label    string
+		contract string
+	}
+	placements := []placement{}
+	for _, variable := range layout.Storage {
+		placements = append(placements, placement{variable.Label, variable.Slot, variable.Offset, layout.Types[variable.Type].Label, variable.Contract})
+	}
+	s.Equal([]placement{
+		{"version", "0", 0, "uint8", "Vault.sol:Vault"},
+		{"owner", "0", 1, "address", "Vault.sol:Vault"},
+		{"paused", "0", 21, "bool", "Vault.sol:Vault"},
+		{"balances", "1", 0, "mapping(address => uint256)", "Vault.sol:Vault"},
+		{"positions", "2", 0, "mapping(string => struct Vault.Position)", "Vault.sol:Vault"},
+		{"history", "3", 0, "uint16[]", "Vault.sol:Vault"},
+		{"limits", "4", 0, "uint64[3]", "Vault.sol:Vault"},
+		{"name", "5", 0, "string", "Vault.sol:Vault"},
+		{"data", "6", 0, "bytes", "Vault.sol:Vault"},
+		{"state", "7", 0, "enum Vault.State", "Vault.sol:Vault"},
+		{"current", "8", 0, "struct Vault.Position", "Vault.sol:Vault"},
+		{"self", "10", 0, "contract Vault", "Vault.sol:Vault"},
+		{"hook", "11", 0, "function () external", "Vault.sol:Vault"},
+	}, placements)
+
+	position := layout.Types[layout.Storage[10].Type]
+	s.Equal("64", position.NumberOfBytes)
+	s.Require().Len(position.Members, 3)
+	s.Equal("1", position.Members[2].Slot)
+	s.Equal(16, position.Members[1].Offset)
+	s.Equal("t_array(t_uint64)3_storage", layout.Storage[6].Type)
+	s.Equal("32", layout.Types[layout.Storage[6].Type].NumberOfBytes)
+
+	base, err := result.Contract("Vault.sol", "Base")
+	s.Require().NoError(err)
+	s.Len(base.StorageLayout.Storage, 2)
+
+	iface, err := result.Contract("Vault.sol", "IVault")
+	s.Require().NoError(err)
+	s.Nil(iface.StorageLayout)
+}
+
+// TestStorageLayoutMatchesSolc compares the layouts derived from the AST with the storageLayout output of
+// solc 0.8.30 for the same source, stored in testdata/layouts.json.
+func (s *CompilerTestSuite) TestStorageLayoutMatchesSolc() {
+	source, err := os.ReadFile(filepath.Join("testdata", "Layouts.sol"))
+	s.Require().NoError(err)
+	golden, err := os.ReadFile(filepath.Join("testdata", "layouts.json"))
+	s.Require().NoError(err)
+	expected := map[string]*storagelayout.Layout{}
+	s.Require().NoError(json.Unmarshal(golden, &expected))
+
+	result, err := Compile(string(source), Options{Version: "0.8.30", FileName: "Layouts.sol"})
+	s.Require().NoError(err)
+
+	for name, layout := range expected {
+		contract, err := result.Contract("Layouts.sol", name)
+		s.Require().NoError(err)
+		if layout.IsEmpty() {
+			s.Nil(contract.StorageLayout, name)
+			continue
+		}
+		layout.Derived = true
+		s.Equal(layout, contract.StorageLayout, name)
+	}
+}
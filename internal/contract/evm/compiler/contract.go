@@ -0,0 +1,41 @@
+package compiler
+
+import (
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// CompileContract compiles the source of a stored contract with the given options and stores the resulting
+// bytecode, ABI and storage layout on the contract. The ABI is attached as an unsaved EvmAbi named after the
+// compiled contract and AbiId is left untouched; the caller is responsible for persisting the ABI (see
+// sql.SaveContractABI) and the contract. When contractName is empty, the single deployable contract declared
+// in the main source file is used. Contracts that are not yet deployed become pending so they can be
+// deployed with the new bytecode.
+func CompileContract(contract *models.EVMContract, options Options, contractName string) (*Result, error) {
+	if contract.ContractCode == nil {
+		return nil, errors.NewContractError(errors.ErrCodeContractCodeRequired, "contract code is required")
+	}
+
+	result, err := Compile(*contract.ContractCode, options)
+	if err != nil {
+		return result, err
+	}
+
+	fileName := options.FileName
+	if fileName == "" {
+		fileName = DefaultFileName
+	}
+	compiled, err := result.Contract(fileName, contractName)
+	if err != nil {
+		return result, err
+	}
+
+	bytecode := compiled.Bytecode
+	contract.Bytecode = &bytecode
+	contract.Abi = &models.EvmAbi{Name: compiled.Name, Abi: models.AbiArrayType{AbiArray: compiled.ABI}}
+	contract.StorageLayout = models.StorageLayoutType{Layout: compiled.StorageLayout}
+	if contract.Status != models.DeploymentStatusDeployed {
+		contract.Status = models.DeploymentStatusPending
+	}
+	return result, nil
+}
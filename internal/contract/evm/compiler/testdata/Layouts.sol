@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.29;
+
+// Storage layout cases checked against the storageLayout output of solc, see layouts.json.
+
+type Price is uint128;
+type Flag is bool;
+
+interface IToken {
+    function balanceOf(address owner) external view returns (uint256);
+}
+
+contract Packing {
+    uint8 internal small;
+    uint16[3] internal shorts;
+    uint8 internal afterArray;
+    bool[40] internal flags;
+    address internal owner;
+    uint96 internal fee;
+    bytes4 internal selector;
+    int24 internal tick;
+    bytes32[2][3] internal matrix;
+    uint8[2][2] internal nested;
+    uint256 internal last;
+}
+
+contract Types {
+    enum Status {
+        Idle,
+        Active,
+        Closed
+    }
+
+    struct Member {
+        uint8 a;
+        uint128 b;
+        address c;
+        uint256 d;
+        bool e;
+        Status status;
+    }
+
+    struct Wrapper {
+        Member member;
+        uint8[3] small;
+        Price price;
+        mapping(address => uint256) balances;
+    }
+
+    Status internal status;
+    Price internal price;
+    Flag internal flag;
+    uint64 internal afterValueTypes;
+    Member internal member;
+    uint8 internal afterStruct;
+    Wrapper internal wrapper;
+    Member[2] internal pair;
+    Member[] internal members;
+    mapping(Status => Price) internal prices;
+    mapping(uint256 => Member[]) internal groups;
+    IToken internal token;
+    address payable internal recipient;
+    function(uint256) external returns (bool) internal callback;
+    function(uint256) internal pure returns (uint256) internal transform;
+    string internal name;
+    bytes internal data;
+    uint256 internal constant LIMIT = 10;
+    uint256 internal immutable created;
+    uint256 internal transient lock;
+
+    constructor() {
+        created = block.timestamp;
+    }
+}
+
+contract Base {
+    uint8 internal baseFlag;
+    uint128 internal baseValue;
+}
+
+contract Middle is Base {
+    uint64 internal middleValue;
+    uint256[] internal list;
+}
+
+contract Other {
+    address internal other;
+}
+
+contract Child is Middle, Other {
+    bool internal childFlag;
+    uint8 internal childSmall;
+}
+
+contract Shifted layout at 0x100 {
+    uint8 internal first;
+    uint256 internal second;
+}
+
+contract ShiftedChild is Base layout at 2 ** 255 + 7 {
+    uint8 internal third;
+}
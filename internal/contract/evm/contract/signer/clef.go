@@ -0,0 +1,226 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// ClefSigner delegates signing to an external signer speaking Clef's account_* JSON-RPC API, so the
+// private key never leaves the signer. The endpoint is an HTTP(S) URL or the path of an IPC socket.
+type ClefSigner struct {
+	Endpoint string
+	address  common.Address
+	client   *rpc.Client
+	timeout  time.Duration
+}
+
+// clefSignTxResult is the response of account_signTransaction.
+type clefSignTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// NewClefSigner connects to a Clef-compatible signer that holds the key for the given address.
+// Requests wait up to timeout for the user to approve them, 5 minutes if timeout is 0.
+func NewClefSigner(endpoint string, address common.Address, timeout time.Duration) (*ClefSigner, error) {
+	if endpoint == "" {
+		return nil, errors.NewSignerError(errors.ErrCodeRemoteSignerFailed, "remote signer endpoint is required")
+	}
+
+	client, err := rpc.Dial(endpoint)
+	if err != nil {
+		return nil, errors.WrapSignerError(err, errors.ErrCodeRemoteSignerFailed, "failed to dial remote signer")
+	}
+
+	// Verify connectivity with account_version, which needs no approval
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var version string
+	if err := client.CallContext(ctx, &version, "account_version"); err != nil {
+		client.Close()
+		return nil, errors.WrapSignerError(err, errors.ErrCodeRemoteSignerFailed, "failed to connect to remote signer")
+	}
+
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+
+	return &ClefSigner{
+		Endpoint: endpoint,
+		address:  address,
+		client:   client,
+		timeout:  timeout,
+	}, nil
+}
+
+// WithTransport creates a new AccountSignerWithTransport with the given transport.
+func (c *ClefSigner) WithTransport(transport transport.Transport) SignerWithTransport {
+	return NewAccountSignerWithTransport(c, transport)
+}
+
+// Close closes the connection to the remote signer.
+func (c *ClefSigner) Close() {
+	c.client.Close()
+}
+
+// GetAddress implements AccountSigner.
+func (c *ClefSigner) GetAddress() common.Address {
+	return c.address
+}
+
+func (c *ClefSigner) call(result any, method string, args ...any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	err := c.client.CallContext(ctx, result, method, args...)
+	if err == nil {
+		return nil
+	}
+	// Clef answers "Request denied" when the user rejects a request
+	if strings.Contains(strings.ToLower(err.Error()), "denied") {
+		return errors.WrapSignerError(err, errors.ErrCodeRemoteSignerRejected, "request rejected by remote signer")
+	}
+	return errors.WrapSignerError(err, errors.ErrCodeRemoteSignerFailed, fmt.Sprintf("remote signer call %s failed", method))
+}
+
+// SignTransaction implements Signer. The remote signer must return the transaction it was sent, signed
+// by this account; a signer that changed any field, for example the gas, is rejected.
+func (c *ClefSigner) SignTransaction(transaction *types.Transaction) (signedTx *types.Transaction, err error) {
+	if transaction.Type() != types.DynamicFeeTxType && transaction.Type() != types.AccessListTxType {
+		return nil, errors.NewSignerError(errors.ErrCodeInvalidTransaction, fmt.Sprintf("remote signer does not support transaction type %d", transaction.Type()))
+	}
+	chainID := transaction.ChainId()
+	if chainID == nil || chainID.Sign() == 0 {
+		return nil, errors.NewSignerError(errors.ErrCodeInvalidChainID, "transaction has no chain ID")
+	}
+
+	// Clef builds a dynamic fee transaction when maxFeePerGas is set, and an access list
+	// transaction when only the access list is
+	accessList := transaction.AccessList()
+	if accessList == nil {
+		accessList = types.AccessList{}
+	}
+	input := hexutil.Bytes(transaction.Data())
+	args := apitypes.SendTxArgs{
+		From:       common.NewMixedcaseAddress(c.address),
+		Gas:        hexutil.Uint64(transaction.Gas()),
+		Value:      hexutil.Big(*transaction.Value()),
+		Nonce:      hexutil.Uint64(transaction.Nonce()),
+		Input:      &input,
+		AccessList: &accessList,
+		ChainID:    (*hexutil.Big)(chainID),
+	}
+	if to := transaction.To(); to != nil {
+		recipient := common.NewMixedcaseAddress(*to)
+		args.To = &recipient
+	}
+	if transaction.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(transaction.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(transaction.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(transaction.GasPrice())
+	}
+
+	var result clefSignTxResult
+	if err := c.call(&result, "account_signTransaction", args, nil); err != nil {
+		return nil, err
+	}
+
+	signedTx = new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(result.Raw); err != nil {
+		return nil, errors.WrapSignerError(err, errors.ErrCodeInvalidTransaction, "remote signer returned an invalid transaction")
+	}
+
+	txSigner := types.LatestSignerForChainID(chainID)
+	if txSigner.Hash(signedTx) != txSigner.Hash(transaction) {
+		return nil, errors.NewSignerError(errors.ErrCodeSignerMismatch, "remote signer returned a different transaction")
+	}
+	sender, err := types.Sender(txSigner, signedTx)
+	if err != nil {
+		return nil, errors.WrapSignerError(err, errors.ErrCodeInvalidSignature, "failed to recover transaction sender")
+	}
+	if sender != c.address {
+		return nil, errors.NewSignerError(errors.ErrCodeSignerMismatch, fmt.Sprintf("remote signer signed as %s, expected %s", sender.Hex(), c.address.Hex()))
+	}
+
+	return signedTx, nil
+}
+
+// SignMessageString implements Signer.
+func (c *ClefSigner) SignMessageString(message string) (signature string, err error) {
+	return c.SignMessage([]byte(message))
+}
+
+// SignMessage signs bytes as an EIP-191 personal message with account_signData. Clef returns 27/28 as
+// recovery id; it is lowered to 0/1 so signatures match those of PrivateKeySigner.
+func (c *ClefSigner) SignMessage(message []byte) (signature string, err error) {
+	var sig hexutil.Bytes
+	if err := c.call(&sig, "account_signData", "text/plain", common.NewMixedcaseAddress(c.address), hexutil.Encode(message)); err != nil {
+		return "", err
+	}
+	if len(sig) == 65 && sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	signature = hexutil.Encode(sig)
+	if err := c.checkSigner(PersonalMessageHash(message), signature); err != nil {
+		return "", err
+	}
+	return signature, nil
+}
+
+// SignTypedData implements Signer with account_signTypedData. The signature keeps 27/28 as recovery id.
+func (c *ClefSigner) SignTypedData(typedData string) (signature string, err error) {
+	parsed, err := ParseTypedData(typedData)
+	if err != nil {
+		return "", err
+	}
+	hash, err := HashTypedData(parsed)
+	if err != nil {
+		return "", err
+	}
+
+	// Send the JSON as given, re-encoding the parsed message would turn large numbers into floats
+	var sig hexutil.Bytes
+	if err := c.call(&sig, "account_signTypedData", common.NewMixedcaseAddress(c.address), json.RawMessage(typedData)); err != nil {
+		return "", err
+	}
+
+	signature = hexutil.Encode(sig)
+	if err := c.checkSigner(hash, signature); err != nil {
+		return "", err
+	}
+	return signature, nil
+}
+
+// checkSigner makes sure a signature returned by the remote signer was made by this account.
+func (c *ClefSigner) checkSigner(hash common.Hash, signature string) error {
+	recovered, err := RecoverAddress(hash, signature)
+	if err != nil {
+		return err
+	}
+	if recovered != c.address {
+		return errors.NewSignerError(errors.ErrCodeSignerMismatch, fmt.Sprintf("remote signer signed as %s, expected %s", recovered.Hex(), c.address.Hex()))
+	}
+	return nil
+}
+
+// VerifyMessageString implements Signer.
+func (c *ClefSigner) VerifyMessageString(address common.Address, message string, signature string) (isValid bool, recoveredAddress common.Address, err error) {
+	return verifyMessage(address, []byte(message), signature)
+}
+
+// VerifyTypedData implements Signer.
+func (c *ClefSigner) VerifyTypedData(address common.Address, typedData string, signature string) (isValid bool, recoveredAddress common.Address, err error) {
+	return verifyTypedData(address, typedData, signature)
+}
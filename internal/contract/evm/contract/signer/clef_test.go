@@ -0,0 +1,290 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+// mockClef implements the account_* methods of Clef used by ClefSigner, approving every request
+// unless deny is set.
+type mockClef struct {
+	key    *ecdsa.PrivateKey
+	deny   bool
+	tamper bool
+}
+
+func (m *mockClef) Version(context.Context) (string, error) {
+	return "6.1.0", nil
+}
+
+func (m *mockClef) SignTransaction(_ context.Context, args apitypes.SendTxArgs, _ *string) (*clefSignTxResult, error) {
+	if m.deny {
+		return nil, fmt.Errorf("request denied")
+	}
+	if m.tamper {
+		args.Gas *= 2
+	}
+	transaction, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := types.SignTx(transaction, types.LatestSignerForChainID((*big.Int)(args.ChainID)), m.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &clefSignTxResult{Raw: raw}, nil
+}
+
+func (m *mockClef) SignData(_ context.Context, contentType string, _ common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if m.deny {
+		return nil, fmt.Errorf("request denied")
+	}
+	if contentType != "text/plain" {
+		return nil, fmt.Errorf("unsupported content type %s", contentType)
+	}
+	return m.sign(PersonalMessageHash(data))
+}
+
+func (m *mockClef) SignTypedData(_ context.Context, _ common.MixedcaseAddress, typedData apitypes.TypedData) (hexutil.Bytes, error) {
+	if m.deny {
+		return nil, fmt.Errorf("request denied")
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return m.sign(common.BytesToHash(hash))
+}
+
+// sign signs like Clef does, with 27/28 as recovery id.
+func (m *mockClef) sign(hash common.Hash) (hexutil.Bytes, error) {
+	sig, err := crypto.Sign(hash.Bytes(), m.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// ClefSignerTestSuite runs ClefSigner against a mock Clef served over HTTP.
+type ClefSignerTestSuite struct {
+	suite.Suite
+	clef    *mockClef
+	server  *httptest.Server
+	signer  *ClefSigner
+	address common.Address
+}
+
+func TestClefSignerTestSuite(t *testing.T) {
+	suite.Run(t, new(ClefSignerTestSuite))
+}
+
+func (s *ClefSignerTestSuite) SetupTest() {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	s.Require().NoError(err)
+	s.clef = &mockClef{key: key}
+
+	server := rpc.NewServer()
+	s.Require().NoError(server.RegisterName("account", s.clef))
+	s.server = httptest.NewServer(server)
+
+	s.address = common.HexToAddress(testAddress)
+	s.signer, err = NewClefSigner(s.server.URL, s.address, 5*time.Second)
+	s.Require().NoError(err)
+}
+
+func (s *ClefSignerTestSuite) TearDownTest() {
+	s.signer.Close()
+	s.server.Close()
+}
+
+func (s *ClefSignerTestSuite) dynamicFeeTx() *types.Transaction {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(testChainID),
+		Nonce:     3,
+		GasTipCap: big.NewInt(1_000_000_000),
+		GasFeeCap: big.NewInt(2_000_000_000),
+		Gas:       21000,
+		To:        &recipient,
+		Value:     big.NewInt(1000),
+		Data:      []byte{0xde, 0xad},
+	})
+}
+
+func (s *ClefSignerTestSuite) TestSignTransaction() {
+	transaction := s.dynamicFeeTx()
+
+	signed, err := s.signer.SignTransaction(transaction)
+	s.Require().NoError(err)
+
+	txSigner := types.LatestSignerForChainID(big.NewInt(testChainID))
+	sender, err := types.Sender(txSigner, signed)
+	s.Require().NoError(err)
+	s.Equal(s.address, sender)
+	s.Equal(txSigner.Hash(transaction), txSigner.Hash(signed))
+	s.Equal(transaction.Data(), signed.Data())
+}
+
+func (s *ClefSignerTestSuite) TestSignAccessListTransaction() {
+	transaction := types.NewTx(&types.AccessListTx{
+		ChainID:  big.NewInt(testChainID),
+		Nonce:    1,
+		GasPrice: big.NewInt(1_000_000_000),
+		Gas:      21000,
+		Value:    big.NewInt(1),
+	})
+
+	signed, err := s.signer.SignTransaction(transaction)
+	s.Require().NoError(err)
+	s.Equal(uint8(types.AccessListTxType), signed.Type())
+	s.Nil(signed.To())
+}
+
+func (s *ClefSignerTestSuite) TestSignOfflineTransaction() {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	unsigned, err := BuildUnsignedTransaction(&offlineTransport{chainID: big.NewInt(testChainID)}, TransactionRequest{
+		From:  s.address,
+		To:    &recipient,
+		Value: big.NewInt(1000),
+	})
+	s.Require().NoError(err)
+
+	signed, err := SignOffline(s.signer, unsigned)
+	s.Require().NoError(err)
+	s.Equal(uint64(7), signed.Nonce())
+}
+
+func (s *ClefSignerTestSuite) TestRejectsChangedTransaction() {
+	s.clef.tamper = true
+
+	_, err := s.signer.SignTransaction(s.dynamicFeeTx())
+	s.True(errors.HasCode(err, errors.ErrCodeSignerMismatch), "%v", err)
+}
+
+func (s *ClefSignerTestSuite) TestRejectsOtherAccount() {
+	other, err := NewClefSigner(s.server.URL, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), time.Second)
+	s.Require().NoError(err)
+	defer other.Close()
+
+	_, err = other.SignTransaction(s.dynamicFeeTx())
+	s.True(errors.HasCode(err, errors.ErrCodeSignerMismatch), "%v", err)
+
+	_, err = other.SignMessageString("hello")
+	s.True(errors.HasCode(err, errors.ErrCodeSignerMismatch), "%v", err)
+}
+
+func (s *ClefSignerTestSuite) TestDeniedRequest() {
+	s.clef.deny = true
+
+	_, err := s.signer.SignTransaction(s.dynamicFeeTx())
+	s.True(errors.HasCode(err, errors.ErrCodeRemoteSignerRejected), "%v", err)
+
+	_, err = s.signer.SignMessageString("hello")
+	s.True(errors.HasCode(err, errors.ErrCodeRemoteSignerRejected), "%v", err)
+}
+
+func (s *ClefSignerTestSuite) TestUnsupportedTransactionType() {
+	legacy := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000})
+
+	_, err := s.signer.SignTransaction(legacy)
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidTransaction), "%v", err)
+}
+
+func (s *ClefSignerTestSuite) TestSignMessage() {
+	signature, err := s.signer.SignMessageString("hello")
+	s.Require().NoError(err)
+
+	// Same signature as a local key, with 0/1 as recovery id
+	local, err := NewPrivateKeySigner(testPrivateKey)
+	s.Require().NoError(err)
+	expected, err := local.SignMessageString("hello")
+	s.Require().NoError(err)
+	s.Equal(expected, signature)
+
+	isValid, recovered, err := s.signer.VerifyMessageString(s.address, "hello", signature)
+	s.Require().NoError(err)
+	s.True(isValid)
+	s.Equal(s.address, recovered)
+}
+
+func (s *ClefSignerTestSuite) TestSignTypedData() {
+	signature, err := s.signer.SignTypedData(mailTypedData)
+	s.Require().NoError(err)
+
+	local, err := NewPrivateKeySigner(testPrivateKey)
+	s.Require().NoError(err)
+	expected, err := local.SignTypedData(mailTypedData)
+	s.Require().NoError(err)
+	s.Equal(expected, signature)
+
+	isValid, _, err := s.signer.VerifyTypedData(s.address, mailTypedData, signature)
+	s.Require().NoError(err)
+	s.True(isValid)
+}
+
+func (s *ClefSignerTestSuite) TestSendTransactionWithTransport() {
+	tr := &offlineTransport{chainID: big.NewInt(testChainID)}
+	signerWithTransport := s.signer.WithTransport(tr)
+
+	address, err := signerWithTransport.GetAddress()
+	s.Require().NoError(err)
+	s.Equal(s.address, address)
+
+	_, err = signerWithTransport.SendTransaction(s.dynamicFeeTx())
+	s.Require().NoError(err)
+	s.Require().Len(tr.sent, 1)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(testChainID)), tr.sent[0])
+	s.Require().NoError(err)
+	s.Equal(s.address, sender)
+}
+
+func (s *ClefSignerTestSuite) TestIPC() {
+	server := rpc.NewServer()
+	s.Require().NoError(server.RegisterName("account", s.clef))
+	defer server.Stop()
+
+	socket := filepath.Join(s.T().TempDir(), "clef.ipc")
+	listener, err := net.Listen("unix", socket)
+	s.Require().NoError(err)
+	go func() { _ = server.ServeListener(listener) }()
+	defer listener.Close()
+
+	ipcSigner, err := NewClefSigner(socket, s.address, 5*time.Second)
+	s.Require().NoError(err)
+	defer ipcSigner.Close()
+
+	signature, err := ipcSigner.SignMessageString("hello")
+	s.Require().NoError(err)
+	recovered, err := RecoverAddress(common.HexToHash(helloHash), signature)
+	s.Require().NoError(err)
+	s.Equal(s.address, recovered)
+}
+
+func (s *ClefSignerTestSuite) TestConnectionFailure() {
+	_, err := NewClefSigner("", s.address, 0)
+	s.True(errors.HasCode(err, errors.ErrCodeRemoteSignerFailed), "%v", err)
+
+	_, err = NewClefSigner(filepath.Join(s.T().TempDir(), "missing.ipc"), s.address, 0)
+	s.True(errors.HasCode(err, errors.ErrCodeRemoteSignerFailed), "%v", err)
+}
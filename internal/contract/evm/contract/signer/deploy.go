@@ -0,0 +1,50 @@
+package signer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// ContractABI returns the stored ABI of the contract, or an empty ABI if none is linked.
+func ContractABI(contract *models.EVMContract) abi.ABI {
+	contractABI := abi.ABI{}
+	if contract.Abi != nil {
+		contractABI.SetElements(abi.ABIArray(contract.Abi.Abi.AbiArray))
+	}
+	return contractABI
+}
+
+// DeployEVMContract deploys a pending contract from its stored bytecode, encoding the
+// constructor arguments with the stored ABI. On completion the contract address is
+// recorded and the status is set to deployed, or to failed if the deployment
+// transaction was mined but reverted. Errors before the transaction is mined leave
+// the contract pending so it can be retried. The caller is responsible for
+// persisting the contract.
+func DeployEVMContract(signer SignerWithTransport, contract *models.EVMContract, value *big.Int, args ...any) (*types.Receipt, error) {
+	if !contract.IsDeployable() {
+		return nil, errors.NewContractError(errors.ErrCodeContractNotDeployable, fmt.Sprintf("contract %s is not deployable (status: %s)", contract.Name, contract.Status))
+	}
+
+	bytecode := common.FromHex(*contract.Bytecode)
+	if len(bytecode) == 0 {
+		return nil, errors.NewContractError(errors.ErrCodeInvalidBytecode, fmt.Sprintf("contract %s has invalid bytecode", contract.Name))
+	}
+
+	address, receipt, err := signer.DeployContract(ContractABI(contract), bytecode, value, 0, nil, args...)
+	if err != nil {
+		if receipt != nil {
+			contract.Status = models.DeploymentStatusFailed
+		}
+		return receipt, err
+	}
+
+	contract.Address = address.Hex()
+	contract.Status = models.DeploymentStatusDeployed
+	return receipt, nil
+}
@@ -0,0 +1,163 @@
+package signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+const constructorABI = `[{"type":"constructor","inputs":[{"name":"owner","type":"address"},{"name":"supply","type":"uint256"}],"stateMutability":"nonpayable"}]`
+
+// recordingTransport mines every sent transaction immediately with the configured status.
+type recordingTransport struct {
+	transport.Transport
+	status uint64
+	sent   []*types.Transaction
+	// estimatedFrom is the sender of every gas estimate
+	estimatedFrom []common.Address
+}
+
+func (r *recordingTransport) SendTransaction(tx *types.Transaction) (common.Hash, error) {
+	r.sent = append(r.sent, tx)
+	return tx.Hash(), nil
+}
+
+func (r *recordingTransport) WaitForTransactionReceipt(txHash common.Hash) (*types.Receipt, error) {
+	for _, tx := range r.sent {
+		if tx.Hash() != txHash {
+			continue
+		}
+		sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return nil, err
+		}
+		return &types.Receipt{
+			Status:          r.status,
+			TxHash:          txHash,
+			ContractAddress: crypto.CreateAddress(sender, tx.Nonce()),
+		}, nil
+	}
+	return nil, fmt.Errorf("transaction not found")
+}
+
+func (r *recordingTransport) EstimateCallGas(msg ethereum.CallMsg) (uint64, error) {
+	r.estimatedFrom = append(r.estimatedFrom, msg.From)
+	return 200000, nil
+}
+
+func (r *recordingTransport) GetTransactionCount(common.Address) (uint64, error) {
+	return uint64(len(r.sent)), nil
+}
+
+func (r *recordingTransport) GetChainID() (*big.Int, error) {
+	return big.NewInt(31337), nil
+}
+
+func (r *recordingTransport) GetTransaction(txHash common.Hash) (*types.Transaction, bool, error) {
+	for _, tx := range r.sent {
+		if tx.Hash() == txHash {
+			return tx, false, nil
+		}
+	}
+	return nil, false, fmt.Errorf("transaction not found")
+}
+
+type DeployTestSuite struct {
+	suite.Suite
+	transport *recordingTransport
+	signer    SignerWithTransport
+	address   common.Address
+	contract  models.EVMContract
+}
+
+func TestDeployTestSuite(t *testing.T) {
+	suite.Run(t, new(DeployTestSuite))
+}
+
+func (suite *DeployTestSuite) SetupTest() {
+	baseSigner, err := NewPrivateKeySigner("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	suite.Require().NoError(err)
+	pkSigner, ok := baseSigner.(*PrivateKeySigner)
+	suite.Require().True(ok)
+
+	suite.transport = &recordingTransport{status: types.ReceiptStatusSuccessful}
+	suite.signer = pkSigner.WithTransport(suite.transport)
+	suite.address = pkSigner.GetAddress()
+
+	var abiArray abi.AbiArray
+	suite.Require().NoError(json.Unmarshal([]byte(constructorABI), &abiArray))
+	bytecode := "0x6080604052"
+	suite.contract = models.EVMContract{
+		Name:     "Token",
+		Status:   models.DeploymentStatusPending,
+		Bytecode: &bytecode,
+		Abi:      &models.EvmAbi{Name: "Token", Abi: models.AbiArrayType{AbiArray: abiArray}},
+	}
+}
+
+func (suite *DeployTestSuite) TestDeployEncodesConstructorArguments() {
+	owner := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	receipt, err := DeployEVMContract(suite.signer, &suite.contract, nil, owner, big.NewInt(1000))
+	suite.Require().NoError(err)
+	suite.Require().NotNil(receipt)
+
+	suite.Require().Len(suite.transport.sent, 1)
+	sent := suite.transport.sent[0]
+	suite.Nil(sent.To(), "deployment must be a contract-creation transaction")
+
+	expected := common.FromHex("0x6080604052")
+	expected = append(expected, common.LeftPadBytes(owner.Bytes(), 32)...)
+	expected = append(expected, common.LeftPadBytes(big.NewInt(1000).Bytes(), 32)...)
+	suite.Equal(expected, sent.Data())
+
+	suite.Equal(models.DeploymentStatusDeployed, suite.contract.Status)
+	suite.Equal(crypto.CreateAddress(suite.address, 0).Hex(), suite.contract.Address)
+	suite.Equal([]common.Address{suite.address}, suite.transport.estimatedFrom, "gas is estimated as sent from the signer")
+}
+
+func (suite *DeployTestSuite) TestDeployRevertedMarksFailed() {
+	suite.transport.status = types.ReceiptStatusFailed
+
+	_, err := DeployEVMContract(suite.signer, &suite.contract, nil, suite.address, big.NewInt(1))
+	suite.True(errors.HasCode(err, errors.ErrCodeContractDeployFailed), "unexpected error: %v", err)
+	suite.Equal(models.DeploymentStatusFailed, suite.contract.Status)
+	suite.Empty(suite.contract.Address)
+}
+
+func (suite *DeployTestSuite) TestDeployInvalidArgumentsStaysPending() {
+	_, err := DeployEVMContract(suite.signer, &suite.contract, nil, "not-an-address")
+	suite.True(errors.HasCode(err, errors.ErrCodeABIPackFailed), "unexpected error: %v", err)
+	suite.Equal(models.DeploymentStatusPending, suite.contract.Status)
+	suite.Empty(suite.transport.sent)
+}
+
+func (suite *DeployTestSuite) TestDeployRequiresPendingContractWithBytecode() {
+	suite.contract.Status = models.DeploymentStatusDeployed
+	_, err := DeployEVMContract(suite.signer, &suite.contract, nil)
+	suite.True(errors.HasCode(err, errors.ErrCodeContractNotDeployable))
+
+	suite.contract.Status = models.DeploymentStatusPending
+	suite.contract.Bytecode = nil
+	_, err = DeployEVMContract(suite.signer, &suite.contract, nil)
+	suite.True(errors.HasCode(err, errors.ErrCodeContractNotDeployable))
+}
+
+func (suite *DeployTestSuite) TestDeployWithoutABI() {
+	suite.contract.Abi = nil
+
+	_, err := DeployEVMContract(suite.signer, &suite.contract, nil)
+	suite.Require().NoError(err)
+	suite.Equal(common.FromHex("0x6080604052"), suite.transport.sent[0].Data())
+}
@@ -0,0 +1,48 @@
+package signer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// MessageMode selects how a message is hashed before it is signed or verified.
+type MessageMode string
+
+const (
+	// MessageModePersonal prefixes the message as an EIP-191 personal message, like personal_sign.
+	MessageModePersonal MessageMode = "personal"
+	// MessageModeHash signs a 32 byte hash as is.
+	MessageModeHash MessageMode = "hash"
+)
+
+// DecodeMessage interprets user input as a message: 0x-prefixed hex is decoded to its bytes and
+// anything else is taken as UTF-8 text.
+func DecodeMessage(input string) (message []byte, isHex bool) {
+	if strings.HasPrefix(input, "0x") || strings.HasPrefix(input, "0X") {
+		if data, err := hexutil.Decode("0x" + input[2:]); err == nil {
+			return data, true
+		}
+	}
+	return []byte(input), false
+}
+
+// MessageHash returns the hash that is signed for the input in the given mode. In hash mode the
+// input must be a 0x-prefixed 32 byte hash.
+func MessageHash(input string, mode MessageMode) (common.Hash, error) {
+	message, isHex := DecodeMessage(input)
+	if mode != MessageModeHash {
+		return PersonalMessageHash(message), nil
+	}
+	if !isHex || len(message) != common.HashLength {
+		return common.Hash{}, errors.NewSignerErrorWithDetails(
+			errors.ErrCodeInvalidMessageHash,
+			"invalid hash",
+			fmt.Sprintf("expected 32 bytes as 0x-prefixed hex, got %d bytes", len(message)),
+		)
+	}
+	return common.BytesToHash(message), nil
+}
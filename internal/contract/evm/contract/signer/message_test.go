@@ -0,0 +1,89 @@
+package signer
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+// helloHash is the EIP-191 personal message hash of "hello".
+const helloHash = "0x50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750"
+
+type MessageTestSuite struct {
+	suite.Suite
+	signer *PrivateKeySigner
+}
+
+func TestMessageTestSuite(t *testing.T) {
+	suite.Run(t, new(MessageTestSuite))
+}
+
+func (s *MessageTestSuite) SetupSuite() {
+	signer, err := NewPrivateKeySigner(testPrivateKey)
+	s.Require().NoError(err)
+	s.signer = signer.(*PrivateKeySigner)
+}
+
+func (s *MessageTestSuite) TestDecodeMessage() {
+	message, isHex := DecodeMessage("0x68656c6c6f")
+	s.True(isHex)
+	s.Equal([]byte("hello"), message)
+
+	message, isHex = DecodeMessage("0xnot hex")
+	s.False(isHex)
+	s.Equal([]byte("0xnot hex"), message)
+
+	message, isHex = DecodeMessage("hello")
+	s.False(isHex)
+	s.Equal([]byte("hello"), message)
+}
+
+func (s *MessageTestSuite) TestMessageHash() {
+	hash, err := MessageHash("hello", MessageModePersonal)
+	s.Require().NoError(err)
+	s.Equal(helloHash, hash.Hex())
+
+	// Hex input signs the bytes, not the hex text.
+	hash, err = MessageHash("0x68656c6c6f", MessageModePersonal)
+	s.Require().NoError(err)
+	s.Equal(helloHash, hash.Hex())
+
+	hash, err = MessageHash(helloHash, MessageModeHash)
+	s.Require().NoError(err)
+	s.Equal(helloHash, hash.Hex())
+
+	for _, input := range []string{"hello", "0x1234"} {
+		_, err = MessageHash(input, MessageModeHash)
+		s.True(errors.HasCode(err, errors.ErrCodeInvalidMessageHash), "%s: %v", input, err)
+	}
+}
+
+func (s *MessageTestSuite) TestSignMessageMatchesString() {
+	fromBytes, err := s.signer.SignMessage([]byte("hello"))
+	s.Require().NoError(err)
+	fromString, err := s.signer.SignMessageString("hello")
+	s.Require().NoError(err)
+	s.Equal(fromString, fromBytes)
+
+	recovered, err := RecoverAddress(common.HexToHash(helloHash), fromBytes)
+	s.Require().NoError(err)
+	s.Equal(common.HexToAddress(testAddress), recovered)
+}
+
+func (s *MessageTestSuite) TestSignHash() {
+	hash := crypto.Keccak256Hash([]byte("order #1"))
+	signature, err := s.signer.SignHash(hash)
+	s.Require().NoError(err)
+
+	recovered, err := RecoverAddress(hash, signature)
+	s.Require().NoError(err)
+	s.Equal(common.HexToAddress(testAddress), recovered)
+
+	// The raw hash signature does not verify as a personal message of the same bytes.
+	isValid, _, err := s.signer.VerifyMessageString(common.HexToAddress(testAddress), string(hash.Bytes()), signature)
+	s.Require().NoError(err)
+	s.False(isValid)
+}
@@ -0,0 +1,356 @@
+package signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Transaction types of an UnsignedTransaction.
+const (
+	TransactionTypeDynamicFee = "eip1559"
+	TransactionTypeLegacy     = "legacy"
+)
+
+// UnsignedTransaction is a transaction exported for signing on another machine. Amounts are decimal
+// strings in wei so the file can be reviewed before it is signed. To is empty for contract creation
+// and From, when set, is the address that is expected to sign.
+type UnsignedTransaction struct {
+	Type                 string `json:"type"`
+	ChainID              string `json:"chainId"`
+	From                 string `json:"from,omitempty"`
+	To                   string `json:"to,omitempty"`
+	Nonce                uint64 `json:"nonce"`
+	Value                string `json:"value"`
+	Data                 string `json:"data"`
+	Gas                  uint64 `json:"gas"`
+	GasPrice             string `json:"gasPrice,omitempty"`
+	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+}
+
+// TransactionRequest describes a transaction to build. Unset fields are filled in from the node:
+// the nonce of From, an estimated gas limit and the default fees used for online transactions.
+type TransactionRequest struct {
+	From     common.Address
+	To       *common.Address
+	Value    *big.Int
+	Data     []byte
+	Nonce    *uint64
+	GasLimit uint64
+	// GasPrice builds a legacy transaction instead of an EIP-1559 one.
+	GasPrice  *big.Int
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+}
+
+// BuildUnsignedTransaction fills in a transaction request from the node without signing it. The gas
+// limit is estimated as sent from From, so calls that depend on msg.sender estimate correctly.
+func BuildUnsignedTransaction(tr transport.Transport, request TransactionRequest) (*UnsignedTransaction, error) {
+	chainID, err := tr.GetChainID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	nonce := uint64(0)
+	if request.Nonce != nil {
+		nonce = *request.Nonce
+	} else {
+		nonce, err = tr.GetTransactionCount(request.From)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction count: %w", err)
+		}
+	}
+
+	value := request.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	var transaction *types.Transaction
+	if request.GasPrice != nil {
+		transaction = types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: request.GasPrice,
+			Gas:      request.GasLimit,
+			To:       request.To,
+			Value:    value,
+			Data:     request.Data,
+		})
+	} else {
+		tipCap := request.GasTipCap
+		if tipCap == nil {
+			tipCap = big.NewInt(1000000000) // 1 gwei, as for online transactions
+		}
+		feeCap := request.GasFeeCap
+		if feeCap == nil {
+			feeCap = new(big.Int).Mul(tipCap, big.NewInt(2))
+		}
+		transaction = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: tipCap,
+			GasFeeCap: feeCap,
+			Gas:       request.GasLimit,
+			To:        request.To,
+			Value:     value,
+			Data:      request.Data,
+		})
+	}
+
+	unsigned := NewUnsignedTransaction(transaction, chainID, request.From)
+	if request.GasLimit == 0 {
+		estimated, err := tr.EstimateCallGas(transport.CallMsg(transaction, request.From))
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		// Same buffer as online transactions, estimation can be inaccurate
+		unsigned.Gas = estimated + estimated/2
+	}
+	return unsigned, nil
+}
+
+// NewUnsignedTransaction exports a transaction. From may be the zero address when the signer is not known.
+func NewUnsignedTransaction(transaction *types.Transaction, chainID *big.Int, from common.Address) *UnsignedTransaction {
+	unsigned := &UnsignedTransaction{
+		Type:    TransactionTypeDynamicFee,
+		ChainID: chainID.String(),
+		Nonce:   transaction.Nonce(),
+		Value:   transaction.Value().String(),
+		Data:    hexutil.Encode(transaction.Data()),
+		Gas:     transaction.Gas(),
+	}
+	if from != (common.Address{}) {
+		unsigned.From = from.Hex()
+	}
+	if transaction.To() != nil {
+		unsigned.To = transaction.To().Hex()
+	}
+	if transaction.Type() == types.LegacyTxType {
+		unsigned.Type = TransactionTypeLegacy
+		unsigned.GasPrice = transaction.GasPrice().String()
+	} else {
+		unsigned.MaxFeePerGas = transaction.GasFeeCap().String()
+		unsigned.MaxPriorityFeePerGas = transaction.GasTipCap().String()
+	}
+	return unsigned
+}
+
+// Transaction converts the exported transaction back into an unsigned transaction.
+func (u *UnsignedTransaction) Transaction() (*types.Transaction, error) {
+	transaction, _, err := u.transaction()
+	return transaction, err
+}
+
+// transaction converts the exported transaction and returns its chain ID, which legacy transactions
+// only carry once they are signed.
+func (u *UnsignedTransaction) transaction() (*types.Transaction, *big.Int, error) {
+	chainID, err := parseAmount("chainId", u.ChainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if chainID.Sign() <= 0 {
+		return nil, nil, errors.NewSignerError(errors.ErrCodeInvalidChainID, "transaction has no chain ID")
+	}
+	value, err := parseAmount("value", u.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := hexutil.Decode(normalizeHex(u.Data))
+	if err != nil {
+		return nil, nil, errors.WrapSignerError(err, errors.ErrCodeInvalidTransaction, "invalid transaction data")
+	}
+	var recipient *common.Address
+	if u.To != "" {
+		if !common.IsHexAddress(u.To) {
+			return nil, nil, errors.NewSignerError(errors.ErrCodeInvalidTransaction, fmt.Sprintf("invalid recipient %s", u.To))
+		}
+		address := common.HexToAddress(u.To)
+		recipient = &address
+	}
+	if u.Gas == 0 {
+		return nil, nil, errors.NewSignerError(errors.ErrCodeInvalidTransaction, "transaction has no gas limit")
+	}
+
+	switch u.Type {
+	case TransactionTypeLegacy:
+		gasPrice, err := parseAmount("gasPrice", u.GasPrice)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Legacy transactions carry the chain ID in their EIP-155 signature
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    u.Nonce,
+			GasPrice: gasPrice,
+			Gas:      u.Gas,
+			To:       recipient,
+			Value:    value,
+			Data:     data,
+		}), chainID, nil
+	case TransactionTypeDynamicFee, "":
+		feeCap, err := parseAmount("maxFeePerGas", u.MaxFeePerGas)
+		if err != nil {
+			return nil, nil, err
+		}
+		tipCap, err := parseAmount("maxPriorityFeePerGas", u.MaxPriorityFeePerGas)
+		if err != nil {
+			return nil, nil, err
+		}
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     u.Nonce,
+			GasTipCap: tipCap,
+			GasFeeCap: feeCap,
+			Gas:       u.Gas,
+			To:        recipient,
+			Value:     value,
+			Data:      data,
+		}), chainID, nil
+	default:
+		return nil, nil, errors.NewSignerError(errors.ErrCodeInvalidTransaction, fmt.Sprintf("unsupported transaction type %q", u.Type))
+	}
+}
+
+// ReadUnsignedTransaction reads an exported transaction from a file.
+func ReadUnsignedTransaction(path string) (*UnsignedTransaction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction file: %w", err)
+	}
+	unsigned := &UnsignedTransaction{}
+	if err := json.Unmarshal(data, unsigned); err != nil {
+		return nil, errors.WrapSignerError(err, errors.ErrCodeInvalidTransaction, fmt.Sprintf("failed to parse transaction file %s", path))
+	}
+	return unsigned, nil
+}
+
+// WriteUnsignedTransaction writes an exported transaction to a file.
+func WriteUnsignedTransaction(path string, unsigned *UnsignedTransaction) error {
+	data, err := json.MarshalIndent(unsigned, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write transaction file: %w", err)
+	}
+	return nil
+}
+
+// SignOffline signs an exported transaction without a transport, so it works on an air-gapped
+// machine. When the transaction names a sender, the signer must be that address.
+func SignOffline(signer Signer, unsigned *UnsignedTransaction) (*types.Transaction, error) {
+	transaction, chainID, err := unsigned.transaction()
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Type() == types.LegacyTxType {
+		transaction, err = signLegacy(signer, transaction, chainID)
+	} else {
+		transaction, err = signer.SignTransaction(transaction)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(transaction.ChainId()), transaction)
+	if err != nil {
+		return nil, errors.WrapSignerError(err, errors.ErrCodePublicKeyRecovery, "failed to recover the transaction sender")
+	}
+	if unsigned.From != "" && !strings.EqualFold(sender.Hex(), unsigned.From) {
+		return nil, errors.NewSignerError(errors.ErrCodeSignerMismatch, fmt.Sprintf("transaction is for %s but the key belongs to %s", unsigned.From, sender.Hex()))
+	}
+	return transaction, nil
+}
+
+// signLegacy signs a legacy transaction with EIP-155 replay protection. Unsigned legacy transactions
+// have no chain ID of their own, so only private key signers are supported.
+func signLegacy(signer Signer, transaction *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	keySigner, ok := signer.(*PrivateKeySigner)
+	if !ok {
+		return nil, errors.NewSignerError(errors.ErrCodeTransactionSignFailed, fmt.Sprintf("legacy transactions cannot be signed by %T", signer))
+	}
+	signed, err := types.SignTx(transaction, types.NewEIP155Signer(chainID), keySigner.PrivateKey)
+	if err != nil {
+		return nil, errors.WrapSignerError(err, errors.ErrCodeTransactionSignFailed, "failed to sign transaction")
+	}
+	return signed, nil
+}
+
+// EncodeSignedTransaction returns the 0x-prefixed RLP (typed envelope) encoding of a signed transaction,
+// as accepted by eth_sendRawTransaction.
+func EncodeSignedTransaction(transaction *types.Transaction) (string, error) {
+	data, err := transaction.MarshalBinary()
+	if err != nil {
+		return "", errors.WrapSignerError(err, errors.ErrCodeInvalidTransaction, "failed to encode transaction")
+	}
+	return hexutil.Encode(data), nil
+}
+
+// DecodeSignedTransaction parses a raw signed transaction and checks that it carries a valid signature.
+func DecodeSignedTransaction(raw string) (*types.Transaction, common.Address, error) {
+	data, err := hexutil.Decode(normalizeHex(strings.TrimSpace(raw)))
+	if err != nil {
+		return nil, common.Address{}, errors.WrapSignerError(err, errors.ErrCodeInvalidTransaction, "raw transaction is not valid hex")
+	}
+	transaction := &types.Transaction{}
+	if err := transaction.UnmarshalBinary(data); err != nil {
+		return nil, common.Address{}, errors.WrapSignerError(err, errors.ErrCodeInvalidTransaction, "failed to decode raw transaction")
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(transaction.ChainId()), transaction)
+	if err != nil {
+		return nil, common.Address{}, errors.WrapSignerError(err, errors.ErrCodeInvalidSignature, "raw transaction is not signed")
+	}
+	return transaction, sender, nil
+}
+
+// BroadcastSignedTransaction submits a raw signed transaction. The chain ID of the transaction must
+// match the node's.
+func BroadcastSignedTransaction(tr transport.Transport, raw string) (*types.Transaction, error) {
+	transaction, _, err := DecodeSignedTransaction(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := tr.GetChainID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	if transaction.Protected() && transaction.ChainId().Cmp(chainID) != 0 {
+		return nil, errors.NewSignerError(errors.ErrCodeInvalidChainID, fmt.Sprintf("transaction is for chain %s but the endpoint is on chain %s", transaction.ChainId(), chainID))
+	}
+
+	if _, err := tr.SendTransaction(transaction); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	return transaction, nil
+}
+
+func parseAmount(field string, value string) (*big.Int, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, errors.NewSignerError(errors.ErrCodeInvalidTransaction, fmt.Sprintf("transaction has no %s", field))
+	}
+	amount, ok := new(big.Int).SetString(strings.TrimSpace(value), 0)
+	if !ok || amount.Sign() < 0 {
+		return nil, errors.NewSignerError(errors.ErrCodeInvalidTransaction, fmt.Sprintf("invalid %s %q", field, value))
+	}
+	return amount, nil
+}
+
+func normalizeHex(value string) string {
+	if value == "" {
+		return "0x"
+	}
+	if !strings.HasPrefix(value, "0x") && !strings.HasPrefix(value, "0X") {
+		return "0x" + value
+	}
+	return value
+}
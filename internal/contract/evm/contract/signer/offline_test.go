@@ -0,0 +1,235 @@
+package signer
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+// offlineTransport answers the calls needed to build a transaction and records sent transactions.
+type offlineTransport struct {
+	transport.Transport
+	chainID *big.Int
+	sent    []*types.Transaction
+	// owner, when set, makes every estimate revert unless it is sent from the owner, like a call of
+	// an owner-only function.
+	owner     common.Address
+	estimated []ethereum.CallMsg
+}
+
+func (o *offlineTransport) GetChainID() (*big.Int, error) {
+	return o.chainID, nil
+}
+
+func (o *offlineTransport) GetTransactionCount(common.Address) (uint64, error) {
+	return 7, nil
+}
+
+func (o *offlineTransport) EstimateCallGas(msg ethereum.CallMsg) (uint64, error) {
+	o.estimated = append(o.estimated, msg)
+	if o.owner != (common.Address{}) && msg.From != o.owner {
+		return 0, errors.NewTransportError(errors.ErrCodeGasEstimateFailed, "execution reverted: caller is not the owner")
+	}
+	return 21000, nil
+}
+
+func (o *offlineTransport) SendTransaction(tx *types.Transaction) (common.Hash, error) {
+	o.sent = append(o.sent, tx)
+	return tx.Hash(), nil
+}
+
+// OfflineTestSuite covers building, signing and broadcasting transactions on separate machines.
+type OfflineTestSuite struct {
+	suite.Suite
+	transport *offlineTransport
+	signer    Signer
+	recipient common.Address
+}
+
+func TestOfflineTestSuite(t *testing.T) {
+	suite.Run(t, new(OfflineTestSuite))
+}
+
+func (s *OfflineTestSuite) SetupTest() {
+	s.transport = &offlineTransport{chainID: big.NewInt(testChainID)}
+	signer, err := NewPrivateKeySigner(testPrivateKey)
+	s.Require().NoError(err)
+	s.signer = signer
+	s.recipient = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+}
+
+func (s *OfflineTestSuite) build(request TransactionRequest) *UnsignedTransaction {
+	unsigned, err := BuildUnsignedTransaction(s.transport, request)
+	s.Require().NoError(err)
+	return unsigned
+}
+
+func (s *OfflineTestSuite) TestBuildFillsDefaults() {
+	unsigned := s.build(TransactionRequest{
+		From:  common.HexToAddress(testAddress),
+		To:    &s.recipient,
+		Value: big.NewInt(1000),
+		Data:  []byte{0xde, 0xad},
+	})
+
+	s.Equal(&UnsignedTransaction{
+		Type:                 TransactionTypeDynamicFee,
+		ChainID:              "31337",
+		From:                 testAddress,
+		To:                   s.recipient.Hex(),
+		Nonce:                7,
+		Value:                "1000",
+		Data:                 "0xdead",
+		Gas:                  31500,
+		MaxFeePerGas:         "2000000000",
+		MaxPriorityFeePerGas: "1000000000",
+	}, unsigned)
+}
+
+func (s *OfflineTestSuite) TestBuildEstimatesAsSender() {
+	s.transport.owner = common.HexToAddress(testAddress)
+
+	unsigned := s.build(TransactionRequest{From: common.HexToAddress(testAddress), To: &s.recipient, Data: []byte{0xf2, 0xfd, 0xe3, 0x8b}})
+	s.Equal(uint64(31500), unsigned.Gas)
+	s.Require().Len(s.transport.estimated, 1)
+	s.Equal(common.HexToAddress(testAddress), s.transport.estimated[0].From)
+	s.Equal(&s.recipient, s.transport.estimated[0].To)
+
+	_, err := BuildUnsignedTransaction(s.transport, TransactionRequest{From: s.recipient, To: &s.recipient})
+	s.True(errors.HasCode(err, errors.ErrCodeGasEstimateFailed), "%v", err)
+}
+
+func (s *OfflineTestSuite) TestBuildKeepsExplicitValues() {
+	nonce := uint64(2)
+	unsigned := s.build(TransactionRequest{
+		From:     common.HexToAddress(testAddress),
+		Nonce:    &nonce,
+		GasLimit: 100000,
+		GasPrice: big.NewInt(5),
+		Data:     []byte{0x60, 0x80},
+	})
+
+	s.Equal(TransactionTypeLegacy, unsigned.Type)
+	s.Equal(uint64(2), unsigned.Nonce)
+	s.Equal(uint64(100000), unsigned.Gas)
+	s.Equal("5", unsigned.GasPrice)
+	s.Empty(unsigned.To)
+	s.Empty(unsigned.MaxFeePerGas)
+}
+
+func (s *OfflineTestSuite) TestSignFileAndBroadcast() {
+	path := filepath.Join(s.T().TempDir(), "unsigned.json")
+	s.Require().NoError(WriteUnsignedTransaction(path, s.build(TransactionRequest{
+		From:  common.HexToAddress(testAddress),
+		To:    &s.recipient,
+		Value: big.NewInt(1000),
+	})))
+
+	// The air-gapped machine only has the file and the key.
+	unsigned, err := ReadUnsignedTransaction(path)
+	s.Require().NoError(err)
+	signed, err := SignOffline(s.signer, unsigned)
+	s.Require().NoError(err)
+	raw, err := EncodeSignedTransaction(signed)
+	s.Require().NoError(err)
+	s.Contains(raw, "0x02")
+
+	decoded, sender, err := DecodeSignedTransaction(raw)
+	s.Require().NoError(err)
+	s.Equal(common.HexToAddress(testAddress), sender)
+	s.Equal(signed.Hash(), decoded.Hash())
+	s.Equal(uint64(7), decoded.Nonce())
+	s.Equal(&s.recipient, decoded.To())
+
+	broadcast, err := BroadcastSignedTransaction(s.transport, raw)
+	s.Require().NoError(err)
+	s.Equal(signed.Hash(), broadcast.Hash())
+	s.Require().Len(s.transport.sent, 1)
+	s.Equal(signed.Hash(), s.transport.sent[0].Hash())
+}
+
+func (s *OfflineTestSuite) TestSignLegacyTransaction() {
+	unsigned := s.build(TransactionRequest{
+		From:     common.HexToAddress(testAddress),
+		To:       &s.recipient,
+		GasPrice: big.NewInt(1000000000),
+	})
+
+	signed, err := SignOffline(s.signer, unsigned)
+	s.Require().NoError(err)
+	s.Equal(uint8(types.LegacyTxType), signed.Type())
+	s.True(signed.Protected())
+	s.Equal(big.NewInt(testChainID), signed.ChainId())
+}
+
+func (s *OfflineTestSuite) TestSignLegacyTransactionWithHexChainID() {
+	unsigned := s.build(TransactionRequest{
+		From:     common.HexToAddress(testAddress),
+		To:       &s.recipient,
+		GasPrice: big.NewInt(1000000000),
+	})
+	unsigned.ChainID = "0x7a69"
+
+	signed, err := SignOffline(s.signer, unsigned)
+	s.Require().NoError(err)
+	s.True(signed.Protected())
+	s.Equal(big.NewInt(testChainID), signed.ChainId())
+}
+
+func (s *OfflineTestSuite) TestSignRejectsOtherKey() {
+	unsigned := s.build(TransactionRequest{From: s.recipient, To: &s.recipient})
+
+	_, err := SignOffline(s.signer, unsigned)
+	s.True(errors.HasCode(err, errors.ErrCodeSignerMismatch), "%v", err)
+
+	unsigned.From = ""
+	_, err = SignOffline(s.signer, unsigned)
+	s.NoError(err)
+}
+
+func (s *OfflineTestSuite) TestInvalidTransactions() {
+	valid := s.build(TransactionRequest{From: common.HexToAddress(testAddress), To: &s.recipient})
+	cases := map[string]func(*UnsignedTransaction){
+		"missing fee":  func(u *UnsignedTransaction) { u.MaxFeePerGas = "" },
+		"bad value":    func(u *UnsignedTransaction) { u.Value = "-1" },
+		"bad data":     func(u *UnsignedTransaction) { u.Data = "0xzz" },
+		"bad to":       func(u *UnsignedTransaction) { u.To = "0x1234" },
+		"no gas":       func(u *UnsignedTransaction) { u.Gas = 0 },
+		"unknown type": func(u *UnsignedTransaction) { u.Type = "blob" },
+	}
+	for name, mutate := range cases {
+		unsigned := *valid
+		mutate(&unsigned)
+		_, err := SignOffline(s.signer, &unsigned)
+		s.True(errors.HasCode(err, errors.ErrCodeInvalidTransaction), "%s: %v", name, err)
+	}
+
+	_, _, err := DecodeSignedTransaction("0x1234")
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidTransaction))
+
+	unsigned, err := valid.Transaction()
+	s.Require().NoError(err)
+	raw, err := EncodeSignedTransaction(unsigned)
+	s.Require().NoError(err)
+	_, _, err = DecodeSignedTransaction(raw)
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidSignature), "%v", err)
+}
+
+func (s *OfflineTestSuite) TestBroadcastRejectsOtherChain() {
+	signed, err := SignOffline(s.signer, s.build(TransactionRequest{From: common.HexToAddress(testAddress), To: &s.recipient}))
+	s.Require().NoError(err)
+	raw, err := EncodeSignedTransaction(signed)
+	s.Require().NoError(err)
+
+	s.transport.chainID = big.NewInt(1)
+	_, err = BroadcastSignedTransaction(s.transport, raw)
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidChainID), "%v", err)
+	s.Empty(s.transport.sent)
+}
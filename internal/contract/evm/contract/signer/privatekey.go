@@ -33,11 +33,13 @@ func NewPrivateKeySigner(privateKey string) (Signer, error) {
 
 // SignMessageString implements Signer.
 func (p *PrivateKeySigner) SignMessageString(message string) (signature string, err error) {
-	// Hash the message using Keccak256 with Ethereum's message prefix
-	hash := crypto.Keccak256Hash([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)))
+	return p.SignMessage([]byte(message))
+}
 
+// SignMessage signs bytes as an EIP-191 personal message, like personal_sign.
+func (p *PrivateKeySigner) SignMessage(message []byte) (signature string, err error) {
 	// Sign the hash
-	sig, err := crypto.Sign(hash.Bytes(), p.PrivateKey)
+	sig, err := crypto.Sign(PersonalMessageHash(message).Bytes(), p.PrivateKey)
 	if err != nil {
 		return "", errors.WrapSignerError(err, errors.ErrCodeSigningFailed, "failed to sign message")
 	}
@@ -46,6 +48,21 @@ func (p *PrivateKeySigner) SignMessageString(message string) (signature string,
 	return "0x" + hex.EncodeToString(sig), nil
 }
 
+// SignHash signs a 32 byte hash as is, without the personal message prefix. Whoever supplied the hash
+// decides what is signed, so it may be a transaction or permit digest.
+func (p *PrivateKeySigner) SignHash(hash common.Hash) (signature string, err error) {
+	sig, err := crypto.Sign(hash.Bytes(), p.PrivateKey)
+	if err != nil {
+		return "", errors.WrapSignerError(err, errors.ErrCodeSigningFailed, "failed to sign hash")
+	}
+	return "0x" + hex.EncodeToString(sig), nil
+}
+
+// PersonalMessageHash hashes a message with Ethereum's message prefix, as defined by EIP-191 version 0x45.
+func PersonalMessageHash(message []byte) common.Hash {
+	return crypto.Keccak256Hash([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))), message)
+}
+
 // SignTransaction implements Signer.
 func (p *PrivateKeySigner) SignTransaction(transaction *types.Transaction) (signedTx *types.Transaction, err error) {
 	// Get the chain ID from the transaction
@@ -68,6 +85,26 @@ func (p *PrivateKeySigner) SignTransaction(transaction *types.Transaction) (sign
 
 // VerifyMessageString implements Signer.
 func (p *PrivateKeySigner) VerifyMessageString(address common.Address, message string, signature string) (isValid bool, recoveredAddress common.Address, err error) {
+	return verifyMessage(address, []byte(message), signature)
+}
+
+// verifyMessage verifies a personal message signature. It needs no key, so every signer shares it.
+func verifyMessage(address common.Address, message []byte, signature string) (isValid bool, recoveredAddress common.Address, err error) {
+	// Hash the message with Ethereum's message prefix (same as signing)
+	recoveredAddress, err = RecoverAddress(PersonalMessageHash(message), signature)
+	if err != nil {
+		return false, common.Address{}, err
+	}
+
+	// Check if the recovered address matches the provided address
+	isValid = recoveredAddress == address
+
+	return isValid, recoveredAddress, nil
+}
+
+// RecoverAddress recovers the address that produced a hex encoded 65 byte signature of a hash.
+// The recovery id may be 0/1 or 27/28.
+func RecoverAddress(hash common.Hash, signature string) (common.Address, error) {
 	// Remove 0x prefix if present
 	if len(signature) > 2 && signature[:2] == "0x" {
 		signature = signature[2:]
@@ -76,21 +113,18 @@ func (p *PrivateKeySigner) VerifyMessageString(address common.Address, message s
 	// Decode the signature from hex
 	sig, err := hex.DecodeString(signature)
 	if err != nil {
-		return false, common.Address{}, errors.WrapSignerError(err, errors.ErrCodeSignatureDecode, "failed to decode signature")
+		return common.Address{}, errors.WrapSignerError(err, errors.ErrCodeSignatureDecode, "failed to decode signature")
 	}
 
 	// Ensure signature is 65 bytes (r, s, v)
 	if len(sig) != 65 {
-		return false, common.Address{}, errors.NewSignerErrorWithDetails(
+		return common.Address{}, errors.NewSignerErrorWithDetails(
 			errors.ErrCodeInvalidSignatureLength,
 			"invalid signature length",
 			fmt.Sprintf("expected 65 bytes, got %d", len(sig)),
 		)
 	}
 
-	// Hash the message with Ethereum's message prefix (same as signing)
-	hash := crypto.Keccak256Hash([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)))
-
 	// Adjust recovery id if needed (go-ethereum expects 0 or 1, but metamask sends 27 or 28)
 	if sig[64] >= 27 {
 		sig[64] -= 27
@@ -99,16 +133,11 @@ func (p *PrivateKeySigner) VerifyMessageString(address common.Address, message s
 	// Recover the public key from the signature
 	pubKey, err := crypto.SigToPub(hash.Bytes(), sig)
 	if err != nil {
-		return false, common.Address{}, errors.WrapSignerError(err, errors.ErrCodePublicKeyRecovery, "failed to recover public key")
+		return common.Address{}, errors.WrapSignerError(err, errors.ErrCodePublicKeyRecovery, "failed to recover public key")
 	}
 
 	// Get the address from the recovered public key
-	recoveredAddress = crypto.PubkeyToAddress(*pubKey)
-
-	// Check if the recovered address matches the provided address
-	isValid = recoveredAddress == address
-
-	return isValid, recoveredAddress, nil
+	return crypto.PubkeyToAddress(*pubKey), nil
 }
 
 func (p *PrivateKeySigner) GetAddress() common.Address {
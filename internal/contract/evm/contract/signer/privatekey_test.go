@@ -3,7 +3,6 @@ package signer
 import (
 	"math/big"
 	"testing"
-	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -15,8 +14,6 @@ const (
 	// Anvil default test account.
 	testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
 	testAddress    = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
-	// Anvil endpoint for E2E tests.
-	testEndpoint = "http://localhost:8545"
 	// Anvil chain ID.
 	testChainID = 31337
 )
@@ -358,14 +355,12 @@ func (suite *PrivateKeySignerTestSuite) TestSignTransaction() {
 	suite.T().Logf("V: %s, R: %s, S: %s", vValue.String(), rValue.String(), sValue.String())
 }
 
-// TestSignTransactionAndSendToE2E is an E2E integration test.
+// TestSignTransactionAndSendToE2E sends a signed transaction to an in-process simulated chain.
 func (suite *PrivateKeySignerTestSuite) TestSignTransactionAndSendToE2E() {
-	// Create transport
-	transport, err := transport.NewHTTPTransport(testEndpoint, 30*time.Second)
-	if err != nil {
-		suite.T().Skipf("Anvil network not running: %v (run 'make e2e-network' first)", err)
-		return
-	}
+	// Create transport on a chain funding the anvil test accounts
+	transport, err := transport.NewSimulatedTransport(nil)
+	suite.Require().NoError(err, "failed to create transport")
+	defer func() { _ = transport.Close() }()
 
 	// Get current nonce
 	nonce, err := transport.GetTransactionCount(suite.testAddress)
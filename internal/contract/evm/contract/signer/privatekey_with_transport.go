@@ -13,19 +13,25 @@ import (
 	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
 )
 
-type PrivateKeySignerWithTransport struct {
-	*PrivateKeySigner
+// AccountSignerWithTransport sends transactions through a transport, signing them with an AccountSigner.
+type AccountSignerWithTransport struct {
+	AccountSigner
 	transport transport.Transport
 }
 
-// WithTransport creates a new PrivateKeySignerWithTransport with the given transport.
-func (p *PrivateKeySigner) WithTransport(transport transport.Transport) SignerWithTransport {
-	return &PrivateKeySignerWithTransport{
-		PrivateKeySigner: p,
-		transport:        transport,
+// NewAccountSignerWithTransport creates a new AccountSignerWithTransport with the given signer and transport.
+func NewAccountSignerWithTransport(accountSigner AccountSigner, transport transport.Transport) SignerWithTransport {
+	return &AccountSignerWithTransport{
+		AccountSigner: accountSigner,
+		transport:     transport,
 	}
 }
 
+// WithTransport creates a new AccountSignerWithTransport with the given transport.
+func (p *PrivateKeySigner) WithTransport(transport transport.Transport) SignerWithTransport {
+	return NewAccountSignerWithTransport(p, transport)
+}
+
 // Helper function to find method in ABI.
 func findMethodInABI(customABI abi.ABI, methodName string) *abi.ABIElement {
 	elements := customABI.Elements()
@@ -54,7 +60,7 @@ func convertToEthereumABI(customABI abi.ABI) (ethabi.ABI, error) {
 }
 
 // executeReadOnlyCall handles read-only contract method calls.
-func (p *PrivateKeySignerWithTransport) executeReadOnlyCall(contractAddress common.Address, contractABI abi.ABI, method *abi.ABIElement, methodName string, args ...any) ([]any, error) {
+func (p *AccountSignerWithTransport) executeReadOnlyCall(contractAddress common.Address, contractABI abi.ABI, method *abi.ABIElement, methodName string, args ...any) ([]any, error) {
 	// Call the contract using transport
 	rawResult, err := p.transport.CallContract(contractAddress, contractABI, methodName, args...)
 	if err != nil {
@@ -71,7 +77,7 @@ func (p *PrivateKeySignerWithTransport) executeReadOnlyCall(contractAddress comm
 }
 
 // decodeCallResult unpacks raw contract call result into typed values.
-func (p *PrivateKeySignerWithTransport) decodeCallResult(contractABI abi.ABI, methodName string, rawResult []byte) ([]any, error) {
+func (p *AccountSignerWithTransport) decodeCallResult(contractABI abi.ABI, methodName string, rawResult []byte) ([]any, error) {
 	ethABI, err := convertToEthereumABI(contractABI)
 	if err != nil {
 		return nil, err
@@ -87,7 +93,7 @@ func (p *PrivateKeySignerWithTransport) decodeCallResult(contractABI abi.ABI, me
 }
 
 // packFunctionData encodes function call data with arguments.
-func (p *PrivateKeySignerWithTransport) packFunctionData(contractABI abi.ABI, methodName string, args ...any) ([]byte, error) {
+func (p *AccountSignerWithTransport) packFunctionData(contractABI abi.ABI, methodName string, args ...any) ([]byte, error) {
 	ethABI, err := convertToEthereumABI(contractABI)
 	if err != nil {
 		return nil, err
@@ -113,7 +119,8 @@ func setDefaultTransactionParams(value, gasPrice **big.Int) {
 }
 
 // buildTransaction creates a transaction with gas estimation if needed.
-func (p *PrivateKeySignerWithTransport) buildTransaction(contractAddress common.Address, nonce uint64, value *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte) (*types.Transaction, error) {
+// A nil recipient creates a contract-creation transaction.
+func (p *AccountSignerWithTransport) buildTransaction(recipient *common.Address, nonce uint64, value *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte) (*types.Transaction, error) {
 	// Get chain ID from transport
 	chainID, err := p.transport.GetChainID()
 	if err != nil {
@@ -125,29 +132,23 @@ func (p *PrivateKeySignerWithTransport) buildTransaction(contractAddress common.
 	gasFeeCap := new(big.Int).Mul(gasPrice, big.NewInt(2)) // 2x gasPrice for max fee
 
 	if gasLimit == 0 {
-		// Estimate gas using a signed transaction so it has a valid 'from' address
-		// Use a reasonable default gas limit for estimation (not too high to avoid balance issues)
+		// Estimate gas as sent from the signer, so calls that depend on msg.sender estimate correctly
+		// No gas is set, the node caps the estimate at what the balance of the signer allows. A lower
+		// cap fails calls that forward gas to subcalls, such as a Safe execTransaction.
 		tempTx := types.NewTx(&types.DynamicFeeTx{
 			ChainID:   chainID,
 			Nonce:     nonce,
 			GasTipCap: gasTipCap,
 			GasFeeCap: gasFeeCap,
-			Gas:       100000, // reasonable gas limit for estimation
-			To:        &contractAddress,
+			To:        recipient,
 			Value:     value,
 			Data:      data,
 		})
 
-		// Sign the temp transaction so it has a valid 'from' address
-		signedTempTx, err := p.PrivateKeySigner.SignTransaction(tempTx)
+		estimatedGas, err := p.EstimateGas(tempTx)
 		if err != nil {
 			return nil, err
 		}
-
-		estimatedGas, err := p.transport.EstimateGas(signedTempTx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to estimate gas: %w", err)
-		}
 		// Add 50% buffer to gas estimate to avoid out-of-gas errors
 		// Gas estimation can be inaccurate, especially for complex contracts
 		gasLimit = estimatedGas + (estimatedGas / 2)
@@ -159,7 +160,7 @@ func (p *PrivateKeySignerWithTransport) buildTransaction(contractAddress common.
 		GasTipCap: gasTipCap,
 		GasFeeCap: gasFeeCap,
 		Gas:       gasLimit,
-		To:        &contractAddress,
+		To:        recipient,
 		Value:     value,
 		Data:      data,
 	})
@@ -167,9 +168,9 @@ func (p *PrivateKeySignerWithTransport) buildTransaction(contractAddress common.
 }
 
 // executeWriteTransaction signs and sends a transaction, then waits for receipt.
-func (p *PrivateKeySignerWithTransport) executeWriteTransaction(tx *types.Transaction) ([]any, error) {
+func (p *AccountSignerWithTransport) executeWriteTransaction(tx *types.Transaction) (*types.Receipt, error) {
 	// Sign the transaction
-	signedTx, err := p.PrivateKeySigner.SignTransaction(tx)
+	signedTx, err := p.AccountSigner.SignTransaction(tx)
 	if err != nil {
 		return nil, err
 	}
@@ -186,12 +187,11 @@ func (p *PrivateKeySignerWithTransport) executeWriteTransaction(tx *types.Transa
 		return nil, fmt.Errorf("failed to wait for transaction receipt: %w", err)
 	}
 
-	// Return status and transaction hash
-	return []any{receipt.Status, txHash.Hex()}, nil
+	return receipt, nil
 }
 
 // CallContractMethod implements SignerWithTransport.
-func (p *PrivateKeySignerWithTransport) CallContractMethod(contractAddress common.Address, contractABI abi.ABI, methodName string, value *big.Int, gasLimit uint64, gasPrice *big.Int, args ...any) (result []any, err error) {
+func (p *AccountSignerWithTransport) CallContractMethod(contractAddress common.Address, contractABI abi.ABI, methodName string, value *big.Int, gasLimit uint64, gasPrice *big.Int, args ...any) (result []any, err error) {
 	// Find method in ABI
 	method := findMethodInABI(contractABI, methodName)
 	if method == nil {
@@ -209,8 +209,19 @@ func (p *PrivateKeySignerWithTransport) CallContractMethod(contractAddress commo
 		return nil, err
 	}
 
+	receipt, err := p.Transact(contractAddress, data, value, gasLimit, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return status and transaction hash
+	return []any{receipt.Status, receipt.TxHash.Hex()}, nil
+}
+
+// Transact implements SignerWithTransport.
+func (p *AccountSignerWithTransport) Transact(contractAddress common.Address, data []byte, value *big.Int, gasLimit uint64, gasPrice *big.Int) (receipt *types.Receipt, err error) {
 	// Get nonce for transaction
-	signerAddress := p.PrivateKeySigner.GetAddress()
+	signerAddress := p.AccountSigner.GetAddress()
 	nonce, err := p.transport.GetTransactionCount(signerAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction count: %w", err)
@@ -220,7 +231,7 @@ func (p *PrivateKeySignerWithTransport) CallContractMethod(contractAddress commo
 	setDefaultTransactionParams(&value, &gasPrice)
 
 	// Build transaction with gas estimation
-	transaction, err := p.buildTransaction(contractAddress, nonce, value, gasLimit, gasPrice, data)
+	transaction, err := p.buildTransaction(&contractAddress, nonce, value, gasLimit, gasPrice, data)
 	if err != nil {
 		return nil, err
 	}
@@ -229,9 +240,59 @@ func (p *PrivateKeySignerWithTransport) CallContractMethod(contractAddress commo
 	return p.executeWriteTransaction(transaction)
 }
 
-// EstimateGas implements SignerWithTransport.
-func (p *PrivateKeySignerWithTransport) EstimateGas(tx *types.Transaction) (gas uint64, err error) {
-	gas, err = p.transport.EstimateGas(tx)
+// DeployContract implements SignerWithTransport.
+func (p *AccountSignerWithTransport) DeployContract(contractABI abi.ABI, bytecode []byte, value *big.Int, gasLimit uint64, gasPrice *big.Int, args ...any) (contractAddress common.Address, receipt *types.Receipt, err error) {
+	if len(bytecode) == 0 {
+		return common.Address{}, nil, errors.NewContractError(errors.ErrCodeBytecodeRequired, "bytecode is required to deploy a contract")
+	}
+
+	// Constructor arguments are ABI-encoded and appended to the creation bytecode
+	ethABI, err := convertToEthereumABI(contractABI)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	encodedArgs, err := ethABI.Pack("", args...)
+	if err != nil {
+		return common.Address{}, nil, errors.WrapABIError(err, errors.ErrCodeABIPackFailed, "failed to pack constructor arguments")
+	}
+
+	data := make([]byte, 0, len(bytecode)+len(encodedArgs))
+	data = append(data, bytecode...)
+	data = append(data, encodedArgs...)
+
+	nonce, err := p.transport.GetTransactionCount(p.AccountSigner.GetAddress())
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("failed to get transaction count: %w", err)
+	}
+
+	setDefaultTransactionParams(&value, &gasPrice)
+
+	transaction, err := p.buildTransaction(nil, nonce, value, gasLimit, gasPrice, data)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	txHash, err := p.SendTransaction(transaction)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	receipt, err = p.WaitForTransactionReceipt(txHash)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, receipt, errors.NewContractError(errors.ErrCodeContractDeployFailed, fmt.Sprintf("deployment transaction %s reverted", txHash.Hex()))
+	}
+
+	return receipt.ContractAddress, receipt, nil
+}
+
+// EstimateGas implements SignerWithTransport. The transaction is estimated as sent from the signer.
+func (p *AccountSignerWithTransport) EstimateGas(tx *types.Transaction) (gas uint64, err error) {
+	gas, err = p.transport.EstimateCallGas(transport.CallMsg(tx, p.AccountSigner.GetAddress()))
 	if err != nil {
 		return 0, fmt.Errorf("failed to estimate gas: %w", err)
 	}
@@ -239,12 +300,12 @@ func (p *PrivateKeySignerWithTransport) EstimateGas(tx *types.Transaction) (gas
 }
 
 // GetAddress implements SignerWithTransport.
-func (p *PrivateKeySignerWithTransport) GetAddress() (address common.Address, err error) {
-	return p.PrivateKeySigner.GetAddress(), nil
+func (p *AccountSignerWithTransport) GetAddress() (address common.Address, err error) {
+	return p.AccountSigner.GetAddress(), nil
 }
 
 // GetBalance implements SignerWithTransport.
-func (p *PrivateKeySignerWithTransport) GetBalance(address common.Address) (balance *big.Int, err error) {
+func (p *AccountSignerWithTransport) GetBalance(address common.Address) (balance *big.Int, err error) {
 	balance, err = p.transport.GetBalance(address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
@@ -253,7 +314,7 @@ func (p *PrivateKeySignerWithTransport) GetBalance(address common.Address) (bala
 }
 
 // GetTransactionCount implements SignerWithTransport.
-func (p *PrivateKeySignerWithTransport) GetTransactionCount(address common.Address) (nonce uint64, err error) {
+func (p *AccountSignerWithTransport) GetTransactionCount(address common.Address) (nonce uint64, err error) {
 	nonce, err = p.transport.GetTransactionCount(address)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get transaction count: %w", err)
@@ -262,9 +323,9 @@ func (p *PrivateKeySignerWithTransport) GetTransactionCount(address common.Addre
 }
 
 // SendTransaction implements SignerWithTransport.
-func (p *PrivateKeySignerWithTransport) SendTransaction(tx *types.Transaction) (txHash common.Hash, err error) {
+func (p *AccountSignerWithTransport) SendTransaction(tx *types.Transaction) (txHash common.Hash, err error) {
 	// Sign the transaction first
-	signedTx, err := p.PrivateKeySigner.SignTransaction(tx)
+	signedTx, err := p.AccountSigner.SignTransaction(tx)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -278,22 +339,32 @@ func (p *PrivateKeySignerWithTransport) SendTransaction(tx *types.Transaction) (
 }
 
 // SignMessageString implements SignerWithTransport.
-func (p *PrivateKeySignerWithTransport) SignMessageString(message string) (signature string, err error) {
-	return p.PrivateKeySigner.SignMessageString(message)
+func (p *AccountSignerWithTransport) SignMessageString(message string) (signature string, err error) {
+	return p.AccountSigner.SignMessageString(message)
 }
 
 // SignTransaction implements SignerWithTransport.
-func (p *PrivateKeySignerWithTransport) SignTransaction(tx *types.Transaction) (signedTx *types.Transaction, err error) {
-	return p.PrivateKeySigner.SignTransaction(tx)
+func (p *AccountSignerWithTransport) SignTransaction(tx *types.Transaction) (signedTx *types.Transaction, err error) {
+	return p.AccountSigner.SignTransaction(tx)
 }
 
 // VerifyMessageString implements SignerWithTransport.
-func (p *PrivateKeySignerWithTransport) VerifyMessageString(address common.Address, message string, signature string) (isValid bool, recoveredAddress common.Address, err error) {
-	return p.PrivateKeySigner.VerifyMessageString(address, message, signature)
+func (p *AccountSignerWithTransport) VerifyMessageString(address common.Address, message string, signature string) (isValid bool, recoveredAddress common.Address, err error) {
+	return p.AccountSigner.VerifyMessageString(address, message, signature)
+}
+
+// SignTypedData implements SignerWithTransport.
+func (p *AccountSignerWithTransport) SignTypedData(typedData string) (signature string, err error) {
+	return p.AccountSigner.SignTypedData(typedData)
+}
+
+// VerifyTypedData implements SignerWithTransport.
+func (p *AccountSignerWithTransport) VerifyTypedData(address common.Address, typedData string, signature string) (isValid bool, recoveredAddress common.Address, err error) {
+	return p.AccountSigner.VerifyTypedData(address, typedData, signature)
 }
 
 // WaitForTransactionReceipt implements SignerWithTransport.
-func (p *PrivateKeySignerWithTransport) WaitForTransactionReceipt(txHash common.Hash) (receipt *types.Receipt, err error) {
+func (p *AccountSignerWithTransport) WaitForTransactionReceipt(txHash common.Hash) (receipt *types.Receipt, err error) {
 	receipt, err = p.transport.WaitForTransactionReceipt(txHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to wait for transaction receipt: %w", err)
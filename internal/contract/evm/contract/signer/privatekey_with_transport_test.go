@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"math/big"
 	"testing"
-	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -63,22 +62,28 @@ contract TestContract {
 type PrivateKeySignerWithTransportTestSuite struct {
 	suite.Suite
 	signer          SignerWithTransport
-	transport       transport.Transport
+	transport       *transport.SimulatedTransport
 	contractAddress common.Address
 	contractABI     abi.ABI
+	bytecode        string
 	testPrivateKey  string
 	testAddress     common.Address
 	chainID         *big.Int
 }
 
+// TearDownSuite stops the simulated chain.
+func (suite *PrivateKeySignerWithTransportTestSuite) TearDownSuite() {
+	suite.NoError(suite.transport.Close())
+}
+
 // SetupSuite runs once before all tests.
 func (suite *PrivateKeySignerWithTransportTestSuite) SetupSuite() {
 	// Anvil test account private key
 	suite.testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
 	suite.testAddress = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
 
-	// Create transport
-	tr, err := transport.NewHTTPTransport("http://localhost:8545", 5*time.Second)
+	// Create transport on an in-process chain funding the anvil test accounts
+	tr, err := transport.NewSimulatedTransport(nil)
 	suite.Require().NoError(err, "Failed to create transport")
 	suite.transport = tr
 
@@ -97,7 +102,7 @@ func (suite *PrivateKeySignerWithTransportTestSuite) SetupSuite() {
 	suite.signer = pkSigner.WithTransport(suite.transport)
 
 	// Compile contract using solc-go
-	compiler, err := solc.NewWithVersion("0.8.20")
+	compiler, err := solc.NewWithVersion("0.8.30")
 	suite.Require().NoError(err, "Failed to create compiler")
 	defer func() {
 		if closeErr := compiler.Close(); closeErr != nil {
@@ -162,6 +167,7 @@ func (suite *PrivateKeySignerWithTransportTestSuite) SetupSuite() {
 	suite.contractABI = *customABI
 
 	// Deploy contract
+	suite.bytecode = bytecode
 	suite.deployContract(bytecode)
 }
 
@@ -200,6 +206,19 @@ func (suite *PrivateKeySignerWithTransportTestSuite) deployContract(bytecode str
 	suite.Require().NotEqual(common.Address{}, suite.contractAddress, "Contract address is empty")
 }
 
+// TestDeployContract tests deploying a contract through the signer.
+func (suite *PrivateKeySignerWithTransportTestSuite) TestDeployContract() {
+	address, receipt, err := suite.signer.DeployContract(suite.contractABI, common.FromHex(suite.bytecode), nil, 0, nil)
+	suite.Require().NoError(err, "DeployContract should not return error")
+	suite.Equal(uint64(1), receipt.Status, "Deployment transaction should succeed")
+	suite.Equal(receipt.ContractAddress, address)
+
+	// The deployed contract must be callable
+	result, err := suite.signer.CallContractMethod(address, suite.contractABI, "getValue", nil, 0, nil)
+	suite.Require().NoError(err, "getValue should not return error")
+	suite.Equal("0", result[0].(*big.Int).String())
+}
+
 // TestGetAddress tests the GetAddress method.
 func (suite *PrivateKeySignerWithTransportTestSuite) TestGetAddress() {
 	address, err := suite.signer.GetAddress()
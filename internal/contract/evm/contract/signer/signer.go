@@ -15,6 +15,17 @@ type Signer interface {
 	SignMessageString(message string) (signature string, err error)
 	// VerifyMessageString verifies a signature against a message for a given address
 	VerifyMessageString(address common.Address, message string, signature string) (isValid bool, recoveredAddress common.Address, err error)
+	// SignTypedData signs EIP-712 typed data given as eth_signTypedData_v4 JSON and returns the signature
+	SignTypedData(typedData string) (signature string, err error)
+	// VerifyTypedData verifies a signature of EIP-712 typed data for a given address
+	VerifyTypedData(address common.Address, typedData string, signature string) (isValid bool, recoveredAddress common.Address, err error)
+}
+
+// AccountSigner is a Signer for a single account, whether the key is held locally or by a remote signer.
+type AccountSigner interface {
+	Signer
+	// GetAddress returns the address of the account
+	GetAddress() common.Address
 }
 
 type SignerWithTransport interface {
@@ -24,6 +35,14 @@ type SignerWithTransport interface {
 	// For write methods, returns transaction status and hash
 	CallContractMethod(contractAddress common.Address, contractABI abi.ABI, methodName string, value *big.Int, gasLimit uint64, gasPrice *big.Int, args ...any) (result []any, err error)
 
+	// Transact sends a transaction with the calldata to the contract and waits for its receipt.
+	// Zero gasLimit estimates the gas and nil gasPrice uses the default gas price
+	Transact(contractAddress common.Address, data []byte, value *big.Int, gasLimit uint64, gasPrice *big.Int) (receipt *types.Receipt, err error)
+
+	// DeployContract sends a contract-creation transaction for the bytecode with the
+	// ABI-encoded constructor arguments and waits for its receipt
+	DeployContract(contractABI abi.ABI, bytecode []byte, value *big.Int, gasLimit uint64, gasPrice *big.Int, args ...any) (contractAddress common.Address, receipt *types.Receipt, err error)
+
 	// EstimateGas estimates the gas required for a transaction
 	EstimateGas(tx *types.Transaction) (gas uint64, err error)
 
@@ -0,0 +1,84 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// ParseTypedData parses EIP-712 typed data in the eth_signTypedData_v4 JSON format, an object with
+// types, primaryType, domain and message, and checks that it can be hashed.
+func ParseTypedData(data string) (*apitypes.TypedData, error) {
+	typedData := &apitypes.TypedData{}
+	if err := json.Unmarshal([]byte(data), typedData); err != nil {
+		return nil, errors.WrapSignerError(err, errors.ErrCodeInvalidTypedData, "failed to parse typed data")
+	}
+	if typedData.PrimaryType == "" {
+		return nil, errors.NewSignerError(errors.ErrCodeInvalidTypedData, "typed data has no primaryType")
+	}
+	if _, ok := typedData.Types[typedData.PrimaryType]; !ok {
+		return nil, errors.NewSignerError(errors.ErrCodeInvalidTypedData, fmt.Sprintf("primary type %s is not defined in types", typedData.PrimaryType))
+	}
+	if _, err := HashTypedData(typedData); err != nil {
+		return nil, err
+	}
+	return typedData, nil
+}
+
+// HashTypedData returns the EIP-712 digest keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message)).
+func HashTypedData(typedData *apitypes.TypedData) (common.Hash, error) {
+	hash, _, err := apitypes.TypedDataAndHash(*typedData)
+	if err != nil {
+		return common.Hash{}, errors.WrapSignerError(err, errors.ErrCodeInvalidTypedData, "failed to hash typed data")
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// SignTypedData implements Signer. The signature uses 27/28 as recovery id, like eth_signTypedData_v4,
+// so it can be passed to ecrecover in permits and orders.
+func (p *PrivateKeySigner) SignTypedData(typedData string) (signature string, err error) {
+	parsed, err := ParseTypedData(typedData)
+	if err != nil {
+		return "", err
+	}
+	hash, err := HashTypedData(parsed)
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := crypto.Sign(hash.Bytes(), p.PrivateKey)
+	if err != nil {
+		return "", errors.WrapSignerError(err, errors.ErrCodeSigningFailed, "failed to sign typed data")
+	}
+	sig[64] += 27
+
+	return "0x" + hex.EncodeToString(sig), nil
+}
+
+// VerifyTypedData implements Signer.
+func (p *PrivateKeySigner) VerifyTypedData(address common.Address, typedData string, signature string) (isValid bool, recoveredAddress common.Address, err error) {
+	return verifyTypedData(address, typedData, signature)
+}
+
+// verifyTypedData verifies a typed data signature. Like verifyMessage it needs no key.
+func verifyTypedData(address common.Address, typedData string, signature string) (isValid bool, recoveredAddress common.Address, err error) {
+	parsed, err := ParseTypedData(typedData)
+	if err != nil {
+		return false, common.Address{}, err
+	}
+	hash, err := HashTypedData(parsed)
+	if err != nil {
+		return false, common.Address{}, err
+	}
+
+	recoveredAddress, err = RecoverAddress(hash, signature)
+	if err != nil {
+		return false, common.Address{}, err
+	}
+	return recoveredAddress == address, recoveredAddress, nil
+}
@@ -0,0 +1,114 @@
+package signer
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+// mailTypedData is the example from EIP-712.
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+const (
+	mailDigest    = "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"
+	mailSignature = "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c"
+)
+
+// TypedDataTestSuite checks EIP-712 signing against the test vector of the specification.
+type TypedDataTestSuite struct {
+	suite.Suite
+	signer  Signer
+	address common.Address
+}
+
+func TestTypedDataTestSuite(t *testing.T) {
+	suite.Run(t, new(TypedDataTestSuite))
+}
+
+func (s *TypedDataTestSuite) SetupSuite() {
+	// The specification signs with keccak256("cow").
+	signer, err := NewPrivateKeySigner(hex.EncodeToString(crypto.Keccak256([]byte("cow"))))
+	s.Require().NoError(err)
+	s.signer = signer
+	s.address = common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+}
+
+func (s *TypedDataTestSuite) TestHashTypedData() {
+	typedData, err := ParseTypedData(mailTypedData)
+	s.Require().NoError(err)
+	hash, err := HashTypedData(typedData)
+	s.Require().NoError(err)
+	s.Equal(mailDigest, hash.Hex())
+}
+
+func (s *TypedDataTestSuite) TestSignAndVerify() {
+	signature, err := s.signer.SignTypedData(mailTypedData)
+	s.Require().NoError(err)
+	s.Equal(mailSignature, signature)
+
+	isValid, recovered, err := s.signer.VerifyTypedData(s.address, mailTypedData, signature)
+	s.Require().NoError(err)
+	s.True(isValid)
+	s.Equal(s.address, recovered)
+
+	isValid, _, err = s.signer.VerifyTypedData(common.HexToAddress(testAddress), mailTypedData, signature)
+	s.Require().NoError(err)
+	s.False(isValid)
+
+	// A signature over a different message recovers another address.
+	other := `{"types":{"EIP712Domain":[{"name":"name","type":"string"}],"Note":[{"name":"text","type":"string"}]},` +
+		`"primaryType":"Note","domain":{"name":"Notes"},"message":{"text":"hi"}}`
+	isValid, _, err = s.signer.VerifyTypedData(s.address, other, signature)
+	s.Require().NoError(err)
+	s.False(isValid)
+}
+
+func (s *TypedDataTestSuite) TestInvalidTypedData() {
+	cases := map[string]string{
+		"not json":          "hello",
+		"no primary type":   `{"types":{"EIP712Domain":[]},"domain":{"name":"x"},"message":{}}`,
+		"undefined primary": `{"types":{"EIP712Domain":[{"name":"name","type":"string"}]},"primaryType":"Mail","domain":{"name":"x"},"message":{}}`,
+		"bad field value":   `{"types":{"EIP712Domain":[{"name":"name","type":"string"}],"Note":[{"name":"to","type":"address"}]},"primaryType":"Note","domain":{"name":"x"},"message":{"to":"nope"}}`,
+	}
+	for name, typedData := range cases {
+		_, err := s.signer.SignTypedData(typedData)
+		s.True(errors.HasCode(err, errors.ErrCodeInvalidTypedData), "%s: %v", name, err)
+	}
+
+	_, _, err := s.signer.VerifyTypedData(s.address, mailTypedData, "0x1234")
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidSignatureLength), "%v", err)
+}
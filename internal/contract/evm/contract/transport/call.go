@@ -0,0 +1,263 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	customabi "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// CallOptions pins a read-only call to a block and overrides account state for the duration of the call.
+type CallOptions struct {
+	// Block is the block the call runs at, nil for the latest block.
+	Block *rpc.BlockNumberOrHash
+	// StateOverrides replaces the state of accounts before the call, nothing is written to the chain.
+	StateOverrides StateOverrides
+}
+
+// StateOverrides maps an address to the state it has during a call.
+type StateOverrides map[common.Address]AccountOverride
+
+// AccountOverride is the state of an account during a call. Nil fields keep the state of the chain.
+type AccountOverride struct {
+	Balance *big.Int
+	Nonce   *uint64
+	Code    []byte
+	// Storage replaces the given storage slots, the other slots keep their value.
+	Storage map[common.Hash]common.Hash
+}
+
+// IsZero reports whether the options call at the latest block without overrides.
+func (o CallOptions) IsZero() bool {
+	return o.Block == nil && len(o.StateOverrides) == 0
+}
+
+// CallContractWithOptions implements Transport.
+func (h *HTTPTransport) CallContractWithOptions(contractAddress common.Address, customABI customabi.ABI, functionName string, options CallOptions, args ...any) (result []byte, err error) {
+	if options.IsZero() {
+		return h.CallContract(contractAddress, customABI, functionName, args...)
+	}
+
+	ethABI, err := convertToEthereumABI(customABI)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ethABI.Pack(functionName, args...)
+	if err != nil {
+		return nil, errors.WrapABIError(err, errors.ErrCodeABIPackFailed, fmt.Sprintf("failed to pack function %s", functionName))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	params := []any{toCallArg(ethereum.CallMsg{To: &contractAddress, Data: data}), blockArg(options.Block)}
+	if len(options.StateOverrides) > 0 {
+		params = append(params, options.StateOverrides.arg())
+	}
+
+	var raw hexutil.Bytes
+	if err := h.client.Client().CallContext(ctx, &raw, "eth_call", params...); err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeRPCCallFailed, fmt.Sprintf("failed to call contract function %s", functionName))
+	}
+
+	return raw, nil
+}
+
+// blockArg is the JSON-RPC block parameter of a block, a block hash is sent as an EIP-1898 object.
+func blockArg(block *rpc.BlockNumberOrHash) any {
+	if block == nil {
+		return "latest"
+	}
+	if hash, ok := block.Hash(); ok {
+		return map[string]any{"blockHash": hash}
+	}
+	number, _ := block.Number()
+	return number.String()
+}
+
+// arg is the state override set parameter of eth_call.
+func (s StateOverrides) arg() map[common.Address]any {
+	arg := make(map[common.Address]any, len(s))
+	for address, override := range s {
+		account := map[string]any{}
+		if override.Balance != nil {
+			account["balance"] = (*hexutil.Big)(override.Balance)
+		}
+		if override.Nonce != nil {
+			account["nonce"] = hexutil.Uint64(*override.Nonce)
+		}
+		if override.Code != nil {
+			account["code"] = hexutil.Bytes(override.Code)
+		}
+		if len(override.Storage) > 0 {
+			account["stateDiff"] = override.Storage
+		}
+		arg[address] = account
+	}
+	return arg
+}
+
+// ParseBlock parses a block number in decimal or hex, a block tag (latest, pending, safe, finalized,
+// earliest) or a block hash. An empty string is the latest block and returns nil.
+func ParseBlock(input string) (*rpc.BlockNumberOrHash, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	switch input {
+	case "":
+		return nil, nil
+	case "latest", "pending", "safe", "finalized", "earliest":
+		var number rpc.BlockNumber
+		if err := number.UnmarshalJSON([]byte(strconv.Quote(input))); err != nil {
+			return nil, errors.WrapTransportError(err, errors.ErrCodeInvalidBlock, "invalid block tag")
+		}
+		block := rpc.BlockNumberOrHashWithNumber(number)
+		return &block, nil
+	}
+
+	if strings.HasPrefix(input, "0x") && len(input) == 2+2*common.HashLength {
+		hash, err := hexutil.Decode(input)
+		if err != nil {
+			return nil, errors.WrapTransportError(err, errors.ErrCodeInvalidBlock, "invalid block hash")
+		}
+		block := rpc.BlockNumberOrHashWithHash(common.BytesToHash(hash), false)
+		return &block, nil
+	}
+
+	number, ok := new(big.Int).SetString(input, 0)
+	if !ok || number.Sign() < 0 || !number.IsInt64() {
+		return nil, errors.NewTransportError(errors.ErrCodeInvalidBlock, fmt.Sprintf("invalid block %q: expected a number, a tag or a hash", input))
+	}
+	block := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(number.Int64()))
+	return &block, nil
+}
+
+// ParseStateOverrides parses overrides separated by semicolons, each setting one field of an account:
+//
+//	0xAddress.balance=<wei>; 0xAddress.nonce=<n>; 0xAddress.code=0x...; 0xAddress.storage[<slot>]=<value>
+//
+// Numbers are decimal or 0x-prefixed hex, storage slots and values are left-padded to 32 bytes.
+func ParseStateOverrides(input string) (StateOverrides, error) {
+	overrides := StateOverrides{}
+	for _, entry := range strings.Split(input, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if err := overrides.parseEntry(entry); err != nil {
+			return nil, err
+		}
+	}
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+	return overrides, nil
+}
+
+func (s StateOverrides) parseEntry(entry string) error {
+	invalid := func(reason string) error {
+		return errors.NewTransportError(errors.ErrCodeInvalidStateOverride, fmt.Sprintf("invalid state override %q: %s", entry, reason))
+	}
+
+	target, value, found := strings.Cut(entry, "=")
+	if !found {
+		return invalid("expected address.field=value")
+	}
+	addressInput, field, found := strings.Cut(strings.TrimSpace(target), ".")
+	if !found {
+		return invalid("expected address.field=value")
+	}
+	if !common.IsHexAddress(addressInput) {
+		return invalid("invalid address")
+	}
+	address := common.HexToAddress(addressInput)
+	value = strings.TrimSpace(value)
+	override := s[address]
+
+	switch field = strings.TrimSpace(field); {
+	case field == "balance":
+		balance, ok := parseUint256(value)
+		if !ok {
+			return invalid("balance must be a non-negative number")
+		}
+		override.Balance = balance
+	case field == "nonce":
+		nonce, err := strconv.ParseUint(value, 0, 64)
+		if err != nil {
+			return invalid("nonce must be a non-negative number")
+		}
+		override.Nonce = &nonce
+	case field == "code":
+		code, err := hexutil.Decode(value)
+		if err != nil {
+			return invalid("code must be 0x-prefixed hex")
+		}
+		override.Code = code
+	case strings.HasPrefix(field, "storage[") && strings.HasSuffix(field, "]"):
+		slot, ok := parseUint256(strings.TrimSuffix(strings.TrimPrefix(field, "storage["), "]"))
+		if !ok {
+			return invalid("storage slot must be a non-negative number")
+		}
+		word, ok := parseUint256(value)
+		if !ok {
+			return invalid("storage value must be a non-negative number")
+		}
+		if override.Storage == nil {
+			override.Storage = map[common.Hash]common.Hash{}
+		}
+		override.Storage[common.BigToHash(slot)] = common.BigToHash(word)
+	default:
+		return invalid("unknown field " + field + ", expected balance, nonce, code or storage[slot]")
+	}
+
+	s[address] = override
+	return nil
+}
+
+// parseUint256 parses a decimal or 0x-prefixed hex number that fits in 256 bits.
+func parseUint256(input string) (*big.Int, bool) {
+	value, ok := new(big.Int).SetString(strings.TrimSpace(input), 0)
+	if !ok || value.Sign() < 0 || value.BitLen() > 256 {
+		return nil, false
+	}
+	return value, true
+}
+
+// Lines describes the overrides for display, one line per field, sorted by address.
+func (s StateOverrides) Lines() []string {
+	addresses := make([]common.Address, 0, len(s))
+	for address := range s {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i].Cmp(addresses[j]) < 0 })
+
+	lines := []string{}
+	for _, address := range addresses {
+		override := s[address]
+		if override.Balance != nil {
+			lines = append(lines, fmt.Sprintf("%s balance = %s wei", address.Hex(), override.Balance))
+		}
+		if override.Nonce != nil {
+			lines = append(lines, fmt.Sprintf("%s nonce = %d", address.Hex(), *override.Nonce))
+		}
+		if override.Code != nil {
+			lines = append(lines, fmt.Sprintf("%s code = %d bytes", address.Hex(), len(override.Code)))
+		}
+		slots := make([]common.Hash, 0, len(override.Storage))
+		for slot := range override.Storage {
+			slots = append(slots, slot)
+		}
+		sort.Slice(slots, func(i, j int) bool { return slots[i].Cmp(slots[j]) < 0 })
+		for _, slot := range slots {
+			lines = append(lines, fmt.Sprintf("%s storage[%s] = %s", address.Hex(), slot.Hex(), override.Storage[slot].Hex()))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,133 @@
+package transport
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// selfBalanceCode returns the balance of the called contract.
+	selfBalanceCode = hexutil.MustDecode("0x4760005260206000f3")
+	// slotZeroCode returns storage slot 0 of the called contract.
+	slotZeroCode = hexutil.MustDecode("0x60005460005260206000f3")
+)
+
+func valueABI(t *testing.T) abi.ABI {
+	t.Helper()
+	elements, err := abi.ParseHumanReadable([]string{"function value() view returns (uint256)"})
+	require.NoError(t, err)
+	contractABI := abi.ABI{}
+	contractABI.SetElements(abi.ABIArray(elements))
+	return contractABI
+}
+
+func TestParseBlock(t *testing.T) {
+	block, err := ParseBlock("")
+	require.NoError(t, err)
+	assert.Nil(t, block)
+
+	block, err = ParseBlock("Finalized")
+	require.NoError(t, err)
+	number, ok := block.Number()
+	assert.True(t, ok)
+	assert.Equal(t, rpc.FinalizedBlockNumber, number)
+
+	block, err = ParseBlock("1000")
+	require.NoError(t, err)
+	assert.Equal(t, "0x3e8", block.String())
+
+	block, err = ParseBlock("0x3e8")
+	require.NoError(t, err)
+	assert.Equal(t, "0x3e8", block.String())
+
+	hash := common.HexToHash("0xabc")
+	block, err = ParseBlock(hash.Hex())
+	require.NoError(t, err)
+	blockHash, ok := block.Hash()
+	assert.True(t, ok)
+	assert.Equal(t, hash, blockHash)
+	assert.Equal(t, map[string]any{"blockHash": hash}, blockArg(block))
+
+	_, err = ParseBlock("yesterday")
+	assert.True(t, errors.HasCode(err, errors.ErrCodeInvalidBlock))
+	_, err = ParseBlock("-1")
+	assert.True(t, errors.HasCode(err, errors.ErrCodeInvalidBlock))
+}
+
+func TestParseStateOverrides(t *testing.T) {
+	token := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	holder := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	overrides, err := ParseStateOverrides(holder.Hex() + ".balance=1000; " + holder.Hex() + ".nonce=0x7;" +
+		token.Hex() + ".code=0x6000; " + token.Hex() + ".storage[2]=0x10;")
+	require.NoError(t, err)
+	require.Len(t, overrides, 2)
+	assert.Equal(t, big.NewInt(1000), overrides[holder].Balance)
+	assert.Equal(t, uint64(7), *overrides[holder].Nonce)
+	assert.Equal(t, []byte{0x60, 0x00}, overrides[token].Code)
+	assert.Equal(t, common.BigToHash(big.NewInt(16)), overrides[token].Storage[common.BigToHash(big.NewInt(2))])
+
+	assert.Equal(t, []string{
+		token.Hex() + " code = 2 bytes",
+		token.Hex() + " storage[" + common.BigToHash(big.NewInt(2)).Hex() + "] = " + common.BigToHash(big.NewInt(16)).Hex(),
+		holder.Hex() + " balance = 1000 wei",
+		holder.Hex() + " nonce = 7",
+	}, overrides.Lines())
+
+	overrides, err = ParseStateOverrides("  ")
+	require.NoError(t, err)
+	assert.Nil(t, overrides)
+
+	for _, input := range []string{
+		"balance=1",
+		"0x1234.balance=1",
+		holder.Hex() + ".balance=-1",
+		holder.Hex() + ".nonce=abc",
+		holder.Hex() + ".code=6000",
+		holder.Hex() + ".storage[x]=1",
+		holder.Hex() + ".owner=1",
+	} {
+		_, err := ParseStateOverrides(input)
+		assert.True(t, errors.HasCode(err, errors.ErrCodeInvalidStateOverride), input)
+	}
+}
+
+func (suite *SimulatedTransportTestSuite) TestCallWithStateOverrides() {
+	target := common.HexToAddress("0x00000000000000000000000000000000000c0de1")
+	overrides, err := ParseStateOverrides(target.Hex() + ".code=" + hexutil.Encode(slotZeroCode) + "; " + target.Hex() + ".storage[0]=42")
+	suite.Require().NoError(err)
+
+	result, err := suite.transport.CallContractWithOptions(target, valueABI(suite.T()), "value", CallOptions{StateOverrides: overrides})
+	suite.Require().NoError(err)
+	suite.Equal(common.LeftPadBytes([]byte{42}, 32), result)
+
+	code, err := suite.transport.GetCode(target)
+	suite.Require().NoError(err)
+	suite.Empty(code, "overrides are not written to the chain")
+}
+
+func (suite *SimulatedTransportTestSuite) TestCallAtBlock() {
+	target := common.HexToAddress("0x00000000000000000000000000000000000c0de2")
+	receipt := suite.send(&target, big.NewInt(1000), nil)
+	overrides := StateOverrides{target: {Code: selfBalanceCode}}
+
+	genesis, err := ParseBlock("0")
+	suite.Require().NoError(err)
+	result, err := suite.transport.CallContractWithOptions(target, valueABI(suite.T()), "value", CallOptions{Block: genesis, StateOverrides: overrides})
+	suite.Require().NoError(err)
+	suite.Equal(common.LeftPadBytes(nil, 32), result)
+
+	mined, err := ParseBlock(receipt.BlockHash.Hex())
+	suite.Require().NoError(err)
+	result, err = suite.transport.CallContractWithOptions(target, valueABI(suite.T()), "value", CallOptions{Block: mined, StateOverrides: overrides})
+	suite.Require().NoError(err)
+	suite.Equal(common.LeftPadBytes(big.NewInt(1000).Bytes(), 32), result)
+}
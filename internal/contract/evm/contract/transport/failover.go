@@ -0,0 +1,457 @@
+package transport
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+const (
+	// minCooldown is how long a member that failed is skipped, it doubles with every consecutive
+	// failure up to maxCooldown.
+	minCooldown = 5 * time.Second
+	maxCooldown = 5 * time.Minute
+	// rpcLimitExceeded is the JSON-RPC error code of providers that rate limit a request.
+	rpcLimitExceeded = -32005
+)
+
+// Member is an endpoint of a failover group.
+type Member struct {
+	URL string
+	// Priority orders the members, lower first. Members with the same priority share the reads
+	// round-robin.
+	Priority int
+}
+
+// MemberHealth is the health of a member as last seen by the failover transport.
+type MemberHealth struct {
+	Member
+	Healthy bool
+	// Failures is the number of consecutive failures
+	Failures  int
+	LastError string
+	// RetryAt is when an unhealthy member is tried again
+	RetryAt time.Time
+	Latency time.Duration
+}
+
+type member struct {
+	Member
+
+	transport Transport
+	failures  int
+	lastError error
+	retryAt   time.Time
+	latency   time.Duration
+}
+
+// FailoverTransport spreads the requests of one chain over several endpoints. Every read goes to
+// the healthy members of the lowest priority in turn; when a member fails with a connection or rate
+// limit error it is skipped for a cooldown and the request is retried on the next member. Other
+// errors, like reverts, are returned as they are.
+//
+// The requests of a transaction lifecycle, its nonce, sending it and looking it up, always go to the
+// first healthy member instead, so the nonce doesn't come from a lagging node and a transaction
+// pending on one node is not reported as dropped by another.
+type FailoverTransport struct {
+	mu      sync.Mutex
+	members []*member
+	chainID *big.Int
+	next    int
+
+	dial func(url string) (Transport, error)
+	now  func() time.Time
+}
+
+// NewFailoverTransport creates a failover transport over the members. Members are dialed on first
+// use with NewHTTPTransport, and a member whose chain ID differs from the first one dialed is
+// never used.
+func NewFailoverTransport(members []Member, timeout time.Duration, opts ...Option) (*FailoverTransport, error) {
+	return newFailoverTransport(members, func(url string) (Transport, error) {
+		return NewHTTPTransport(url, timeout, opts...)
+	})
+}
+
+func newFailoverTransport(members []Member, dial func(url string) (Transport, error)) (*FailoverTransport, error) {
+	if len(members) == 0 {
+		return nil, errors.NewTransportError(errors.ErrCodeEndpointRequired, "endpoint is required")
+	}
+
+	failover := &FailoverTransport{dial: dial, now: time.Now}
+	for _, configured := range members {
+		failover.members = append(failover.members, &member{Member: configured})
+	}
+	sort.SliceStable(failover.members, func(i, j int) bool {
+		return failover.members[i].Priority < failover.members[j].Priority
+	})
+	return failover, nil
+}
+
+// order returns the members to try: the healthy ones by priority, rotated within the first
+// priority when rotate is set so that its members share the load, then the unhealthy ones by retry
+// time as a last resort.
+func (f *FailoverTransport) order(rotate bool) []*member {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	healthy := []*member{}
+	unhealthy := []*member{}
+	for _, candidate := range f.members {
+		if candidate.retryAt.After(now) {
+			unhealthy = append(unhealthy, candidate)
+		} else {
+			healthy = append(healthy, candidate)
+		}
+	}
+
+	tier := 0
+	for tier < len(healthy) && healthy[tier].Priority == healthy[0].Priority {
+		tier++
+	}
+	if rotate && tier > 1 {
+		offset := f.next % tier
+		f.next++
+		rotated := append(append([]*member{}, healthy[offset:tier]...), healthy[:offset]...)
+		copy(healthy, rotated)
+	}
+
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return unhealthy[i].retryAt.Before(unhealthy[j].retryAt)
+	})
+	return append(healthy, unhealthy...)
+}
+
+// connect returns the transport of a member, dialing it and checking its chain ID the first time.
+func (f *FailoverTransport) connect(candidate *member) (Transport, error) {
+	f.mu.Lock()
+	tr := candidate.transport
+	f.mu.Unlock()
+	if tr != nil {
+		return tr, nil
+	}
+
+	tr, err := f.dial(candidate.URL)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := tr.GetChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.chainID == nil {
+		f.chainID = chainID
+	}
+	if f.chainID.Cmp(chainID) != 0 {
+		return nil, errors.NewTransportError(errors.ErrCodeInvalidChainID, fmt.Sprintf(
+			"endpoint %s is on chain %s, the other endpoints are on chain %s", candidate.URL, chainID, f.chainID))
+	}
+	candidate.transport = tr
+	return tr, nil
+}
+
+func (f *FailoverTransport) succeeded(candidate *member, latency time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	candidate.failures = 0
+	candidate.lastError = nil
+	candidate.retryAt = time.Time{}
+	candidate.latency = latency
+}
+
+func (f *FailoverTransport) failed(candidate *member, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cooldown := minCooldown << min(candidate.failures, 10)
+	candidate.failures++
+	candidate.lastError = err
+	candidate.retryAt = f.now().Add(min(cooldown, maxCooldown))
+}
+
+// do runs a read on the members in order until one answers without a failover error.
+func (f *FailoverTransport) do(request func(tr Transport) error) error {
+	return f.run(f.order(true), request)
+}
+
+// doPinned runs a request of a transaction lifecycle, starting with the first healthy member
+// every time.
+func (f *FailoverTransport) doPinned(request func(tr Transport) error) error {
+	return f.run(f.order(false), request)
+}
+
+func (f *FailoverTransport) run(members []*member, request func(tr Transport) error) error {
+	var failures []string
+	for _, candidate := range members {
+		tr, err := f.connect(candidate)
+		if err != nil {
+			// An endpoint that can't be dialed or is on another chain is unusable for any request
+			f.failed(candidate, err)
+			failures = append(failures, fmt.Sprintf("%s: %v", candidate.URL, err))
+			continue
+		}
+
+		started := f.now()
+		err = request(tr)
+		if err != nil && IsFailoverError(err) {
+			f.failed(candidate, err)
+			failures = append(failures, fmt.Sprintf("%s: %v", candidate.URL, err))
+			continue
+		}
+		f.succeeded(candidate, f.now().Sub(started))
+		return err
+	}
+	return errors.NewTransportError(errors.ErrCodeConnectionFailed, "all endpoints failed: "+strings.Join(failures, "; "))
+}
+
+// IsFailoverError reports whether an error means the endpoint is unavailable or rate limiting,
+// so the request can be retried on another endpoint.
+func IsFailoverError(err error) bool {
+	if errors.HasCode(err, errors.ErrCodeConnectionFailed) {
+		return true
+	}
+
+	var httpErr rpc.HTTPError
+	if goerrors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	var rpcErr rpc.Error
+	if goerrors.As(err, &rpcErr) && rpcErr.ErrorCode() == rpcLimitExceeded {
+		return true
+	}
+	var netErr net.Error
+	if goerrors.As(err, &netErr) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, hint := range []string{"too many requests", "rate limit", "connection refused", "connection reset", "no such host", "unexpected eof"} {
+		if strings.Contains(message, hint) {
+			return true
+		}
+	}
+	return strings.HasSuffix(message, ": eof")
+}
+
+// Health returns the health of every member by priority.
+func (f *FailoverTransport) Health() []MemberHealth {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	health := make([]MemberHealth, 0, len(f.members))
+	for _, candidate := range f.members {
+		status := MemberHealth{
+			Member:   candidate.Member,
+			Healthy:  !candidate.retryAt.After(now),
+			Failures: candidate.failures,
+			RetryAt:  candidate.retryAt,
+			Latency:  candidate.latency,
+		}
+		if candidate.lastError != nil {
+			status.LastError = candidate.lastError.Error()
+		}
+		health = append(health, status)
+	}
+	return health
+}
+
+// CheckHealth queries the chain ID of every member, unhealthy ones included, and returns their
+// health afterwards.
+func (f *FailoverTransport) CheckHealth() []MemberHealth {
+	for _, candidate := range f.members {
+		tr, err := f.connect(candidate)
+		if err == nil {
+			started := f.now()
+			_, err = tr.GetChainID()
+			if err == nil {
+				f.succeeded(candidate, f.now().Sub(started))
+				continue
+			}
+		}
+		f.failed(candidate, err)
+	}
+	return f.Health()
+}
+
+// SendTransaction implements Transport. A transaction that reached a node before the connection
+// failed is known to the next one, which is not an error.
+func (f *FailoverTransport) SendTransaction(tx *types.Transaction) (txHash common.Hash, err error) {
+	err = f.doPinned(func(tr Transport) error {
+		txHash, err = tr.SendTransaction(tx)
+		if err != nil && strings.Contains(strings.ToLower(err.Error()), "already known") {
+			txHash, err = tx.Hash(), nil
+		}
+		return err
+	})
+	return txHash, err
+}
+
+// WaitForTransactionReceipt implements Transport.
+func (f *FailoverTransport) WaitForTransactionReceipt(txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = f.doPinned(func(tr Transport) error {
+		receipt, err = tr.WaitForTransactionReceipt(txHash)
+		return err
+	})
+	return receipt, err
+}
+
+// Call implements Transport.
+func (f *FailoverTransport) Call(msg ethereum.CallMsg) (result []byte, err error) {
+	err = f.do(func(tr Transport) error {
+		result, err = tr.Call(msg)
+		return err
+	})
+	return result, err
+}
+
+// CallContract implements Transport.
+func (f *FailoverTransport) CallContract(contractAddress common.Address, contractABI abi.ABI, functionName string, args ...any) (result []byte, err error) {
+	err = f.do(func(tr Transport) error {
+		result, err = tr.CallContract(contractAddress, contractABI, functionName, args...)
+		return err
+	})
+	return result, err
+}
+
+// CallContractWithOptions implements Transport.
+func (f *FailoverTransport) CallContractWithOptions(contractAddress common.Address, contractABI abi.ABI, functionName string, options CallOptions, args ...any) (result []byte, err error) {
+	err = f.do(func(tr Transport) error {
+		result, err = tr.CallContractWithOptions(contractAddress, contractABI, functionName, options, args...)
+		return err
+	})
+	return result, err
+}
+
+// EstimateGas implements Transport.
+func (f *FailoverTransport) EstimateGas(tx *types.Transaction) (gas uint64, err error) {
+	err = f.do(func(tr Transport) error {
+		gas, err = tr.EstimateGas(tx)
+		return err
+	})
+	return gas, err
+}
+
+// EstimateCallGas implements Transport.
+func (f *FailoverTransport) EstimateCallGas(msg ethereum.CallMsg) (gas uint64, err error) {
+	err = f.do(func(tr Transport) error {
+		gas, err = tr.EstimateCallGas(msg)
+		return err
+	})
+	return gas, err
+}
+
+// GetTransactionCount implements Transport.
+func (f *FailoverTransport) GetTransactionCount(address common.Address) (nonce uint64, err error) {
+	err = f.doPinned(func(tr Transport) error {
+		nonce, err = tr.GetTransactionCount(address)
+		return err
+	})
+	return nonce, err
+}
+
+// GetBalance implements Transport.
+func (f *FailoverTransport) GetBalance(address common.Address) (balance *big.Int, err error) {
+	err = f.do(func(tr Transport) error {
+		balance, err = tr.GetBalance(address)
+		return err
+	})
+	return balance, err
+}
+
+// GetChainID implements Transport.
+func (f *FailoverTransport) GetChainID() (chainID *big.Int, err error) {
+	err = f.do(func(tr Transport) error {
+		chainID, err = tr.GetChainID()
+		return err
+	})
+	return chainID, err
+}
+
+// FilterLogs implements Transport.
+func (f *FailoverTransport) FilterLogs(query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = f.do(func(tr Transport) error {
+		logs, err = tr.FilterLogs(query)
+		return err
+	})
+	return logs, err
+}
+
+// GetCode implements Transport.
+func (f *FailoverTransport) GetCode(address common.Address) (code []byte, err error) {
+	err = f.do(func(tr Transport) error {
+		code, err = tr.GetCode(address)
+		return err
+	})
+	return code, err
+}
+
+// GetStorageAt implements Transport.
+func (f *FailoverTransport) GetStorageAt(address common.Address, slot common.Hash) (value common.Hash, err error) {
+	err = f.do(func(tr Transport) error {
+		value, err = tr.GetStorageAt(address, slot)
+		return err
+	})
+	return value, err
+}
+
+// GetTransaction implements Transport.
+func (f *FailoverTransport) GetTransaction(txHash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	err = f.doPinned(func(tr Transport) error {
+		tx, isPending, err = tr.GetTransaction(txHash)
+		return err
+	})
+	return tx, isPending, err
+}
+
+// Multicall implements Transport.
+func (f *FailoverTransport) Multicall(calls []Call) (results []CallResult, err error) {
+	err = f.do(func(tr Transport) error {
+		results, err = tr.Multicall(calls)
+		return err
+	})
+	return results, err
+}
+
+// SimulateCall implements Transport.
+func (f *FailoverTransport) SimulateCall(msg ethereum.CallMsg) (result []byte, err error) {
+	err = f.do(func(tr Transport) error {
+		result, err = tr.SimulateCall(msg)
+		return err
+	})
+	return result, err
+}
+
+// TraceCall implements Transport.
+func (f *FailoverTransport) TraceCall(msg ethereum.CallMsg, tracer string, tracerConfig map[string]any) (result json.RawMessage, err error) {
+	err = f.do(func(tr Transport) error {
+		result, err = tr.TraceCall(msg, tracer, tracerConfig)
+		return err
+	})
+	return result, err
+}
+
+// TraceTransaction implements Transport.
+func (f *FailoverTransport) TraceTransaction(txHash common.Hash, tracer string, tracerConfig map[string]any) (result json.RawMessage, err error) {
+	err = f.doPinned(func(tr Transport) error {
+		result, err = tr.TraceTransaction(txHash, tracer, tracerConfig)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,236 @@
+package transport
+
+import (
+	goerrors "errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memberTransport is an endpoint of a failover test, it fails every balance query with err.
+type memberTransport struct {
+	Transport
+	chainID  int64
+	err      error
+	balances int
+	// transactions counts the requests of a transaction lifecycle
+	transactions int
+}
+
+func (m *memberTransport) GetChainID() (*big.Int, error) {
+	return big.NewInt(m.chainID), nil
+}
+
+func (m *memberTransport) GetBalance(common.Address) (*big.Int, error) {
+	m.balances++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return big.NewInt(m.chainID), nil
+}
+
+func (m *memberTransport) SendTransaction(*types.Transaction) (common.Hash, error) {
+	m.transactions++
+	if m.err != nil {
+		return common.Hash{}, m.err
+	}
+	return common.HexToHash("0x01"), nil
+}
+
+func (m *memberTransport) GetTransactionCount(common.Address) (uint64, error) {
+	m.transactions++
+	return 0, nil
+}
+
+func (m *memberTransport) WaitForTransactionReceipt(txHash common.Hash) (*types.Receipt, error) {
+	m.transactions++
+	return &types.Receipt{TxHash: txHash, Status: types.ReceiptStatusSuccessful}, nil
+}
+
+func (m *memberTransport) GetTransaction(common.Hash) (*types.Transaction, bool, error) {
+	m.transactions++
+	return types.NewTx(&types.LegacyTx{}), false, nil
+}
+
+type failoverFixture struct {
+	transport  *FailoverTransport
+	transports map[string]*memberTransport
+	now        time.Time
+}
+
+func newFailoverFixture(t *testing.T, members []Member, transports map[string]*memberTransport) *failoverFixture {
+	fixture := &failoverFixture{transports: transports, now: time.Unix(1_700_000_000, 0)}
+	failover, err := newFailoverTransport(members, func(url string) (Transport, error) {
+		tr, ok := transports[url]
+		if !ok {
+			return nil, errors.NewTransportError(errors.ErrCodeConnectionFailed, "failed to dial endpoint")
+		}
+		return tr, nil
+	})
+	require.NoError(t, err)
+	failover.now = func() time.Time { return fixture.now }
+	fixture.transport = failover
+	return fixture
+}
+
+func rateLimited() error {
+	return errors.WrapTransportError(rpc.HTTPError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"},
+		errors.ErrCodeBalanceQueryFailed, "failed to query balance")
+}
+
+func TestFailoverToNextPriority(t *testing.T) {
+	primary := &memberTransport{chainID: 1, err: rateLimited()}
+	backup := &memberTransport{chainID: 1}
+	fixture := newFailoverFixture(t, []Member{{URL: "backup", Priority: 1}, {URL: "primary"}},
+		map[string]*memberTransport{"primary": primary, "backup": backup})
+
+	_, err := fixture.transport.GetBalance(common.Address{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, primary.balances)
+	assert.Equal(t, 1, backup.balances)
+
+	// The primary cools down and requests go straight to the backup
+	_, err = fixture.transport.GetBalance(common.Address{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, primary.balances)
+	assert.Equal(t, 2, backup.balances)
+
+	health := fixture.transport.Health()
+	require.Len(t, health, 2)
+	assert.Equal(t, "primary", health[0].URL)
+	assert.False(t, health[0].Healthy)
+	assert.Equal(t, 1, health[0].Failures)
+	assert.Contains(t, health[0].LastError, "429")
+	assert.True(t, health[1].Healthy)
+
+	// After the cooldown the primary is tried again and takes over once it recovers
+	primary.err = nil
+	fixture.now = fixture.now.Add(minCooldown)
+	_, err = fixture.transport.GetBalance(common.Address{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, primary.balances)
+	assert.True(t, fixture.transport.Health()[0].Healthy)
+}
+
+func TestRoundRobinWithinPriority(t *testing.T) {
+	first := &memberTransport{chainID: 1}
+	second := &memberTransport{chainID: 1}
+	third := &memberTransport{chainID: 1}
+	fixture := newFailoverFixture(t, []Member{{URL: "first"}, {URL: "second"}, {URL: "third", Priority: 1}},
+		map[string]*memberTransport{"first": first, "second": second, "third": third})
+
+	for range 4 {
+		_, err := fixture.transport.GetBalance(common.Address{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, first.balances)
+	assert.Equal(t, 2, second.balances)
+	assert.Equal(t, 0, third.balances)
+}
+
+// TestTransactionLifecycleStaysOnOneMember tests that the requests of a transaction are not spread
+// over the members of a priority, unlike reads.
+func TestTransactionLifecycleStaysOnOneMember(t *testing.T) {
+	first := &memberTransport{chainID: 1}
+	second := &memberTransport{chainID: 1}
+	fixture := newFailoverFixture(t, []Member{{URL: "first"}, {URL: "second"}},
+		map[string]*memberTransport{"first": first, "second": second})
+
+	for range 2 {
+		_, err := fixture.transport.GetTransactionCount(common.Address{})
+		require.NoError(t, err)
+		_, err = fixture.transport.GetBalance(common.Address{})
+		require.NoError(t, err)
+		txHash, err := fixture.transport.SendTransaction(types.NewTx(&types.LegacyTx{}))
+		require.NoError(t, err)
+		_, err = fixture.transport.WaitForTransactionReceipt(txHash)
+		require.NoError(t, err)
+		_, _, err = fixture.transport.GetTransaction(txHash)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 8, first.transactions)
+	assert.Equal(t, 0, second.transactions)
+	assert.Equal(t, 1, first.balances)
+	assert.Equal(t, 1, second.balances)
+
+	// A failing member hands the whole lifecycle over to the next one
+	first.err = rateLimited()
+	_, err := fixture.transport.SendTransaction(types.NewTx(&types.LegacyTx{}))
+	require.NoError(t, err)
+	_, err = fixture.transport.GetTransactionCount(common.Address{})
+	require.NoError(t, err)
+	assert.Equal(t, 9, first.transactions)
+	assert.Equal(t, 2, second.transactions)
+}
+
+func TestRequestErrorsDoNotFailOver(t *testing.T) {
+	primary := &memberTransport{chainID: 1, err: errors.NewTransportError(errors.ErrCodeBalanceQueryFailed, "invalid address")}
+	backup := &memberTransport{chainID: 1}
+	fixture := newFailoverFixture(t, []Member{{URL: "primary"}, {URL: "backup", Priority: 1}},
+		map[string]*memberTransport{"primary": primary, "backup": backup})
+
+	_, err := fixture.transport.GetBalance(common.Address{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid address")
+	assert.Equal(t, 0, backup.balances)
+	assert.True(t, fixture.transport.Health()[0].Healthy)
+}
+
+func TestSkipsUnreachableAndOtherChainEndpoints(t *testing.T) {
+	mainnet := &memberTransport{chainID: 1}
+	sepolia := &memberTransport{chainID: 11155111}
+	fixture := newFailoverFixture(t, []Member{{URL: "mainnet"}, {URL: "sepolia", Priority: 1}, {URL: "down", Priority: 2}},
+		map[string]*memberTransport{"mainnet": mainnet, "sepolia": sepolia})
+
+	health := fixture.transport.CheckHealth()
+	assert.True(t, health[0].Healthy)
+	assert.False(t, health[1].Healthy)
+	assert.Contains(t, health[1].LastError, "is on chain 11155111")
+	assert.False(t, health[2].Healthy)
+
+	mainnet.err = rateLimited()
+	_, err := fixture.transport.GetBalance(common.Address{})
+	require.Error(t, err)
+	assert.True(t, errors.HasCode(err, errors.ErrCodeConnectionFailed))
+	assert.Contains(t, err.Error(), "all endpoints failed")
+	assert.Equal(t, 0, sepolia.balances)
+}
+
+func TestResendOfKnownTransaction(t *testing.T) {
+	primary := &memberTransport{chainID: 1, err: errors.WrapTransportError(fmt.Errorf("read tcp: connection reset by peer"), errors.ErrCodeTransactionSendFailed, "failed to send transaction")}
+	backup := &memberTransport{chainID: 1, err: errors.WrapTransportError(goerrors.New("already known"), errors.ErrCodeTransactionSendFailed, "failed to send transaction")}
+	fixture := newFailoverFixture(t, []Member{{URL: "primary"}, {URL: "backup", Priority: 1}},
+		map[string]*memberTransport{"primary": primary, "backup": backup})
+
+	transaction := types.NewTx(&types.LegacyTx{Nonce: 7})
+	hash, err := fixture.transport.SendTransaction(transaction)
+	require.NoError(t, err)
+	assert.Equal(t, transaction.Hash(), hash)
+}
+
+func TestIsFailoverError(t *testing.T) {
+	tests := []struct {
+		err      error
+		failover bool
+	}{
+		{rateLimited(), true},
+		{rpc.HTTPError{StatusCode: http.StatusBadGateway}, true},
+		{rpc.HTTPError{StatusCode: http.StatusBadRequest}, false},
+		{errors.NewTransportError(errors.ErrCodeConnectionFailed, "failed to dial endpoint"), true},
+		{fmt.Errorf("Post \"http://localhost:8545\": dial tcp: connection refused"), true},
+		{fmt.Errorf("daily request limit reached, rate limit exceeded"), true},
+		{errors.NewTransportError(errors.ErrCodeGasEstimateFailed, "execution reverted"), false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.failover, IsFailoverError(test.err), test.err.Error())
+	}
+}
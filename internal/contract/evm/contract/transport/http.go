@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -21,9 +22,14 @@ type HTTPTransport struct {
 	Endpoint string
 	client   *ethclient.Client
 	timeout  time.Duration
+	// confirmations is the number of blocks WaitForTransactionReceipt waits for
+	confirmations uint64
+
+	multicallMu        sync.Mutex
+	multicallAvailable *bool
 }
 
-func NewHTTPTransport(endpoint string, timeout time.Duration) (Transport, error) {
+func NewHTTPTransport(endpoint string, timeout time.Duration, opts ...Option) (Transport, error) {
 	if endpoint == "" {
 		return nil, errors.NewTransportError(errors.ErrCodeEndpointRequired, "endpoint is required")
 	}
@@ -46,9 +52,10 @@ func NewHTTPTransport(endpoint string, timeout time.Duration) (Transport, error)
 	}
 
 	return &HTTPTransport{
-		Endpoint: endpoint,
-		client:   client,
-		timeout:  timeout,
+		Endpoint:      endpoint,
+		client:        client,
+		timeout:       timeout,
+		confirmations: newOptions(opts).confirmations,
 	}, nil
 }
 
@@ -83,44 +90,65 @@ func (h *HTTPTransport) CallContract(contractAddress common.Address, customABI c
 		return nil, errors.WrapABIError(err, errors.ErrCodeABIPackFailed, fmt.Sprintf("failed to pack function %s", functionName))
 	}
 
-	// Create call message
-	msg := ethereum.CallMsg{
+	// Call the contract
+	result, err = h.Call(ethereum.CallMsg{
 		To:   &contractAddress,
 		Data: data,
+	})
+	if err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeRPCCallFailed, fmt.Sprintf("failed to call contract function %s", functionName))
 	}
 
-	// Call the contract
-	ctx := context.Background()
-	result, err = h.client.CallContract(ctx, msg, nil)
+	return result, nil
+}
+
+// Call implements Transport.
+func (h *HTTPTransport) Call(msg ethereum.CallMsg) (result []byte, err error) {
+	result, err = h.client.CallContract(context.Background(), msg, nil)
 	if err != nil {
-		return nil, errors.WrapTransportError(err, errors.ErrCodeRPCCallFailed, fmt.Sprintf("failed to call contract function %s", functionName))
+		return nil, errors.WrapTransportError(err, errors.ErrCodeRPCCallFailed, "failed to call contract")
 	}
 
 	return result, nil
 }
 
-// EstimateGas implements Transport.
+// EstimateGas implements Transport. The call has no sender, use EstimateCallGas for calls that depend
+// on msg.sender.
 func (h *HTTPTransport) EstimateGas(transaction *types.Transaction) (gas uint64, err error) {
+	return h.EstimateCallGas(CallMsg(transaction, common.Address{}))
+}
+
+// EstimateCallGas implements Transport.
+func (h *HTTPTransport) EstimateCallGas(msg ethereum.CallMsg) (gas uint64, err error) {
 	ctx := context.Background()
 
-	// Estimate gas for the transaction
+	gas, err = h.client.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, errors.WrapTransportError(err, errors.ErrCodeGasEstimateFailed, "failed to estimate gas")
+	}
+
+	return gas, nil
+}
+
+// CallMsg returns the call of a transaction sent from the address, to estimate the gas of a
+// transaction that is not signed yet.
+func CallMsg(transaction *types.Transaction, from common.Address) ethereum.CallMsg {
 	msg := ethereum.CallMsg{
+		From:       from,
 		To:         transaction.To(),
 		Gas:        transaction.Gas(),
-		GasPrice:   transaction.GasPrice(),
-		GasFeeCap:  transaction.GasFeeCap(),
-		GasTipCap:  transaction.GasTipCap(),
 		Value:      transaction.Value(),
 		Data:       transaction.Data(),
 		AccessList: transaction.AccessList(),
 	}
-
-	gas, err = h.client.EstimateGas(ctx, msg)
-	if err != nil {
-		return 0, errors.WrapTransportError(err, errors.ErrCodeGasEstimateFailed, "failed to estimate gas")
+	// Geth rejects calls that set both the legacy gas price and the EIP-1559 fees.
+	if transaction.Type() == types.LegacyTxType || transaction.Type() == types.AccessListTxType {
+		msg.GasPrice = transaction.GasPrice()
+	} else {
+		msg.GasFeeCap = transaction.GasFeeCap()
+		msg.GasTipCap = transaction.GasTipCap()
 	}
-
-	return gas, nil
+	return msg
 }
 
 // GetBalance implements Transport.
@@ -159,33 +187,17 @@ func (h *HTTPTransport) SendTransaction(transaction *types.Transaction) (txHash
 	return transaction.Hash(), nil
 }
 
-// WaitForTransactionReceipt implements Transport.
+// WaitForTransactionReceipt implements Transport. It waits until the transaction has the configured
+// number of confirmations, and tells a dropped transaction from a pending one when the timeout expires.
 func (h *HTTPTransport) WaitForTransactionReceipt(txHash common.Hash) (receipt *types.Receipt, err error) {
-	ctx := context.Background()
-
-	// Poll for the transaction receipt
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	timeout := time.After(h.timeout)
-
-	for {
-		select {
-		case <-timeout:
-			return nil, errors.NewTransportError(errors.ErrCodeTransactionTimeout, "timeout waiting for transaction receipt")
-		case <-ticker.C:
-			receipt, err = h.client.TransactionReceipt(ctx, txHash)
-			if err == nil {
-				return receipt, nil
-			}
-			// If error is "not found", continue polling
-			if goerrors.Is(err, ethereum.NotFound) {
-				continue
-			}
-			// For any other error, return it
-			return nil, errors.WrapTransportError(err, errors.ErrCodeReceiptQueryFailed, "failed to query transaction receipt")
-		}
+	waiter := receiptWaiter{
+		chain:         h.client,
+		confirmations: h.confirmations,
+		timeout:       h.timeout,
+		minInterval:   minPollInterval,
+		maxInterval:   maxPollInterval,
 	}
+	return waiter.wait(txHash)
 }
 
 // GetChainID implements Transport.
@@ -199,3 +211,54 @@ func (h *HTTPTransport) GetChainID() (chainID *big.Int, err error) {
 
 	return chainID, nil
 }
+
+// FilterLogs implements Transport.
+func (h *HTTPTransport) FilterLogs(query ethereum.FilterQuery) (logs []types.Log, err error) {
+	ctx := context.Background()
+
+	logs, err = h.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeLogQueryFailed, "failed to query logs")
+	}
+
+	return logs, nil
+}
+
+// GetCode implements Transport.
+func (h *HTTPTransport) GetCode(address common.Address) (code []byte, err error) {
+	ctx := context.Background()
+
+	code, err = h.client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeCodeQueryFailed, "failed to query contract code")
+	}
+
+	return code, nil
+}
+
+// GetStorageAt implements Transport.
+func (h *HTTPTransport) GetStorageAt(address common.Address, slot common.Hash) (value common.Hash, err error) {
+	ctx := context.Background()
+
+	data, err := h.client.StorageAt(ctx, address, slot, nil)
+	if err != nil {
+		return common.Hash{}, errors.WrapTransportError(err, errors.ErrCodeStorageQueryFailed, "failed to query storage slot")
+	}
+
+	return common.BytesToHash(data), nil
+}
+
+// GetTransaction implements Transport.
+func (h *HTTPTransport) GetTransaction(txHash common.Hash) (transaction *types.Transaction, isPending bool, err error) {
+	ctx := context.Background()
+
+	transaction, isPending, err = h.client.TransactionByHash(ctx, txHash)
+	if goerrors.Is(err, ethereum.NotFound) {
+		return nil, false, errors.NewTransportError(errors.ErrCodeTransactionNotFound, fmt.Sprintf("transaction %s not found", txHash.Hex()))
+	}
+	if err != nil {
+		return nil, false, errors.WrapTransportError(err, errors.ErrCodeTransactionQueryFailed, "failed to query transaction")
+	}
+
+	return transaction, isPending, nil
+}
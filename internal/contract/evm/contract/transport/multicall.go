@@ -0,0 +1,267 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	customabi "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Multicall3Address is the address Multicall3 is deployed at on most EVM chains.
+var Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// maxMulticallBatchSize limits the number of calls sent in a single aggregate3 call or JSON-RPC batch.
+const maxMulticallBatchSize = 200
+
+const multicall3ABIJSON = `[{"type":"function","name":"aggregate3","stateMutability":"payable","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}]}],"outputs":[{"name":"returnData","type":"tuple[]","components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]}]`
+
+var multicall3ABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(multicall3ABIJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid Multicall3 ABI: %v", err))
+	}
+	return parsed
+}()
+
+// multicall3Call mirrors the Multicall3.Call3 struct.
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// multicall3Result mirrors the Multicall3.Result struct.
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Call is a single read-only contract call in a multicall batch.
+type Call struct {
+	Target       common.Address
+	ABI          customabi.ABI
+	FunctionName string
+	Args         []any
+}
+
+// CallResult is the outcome of a single call in a multicall batch.
+type CallResult struct {
+	// Data is the raw return data of the call.
+	Data []byte
+	// Values are the decoded return values of the call.
+	Values []any
+	// Err is set when the call could not be encoded, reverted or could not be decoded.
+	Err error
+}
+
+// preparedCall is a call with its resolved go-ethereum ABI and packed calldata.
+type preparedCall struct {
+	index  int
+	target common.Address
+	abi    abi.ABI
+	method string
+	data   []byte
+}
+
+// prepareCalls packs every call, recording encoding failures in results.
+func prepareCalls(calls []Call, results []CallResult) []preparedCall {
+	prepared := make([]preparedCall, 0, len(calls))
+	for index, call := range calls {
+		ethABI, err := convertToEthereumABI(call.ABI)
+		if err != nil {
+			results[index].Err = err
+			continue
+		}
+
+		data, err := ethABI.Pack(call.FunctionName, call.Args...)
+		if err != nil {
+			results[index].Err = errors.WrapABIError(err, errors.ErrCodeABIPackFailed, fmt.Sprintf("failed to pack function %s", call.FunctionName))
+			continue
+		}
+
+		prepared = append(prepared, preparedCall{
+			index:  index,
+			target: call.Target,
+			abi:    ethABI,
+			method: call.FunctionName,
+			data:   data,
+		})
+	}
+	return prepared
+}
+
+// decodeCallResult decodes the return data of a successful call.
+func decodeCallResult(call preparedCall, data []byte) CallResult {
+	values, err := call.abi.Unpack(call.method, data)
+	if err != nil {
+		return CallResult{
+			Data: data,
+			Err:  errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, fmt.Sprintf("failed to unpack result of %s", call.method)),
+		}
+	}
+	return CallResult{Data: data, Values: values}
+}
+
+// revertError builds the per-call error for a reverted call.
+func revertError(call preparedCall, data []byte) error {
+	message := fmt.Sprintf("call to %s reverted", call.method)
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		message = fmt.Sprintf("%s: %s", message, reason)
+	}
+	return errors.NewTransportError(errors.ErrCodeCallReverted, message)
+}
+
+// chunkCalls splits calls into batches of at most maxMulticallBatchSize.
+func chunkCalls(calls []preparedCall) [][]preparedCall {
+	chunks := make([][]preparedCall, 0, (len(calls)+maxMulticallBatchSize-1)/maxMulticallBatchSize)
+	for start := 0; start < len(calls); start += maxMulticallBatchSize {
+		end := min(start+maxMulticallBatchSize, len(calls))
+		chunks = append(chunks, calls[start:end])
+	}
+	return chunks
+}
+
+// Multicall implements Transport.
+// Calls are aggregated through Multicall3 when it is deployed on the chain,
+// otherwise they are sent as a JSON-RPC batch of eth_call requests.
+func (h *HTTPTransport) Multicall(calls []Call) (results []CallResult, err error) {
+	results = make([]CallResult, len(calls))
+	prepared := prepareCalls(calls, results)
+	if len(prepared) == 0 {
+		return results, nil
+	}
+
+	useMulticall3, err := h.hasMulticall3()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chunk := range chunkCalls(prepared) {
+		if useMulticall3 {
+			err = h.aggregate3(chunk, results)
+		} else {
+			err = h.batchCall(chunk, results)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// hasMulticall3 reports whether Multicall3 is deployed on the connected chain.
+// A successful lookup is cached for the lifetime of the transport.
+func (h *HTTPTransport) hasMulticall3() (bool, error) {
+	h.multicallMu.Lock()
+	defer h.multicallMu.Unlock()
+
+	if h.multicallAvailable != nil {
+		return *h.multicallAvailable, nil
+	}
+
+	code, err := h.GetCode(Multicall3Address)
+	if err != nil {
+		return false, err
+	}
+
+	available := len(code) > 0
+	h.multicallAvailable = &available
+	return available, nil
+}
+
+// aggregate3 executes the calls through Multicall3.aggregate3 with allowFailure set,
+// so a single reverting call does not fail the whole batch.
+func (h *HTTPTransport) aggregate3(calls []preparedCall, results []CallResult) error {
+	multicallCalls := make([]multicall3Call, len(calls))
+	for i, call := range calls {
+		multicallCalls[i] = multicall3Call{Target: call.target, AllowFailure: true, CallData: call.data}
+	}
+
+	data, err := multicall3ABI.Pack("aggregate3", multicallCalls)
+	if err != nil {
+		return errors.WrapABIError(err, errors.ErrCodeABIPackFailed, "failed to pack aggregate3 call")
+	}
+
+	var raw hexutil.Bytes
+	err = h.client.Client().CallContext(context.Background(), &raw, "eth_call", callArgs(Multicall3Address, data), "latest")
+	if err != nil {
+		return errors.WrapTransportError(err, errors.ErrCodeMulticallFailed, "failed to execute aggregate3")
+	}
+
+	return decodeAggregate3(calls, raw, results)
+}
+
+// decodeAggregate3 decodes an aggregate3 response into the per-call results.
+func decodeAggregate3(calls []preparedCall, raw []byte, results []CallResult) error {
+	var unpacked struct {
+		ReturnData []multicall3Result
+	}
+	if err := multicall3ABI.UnpackIntoInterface(&unpacked, "aggregate3", raw); err != nil {
+		return errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, "failed to unpack aggregate3 result")
+	}
+
+	multicallResults := unpacked.ReturnData
+	if len(multicallResults) != len(calls) {
+		return errors.NewTransportError(errors.ErrCodeMulticallFailed, fmt.Sprintf("aggregate3 returned %d results for %d calls", len(multicallResults), len(calls)))
+	}
+
+	for i, call := range calls {
+		result := multicallResults[i]
+		if !result.Success {
+			results[call.index] = CallResult{Data: result.ReturnData, Err: revertError(call, result.ReturnData)}
+			continue
+		}
+		results[call.index] = decodeCallResult(call, result.ReturnData)
+	}
+	return nil
+}
+
+// batchCall sends the calls as a single JSON-RPC batch of eth_call requests.
+func (h *HTTPTransport) batchCall(calls []preparedCall, results []CallResult) error {
+	responses := make([]hexutil.Bytes, len(calls))
+	batch := make([]rpc.BatchElem, len(calls))
+	for i, call := range calls {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []any{callArgs(call.target, call.data), "latest"},
+			Result: &responses[i],
+		}
+	}
+
+	if err := h.client.Client().BatchCallContext(context.Background(), batch); err != nil {
+		return errors.WrapTransportError(err, errors.ErrCodeMulticallFailed, "failed to execute batch call")
+	}
+
+	for i, call := range calls {
+		if batch[i].Error != nil {
+			results[call.index] = CallResult{Err: rpcCallError(call, batch[i].Error)}
+			continue
+		}
+		results[call.index] = decodeCallResult(call, responses[i])
+	}
+	return nil
+}
+
+// rpcCallError converts the error of an eth_call into a per-call error,
+// decoding the revert reason when the node returns revert data.
+func rpcCallError(call preparedCall, err error) error {
+	if data, ok := RevertData(err); ok {
+		return revertError(call, data)
+	}
+	return errors.WrapTransportError(err, errors.ErrCodeCallReverted, fmt.Sprintf("call to %s failed", call.method))
+}
+
+// callArgs builds the eth_call transaction object.
+func callArgs(to common.Address, data []byte) map[string]any {
+	return map[string]any{
+		"to":   to,
+		"data": hexutil.Bytes(data),
+	}
+}
@@ -0,0 +1,268 @@
+package transport
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+const multicallTestABI = `[
+	{"type":"function","name":"balanceOf","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"totalSupply","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"fail","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}
+]`
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// fakeNode is a minimal JSON-RPC node that executes calls against the test ABI
+// and, when enabled, emulates a deployed Multicall3 contract.
+type fakeNode struct {
+	mu            sync.Mutex
+	abi           ethabi.ABI
+	hasMulticall3 bool
+	ethCalls      int
+	batches       int
+	lastBlock     string
+}
+
+func (n *fakeNode) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	writer.Header().Set("Content-Type", "application/json")
+
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+		var requests []rpcRequest
+		_ = json.Unmarshal(body, &requests)
+		n.mu.Lock()
+		n.batches++
+		n.mu.Unlock()
+
+		responses := make([]rpcResponse, len(requests))
+		for i, req := range requests {
+			responses[i] = n.handle(req)
+		}
+		_ = json.NewEncoder(writer).Encode(responses)
+		return
+	}
+
+	var req rpcRequest
+	_ = json.Unmarshal(body, &req)
+	_ = json.NewEncoder(writer).Encode(n.handle(req))
+}
+
+func (n *fakeNode) handle(req rpcRequest) rpcResponse {
+	response := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+
+	switch req.Method {
+	case "eth_chainId":
+		response.Result = "0x7a69"
+	case "eth_getCode":
+		response.Result = "0x"
+		if n.hasMulticall3 {
+			response.Result = "0x6080"
+		}
+	case "eth_call":
+		n.mu.Lock()
+		n.ethCalls++
+		n.mu.Unlock()
+
+		var call struct {
+			To    common.Address `json:"to"`
+			Data  hexutil.Bytes  `json:"data"`
+			Input hexutil.Bytes  `json:"input"`
+		}
+		_ = json.Unmarshal(req.Params[0], &call)
+		if len(call.Data) == 0 {
+			call.Data = call.Input
+		}
+		_ = json.Unmarshal(req.Params[1], &n.lastBlock)
+
+		if call.To == Multicall3Address && n.hasMulticall3 {
+			response.Result = hexutil.Bytes(n.aggregate3(call.Data))
+			return response
+		}
+
+		data, reverted := n.execute(call.Data)
+		if reverted {
+			response.Error = &rpcError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(data)}
+			return response
+		}
+		response.Result = hexutil.Bytes(data)
+	case "debug_traceCall":
+		var config struct {
+			Tracer string `json:"tracer"`
+		}
+		_ = json.Unmarshal(req.Params[2], &config)
+		response.Result = json.RawMessage(`{"tracer":"` + config.Tracer + `"}`)
+	default:
+		response.Error = &rpcError{Code: -32601, Message: "method not found"}
+	}
+
+	return response
+}
+
+// execute runs a single call and returns its return data or revert data.
+func (n *fakeNode) execute(data []byte) ([]byte, bool) {
+	method, err := n.abi.MethodById(data)
+	if err != nil {
+		return nil, true
+	}
+
+	switch method.Name {
+	case "totalSupply":
+		out, _ := method.Outputs.Pack(big.NewInt(1000))
+		return out, false
+	case "balanceOf":
+		out, _ := method.Outputs.Pack(big.NewInt(42))
+		return out, false
+	default:
+		stringType, _ := ethabi.NewType("string", "", nil)
+		reason, _ := ethabi.Arguments{{Type: stringType}}.Pack("nope")
+		return append([]byte{0x08, 0xc3, 0x79, 0xa0}, reason...), true
+	}
+}
+
+func (n *fakeNode) aggregate3(data []byte) []byte {
+	method := multicall3ABI.Methods["aggregate3"]
+	var input struct {
+		Calls []multicall3Call
+	}
+	values, _ := method.Inputs.Unpack(data[4:])
+	_ = method.Inputs.Copy(&input, values)
+
+	results := make([]multicall3Result, len(input.Calls))
+	for i, call := range input.Calls {
+		returnData, reverted := n.execute(call.CallData)
+		results[i] = multicall3Result{Success: !reverted, ReturnData: returnData}
+	}
+
+	out, _ := method.Outputs.Pack(results)
+	return out
+}
+
+type MulticallTestSuite struct {
+	suite.Suite
+	contractABI abi.ABI
+	contract    common.Address
+}
+
+func TestMulticallTestSuite(t *testing.T) {
+	suite.Run(t, new(MulticallTestSuite))
+}
+
+func (suite *MulticallTestSuite) SetupSuite() {
+	suite.Require().NoError(json.Unmarshal([]byte(multicallTestABI), &suite.contractABI))
+	suite.contract = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+}
+
+func (suite *MulticallTestSuite) newTransport(hasMulticall3 bool) (Transport, *fakeNode) {
+	ethABI, err := ethabi.JSON(strings.NewReader(multicallTestABI))
+	suite.Require().NoError(err)
+
+	node := &fakeNode{abi: ethABI, hasMulticall3: hasMulticall3}
+	server := httptest.NewServer(node)
+	suite.T().Cleanup(server.Close)
+
+	tr, err := NewHTTPTransport(server.URL, 5*time.Second)
+	suite.Require().NoError(err)
+	return tr, node
+}
+
+func (suite *MulticallTestSuite) calls() []Call {
+	return []Call{
+		{Target: suite.contract, ABI: suite.contractABI, FunctionName: "totalSupply"},
+		{Target: suite.contract, ABI: suite.contractABI, FunctionName: "balanceOf", Args: []any{common.HexToAddress(testAddress)}},
+		{Target: suite.contract, ABI: suite.contractABI, FunctionName: "fail"},
+		{Target: suite.contract, ABI: suite.contractABI, FunctionName: "balanceOf", Args: []any{"not-an-address"}},
+	}
+}
+
+func (suite *MulticallTestSuite) assertResults(results []CallResult) {
+	suite.Require().Len(results, 4)
+
+	suite.Require().NoError(results[0].Err)
+	suite.Equal(big.NewInt(1000), results[0].Values[0])
+
+	suite.Require().NoError(results[1].Err)
+	suite.Equal(big.NewInt(42), results[1].Values[0])
+
+	suite.True(errors.HasCode(results[2].Err, errors.ErrCodeCallReverted), "unexpected error: %v", results[2].Err)
+	suite.Contains(results[2].Err.Error(), "nope")
+
+	suite.True(errors.HasCode(results[3].Err, errors.ErrCodeABIPackFailed), "unexpected error: %v", results[3].Err)
+}
+
+func (suite *MulticallTestSuite) TestMulticall3() {
+	tr, node := suite.newTransport(true)
+
+	results, err := tr.Multicall(suite.calls())
+	suite.Require().NoError(err)
+	suite.assertResults(results)
+	suite.Equal(1, node.ethCalls, "all calls should be aggregated into a single eth_call")
+	suite.Equal(0, node.batches)
+}
+
+func (suite *MulticallTestSuite) TestBatchFallback() {
+	tr, node := suite.newTransport(false)
+
+	results, err := tr.Multicall(suite.calls())
+	suite.Require().NoError(err)
+	suite.assertResults(results)
+	suite.Equal(3, node.ethCalls)
+	suite.Equal(1, node.batches, "calls should be sent as a single JSON-RPC batch")
+}
+
+func (suite *MulticallTestSuite) TestEmptyBatch() {
+	tr, node := suite.newTransport(true)
+
+	results, err := tr.Multicall(nil)
+	suite.Require().NoError(err)
+	suite.Empty(results)
+	suite.Equal(0, node.ethCalls)
+}
+
+func (suite *MulticallTestSuite) TestChunking() {
+	tr, node := suite.newTransport(true)
+
+	calls := make([]Call, maxMulticallBatchSize+1)
+	for i := range calls {
+		calls[i] = Call{Target: suite.contract, ABI: suite.contractABI, FunctionName: "totalSupply"}
+	}
+
+	results, err := tr.Multicall(calls)
+	suite.Require().NoError(err)
+	suite.Len(results, len(calls))
+	for _, result := range results {
+		suite.NoError(result.Err)
+	}
+	suite.Equal(2, node.ethCalls)
+}
@@ -0,0 +1,193 @@
+package transport
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+const (
+	// minPollInterval is the first delay between receipt queries, it doubles up to maxPollInterval.
+	minPollInterval = 500 * time.Millisecond
+	maxPollInterval = 8 * time.Second
+	// statusTimeout bounds the query for the status of a transaction whose receipt timed out.
+	statusTimeout = 5 * time.Second
+)
+
+// Option configures a transport created by New or NewHTTPTransport.
+type Option func(*options)
+
+type options struct {
+	confirmations uint64
+}
+
+// WithConfirmations sets the number of blocks, the block of the transaction included, that
+// WaitForTransactionReceipt waits for. 0 and 1 return the first receipt.
+func WithConfirmations(confirmations uint64) Option {
+	return func(o *options) {
+		o.confirmations = confirmations
+	}
+}
+
+func newOptions(opts []Option) options {
+	result := options{}
+	for _, opt := range opts {
+		opt(&result)
+	}
+	return result
+}
+
+// chainReader is the part of the ethclient.Client used to wait for receipts.
+type chainReader interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	TransactionByHash(ctx context.Context, txHash common.Hash) (tx *types.Transaction, isPending bool, err error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// receiptWaiter waits for the receipt of a transaction to reach a confirmation depth. New heads
+// trigger a query when the endpoint supports subscriptions, otherwise the receipt is polled with
+// exponential backoff. The timeout restarts on every new confirmation, so deep confirmation depths
+// on slow chains don't need a longer timeout.
+type receiptWaiter struct {
+	chain         chainReader
+	confirmations uint64
+	timeout       time.Duration
+	minInterval   time.Duration
+	maxInterval   time.Duration
+}
+
+// waitState is what the waiter has seen of the transaction so far.
+type waitState struct {
+	receipt       *types.Receipt
+	confirmations uint64
+	reorged       bool
+}
+
+func (w receiptWaiter) wait(txHash common.Hash) (*types.Receipt, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// HTTP endpoints don't support subscriptions, heads never fire and the backoff drives the queries
+	heads := make(chan *types.Header, 16)
+	if subscription, err := w.chain.SubscribeNewHead(ctx, heads); err == nil {
+		defer subscription.Unsubscribe()
+	}
+
+	deadline := time.NewTimer(w.timeout)
+	defer deadline.Stop()
+
+	state := &waitState{}
+	interval := w.minInterval
+	for {
+		confirmations := state.confirmations
+		receipt, err := w.check(ctx, txHash, state)
+		if err != nil || receipt != nil {
+			return receipt, err
+		}
+		if state.confirmations > confirmations {
+			deadline.Reset(w.timeout)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-deadline.C:
+			timer.Stop()
+			return nil, w.timeoutError(txHash, state)
+		case <-heads:
+			timer.Stop()
+		case <-timer.C:
+			interval = min(interval*2, w.maxInterval)
+		}
+	}
+}
+
+// check queries the receipt and returns it once it has enough confirmations and its block is
+// still canonical. A nil receipt and error means keep waiting.
+func (w receiptWaiter) check(ctx context.Context, txHash common.Hash, state *waitState) (*types.Receipt, error) {
+	receipt, err := w.chain.TransactionReceipt(ctx, txHash)
+	if goerrors.Is(err, ethereum.NotFound) {
+		if state.receipt != nil {
+			// The transaction was mined and its block left the chain
+			state.reorged = true
+			state.receipt = nil
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeReceiptQueryFailed, "failed to query transaction receipt")
+	}
+	if state.receipt != nil && state.receipt.BlockHash != receipt.BlockHash {
+		state.reorged = true
+	}
+	state.receipt = receipt
+	state.confirmations = 1
+	if w.confirmations <= 1 {
+		return receipt, nil
+	}
+
+	head, err := w.chain.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeReceiptQueryFailed, "failed to query the latest block")
+	}
+	if head.Number.Cmp(receipt.BlockNumber) < 0 {
+		return nil, nil
+	}
+	state.confirmations = new(big.Int).Sub(head.Number, receipt.BlockNumber).Uint64() + 1
+	if state.confirmations < w.confirmations {
+		return nil, nil
+	}
+
+	canonical, err := w.chain.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil && !goerrors.Is(err, ethereum.NotFound) {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeReceiptQueryFailed, "failed to query the block of the transaction")
+	}
+	if canonical == nil || canonical.Hash() != receipt.BlockHash {
+		// The node returned the receipt of a block that was reorged out, wait for the new one
+		state.reorged = true
+		state.receipt = nil
+		state.confirmations = 0
+		return nil, nil
+	}
+	return receipt, nil
+}
+
+// timeoutError tells a transaction that is still pending, or mined without enough confirmations,
+// from one that was dropped from the mempool.
+func (w receiptWaiter) timeoutError(txHash common.Hash, state *waitState) error {
+	if state.receipt != nil {
+		return errors.NewTransportError(errors.ErrCodeTransactionPending, fmt.Sprintf(
+			"transaction %s was mined in block %s but has %d of %d confirmations, no new block for %s",
+			txHash.Hex(), state.receipt.BlockNumber, state.confirmations, w.confirmations, w.timeout))
+	}
+
+	reorged := ""
+	if state.reorged {
+		reorged = ", its block was reorged out"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+	_, isPending, err := w.chain.TransactionByHash(ctx, txHash)
+	switch {
+	case goerrors.Is(err, ethereum.NotFound):
+		return errors.NewTransportError(errors.ErrCodeTransactionDropped, fmt.Sprintf(
+			"transaction %s was dropped%s, it is neither in the mempool nor on chain", txHash.Hex(), reorged))
+	case err != nil:
+		return errors.WrapTransportError(err, errors.ErrCodeTransactionTimeout, fmt.Sprintf(
+			"timeout waiting for transaction receipt after %s and its status could not be queried", w.timeout))
+	case isPending:
+		return errors.NewTransportError(errors.ErrCodeTransactionPending, fmt.Sprintf(
+			"transaction %s is still pending after %s%s", txHash.Hex(), w.timeout, reorged))
+	default:
+		return errors.NewTransportError(errors.ErrCodeTransactionPending, fmt.Sprintf(
+			"transaction %s was mined but its receipt is not available yet after %s%s", txHash.Hex(), w.timeout, reorged))
+	}
+}
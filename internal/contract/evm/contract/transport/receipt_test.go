@@ -0,0 +1,144 @@
+package transport
+
+import (
+	"context"
+	goerrors "errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var waitedHash = common.HexToHash("0xaa")
+
+// fakeChain is a chain whose head advances by one block on every receipt query, up to the tip when
+// it is set. Blocks are keyed by number, a reorg replaces the header of a number.
+type fakeChain struct {
+	mu sync.Mutex
+	// receipts are returned in order by TransactionReceipt, the last one repeats. A nil receipt
+	// means not found.
+	receipts  []*types.Receipt
+	head      int64
+	tip       int64
+	headers   map[int64]*types.Header
+	pending   bool
+	inMempool bool
+}
+
+func header(number int64, extra string) *types.Header {
+	return &types.Header{Number: big.NewInt(number), Extra: []byte(extra)}
+}
+
+func receiptIn(block *types.Header) *types.Receipt {
+	return &types.Receipt{TxHash: waitedHash, BlockHash: block.Hash(), BlockNumber: block.Number, Status: types.ReceiptStatusSuccessful}
+}
+
+func (f *fakeChain) TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.tip == 0 || f.head < f.tip {
+		f.head++
+	}
+	receipt := f.receipts[0]
+	if len(f.receipts) > 1 {
+		f.receipts = f.receipts[1:]
+	}
+	if receipt == nil {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (f *fakeChain) TransactionByHash(context.Context, common.Hash) (*types.Transaction, bool, error) {
+	if !f.inMempool {
+		return nil, false, ethereum.NotFound
+	}
+	return types.NewTx(&types.LegacyTx{}), f.pending, nil
+}
+
+func (f *fakeChain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if number == nil {
+		return header(f.head, ""), nil
+	}
+	if block, ok := f.headers[number.Int64()]; ok {
+		return block, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (f *fakeChain) SubscribeNewHead(context.Context, chan<- *types.Header) (ethereum.Subscription, error) {
+	return nil, goerrors.New("notifications not supported")
+}
+
+func waiter(chain *fakeChain, confirmations uint64, timeout time.Duration) receiptWaiter {
+	return receiptWaiter{
+		chain:         chain,
+		confirmations: confirmations,
+		timeout:       timeout,
+		minInterval:   time.Millisecond,
+		maxInterval:   4 * time.Millisecond,
+	}
+}
+
+func TestWaitForConfirmations(t *testing.T) {
+	block := header(1, "a")
+	chain := &fakeChain{receipts: []*types.Receipt{nil, receiptIn(block)}, headers: map[int64]*types.Header{1: block}}
+
+	receipt, err := waiter(chain, 3, time.Second).wait(waitedHash)
+	require.NoError(t, err)
+	assert.Equal(t, block.Hash(), receipt.BlockHash)
+	assert.GreaterOrEqual(t, chain.head, int64(3))
+}
+
+func TestWaitSurvivesReorg(t *testing.T) {
+	orphaned := header(1, "orphaned")
+	canonical := header(1, "canonical")
+	chain := &fakeChain{
+		// The transaction is mined, its block is reorged out and it is mined again
+		receipts: []*types.Receipt{receiptIn(orphaned), receiptIn(orphaned), nil, receiptIn(canonical)},
+		headers:  map[int64]*types.Header{1: canonical},
+	}
+
+	receipt, err := waiter(chain, 2, time.Second).wait(waitedHash)
+	require.NoError(t, err)
+	assert.Equal(t, canonical.Hash(), receipt.BlockHash)
+}
+
+func TestWaitReportsDroppedTransaction(t *testing.T) {
+	block := header(1, "orphaned")
+	chain := &fakeChain{receipts: []*types.Receipt{receiptIn(block), nil}}
+
+	_, err := waiter(chain, 2, 50*time.Millisecond).wait(waitedHash)
+	require.Error(t, err)
+	assert.True(t, errors.HasCode(err, errors.ErrCodeTransactionDropped))
+	assert.Contains(t, err.Error(), "its block was reorged out")
+}
+
+func TestWaitReportsPendingTransaction(t *testing.T) {
+	chain := &fakeChain{receipts: []*types.Receipt{nil}, inMempool: true, pending: true}
+
+	_, err := waiter(chain, 1, 50*time.Millisecond).wait(waitedHash)
+	require.Error(t, err)
+	assert.True(t, errors.HasCode(err, errors.ErrCodeTransactionPending))
+	assert.Contains(t, err.Error(), "is still pending")
+}
+
+func TestWaitReportsMissingConfirmations(t *testing.T) {
+	block := header(1, "a")
+	chain := &fakeChain{receipts: []*types.Receipt{receiptIn(block)}, headers: map[int64]*types.Header{1: block}, tip: 3}
+
+	_, err := waiter(chain, 12, 50*time.Millisecond).wait(waitedHash)
+	require.Error(t, err)
+	assert.True(t, errors.HasCode(err, errors.ErrCodeTransactionPending))
+	assert.Contains(t, err.Error(), "was mined in block 1")
+	assert.Contains(t, err.Error(), "has 3 of 12 confirmations")
+}
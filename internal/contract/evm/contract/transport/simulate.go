@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// SimulateCall implements Transport.
+func (h *HTTPTransport) SimulateCall(msg ethereum.CallMsg) (result []byte, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	result, err = h.client.PendingCallContract(ctx, msg)
+	if err != nil {
+		if _, ok := RevertData(err); ok || strings.Contains(err.Error(), "execution reverted") {
+			return nil, errors.WrapTransportError(err, errors.ErrCodeCallReverted, "simulated call reverted")
+		}
+		return nil, errors.WrapTransportError(err, errors.ErrCodeRPCCallFailed, "failed to simulate call")
+	}
+
+	return result, nil
+}
+
+// TraceCall implements Transport.
+func (h *HTTPTransport) TraceCall(msg ethereum.CallMsg, tracer string, tracerConfig map[string]any) (result json.RawMessage, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	// Geth does not trace on top of the pending block, so the trace runs at the latest block.
+	if err := h.client.Client().CallContext(ctx, &result, "debug_traceCall", toCallArg(msg), "latest", tracerArg(tracer, tracerConfig)); err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeTraceFailed, "failed to trace call")
+	}
+
+	return result, nil
+}
+
+// RevertData returns the revert data a node attached to the error of a reverted call.
+func RevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !goerrors.As(err, &dataErr) {
+		return nil, false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, err := hexutil.Decode(hexData)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// toCallArg builds the JSON-RPC call object of a call message.
+func toCallArg(msg ethereum.CallMsg) map[string]any {
+	arg := map[string]any{
+		"from": msg.From,
+	}
+	if msg.To != nil {
+		arg["to"] = msg.To
+	}
+	if len(msg.Data) > 0 {
+		arg["input"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	return arg
+}
@@ -0,0 +1,56 @@
+package transport
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+func (suite *MulticallTestSuite) callMsg(method string) ethereum.CallMsg {
+	ethABI, err := ethabi.JSON(strings.NewReader(multicallTestABI))
+	suite.Require().NoError(err)
+	data, err := ethABI.Pack(method)
+	suite.Require().NoError(err)
+	return ethereum.CallMsg{To: &suite.contract, Data: data}
+}
+
+func (suite *MulticallTestSuite) TestSimulateCallAtPendingBlock() {
+	tr, node := suite.newTransport(false)
+
+	result, err := tr.SimulateCall(suite.callMsg("totalSupply"))
+	suite.Require().NoError(err)
+	suite.Equal("pending", node.lastBlock)
+
+	suite.Equal(common.LeftPadBytes(big.NewInt(1000).Bytes(), 32), result)
+}
+
+func (suite *MulticallTestSuite) TestSimulateCallRevert() {
+	tr, _ := suite.newTransport(false)
+
+	_, err := tr.SimulateCall(suite.callMsg("fail"))
+	suite.True(errors.HasCode(err, errors.ErrCodeCallReverted))
+
+	data, ok := RevertData(err)
+	suite.Require().True(ok)
+	reason, err := ethabi.UnpackRevert(data)
+	suite.Require().NoError(err)
+	suite.Equal("nope", reason)
+}
+
+func (suite *MulticallTestSuite) TestTraceCall() {
+	tr, _ := suite.newTransport(false)
+
+	result, err := tr.TraceCall(suite.callMsg("totalSupply"), "callTracer", map[string]any{"withLog": true})
+	suite.Require().NoError(err)
+
+	var output struct {
+		Tracer string `json:"tracer"`
+	}
+	suite.Require().NoError(json.Unmarshal(result, &output))
+	suite.Equal("callTracer", output.Tracer)
+}
@@ -0,0 +1,312 @@
+package transport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/catalyst"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+
+	// Register the native tracers used by TraceCall.
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+)
+
+// SimulatedEndpoint is the endpoint URL that selects the in-process simulated chain instead of an RPC node.
+const SimulatedEndpoint = "simulated"
+
+// SimulatedChainID is the chain ID of the simulated chain, the same as anvil's so that
+// transactions signed for a local anvil node also work on it.
+const SimulatedChainID = 31337
+
+// DevPrivateKeys are the private keys of the accounts funded on the simulated chain. They are the
+// default accounts of anvil and hardhat, derived from "test test test test test test test test test
+// test test junk".
+var DevPrivateKeys = []string{
+	"ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+	"59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+	"5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a",
+	"7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6",
+	"47e179ec197488593b187f80a00eb0da91f1b9d0b13f8733639f19c30a34926a",
+	"8b3a350cf5c34c9194ca85829a2df0ec3153be0318b5e2d3348e872092edffba",
+	"92db14e403b83dfe3df233f83dfa3a0d7096f21ca9b0d6d6b8d88b2b4ec1564e",
+	"4bbbf85ce3377467afe5d46f804f221813b2bb87f24d81f60f1fcdbf7cbf4356",
+	"dbda1821b80551c9d65939329250298aa3472ba22feea921c0cf5d620ea67b97",
+	"2a871d0798f97d79848a013d4936a73bf4cc922c825d33c1cf7073dff6d409c6",
+}
+
+// DevAccountBalance is the balance of every funded account on a new simulated chain, 10000 ether.
+var DevAccountBalance = new(big.Int).Mul(big.NewInt(10000), big.NewInt(params.Ether))
+
+// SimulatedTransport runs an ephemeral chain in process, so contracts can be deployed and called
+// without a node or network. Every transaction is mined into its own block as soon as it is sent.
+type SimulatedTransport struct {
+	*HTTPTransport
+
+	stack  *node.Node
+	beacon *catalyst.SimulatedBeacon
+	// mineMu serializes sending and mining, so every block holds exactly one transaction.
+	mineMu sync.Mutex
+	// mined maps the hash of every sent transaction to the hash of its block, the chain indexes
+	// transactions in the background so they can't be looked up right after mining.
+	mined map[common.Hash]common.Hash
+}
+
+var (
+	sharedSimulatedMu sync.Mutex
+	sharedSimulated   *SimulatedTransport
+)
+
+// New returns the transport for an endpoint URL: the shared simulated chain for SimulatedEndpoint,
+// otherwise an HTTPTransport. The simulated chain ignores the options, it mines every transaction
+// when it is sent and never reorgs.
+func New(endpoint string, timeout time.Duration, opts ...Option) (Transport, error) {
+	if endpoint == SimulatedEndpoint {
+		return SharedSimulatedTransport()
+	}
+	return NewHTTPTransport(endpoint, timeout, opts...)
+}
+
+// SharedSimulatedTransport returns the simulated chain shared by the whole process, starting it on
+// first use. Its state lives until the process exits.
+func SharedSimulatedTransport() (*SimulatedTransport, error) {
+	sharedSimulatedMu.Lock()
+	defer sharedSimulatedMu.Unlock()
+
+	if sharedSimulated == nil {
+		simulated, err := NewSimulatedTransport(nil)
+		if err != nil {
+			return nil, err
+		}
+		sharedSimulated = simulated
+	}
+	return sharedSimulated, nil
+}
+
+// NewSimulatedTransport starts a new simulated chain with the given genesis accounts. When alloc is
+// nil, the accounts of DevPrivateKeys are funded with DevAccountBalance. Close stops the chain.
+func NewSimulatedTransport(alloc types.GenesisAlloc) (*SimulatedTransport, error) {
+	if alloc == nil {
+		accounts, err := DevAccounts()
+		if err != nil {
+			return nil, err
+		}
+		alloc = types.GenesisAlloc{}
+		for _, account := range accounts {
+			alloc[account] = types.Account{Balance: DevAccountBalance}
+		}
+	}
+
+	chainConfig := *params.AllDevChainProtocolChanges
+	chainConfig.ChainID = big.NewInt(SimulatedChainID)
+
+	nodeConfig := node.DefaultConfig
+	nodeConfig.DataDir = ""
+	nodeConfig.IPCPath = ""
+	nodeConfig.P2P = p2p.Config{NoDiscovery: true}
+
+	ethConfig := ethconfig.Defaults
+	ethConfig.Genesis = &core.Genesis{
+		Config:   &chainConfig,
+		GasLimit: ethconfig.Defaults.Miner.GasCeil,
+		Alloc:    alloc,
+	}
+	ethConfig.SyncMode = ethconfig.FullSync
+	ethConfig.TxPool.NoLocals = true
+	// Mine every valid transaction, however small its tip.
+	ethConfig.Miner.GasPrice = big.NewInt(1)
+
+	stack, err := node.New(&nodeConfig)
+	if err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeConnectionFailed, "failed to create simulated node")
+	}
+	backend, err := eth.New(stack, &ethConfig)
+	if err != nil {
+		_ = stack.Close()
+		return nil, errors.WrapTransportError(err, errors.ErrCodeConnectionFailed, "failed to create simulated chain")
+	}
+
+	filterSystem := filters.NewFilterSystem(backend.APIBackend, filters.Config{})
+	stack.RegisterAPIs(append(tracers.APIs(backend.APIBackend), rpc.API{
+		Namespace: "eth",
+		Service:   filters.NewFilterAPI(filterSystem),
+	}))
+	if err := stack.Start(); err != nil {
+		_ = stack.Close()
+		return nil, errors.WrapTransportError(err, errors.ErrCodeConnectionFailed, "failed to start simulated chain")
+	}
+
+	beacon, err := catalyst.NewSimulatedBeacon(0, common.Address{}, backend)
+	if err != nil {
+		_ = stack.Close()
+		return nil, errors.WrapTransportError(err, errors.ErrCodeConnectionFailed, "failed to start simulated consensus")
+	}
+	if err := beacon.Fork(backend.BlockChain().GetCanonicalHash(0)); err != nil {
+		_ = stack.Close()
+		return nil, errors.WrapTransportError(err, errors.ErrCodeConnectionFailed, "failed to reset simulated chain")
+	}
+
+	return &SimulatedTransport{
+		HTTPTransport: &HTTPTransport{
+			Endpoint: SimulatedEndpoint,
+			client:   ethclient.NewClient(stack.Attach()),
+			timeout:  30 * time.Second,
+		},
+		stack:  stack,
+		beacon: beacon,
+		mined:  map[common.Hash]common.Hash{},
+	}, nil
+}
+
+// DevAccounts returns the addresses of DevPrivateKeys.
+func DevAccounts() ([]common.Address, error) {
+	accounts := make([]common.Address, 0, len(DevPrivateKeys))
+	for index := range DevPrivateKeys {
+		key, err := DevPrivateKey(index)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dev private key %d: %w", index, err)
+		}
+		accounts = append(accounts, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return accounts, nil
+}
+
+// DevPrivateKey returns the private key of the funded account at index.
+func DevPrivateKey(index int) (*ecdsa.PrivateKey, error) {
+	if index < 0 || index >= len(DevPrivateKeys) {
+		return nil, fmt.Errorf("dev account index %d out of range", index)
+	}
+	return crypto.HexToECDSA(DevPrivateKeys[index])
+}
+
+// SendTransaction implements Transport. The transaction is mined before it returns.
+func (s *SimulatedTransport) SendTransaction(transaction *types.Transaction) (txHash common.Hash, err error) {
+	s.mineMu.Lock()
+	defer s.mineMu.Unlock()
+
+	txHash, err = s.HTTPTransport.SendTransaction(transaction)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	blockHash := s.beacon.Commit()
+	block, err := s.client.BlockByHash(context.Background(), blockHash)
+	if err != nil {
+		return common.Hash{}, errors.WrapTransportError(err, errors.ErrCodeTransactionSendFailed, "failed to mine transaction")
+	}
+	if block.Transaction(txHash) == nil {
+		return common.Hash{}, errors.NewTransportError(errors.ErrCodeTransactionSendFailed, "transaction was not mined, check its nonce and fee cap")
+	}
+	s.mined[txHash] = blockHash
+	return txHash, nil
+}
+
+// WaitForTransactionReceipt implements Transport. Transactions are mined when they are sent, so the
+// receipt is read from their block right away instead of waiting for the chain to index them.
+func (s *SimulatedTransport) WaitForTransactionReceipt(txHash common.Hash) (receipt *types.Receipt, err error) {
+	blockHash, ok := s.blockOf(txHash)
+	if !ok {
+		return nil, errors.NewTransportError(errors.ErrCodeTransactionNotFound, fmt.Sprintf("transaction %s not found", txHash.Hex()))
+	}
+
+	receipts, err := s.client.BlockReceipts(context.Background(), rpc.BlockNumberOrHashWithHash(blockHash, false))
+	if err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeReceiptQueryFailed, "failed to query transaction receipt")
+	}
+	for _, receipt := range receipts {
+		if receipt.TxHash == txHash {
+			return receipt, nil
+		}
+	}
+	return nil, errors.NewTransportError(errors.ErrCodeTransactionNotFound, fmt.Sprintf("transaction %s not found", txHash.Hex()))
+}
+
+// GetTransaction implements Transport. Sent transactions are never pending on the simulated chain.
+func (s *SimulatedTransport) GetTransaction(txHash common.Hash) (transaction *types.Transaction, isPending bool, err error) {
+	blockHash, ok := s.blockOf(txHash)
+	if !ok {
+		return nil, false, errors.NewTransportError(errors.ErrCodeTransactionNotFound, fmt.Sprintf("transaction %s not found", txHash.Hex()))
+	}
+
+	block, err := s.client.BlockByHash(context.Background(), blockHash)
+	if err != nil {
+		return nil, false, errors.WrapTransportError(err, errors.ErrCodeTransactionQueryFailed, "failed to query transaction")
+	}
+	if transaction = block.Transaction(txHash); transaction == nil {
+		return nil, false, errors.NewTransportError(errors.ErrCodeTransactionNotFound, fmt.Sprintf("transaction %s not found", txHash.Hex()))
+	}
+	return transaction, false, nil
+}
+
+// TraceTransaction implements Transport. The block of the transaction is traced instead, since
+// debug_traceTransaction waits for the chain to index the transaction.
+func (s *SimulatedTransport) TraceTransaction(txHash common.Hash, tracer string, tracerConfig map[string]any) (result json.RawMessage, err error) {
+	blockHash, ok := s.blockOf(txHash)
+	if !ok {
+		return nil, errors.NewTransportError(errors.ErrCodeTransactionNotFound, fmt.Sprintf("transaction %s not found", txHash.Hex()))
+	}
+
+	var traces []struct {
+		TxHash common.Hash     `json:"txHash"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := s.client.Client().CallContext(context.Background(), &traces, "debug_traceBlockByHash", blockHash, tracerArg(tracer, tracerConfig)); err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeTraceFailed, "failed to trace transaction")
+	}
+	for _, trace := range traces {
+		if trace.TxHash == txHash {
+			return trace.Result, nil
+		}
+	}
+	return nil, errors.NewTransportError(errors.ErrCodeTransactionNotFound, fmt.Sprintf("transaction %s not found", txHash.Hex()))
+}
+
+// blockOf returns the hash of the block a transaction sent through the transport was mined in.
+func (s *SimulatedTransport) blockOf(txHash common.Hash) (common.Hash, bool) {
+	s.mineMu.Lock()
+	defer s.mineMu.Unlock()
+
+	blockHash, ok := s.mined[txHash]
+	return blockHash, ok
+}
+
+// Commit mines a block with the pending transactions and returns its hash.
+func (s *SimulatedTransport) Commit() common.Hash {
+	s.mineMu.Lock()
+	defer s.mineMu.Unlock()
+
+	return s.beacon.Commit()
+}
+
+// AdjustTime mines an empty block with its timestamp moved forward, e.g. to pass a time lock.
+func (s *SimulatedTransport) AdjustTime(adjustment time.Duration) error {
+	s.mineMu.Lock()
+	defer s.mineMu.Unlock()
+
+	if err := s.beacon.AdjustTime(adjustment); err != nil {
+		return errors.WrapTransportError(err, errors.ErrCodeRPCCallFailed, "failed to adjust simulated time")
+	}
+	return nil
+}
+
+// Close stops the simulated chain. The transport can't be used afterwards.
+func (s *SimulatedTransport) Close() error {
+	s.client.Close()
+	return s.stack.Close()
+}
@@ -0,0 +1,155 @@
+package transport
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+// answerInitCode deploys a contract whose runtime code returns 42 for any call.
+const answerInitCode = "0x600a600c600039600a6000f3602a60005260206000f3"
+
+// SimulatedTransportTestSuite runs against an in-process simulated chain, no node is needed.
+type SimulatedTransportTestSuite struct {
+	suite.Suite
+	transport *SimulatedTransport
+	sender    common.Address
+}
+
+func TestSimulatedTransportTestSuite(t *testing.T) {
+	suite.Run(t, new(SimulatedTransportTestSuite))
+}
+
+func (suite *SimulatedTransportTestSuite) SetupTest() {
+	tr, err := NewSimulatedTransport(nil)
+	suite.Require().NoError(err)
+	suite.transport = tr
+
+	accounts, err := DevAccounts()
+	suite.Require().NoError(err)
+	suite.sender = accounts[0]
+}
+
+func (suite *SimulatedTransportTestSuite) TearDownTest() {
+	suite.NoError(suite.transport.Close())
+}
+
+// send signs a transaction from the first dev account and returns its receipt.
+func (suite *SimulatedTransportTestSuite) send(to *common.Address, value *big.Int, data []byte) *types.Receipt {
+	key, err := DevPrivateKey(0)
+	suite.Require().NoError(err)
+	nonce, err := suite.transport.GetTransactionCount(suite.sender)
+	suite.Require().NoError(err)
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(SimulatedChainID)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(SimulatedChainID),
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10_000_000_000),
+		Gas:       100_000,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	})
+	suite.Require().NoError(err)
+
+	txHash, err := suite.transport.SendTransaction(tx)
+	suite.Require().NoError(err)
+	receipt, err := suite.transport.WaitForTransactionReceipt(txHash)
+	suite.Require().NoError(err)
+	return receipt
+}
+
+func (suite *SimulatedTransportTestSuite) TestEstimateCallGasFromSender() {
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+	transfer := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(SimulatedChainID),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10_000_000_000),
+		To:        &recipient,
+		Value:     big.NewInt(1_000_000_000_000_000_000),
+	})
+
+	gas, err := suite.transport.EstimateCallGas(CallMsg(transfer, suite.sender))
+	suite.Require().NoError(err)
+	suite.Equal(uint64(21000), gas)
+
+	// The unsigned transaction estimates as sent from the zero address, which can't pay the value
+	_, err = suite.transport.EstimateCallGas(CallMsg(transfer, common.Address{}))
+	suite.True(errors.HasCode(err, errors.ErrCodeGasEstimateFailed), "%v", err)
+}
+
+func (suite *SimulatedTransportTestSuite) TestDevAccountsAreFunded() {
+	chainID, err := suite.transport.GetChainID()
+	suite.Require().NoError(err)
+	suite.Equal(int64(SimulatedChainID), chainID.Int64())
+
+	suite.Equal("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", suite.sender.Hex())
+	balance, err := suite.transport.GetBalance(suite.sender)
+	suite.Require().NoError(err)
+	suite.Equal(DevAccountBalance, balance)
+}
+
+func (suite *SimulatedTransportTestSuite) TestTransferIsMinedImmediately() {
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	receipt := suite.send(&recipient, big.NewInt(1000), nil)
+	suite.Equal(types.ReceiptStatusSuccessful, receipt.Status)
+	suite.Equal(uint64(1), receipt.BlockNumber.Uint64())
+
+	balance, err := suite.transport.GetBalance(recipient)
+	suite.Require().NoError(err)
+	suite.Equal(big.NewInt(1000), balance)
+
+	_, isPending, err := suite.transport.GetTransaction(receipt.TxHash)
+	suite.Require().NoError(err)
+	suite.False(isPending)
+}
+
+func (suite *SimulatedTransportTestSuite) TestDeployAndCall() {
+	receipt := suite.send(nil, nil, hexutil.MustDecode(answerInitCode))
+	suite.Require().Equal(types.ReceiptStatusSuccessful, receipt.Status)
+	suite.Equal(crypto.CreateAddress(suite.sender, 0), receipt.ContractAddress)
+
+	code, err := suite.transport.GetCode(receipt.ContractAddress)
+	suite.Require().NoError(err)
+	suite.Equal(hexutil.MustDecode("0x602a60005260206000f3"), code)
+
+	msg := ethereum.CallMsg{From: suite.sender, To: &receipt.ContractAddress}
+	result, err := suite.transport.SimulateCall(msg)
+	suite.Require().NoError(err)
+	suite.Equal(common.LeftPadBytes([]byte{42}, 32), result)
+
+	trace, err := suite.transport.TraceCall(msg, "callTracer", nil)
+	suite.Require().NoError(err)
+	var frame struct {
+		Type   string        `json:"type"`
+		Output hexutil.Bytes `json:"output"`
+	}
+	suite.Require().NoError(json.Unmarshal(trace, &frame))
+	suite.Equal("CALL", frame.Type)
+	suite.Equal(result, []byte(frame.Output))
+}
+
+func (suite *SimulatedTransportTestSuite) TestReceiptOfUnknownTransaction() {
+	_, err := suite.transport.WaitForTransactionReceipt(common.HexToHash("0x01"))
+	suite.True(errors.HasCode(err, errors.ErrCodeTransactionNotFound))
+}
+
+func (suite *SimulatedTransportTestSuite) TestNewSelectsSimulatedChain() {
+	tr, err := New(SimulatedEndpoint, 0)
+	suite.Require().NoError(err)
+	_, ok := tr.(*SimulatedTransport)
+	suite.True(ok)
+
+	again, err := New(SimulatedEndpoint, 0)
+	suite.Require().NoError(err)
+	suite.Same(tr, again)
+}
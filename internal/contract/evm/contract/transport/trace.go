@@ -0,0 +1,30 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// TraceTransaction implements Transport.
+func (h *HTTPTransport) TraceTransaction(txHash common.Hash, tracer string, tracerConfig map[string]any) (result json.RawMessage, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	if err := h.client.Client().CallContext(ctx, &result, "debug_traceTransaction", txHash, tracerArg(tracer, tracerConfig)); err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeTraceFailed, "failed to trace transaction")
+	}
+
+	return result, nil
+}
+
+// tracerArg is the trace config parameter of the debug_trace methods.
+func tracerArg(tracer string, tracerConfig map[string]any) map[string]any {
+	config := map[string]any{"tracer": tracer}
+	if tracerConfig != nil {
+		config["tracerConfig"] = tracerConfig
+	}
+	return config
+}
@@ -1,8 +1,10 @@
 package transport
 
 import (
+	"encoding/json"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
@@ -15,12 +17,23 @@ type Transport interface {
 	// WaitForTransactionReceipt waits for a transaction receipt and returns it
 	WaitForTransactionReceipt(txHash common.Hash) (receipt *types.Receipt, err error)
 
+	// Call executes a call with its raw calldata at the latest block and returns the result
+	Call(msg ethereum.CallMsg) (result []byte, err error)
+
 	// CallContract calls a contract function and returns the result
 	CallContract(contractAddress common.Address, abi abi.ABI, functionName string, args ...any) (result []byte, err error)
 
+	// CallContractWithOptions calls a contract function at the block of the options, with their state
+	// overrides applied, and returns the result
+	CallContractWithOptions(contractAddress common.Address, abi abi.ABI, functionName string, options CallOptions, args ...any) (result []byte, err error)
+
 	// EstimateGas estimates the gas required for a transaction
 	EstimateGas(tx *types.Transaction) (gas uint64, err error)
 
+	// EstimateCallGas estimates the gas required for a call sent from msg.From, for transactions that
+	// are not signed yet. See CallMsg
+	EstimateCallGas(msg ethereum.CallMsg) (gas uint64, err error)
+
 	// GetTransactionCount gets the nonce for an address
 	GetTransactionCount(address common.Address) (nonce uint64, err error)
 
@@ -29,4 +42,31 @@ type Transport interface {
 
 	// GetChainID gets the chain ID from the blockchain
 	GetChainID() (chainID *big.Int, err error)
+
+	// FilterLogs returns the logs matching the given filter query
+	FilterLogs(query ethereum.FilterQuery) (logs []types.Log, err error)
+
+	// GetCode gets the deployed bytecode at an address
+	GetCode(address common.Address) (code []byte, err error)
+
+	// GetStorageAt reads a storage slot of an address at the latest block
+	GetStorageAt(address common.Address, slot common.Hash) (value common.Hash, err error)
+
+	// GetTransaction gets a transaction by hash and reports whether it is still pending
+	GetTransaction(txHash common.Hash) (tx *types.Transaction, isPending bool, err error)
+
+	// Multicall executes many read-only calls in as few round trips as possible.
+	// The returned error only reports a failure of the whole batch, failures of
+	// individual calls are reported in their CallResult
+	Multicall(calls []Call) (results []CallResult, err error)
+
+	// SimulateCall executes a call at the pending block without sending a transaction and returns
+	// its return data. The error of a reverted call carries the revert data, see RevertData
+	SimulateCall(msg ethereum.CallMsg) (result []byte, err error)
+
+	// TraceCall runs debug_traceCall with the named tracer and returns the raw tracer output
+	TraceCall(msg ethereum.CallMsg, tracer string, tracerConfig map[string]any) (result json.RawMessage, err error)
+
+	// TraceTransaction runs debug_traceTransaction with the named tracer and returns the raw tracer output
+	TraceTransaction(txHash common.Hash, tracer string, tracerConfig map[string]any) (result json.RawMessage, err error)
 }
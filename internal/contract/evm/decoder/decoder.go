@@ -0,0 +1,389 @@
+// Package decoder identifies the function called by raw calldata or a transaction and decodes its
+// arguments into named fields, using the stored ABIs and a local database of well-known selectors.
+package decoder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/signatures"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+const listPageSize = 100
+
+// Value is a decoded argument. Tuples and arrays hold their components or elements in Components.
+type Value struct {
+	Name       string  `json:"name"`
+	Type       string  `json:"type"`
+	Value      string  `json:"value,omitempty"`
+	Components []Value `json:"components,omitempty"`
+}
+
+// Call is a function call decoded from calldata.
+type Call struct {
+	Selector  string         `json:"selector"`
+	Signature string         `json:"signature"`
+	Source    string         `json:"source"`
+	Function  abi.ABIElement `json:"-"`
+	Arguments []Value        `json:"arguments"`
+}
+
+// Transaction is a transaction fetched by hash together with its decoded calls.
+type Transaction struct {
+	Hash    common.Hash     `json:"hash"`
+	To      *common.Address `json:"to"`
+	Value   *big.Int        `json:"value"`
+	Pending bool            `json:"pending"`
+	Calls   []Call          `json:"calls"`
+}
+
+// Input is parsed user input, either calldata or a transaction hash.
+type Input struct {
+	Calldata      []byte
+	TxHash        common.Hash
+	IsTransaction bool
+}
+
+type candidate struct {
+	element   abi.ABIElement
+	arguments ethabi.Arguments
+	signature string
+	source    string
+}
+
+// SignatureLookup finds functions and custom errors by selector and events by topic, used for the
+// selectors and topics that no registered ABI knows.
+type SignatureLookup interface {
+	Functions(selector [4]byte) ([]abi.ABIElement, error)
+	Errors(selector [4]byte) ([]abi.ABIElement, error)
+	Events(topic common.Hash) ([]abi.ABIElement, error)
+}
+
+// Decoder matches selectors against the functions it knows.
+type Decoder struct {
+	candidates map[[4]byte][]candidate
+	lookup     SignatureLookup
+}
+
+// New creates a decoder that knows the functions of the signature dump bundled with the CLI, the
+// functions of widely deployed standards and contracts.
+func New() *Decoder {
+	decoder := &Decoder{candidates: map[[4]byte][]candidate{}}
+	builtin, err := signatures.Bundled()
+	if err != nil {
+		panic(fmt.Sprintf("invalid bundled signatures: %v", err))
+	}
+	decoder.AddABI(BuiltinSource, builtin)
+	return decoder
+}
+
+// AddABI registers the functions of an ABI under the source name. Functions whose types cannot be
+// decoded are skipped.
+func (d *Decoder) AddABI(source string, elements abi.AbiArray) {
+	for _, element := range elements {
+		if element.Type != "function" {
+			continue
+		}
+		selector, newCandidate, err := newCandidate(element, source)
+		if err != nil {
+			continue
+		}
+
+		duplicate := false
+		for _, existing := range d.candidates[selector] {
+			if existing.source == source && existing.signature == newCandidate.signature {
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			d.candidates[selector] = append(d.candidates[selector], newCandidate)
+		}
+	}
+}
+
+// UseSignatures makes the decoder look up selectors that no registered ABI knows in the signature database.
+func (d *Decoder) UseSignatures(lookup SignatureLookup) {
+	d.lookup = lookup
+}
+
+// Signatures returns the signature database of the decoder, nil when it has none.
+func (d *Decoder) Signatures() SignatureLookup {
+	return d.lookup
+}
+
+func newCandidate(element abi.ABIElement, source string) ([4]byte, candidate, error) {
+	arguments, err := abi.Arguments(element.Inputs)
+	if err != nil {
+		return [4]byte{}, candidate{}, err
+	}
+	signature, err := element.Signature()
+	if err != nil {
+		return [4]byte{}, candidate{}, err
+	}
+	selector, err := element.Selector()
+	if err != nil {
+		return [4]byte{}, candidate{}, err
+	}
+	return selector, candidate{element: element, arguments: arguments, signature: signature, source: source}, nil
+}
+
+// AddStoredABIs registers the functions of every ABI in the storage, using the ABI names as sources.
+func (d *Decoder) AddStoredABIs(storage sql.Storage) error {
+	for page := int64(1); ; page++ {
+		result, err := storage.ListABIs(page, listPageSize)
+		if err != nil {
+			return err
+		}
+		for _, stored := range result.Items {
+			d.AddABI(stored.Name, stored.Abi.AbiArray)
+		}
+		if page >= result.TotalPages {
+			return nil
+		}
+	}
+}
+
+// Decode decodes calldata against every function with a matching selector. Functions from stored
+// ABIs come first; the builtin selectors are only used when no stored ABI has the signature, and the
+// signature database only when neither knows the selector.
+func (d *Decoder) Decode(calldata []byte) ([]Call, error) {
+	if len(calldata) < 4 {
+		return nil, errors.NewABIError(errors.ErrCodeInvalidCalldata, "calldata must be at least 4 bytes")
+	}
+
+	var selector [4]byte
+	copy(selector[:], calldata[:4])
+	selectorHex := hexutil.Encode(selector[:])
+	candidates := d.rankedCandidates(selector)
+	if len(candidates) == 0 && d.lookup != nil {
+		var err error
+		if candidates, err = d.lookupCandidates(selector); err != nil {
+			return nil, err
+		}
+	}
+	return d.decodeCandidates(selectorHex, candidates, calldata)
+}
+
+// lookupCandidates returns the functions of the signature database with the selector.
+func (d *Decoder) lookupCandidates(selector [4]byte) ([]candidate, error) {
+	elements, err := d.lookup.Functions(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []candidate{}
+	for _, element := range elements {
+		if _, candidate, err := newCandidate(element, SignaturesSource); err == nil {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
+
+func (d *Decoder) decodeCandidates(selectorHex string, candidates []candidate, calldata []byte) ([]Call, error) {
+	if len(candidates) == 0 {
+		return nil, errors.NewABIError(errors.ErrCodeSelectorNotFound, fmt.Sprintf("no function found for selector %s", selectorHex))
+	}
+
+	calls := make([]Call, 0, len(candidates))
+	var lastErr error
+	for _, candidate := range candidates {
+		values, err := candidate.arguments.Unpack(calldata[4:])
+		if err != nil {
+			lastErr = errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, fmt.Sprintf("failed to decode arguments of %s", candidate.signature))
+			continue
+		}
+
+		arguments := make([]Value, 0, len(values))
+		for index, value := range values {
+			param := candidate.element.Inputs[index]
+			arguments = append(arguments, newValue(abi.ArgumentName(param, index), param, candidate.arguments[index].Type, reflect.ValueOf(value)))
+		}
+		calls = append(calls, Call{
+			Selector:  selectorHex,
+			Signature: candidate.signature,
+			Source:    candidate.source,
+			Function:  candidate.element,
+			Arguments: arguments,
+		})
+	}
+
+	if len(calls) == 0 {
+		return nil, lastErr
+	}
+	return calls, nil
+}
+
+// rankedCandidates returns the stored candidates sorted by source, then the builtin ones that no stored ABI covers.
+func (d *Decoder) rankedCandidates(selector [4]byte) []candidate {
+	var stored, builtin []candidate
+	known := map[string]bool{}
+	for _, candidate := range d.candidates[selector] {
+		if candidate.source == BuiltinSource {
+			builtin = append(builtin, candidate)
+			continue
+		}
+		stored = append(stored, candidate)
+		known[candidate.signature] = true
+	}
+	sort.SliceStable(stored, func(i, j int) bool { return stored[i].source < stored[j].source })
+
+	for _, candidate := range builtin {
+		if !known[candidate.signature] {
+			stored = append(stored, candidate)
+		}
+	}
+	return stored
+}
+
+// DecodeTransaction fetches a transaction and decodes its input. Contract creations and plain
+// transfers have no calls. When the input cannot be decoded the transaction is returned with the error.
+func (d *Decoder) DecodeTransaction(tr transport.Transport, txHash common.Hash) (*Transaction, error) {
+	tx, pending, err := tr.GetTransaction(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := newTransaction(tx, pending)
+	if tx.To() == nil || len(tx.Data()) == 0 {
+		return transaction, nil
+	}
+
+	calls, err := d.Decode(tx.Data())
+	transaction.Calls = calls
+	return transaction, err
+}
+
+func newTransaction(tx *types.Transaction, pending bool) *Transaction {
+	return &Transaction{
+		Hash:    tx.Hash(),
+		To:      tx.To(),
+		Value:   tx.Value(),
+		Pending: pending,
+	}
+}
+
+// ParseInput parses 0x-prefixed hex calldata, or a transaction hash when the input is exactly 32 bytes.
+// Calldata is a selector followed by 32 byte words, so it is never 32 bytes long.
+func ParseInput(text string) (Input, error) {
+	text = strings.Join(strings.Fields(text), "")
+	if !strings.HasPrefix(text, "0x") && !strings.HasPrefix(text, "0X") {
+		return Input{}, errors.NewABIError(errors.ErrCodeInvalidCalldata, "input must be 0x-prefixed hex")
+	}
+	data, err := hex.DecodeString(text[2:])
+	if err != nil {
+		return Input{}, errors.WrapABIError(err, errors.ErrCodeInvalidCalldata, "input is not valid hex")
+	}
+	if len(data) == common.HashLength {
+		return Input{TxHash: common.BytesToHash(data), IsTransaction: true}, nil
+	}
+	return Input{Calldata: data}, nil
+}
+
+// DecodeValues decodes ABI encoded data, such as the return data of a call, into named values.
+func DecodeValues(params []abi.ABIParam, data []byte) ([]Value, error) {
+	arguments, err := abi.Arguments(params)
+	if err != nil {
+		return nil, err
+	}
+	values, err := arguments.Unpack(data)
+	if err != nil {
+		return nil, errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, "failed to decode values")
+	}
+
+	decoded := make([]Value, 0, len(values))
+	for index, value := range values {
+		decoded = append(decoded, newValue(abi.ArgumentName(params[index], index), params[index], arguments[index].Type, reflect.ValueOf(value)))
+	}
+	return decoded, nil
+}
+
+// newValue converts an unpacked go-ethereum value into a named value tree.
+func newValue(name string, param abi.ABIParam, abiType ethabi.Type, value reflect.Value) Value {
+	decoded := Value{Name: name, Type: param.Type}
+
+	switch abiType.T {
+	case ethabi.TupleTy:
+		for index, elem := range abiType.TupleElems {
+			component := param.Components[index]
+			decoded.Components = append(decoded.Components, newValue(abi.ArgumentName(component, index), component, *elem, value.Field(index)))
+		}
+	case ethabi.SliceTy, ethabi.ArrayTy:
+		elementParam := abi.ABIParam{Type: elementType(param.Type), Components: param.Components}
+		for index := 0; index < value.Len(); index++ {
+			decoded.Components = append(decoded.Components, newValue(fmt.Sprintf("[%d]", index), elementParam, *abiType.Elem, value.Index(index)))
+		}
+	default:
+		decoded.Value = formatValue(value)
+	}
+	return decoded
+}
+
+// elementType strips the last array dimension, e.g. "tuple[2][]" becomes "tuple[2]".
+func elementType(typeName string) string {
+	if bracket := strings.LastIndex(typeName, "["); bracket >= 0 {
+		return typeName[:bracket]
+	}
+	return typeName
+}
+
+func formatValue(value reflect.Value) string {
+	switch typed := value.Interface().(type) {
+	case common.Address:
+		return typed.Hex()
+	case *big.Int:
+		return typed.String()
+	case []byte:
+		return hexutil.Encode(typed)
+	case string:
+		return typed
+	}
+
+	if value.Kind() == reflect.Array && value.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, value.Len())
+		reflect.Copy(reflect.ValueOf(data), value)
+		return hexutil.Encode(data)
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// Lines renders the call, one argument per line with nested values indented.
+func (c Call) Lines() []string {
+	lines := []string{fmt.Sprintf("%s  [%s, %s]", c.Signature, c.Selector, c.Source)}
+	for _, argument := range c.Arguments {
+		lines = append(lines, argument.lines("  ")...)
+	}
+	return lines
+}
+
+// Lines renders the value with nested values indented.
+func (v Value) Lines() []string {
+	return v.lines("")
+}
+
+func (v Value) lines(indent string) []string {
+	if v.Components == nil && !strings.HasSuffix(v.Type, "]") && !strings.HasPrefix(v.Type, "tuple") {
+		return []string{fmt.Sprintf("%s%s (%s): %s", indent, v.Name, v.Type, v.Value)}
+	}
+
+	lines := []string{fmt.Sprintf("%s%s (%s):", indent, v.Name, v.Type)}
+	if len(v.Components) == 0 {
+		return append(lines, indent+"  (empty)")
+	}
+	for _, component := range v.Components {
+		lines = append(lines, component.lines(indent+"  ")...)
+	}
+	return lines
+}
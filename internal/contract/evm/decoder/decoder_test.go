@@ -0,0 +1,248 @@
+package decoder
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var recipient = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+// pack encodes a call of the human-readable function with the arguments.
+func pack(t *testing.T, signature string, args ...any) []byte {
+	t.Helper()
+	elements, err := abi.ParseHumanReadable([]string{signature})
+	require.NoError(t, err)
+	arguments, err := abi.Arguments(elements[0].Inputs)
+	require.NoError(t, err)
+	selector, err := elements[0].Selector()
+	require.NoError(t, err)
+	packed, err := arguments.Pack(args...)
+	require.NoError(t, err)
+	return append(selector[:], packed...)
+}
+
+func TestDecodeBuiltin(t *testing.T) {
+	calldata := pack(t, "function transfer(address to, uint256 value)", recipient, big.NewInt(1000))
+
+	calls, err := New().Decode(calldata)
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+
+	call := calls[0]
+	assert.Equal(t, "0xa9059cbb", call.Selector)
+	assert.Equal(t, "transfer(address,uint256)", call.Signature)
+	assert.Equal(t, BuiltinSource, call.Source)
+	assert.Equal(t, []Value{
+		{Name: "to", Type: "address", Value: recipient.Hex()},
+		{Name: "value", Type: "uint256", Value: "1000"},
+	}, call.Arguments)
+}
+
+func TestDecodeStoredABITakesPrecedence(t *testing.T) {
+	decoder := New()
+	stored, err := abi.ParseHumanReadable([]string{"function transfer(address recipient, uint256 amount) returns (bool)"})
+	require.NoError(t, err)
+	decoder.AddABI("MyToken", stored)
+
+	calls, err := decoder.Decode(pack(t, "function transfer(address, uint256)", recipient, big.NewInt(5)))
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	assert.Equal(t, "MyToken", calls[0].Source)
+	assert.Equal(t, "recipient", calls[0].Arguments[0].Name)
+	assert.Equal(t, "amount", calls[0].Arguments[1].Name)
+}
+
+func TestDecodeNestedTuples(t *testing.T) {
+	type call3 struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	}
+	calldata := pack(t,
+		"function aggregate3((address target, bool allowFailure, bytes callData)[] calls)",
+		[]call3{
+			{Target: recipient, AllowFailure: true, CallData: []byte{0x18, 0x16, 0x0d, 0xdd}},
+			{Target: recipient, CallData: []byte{}},
+		},
+	)
+
+	calls, err := New().Decode(calldata)
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+
+	argument := calls[0].Arguments[0]
+	assert.Equal(t, "calls", argument.Name)
+	assert.Equal(t, "tuple[]", argument.Type)
+	require.Len(t, argument.Components, 2)
+	assert.Equal(t, Value{
+		Name: "[0]",
+		Type: "tuple",
+		Components: []Value{
+			{Name: "target", Type: "address", Value: recipient.Hex()},
+			{Name: "allowFailure", Type: "bool", Value: "true"},
+			{Name: "callData", Type: "bytes", Value: "0x18160ddd"},
+		},
+	}, argument.Components[0])
+
+	lines := calls[0].Lines()
+	assert.Equal(t, "aggregate3((address,bool,bytes)[])  [0x82ad56cb, builtin]", lines[0])
+	assert.Contains(t, lines, "      callData (bytes): 0x18160ddd")
+}
+
+func TestDecodeErrors(t *testing.T) {
+	_, err := New().Decode([]byte{0x01, 0x02})
+	assert.True(t, errors.HasCode(err, errors.ErrCodeInvalidCalldata))
+
+	_, err = New().Decode(hexutil.MustDecode("0xdeadbeef"))
+	assert.True(t, errors.HasCode(err, errors.ErrCodeSelectorNotFound))
+	assert.ErrorContains(t, err, "0xdeadbeef")
+
+	// The transfer selector with truncated arguments.
+	_, err = New().Decode(hexutil.MustDecode("0xa9059cbb0000"))
+	assert.True(t, errors.HasCode(err, errors.ErrCodeABIUnpackFailed))
+}
+
+func TestAddStoredABIs(t *testing.T) {
+	storage, err := sql.NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+
+	elements, err := abi.ParseHumanReadable([]string{"function setGreeting(string greeting)"})
+	require.NoError(t, err)
+	_, err = storage.CreateABI(models.EvmAbi{Name: "Greeter", Abi: models.AbiArrayType{AbiArray: elements}})
+	require.NoError(t, err)
+
+	decoder := New()
+	require.NoError(t, decoder.AddStoredABIs(storage))
+
+	stringType, err := ethabi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := ethabi.Arguments{{Type: stringType}}.Pack("hello")
+	require.NoError(t, err)
+	selector, err := elements[0].Selector()
+	require.NoError(t, err)
+
+	calls, err := decoder.Decode(append(selector[:], packed...))
+	require.NoError(t, err)
+	assert.Equal(t, "Greeter", calls[0].Source)
+	assert.Equal(t, "hello", calls[0].Arguments[0].Value)
+}
+
+func TestParseInput(t *testing.T) {
+	input, err := ParseInput("0xa9059cbb\n0000")
+	require.NoError(t, err)
+	assert.False(t, input.IsTransaction)
+	assert.Equal(t, []byte{0xa9, 0x05, 0x9c, 0xbb, 0x00, 0x00}, input.Calldata)
+
+	hash := "0x" + "ab" + "00000000000000000000000000000000000000000000000000000000000000"
+	input, err = ParseInput(hash)
+	require.NoError(t, err)
+	assert.True(t, input.IsTransaction)
+	assert.Equal(t, common.HexToHash(hash), input.TxHash)
+
+	_, err = ParseInput("a9059cbb")
+	assert.True(t, errors.HasCode(err, errors.ErrCodeInvalidCalldata))
+	_, err = ParseInput("0xzz")
+	assert.True(t, errors.HasCode(err, errors.ErrCodeInvalidCalldata))
+}
+
+// fakeTransport only implements GetTransaction.
+type fakeTransport struct {
+	transport.Transport
+	tx *types.Transaction
+}
+
+func (f *fakeTransport) GetTransaction(common.Hash) (*types.Transaction, bool, error) {
+	return f.tx, true, nil
+}
+
+func TestDecodeTransaction(t *testing.T) {
+	calldata := pack(t, "function approve(address spender, uint256 value)", recipient, big.NewInt(7))
+	tx := types.NewTx(&types.LegacyTx{To: &recipient, Value: big.NewInt(0), Data: calldata})
+
+	transaction, err := New().DecodeTransaction(&fakeTransport{tx: tx}, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), transaction.Hash)
+	assert.True(t, transaction.Pending)
+	require.Len(t, transaction.Calls, 1)
+	assert.Equal(t, "approve(address,uint256)", transaction.Calls[0].Signature)
+
+	creation := types.NewTx(&types.LegacyTx{Data: []byte{0x60, 0x80}})
+	transaction, err = New().DecodeTransaction(&fakeTransport{tx: creation}, creation.Hash())
+	require.NoError(t, err)
+	assert.Nil(t, transaction.To)
+	assert.Empty(t, transaction.Calls)
+}
+
+// fakeLookup returns the same functions for every selector, it knows no errors or events.
+type fakeLookup struct {
+	functions []abi.ABIElement
+}
+
+func (f *fakeLookup) Functions([4]byte) ([]abi.ABIElement, error) {
+	return f.functions, nil
+}
+
+func (f *fakeLookup) Errors([4]byte) ([]abi.ABIElement, error) {
+	return nil, nil
+}
+
+func (f *fakeLookup) Events(common.Hash) ([]abi.ABIElement, error) {
+	return nil, nil
+}
+
+func TestDecodeWithSignatureLookup(t *testing.T) {
+	elements, err := abi.ParseHumanReadable([]string{"function setGreeting(string)"})
+	require.NoError(t, err)
+	stringType, err := ethabi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := ethabi.Arguments{{Type: stringType}}.Pack("hi")
+	require.NoError(t, err)
+	selector, err := elements[0].Selector()
+	require.NoError(t, err)
+
+	decoder := New()
+	decoder.UseSignatures(&fakeLookup{functions: elements})
+	calls, err := decoder.Decode(append(selector[:], packed...))
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	assert.Equal(t, SignaturesSource, calls[0].Source)
+	assert.Equal(t, Value{Name: "arg0", Type: "string", Value: "hi"}, calls[0].Arguments[0])
+
+	// Builtin selectors are matched without consulting the lookup
+	decoder.UseSignatures(&fakeLookup{})
+	calls, err = decoder.Decode(pack(t, "function transfer(address, uint256)", recipient, big.NewInt(1)))
+	require.NoError(t, err)
+	assert.Equal(t, BuiltinSource, calls[0].Source)
+}
+
+func TestDecodeValues(t *testing.T) {
+	elements, err := abi.ParseHumanReadable([]string{"function getReserves() view returns (uint112 reserve0, uint112, address[] owners)"})
+	require.NoError(t, err)
+	outputs, err := abi.Arguments(elements[0].Outputs)
+	require.NoError(t, err)
+	data, err := outputs.Pack(big.NewInt(10), big.NewInt(20), []common.Address{recipient})
+	require.NoError(t, err)
+
+	values, err := DecodeValues(elements[0].Outputs, data)
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+	assert.Equal(t, []string{"reserve0 (uint112): 10"}, values[0].Lines())
+	assert.Equal(t, []string{"arg1 (uint112): 20"}, values[1].Lines())
+	assert.Equal(t, []string{"owners (address[]):", "  [0] (address): " + recipient.Hex()}, values[2].Lines())
+
+	_, err = DecodeValues(elements[0].Outputs, data[:10])
+	assert.True(t, errors.HasCode(err, errors.ErrCodeABIUnpackFailed))
+}
@@ -0,0 +1,186 @@
+package decoder
+
+import (
+	"fmt"
+	"strings"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+)
+
+// Event is a log decoded against an event of an ABI.
+type Event struct {
+	Address   common.Address `json:"address"`
+	Signature string         `json:"signature"`
+	Arguments []Value        `json:"arguments"`
+}
+
+// DecodeRevert describes the revert data of a call: the reason of Error(string), the code of
+// Panic(uint256), or a custom error of the ABI with its decoded arguments. Custom errors that the
+// ABI doesn't know are looked up in the signature database when one is given.
+func DecodeRevert(data []byte, elements abi.AbiArray, lookup SignatureLookup) string {
+	if len(data) == 0 {
+		return "execution reverted without data"
+	}
+	if reason, err := ethabi.UnpackRevert(data); err == nil {
+		return reason
+	}
+
+	if len(data) >= 4 {
+		if reason, ok := decodeCustomError(data, elements); ok {
+			return reason
+		}
+		if lookup != nil {
+			if found, err := lookup.Errors([4]byte(data[:4])); err == nil {
+				if reason, ok := decodeCustomError(data, found); ok {
+					return reason
+				}
+			}
+		}
+	}
+	return "custom error " + hexutil.Encode(data)
+}
+
+func decodeCustomError(data []byte, elements abi.AbiArray) (string, bool) {
+	for _, element := range elements {
+		if element.Type != "error" {
+			continue
+		}
+		selector, err := element.Selector()
+		if err != nil || [4]byte(data[:4]) != selector {
+			continue
+		}
+		values, err := DecodeValues(element.Inputs, data[4:])
+		if err != nil {
+			continue
+		}
+		arguments := make([]string, 0, len(values))
+		for _, value := range values {
+			arguments = append(arguments, value.String())
+		}
+		return fmt.Sprintf("%s(%s)", element.Name, strings.Join(arguments, ", ")), true
+	}
+	return "", false
+}
+
+// DecodeEvent decodes a log against the events of the ABI, then against the events of the signature
+// database when one is given. Indexed arguments of dynamic types are stored as their hash, so they
+// are shown as the topic.
+func DecodeEvent(log types.Log, elements abi.AbiArray, lookup SignatureLookup) (Event, bool) {
+	if len(log.Topics) == 0 {
+		return Event{}, false
+	}
+
+	if event, ok := decodeEvent(log, elements); ok {
+		return event, true
+	}
+	if lookup == nil {
+		return Event{}, false
+	}
+	found, err := lookup.Events(log.Topics[0])
+	if err != nil {
+		return Event{}, false
+	}
+	return decodeEvent(log, found)
+}
+
+func decodeEvent(log types.Log, elements abi.AbiArray) (Event, bool) {
+	for _, element := range elements {
+		if element.Type != "event" || element.Anonymous {
+			continue
+		}
+		topic, err := element.Topic()
+		if err != nil || topic != log.Topics[0] {
+			continue
+		}
+		arguments, err := decodeEventArguments(element, log)
+		if err != nil {
+			continue
+		}
+		signature, _ := element.Signature()
+		return Event{Address: log.Address, Signature: signature, Arguments: arguments}, true
+	}
+	return Event{}, false
+}
+
+func decodeEventArguments(element abi.ABIElement, log types.Log) ([]Value, error) {
+	nonIndexed := []abi.ABIParam{}
+	for _, input := range element.Inputs {
+		if !input.Indexed {
+			nonIndexed = append(nonIndexed, input)
+		}
+	}
+	data, err := DecodeValues(nonIndexed, log.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments := make([]Value, 0, len(element.Inputs))
+	topicIndex := 1
+	for index, input := range element.Inputs {
+		if !input.Indexed {
+			arguments = append(arguments, data[0])
+			data = data[1:]
+			continue
+		}
+		if topicIndex >= len(log.Topics) {
+			return nil, fmt.Errorf("missing topic of %s", input.Name)
+		}
+		topic := log.Topics[topicIndex]
+		topicIndex++
+
+		name := abi.ArgumentName(input, index)
+		if isDynamic(input.Type) {
+			arguments = append(arguments, Value{Name: name, Type: input.Type, Value: topic.Hex()})
+			continue
+		}
+		input.Indexed = false
+		values, err := DecodeValues([]abi.ABIParam{input}, topic.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		values[0].Name = name
+		arguments = append(arguments, values[0])
+	}
+	return arguments, nil
+}
+
+// isDynamic reports whether an indexed argument of the type is stored as the hash of its value.
+func isDynamic(typeName string) bool {
+	return typeName == "string" || typeName == "bytes" || strings.HasSuffix(typeName, "]") || strings.HasPrefix(typeName, "tuple")
+}
+
+// String renders the value on a single line, e.g. "amount=1000" or "pair=(1, 0x...)".
+func (v Value) String() string {
+	return v.Name + "=" + v.inline()
+}
+
+func (v Value) inline() string {
+	if v.Components == nil && !strings.HasSuffix(v.Type, "]") && !strings.HasPrefix(v.Type, "tuple") {
+		return v.Value
+	}
+	components := make([]string, 0, len(v.Components))
+	for _, component := range v.Components {
+		components = append(components, component.inline())
+	}
+	if strings.HasSuffix(v.Type, "]") {
+		return "[" + strings.Join(components, ", ") + "]"
+	}
+	return "(" + strings.Join(components, ", ") + ")"
+}
+
+// Line renders the event on a single line, e.g. "Transfer(from=0x..., to=0x..., value=1)".
+func (e Event) Line() string {
+	name := e.Signature
+	if paren := strings.Index(name, "("); paren >= 0 {
+		name = name[:paren]
+	}
+	arguments := make([]string, 0, len(e.Arguments))
+	for _, argument := range e.Arguments {
+		arguments = append(arguments, argument.String())
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(arguments, ", "))
+}
@@ -0,0 +1,91 @@
+package decoder
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/signatures"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRevert(t *testing.T) {
+	elements, err := abi.ParseHumanReadable([]string{
+		"error InsufficientBalance(uint256 available, uint256 required)",
+	})
+	require.NoError(t, err)
+
+	reason := pack(t, "function Error(string)", "not the owner")
+	assert.Equal(t, "not the owner", DecodeRevert(reason, elements, nil))
+
+	custom := pack(t, "function InsufficientBalance(uint256, uint256)", big.NewInt(1), big.NewInt(2))
+	assert.Equal(t, "InsufficientBalance(available=1, required=2)", DecodeRevert(custom, elements, nil))
+
+	assert.Equal(t, "custom error 0xdeadbeef", DecodeRevert(common.FromHex("0xdeadbeef"), elements, nil))
+	assert.Equal(t, "execution reverted without data", DecodeRevert(nil, elements, nil))
+}
+
+func TestDecodeEvent(t *testing.T) {
+	elements, err := abi.ParseHumanReadable([]string{
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Named(string indexed name)",
+	})
+	require.NoError(t, err)
+	transferTopic, err := elements[0].Topic()
+	require.NoError(t, err)
+
+	from := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	log := types.Log{
+		Address: recipient,
+		Topics:  []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(recipient.Bytes())},
+		Data:    common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+	}
+
+	event, ok := DecodeEvent(log, elements, nil)
+	require.True(t, ok)
+	assert.Equal(t, "Transfer(address,address,uint256)", event.Signature)
+	assert.Equal(t, "Transfer(from="+from.Hex()+", to="+recipient.Hex()+", value=1000)", event.Line())
+
+	namedTopic, err := elements[1].Topic()
+	require.NoError(t, err)
+	nameHash := common.HexToHash("0x01")
+	event, ok = DecodeEvent(types.Log{Topics: []common.Hash{namedTopic, nameHash}}, elements, nil)
+	require.True(t, ok)
+	assert.Equal(t, nameHash.Hex(), event.Arguments[0].Value)
+
+	_, ok = DecodeEvent(types.Log{Topics: []common.Hash{common.HexToHash("0x02")}}, elements, nil)
+	assert.False(t, ok)
+}
+
+func TestDecodeWithSignatureDatabase(t *testing.T) {
+	storage, err := sql.NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	_, err = signatures.Import(storage, []byte("error Unauthorized(address caller)\nevent Swept(address indexed to, uint256 amount)"), "dump.txt")
+	require.NoError(t, err)
+	database := signatures.NewDatabase(storage)
+
+	// Neither is in the ABI, only in the signature database
+	unauthorized := pack(t, "function Unauthorized(address)", recipient)
+	assert.Equal(t, "custom error "+hexutil.Encode(unauthorized), DecodeRevert(unauthorized, nil, nil))
+	assert.Equal(t, "Unauthorized(caller="+recipient.Hex()+")", DecodeRevert(unauthorized, nil, database))
+
+	elements, err := abi.ParseHumanReadable([]string{"event Swept(address indexed to, uint256 amount)"})
+	require.NoError(t, err)
+	topic, err := elements[0].Topic()
+	require.NoError(t, err)
+	log := types.Log{
+		Topics: []common.Hash{topic, common.BytesToHash(recipient.Bytes())},
+		Data:   common.LeftPadBytes(big.NewInt(7).Bytes(), 32),
+	}
+	_, ok := DecodeEvent(log, nil, nil)
+	assert.False(t, ok)
+	event, ok := DecodeEvent(log, nil, database)
+	require.True(t, ok)
+	assert.Equal(t, "Swept(to="+recipient.Hex()+", amount=7)", event.Line())
+}
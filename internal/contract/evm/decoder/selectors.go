@@ -0,0 +1,8 @@
+package decoder
+
+const (
+	// BuiltinSource is the source name of the calls matched against the functions bundled with the CLI.
+	BuiltinSource = "builtin"
+	// SignaturesSource is the source name of the calls matched against the signature database.
+	SignaturesSource = "signatures"
+)
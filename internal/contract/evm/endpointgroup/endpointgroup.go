@@ -0,0 +1,162 @@
+// Package endpointgroup connects to a chain through every stored endpoint on it. The endpoints with
+// the chain ID of the selected one form a group, and the returned transport fails over between them
+// by priority, so the signer and the wallet service keep using a plain transport.Transport.
+package endpointgroup
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+)
+
+const listPageSize = 100
+
+var (
+	sharedMu sync.Mutex
+	// shared keeps the failover transport of every group for the life of the process, so the health
+	// of its endpoints carries over from one page to the next.
+	shared = map[string]*transport.FailoverTransport{}
+)
+
+// Group is the stored endpoints of a chain by priority.
+type Group struct {
+	ChainID   string
+	Endpoints []models.EVMEndpoint
+}
+
+// NewTransport returns the transport of an endpoint. When other stored endpoints are on the same
+// chain, it fails over between all of them; the confirmations of the given endpoint apply.
+func NewTransport(storage sql.Storage, endpoint models.EVMEndpoint, timeout time.Duration) (transport.Transport, error) {
+	confirmations := transport.WithConfirmations(uint64(endpoint.Confirmations))
+	if endpoint.Url == transport.SimulatedEndpoint {
+		return transport.New(endpoint.Url, timeout, confirmations)
+	}
+
+	groups, err := List(storage)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if !group.contains(endpoint) {
+			continue
+		}
+		if len(group.Endpoints) == 1 {
+			break
+		}
+		return sharedTransport(group, endpoint.Confirmations, timeout)
+	}
+	return transport.New(endpoint.Url, timeout, confirmations)
+}
+
+// NewTransportForURL returns the transport of the stored endpoint with the URL, or a transport of
+// the URL alone when no endpoint has it.
+func NewTransportForURL(storage sql.Storage, url string, timeout time.Duration) (transport.Transport, error) {
+	if storage != nil && url != transport.SimulatedEndpoint {
+		groups, err := List(storage)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			for _, endpoint := range group.Endpoints {
+				if endpoint.Url == url {
+					return NewTransport(storage, endpoint, timeout)
+				}
+			}
+		}
+	}
+	return transport.New(url, timeout)
+}
+
+// List returns the stored endpoints grouped by chain ID, the simulated chain excluded. Groups are
+// sorted by chain ID and endpoints by priority, then name.
+func List(storage sql.Storage) ([]Group, error) {
+	byChain := map[string]*Group{}
+	for page := int64(1); ; page++ {
+		result, err := storage.ListEndpoints(page, listPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list endpoints: %w", err)
+		}
+		for _, endpoint := range result.Items {
+			if endpoint.Url == transport.SimulatedEndpoint {
+				continue
+			}
+			chainID := normalizeChainID(endpoint.ChainId)
+			group, ok := byChain[chainID]
+			if !ok {
+				group = &Group{ChainID: chainID}
+				byChain[chainID] = group
+			}
+			group.Endpoints = append(group.Endpoints, endpoint)
+		}
+		if page >= result.TotalPages {
+			break
+		}
+	}
+
+	groups := make([]Group, 0, len(byChain))
+	for _, group := range byChain {
+		sort.SliceStable(group.Endpoints, func(i, j int) bool {
+			if group.Endpoints[i].Priority != group.Endpoints[j].Priority {
+				return group.Endpoints[i].Priority < group.Endpoints[j].Priority
+			}
+			return group.Endpoints[i].Name < group.Endpoints[j].Name
+		})
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ChainID < groups[j].ChainID
+	})
+	return groups, nil
+}
+
+// Transport returns the shared failover transport of the group.
+func (g Group) Transport(timeout time.Duration) (*transport.FailoverTransport, error) {
+	return sharedTransport(g, 0, timeout)
+}
+
+func (g Group) contains(endpoint models.EVMEndpoint) bool {
+	for _, member := range g.Endpoints {
+		if member.ID == endpoint.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func sharedTransport(group Group, confirmations uint, timeout time.Duration) (*transport.FailoverTransport, error) {
+	members := make([]transport.Member, 0, len(group.Endpoints))
+	keys := make([]string, 0, len(group.Endpoints))
+	for _, endpoint := range group.Endpoints {
+		members = append(members, transport.Member{URL: endpoint.Url, Priority: endpoint.Priority})
+		keys = append(keys, fmt.Sprintf("%s@%d", endpoint.Url, endpoint.Priority))
+	}
+	key := fmt.Sprintf("%s|%d|%s|%s", group.ChainID, confirmations, timeout, strings.Join(keys, ","))
+
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	if failover, ok := shared[key]; ok {
+		return failover, nil
+	}
+	failover, err := transport.NewFailoverTransport(members, timeout, transport.WithConfirmations(uint64(confirmations)))
+	if err != nil {
+		return nil, err
+	}
+	shared[key] = failover
+	return failover, nil
+}
+
+// normalizeChainID returns the decimal chain ID, so "0x1" and "1" are the same chain.
+func normalizeChainID(chainID string) string {
+	value, ok := new(big.Int).SetString(strings.TrimSpace(chainID), 0)
+	if !ok {
+		return strings.TrimSpace(chainID)
+	}
+	return value.String()
+}
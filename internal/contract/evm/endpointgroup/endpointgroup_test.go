@@ -0,0 +1,73 @@
+package endpointgroup
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStorage(t *testing.T) (sql.Storage, map[string]models.EVMEndpoint) {
+	storage, err := sql.NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+
+	endpoints := map[string]models.EVMEndpoint{}
+	for _, endpoint := range []models.EVMEndpoint{
+		{Name: "mainnet-backup", Url: "https://backup.example", ChainId: "0x1", Priority: 1},
+		{Name: "mainnet-b", Url: "https://b.example", ChainId: "1"},
+		{Name: "mainnet-a", Url: "https://a.example", ChainId: "1", Confirmations: 3},
+		{Name: "sepolia", Url: "https://sepolia.example", ChainId: "11155111"},
+		{Name: "simulated", Url: transport.SimulatedEndpoint, ChainId: "31337"},
+	} {
+		id, err := storage.CreateEndpoint(endpoint)
+		require.NoError(t, err)
+		endpoint.ID = id
+		endpoints[endpoint.Name] = endpoint
+	}
+	return storage, endpoints
+}
+
+func TestListGroupsByChain(t *testing.T) {
+	storage, _ := newStorage(t)
+
+	groups, err := List(storage)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	assert.Equal(t, "1", groups[0].ChainID)
+	names := []string{}
+	for _, endpoint := range groups[0].Endpoints {
+		names = append(names, endpoint.Name)
+	}
+	assert.Equal(t, []string{"mainnet-a", "mainnet-b", "mainnet-backup"}, names)
+	assert.Equal(t, "11155111", groups[1].ChainID)
+}
+
+func TestNewTransportFailsOverWithinChain(t *testing.T) {
+	storage, endpoints := newStorage(t)
+
+	tr, err := NewTransport(storage, endpoints["mainnet-a"], time.Second)
+	require.NoError(t, err)
+	failover, ok := tr.(*transport.FailoverTransport)
+	require.True(t, ok)
+
+	urls := []string{}
+	for _, member := range failover.Health() {
+		urls = append(urls, member.URL)
+	}
+	assert.Equal(t, []string{"https://a.example", "https://b.example", "https://backup.example"}, urls)
+
+	// The group is shared, so the health of its endpoints carries over
+	again, err := NewTransport(storage, endpoints["mainnet-a"], time.Second)
+	require.NoError(t, err)
+	assert.Same(t, failover, again)
+
+	byURL, err := NewTransportForURL(storage, "https://a.example", time.Second)
+	require.NoError(t, err)
+	assert.Same(t, failover, byURL)
+}
@@ -0,0 +1,320 @@
+// Package gasreport summarizes the recorded gas usage of a contract: min/avg/max gas by function, the
+// fees paid on every network and how the gas of each function changes from one deployment to the next.
+// Deployments are the stored contracts sharing the name of the reported contract.
+package gasreport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+)
+
+// Function is the gas used by a function. Gas statistics cover the successful calls, mined and
+// simulated; fees cover every mined transaction, reverted ones included. Fees are paid in the native
+// currency of a chain, so FeeWei is only set on the functions of a Deployment and never added up
+// across networks.
+type Function struct {
+	Selector    string   `json:"selector"`
+	Function    string   `json:"function"`
+	Calls       int      `json:"calls"`
+	Simulations int      `json:"simulations"`
+	Reverted    int      `json:"reverted"`
+	MinGas      uint64   `json:"min_gas"`
+	AvgGas      uint64   `json:"avg_gas"`
+	MaxGas      uint64   `json:"max_gas"`
+	FeeWei      *big.Int `json:"fee_wei,omitempty"`
+
+	totalGas uint64
+	samples  int
+}
+
+// Network is the gas used and the fees paid by the mined transactions on a network.
+type Network struct {
+	Network      string   `json:"network"`
+	ChainID      string   `json:"chain_id"`
+	Transactions int      `json:"transactions"`
+	GasUsed      uint64   `json:"gas_used"`
+	FeeWei       *big.Int `json:"fee_wei"`
+}
+
+// Deployment is the gas used by the functions of one deployed contract.
+type Deployment struct {
+	ContractID uint       `json:"contract_id"`
+	Address    string     `json:"address"`
+	Network    string     `json:"network"`
+	ChainID    string     `json:"chain_id"`
+	Functions  []Function `json:"functions"`
+}
+
+// Trend is the average gas of a function on every deployment, oldest first.
+type Trend struct {
+	Selector string       `json:"selector"`
+	Function string       `json:"function"`
+	Points   []TrendPoint `json:"points"`
+}
+
+// TrendPoint is the average gas of a function on a deployment, zero samples when it was not used there.
+type TrendPoint struct {
+	ContractID uint   `json:"contract_id"`
+	AvgGas     uint64 `json:"avg_gas"`
+	Samples    int    `json:"samples"`
+}
+
+// Report is the gas report of a contract across its deployments.
+type Report struct {
+	Contract    string       `json:"contract"`
+	Functions   []Function   `json:"functions"`
+	Networks    []Network    `json:"networks"`
+	Deployments []Deployment `json:"deployments"`
+	Trends      []Trend      `json:"trends"`
+}
+
+// Build summarizes the recorded transactions of the deployments of a contract. The transactions
+// need their contract and its endpoint loaded, as returned by ListTransactionsByContractName.
+func Build(name string, transactions []models.EVMTransaction) Report {
+	report := Report{Contract: name}
+	functions := map[string]*Function{}
+	networks := map[string]*Network{}
+	deployments := map[uint]*Deployment{}
+	deploymentFunctions := map[uint]map[string]*Function{}
+
+	for index := range transactions {
+		transaction := &transactions[index]
+		add(functions, transaction, false)
+
+		deployment, ok := deployments[transaction.ContractId]
+		if !ok {
+			deployment = newDeployment(transaction)
+			deployments[transaction.ContractId] = deployment
+			deploymentFunctions[transaction.ContractId] = map[string]*Function{}
+		}
+		add(deploymentFunctions[transaction.ContractId], transaction, true)
+
+		if transaction.Kind == models.TransactionKindSimulation {
+			continue
+		}
+		key := deployment.Network + "\x00" + deployment.ChainID
+		network, ok := networks[key]
+		if !ok {
+			network = &Network{Network: deployment.Network, ChainID: deployment.ChainID, FeeWei: big.NewInt(0)}
+			networks[key] = network
+		}
+		network.Transactions++
+		network.GasUsed += transaction.GasUsed
+		network.FeeWei.Add(network.FeeWei, transaction.Fee())
+	}
+
+	report.Functions = sorted(functions)
+	for _, network := range networks {
+		report.Networks = append(report.Networks, *network)
+	}
+	sort.Slice(report.Networks, func(i, j int) bool {
+		return report.Networks[i].FeeWei.Cmp(report.Networks[j].FeeWei) > 0
+	})
+
+	for id, deployment := range deployments {
+		deployment.Functions = sorted(deploymentFunctions[id])
+		report.Deployments = append(report.Deployments, *deployment)
+	}
+	sort.Slice(report.Deployments, func(i, j int) bool {
+		return report.Deployments[i].ContractID < report.Deployments[j].ContractID
+	})
+
+	report.Trends = trends(report.Functions, report.Deployments)
+	return report
+}
+
+func newDeployment(transaction *models.EVMTransaction) *Deployment {
+	deployment := &Deployment{ContractID: transaction.ContractId}
+	if contract := transaction.Contract; contract != nil {
+		deployment.Address = contract.Address
+		if contract.Endpoint != nil {
+			deployment.Network = contract.Endpoint.Name
+			deployment.ChainID = contract.Endpoint.ChainId
+		}
+	}
+	return deployment
+}
+
+// add adds the transaction to the statistics of its function, and its fee when withFees is set.
+func add(functions map[string]*Function, transaction *models.EVMTransaction, withFees bool) {
+	function, ok := functions[transaction.Selector]
+	if !ok {
+		function = &Function{Selector: transaction.Selector, Function: transaction.Function}
+		if withFees {
+			function.FeeWei = big.NewInt(0)
+		}
+		functions[transaction.Selector] = function
+	}
+	if function.Function == "" {
+		function.Function = transaction.Function
+	}
+
+	if transaction.Kind == models.TransactionKindSimulation {
+		function.Simulations++
+	} else {
+		function.Calls++
+		if withFees {
+			function.FeeWei.Add(function.FeeWei, transaction.Fee())
+		}
+	}
+	if !transaction.Success {
+		function.Reverted++
+		return
+	}
+
+	if function.samples == 0 || transaction.GasUsed < function.MinGas {
+		function.MinGas = transaction.GasUsed
+	}
+	if transaction.GasUsed > function.MaxGas {
+		function.MaxGas = transaction.GasUsed
+	}
+	function.samples++
+	function.totalGas += transaction.GasUsed
+	function.AvgGas = function.totalGas / uint64(function.samples)
+}
+
+// sorted returns the functions by average gas, most expensive first.
+func sorted(functions map[string]*Function) []Function {
+	result := make([]Function, 0, len(functions))
+	for _, function := range functions {
+		result = append(result, *function)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].AvgGas != result[j].AvgGas {
+			return result[i].AvgGas > result[j].AvgGas
+		}
+		return result[i].Selector < result[j].Selector
+	})
+	return result
+}
+
+func trends(functions []Function, deployments []Deployment) []Trend {
+	result := make([]Trend, 0, len(functions))
+	for _, function := range functions {
+		trend := Trend{Selector: function.Selector, Function: function.Function}
+		for _, deployment := range deployments {
+			point := TrendPoint{ContractID: deployment.ContractID}
+			for _, used := range deployment.Functions {
+				if used.Selector == function.Selector {
+					point.AvgGas = used.AvgGas
+					point.Samples = used.samples
+				}
+			}
+			trend.Points = append(trend.Points, point)
+		}
+		result = append(result, trend)
+	}
+	return result
+}
+
+// Samples returns the number of successful calls the gas statistics are computed from.
+func (f Function) Samples() int {
+	return f.samples
+}
+
+// Label is the signature of the function, or its selector when the signature is unknown.
+func (f Function) Label() string {
+	if f.Function != "" {
+		return f.Function
+	}
+	return f.Selector
+}
+
+// Change returns the change of the average gas from the first to the last deployment that used the
+// function, in percent. It is false when fewer than two deployments used the function.
+func (t Trend) Change() (float64, bool) {
+	var first, last *TrendPoint
+	for index := range t.Points {
+		if t.Points[index].Samples == 0 {
+			continue
+		}
+		if first == nil {
+			first = &t.Points[index]
+		}
+		last = &t.Points[index]
+	}
+	if first == nil || first == last || first.AvgGas == 0 {
+		return 0, false
+	}
+	return (float64(last.AvgGas) - float64(first.AvgGas)) / float64(first.AvgGas) * 100, true
+}
+
+// csvHeader is the header of the CSV export, one row per function of every deployment.
+var csvHeader = []string{
+	"contract", "contract_id", "address", "network", "chain_id", "selector", "function",
+	"calls", "simulations", "reverted", "min_gas", "avg_gas", "max_gas", "fee_wei",
+}
+
+// WriteCSV writes one row per function of every deployment.
+func (r Report) WriteCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, deployment := range r.Deployments {
+		for _, function := range deployment.Functions {
+			record := []string{
+				r.Contract,
+				strconv.FormatUint(uint64(deployment.ContractID), 10),
+				deployment.Address,
+				deployment.Network,
+				deployment.ChainID,
+				function.Selector,
+				function.Function,
+				strconv.Itoa(function.Calls),
+				strconv.Itoa(function.Simulations),
+				strconv.Itoa(function.Reverted),
+				strconv.FormatUint(function.MinGas, 10),
+				strconv.FormatUint(function.AvgGas, 10),
+				strconv.FormatUint(function.MaxGas, 10),
+				function.FeeWei.String(),
+			}
+			if err := csvWriter.Write(record); err != nil {
+				return fmt.Errorf("failed to write CSV: %w", err)
+			}
+		}
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON writes the whole report as indented JSON.
+func (r Report) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// Export writes the report to a file, as JSON when the path ends in .json and as CSV otherwise.
+func (r Report) Export(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = r.WriteJSON(file)
+	} else {
+		err = r.WriteCSV(file)
+	}
+	if closeErr := file.Close(); closeErr != nil && err == nil {
+		return fmt.Errorf("failed to write report file: %w", closeErr)
+	}
+	return err
+}
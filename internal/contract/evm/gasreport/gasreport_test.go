@@ -0,0 +1,166 @@
+package gasreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transferSelector = "0xa9059cbb"
+
+var (
+	sepolia = &models.EVMEndpoint{Name: "sepolia", ChainId: "11155111"}
+	anvil   = &models.EVMEndpoint{Name: "anvil", ChainId: "31337"}
+	first   = &models.EVMContract{ID: 1, Name: "Token", Address: "0x5FbDB2315678afecb367f032d93F642f64180aa3", Endpoint: sepolia}
+	second  = &models.EVMContract{ID: 2, Name: "Token", Address: "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512", Endpoint: anvil}
+)
+
+func transaction(contract *models.EVMContract, kind models.TransactionKind, selector string, gasUsed uint64, gasPrice string, success bool) models.EVMTransaction {
+	function := "transfer(address,uint256)"
+	if selector == models.DeploymentSelector {
+		function = ""
+	}
+	return models.EVMTransaction{
+		ContractId: contract.ID,
+		Contract:   contract,
+		Kind:       kind,
+		Selector:   selector,
+		Function:   function,
+		GasUsed:    gasUsed,
+		GasPrice:   gasPrice,
+		Success:    success,
+	}
+}
+
+func history() []models.EVMTransaction {
+	return []models.EVMTransaction{
+		transaction(first, models.TransactionKindDeployment, models.DeploymentSelector, 600000, "10", true),
+		transaction(first, models.TransactionKindCall, transferSelector, 50000, "10", true),
+		transaction(first, models.TransactionKindCall, transferSelector, 30000, "10", true),
+		transaction(first, models.TransactionKindCall, transferSelector, 25000, "10", false),
+		transaction(second, models.TransactionKindDeployment, models.DeploymentSelector, 550000, "1", true),
+		transaction(second, models.TransactionKindSimulation, transferSelector, 36000, "0", true),
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	report := Build("Token", history())
+
+	require.Len(t, report.Functions, 2)
+	deployment := report.Functions[0]
+	assert.Equal(t, models.DeploymentSelector, deployment.Label())
+	assert.Equal(t, uint64(575000), deployment.AvgGas)
+
+	transfer := report.Functions[1]
+	assert.Equal(t, "transfer(address,uint256)", transfer.Label())
+	assert.Equal(t, 3, transfer.Calls)
+	assert.Equal(t, 1, transfer.Simulations)
+	assert.Equal(t, 1, transfer.Reverted)
+	assert.Equal(t, 3, transfer.Samples())
+	assert.Equal(t, uint64(30000), transfer.MinGas)
+	assert.Equal(t, uint64(38666), transfer.AvgGas)
+	assert.Equal(t, uint64(50000), transfer.MaxGas)
+	assert.Nil(t, transfer.FeeWei, "Fees of different chains should not be added up")
+	assert.Equal(t, "1050000", report.Deployments[0].Functions[1].FeeWei.String())
+
+	require.Len(t, report.Networks, 2)
+	assert.Equal(t, Network{Network: "sepolia", ChainID: "11155111", Transactions: 4, GasUsed: 705000, FeeWei: report.Networks[0].FeeWei}, report.Networks[0])
+	assert.Equal(t, "7050000", report.Networks[0].FeeWei.String())
+	assert.Equal(t, "anvil", report.Networks[1].Network)
+	assert.Equal(t, 1, report.Networks[1].Transactions)
+	assert.Equal(t, "550000", report.Networks[1].FeeWei.String())
+
+	require.Len(t, report.Deployments, 2)
+	assert.Equal(t, first.Address, report.Deployments[0].Address)
+	assert.Equal(t, "anvil", report.Deployments[1].Network)
+
+	require.Len(t, report.Trends, 2)
+	change, ok := report.Trends[0].Change()
+	require.True(t, ok)
+	assert.InDelta(t, -8.33, change, 0.01)
+	transferTrend := report.Trends[1]
+	assert.Equal(t, []TrendPoint{{ContractID: 1, AvgGas: 40000, Samples: 2}, {ContractID: 2, AvgGas: 36000, Samples: 1}}, transferTrend.Points)
+	change, ok = transferTrend.Change()
+	require.True(t, ok)
+	assert.InDelta(t, -10, change, 0.01)
+}
+
+func TestTrendNeedsTwoDeployments(t *testing.T) {
+	report := Build("Token", history()[:3])
+	require.Len(t, report.Trends, 2)
+	_, ok := report.Trends[0].Change()
+	assert.False(t, ok)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var output bytes.Buffer
+	require.NoError(t, Build("Token", history()).WriteCSV(&output))
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, "contract,contract_id,address,network,chain_id,selector,function,calls,simulations,reverted,min_gas,avg_gas,max_gas,fee_wei", lines[0])
+	assert.Equal(t, "Token,1,"+first.Address+",sepolia,11155111,constructor,,1,0,0,600000,600000,600000,6000000", lines[1])
+	assert.Equal(t, "Token,1,"+first.Address+",sepolia,11155111,0xa9059cbb,\"transfer(address,uint256)\",3,0,1,30000,40000,50000,1050000", lines[2])
+	assert.Equal(t, "Token,2,"+second.Address+",anvil,31337,0xa9059cbb,\"transfer(address,uint256)\",0,1,0,36000,36000,36000,0", lines[4])
+}
+
+func TestExportByExtension(t *testing.T) {
+	report := Build("Token", history())
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "report.json")
+	require.NoError(t, report.Export(jsonPath))
+	data, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var decoded Report
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "Token", decoded.Contract)
+	assert.Len(t, decoded.Deployments, 2)
+	assert.Equal(t, "7050000", decoded.Networks[0].FeeWei.String())
+
+	csvPath := filepath.Join(dir, "report.csv")
+	require.NoError(t, report.Export(csvPath))
+	data, err = os.ReadFile(csvPath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "contract,contract_id"))
+}
+
+func TestRecords(t *testing.T) {
+	elements, err := abi.ParseHumanReadable([]string{"function transfer(address to, uint256 value) returns (bool)"})
+	require.NoError(t, err)
+	receipt := &types.Receipt{
+		Status:            types.ReceiptStatusFailed,
+		TxHash:            common.HexToHash("0x01"),
+		GasUsed:           25000,
+		EffectiveGasPrice: big.NewInt(7),
+	}
+
+	call := CallRecord(1, elements[0], receipt)
+	assert.Equal(t, models.TransactionKindCall, call.Kind)
+	assert.Equal(t, transferSelector, call.Selector)
+	assert.Equal(t, "transfer(address,uint256)", call.Function)
+	assert.False(t, call.Success)
+	assert.Equal(t, receipt.TxHash.Hex(), *call.TxHash)
+	assert.Equal(t, "175000", call.Fee().String())
+
+	receipt.Status = types.ReceiptStatusSuccessful
+	deployment := DeploymentRecord(1, receipt)
+	assert.Equal(t, models.DeploymentSelector, deployment.Selector)
+	assert.True(t, deployment.Success)
+
+	simulation := SimulationRecord(1, elements[0], 36000)
+	assert.Equal(t, models.TransactionKindSimulation, simulation.Kind)
+	assert.Nil(t, simulation.TxHash)
+	assert.Equal(t, "0", simulation.Fee().String())
+}
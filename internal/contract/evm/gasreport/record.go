@@ -0,0 +1,53 @@
+package gasreport
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+)
+
+// DeploymentRecord is the history record of a mined deployment of the contract.
+func DeploymentRecord(contractID uint, receipt *types.Receipt) models.EVMTransaction {
+	record := models.EVMTransaction{ContractId: contractID, Kind: models.TransactionKindDeployment, Selector: models.DeploymentSelector}
+	withReceipt(&record, receipt)
+	return record
+}
+
+// CallRecord is the history record of a mined call of a function of the contract.
+func CallRecord(contractID uint, function abi.ABIElement, receipt *types.Receipt) models.EVMTransaction {
+	record := functionRecord(contractID, models.TransactionKindCall, function)
+	withReceipt(&record, receipt)
+	return record
+}
+
+// SimulationRecord is the history record of a successful simulated call of a function of the contract.
+func SimulationRecord(contractID uint, function abi.ABIElement, gasUsed uint64) models.EVMTransaction {
+	record := functionRecord(contractID, models.TransactionKindSimulation, function)
+	record.Success = true
+	record.GasUsed = gasUsed
+	record.GasPrice = "0"
+	return record
+}
+
+func functionRecord(contractID uint, kind models.TransactionKind, function abi.ABIElement) models.EVMTransaction {
+	record := models.EVMTransaction{ContractId: contractID, Kind: kind}
+	if selector, err := function.Selector(); err == nil {
+		record.Selector = hexutil.Encode(selector[:])
+	}
+	if signature, err := function.Signature(); err == nil {
+		record.Function = signature
+	}
+	return record
+}
+
+func withReceipt(record *models.EVMTransaction, receipt *types.Receipt) {
+	txHash := receipt.TxHash.Hex()
+	record.TxHash = &txHash
+	record.Success = receipt.Status == types.ReceiptStatusSuccessful
+	record.GasUsed = receipt.GasUsed
+	record.GasPrice = "0"
+	if receipt.EffectiveGasPrice != nil {
+		record.GasPrice = receipt.EffectiveGasPrice.String()
+	}
+}
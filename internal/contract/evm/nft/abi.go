@@ -0,0 +1,82 @@
+package nft
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	customabi "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// erc721ABIJSON contains the subset of ERC-721 (and its enumerable/metadata
+// extensions) used by the inventory and transfer helpers.
+const erc721ABIJSON = `[
+	{"type":"function","name":"supportsInterface","stateMutability":"view","inputs":[{"name":"interfaceId","type":"bytes4"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"ownerOf","stateMutability":"view","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"tokenOfOwnerByIndex","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"index","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"tokenURI","stateMutability":"view","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"safeTransferFrom","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"tokenId","type":"uint256","indexed":true}]}
+]`
+
+// erc1155ABIJSON contains the subset of ERC-1155 (and its metadata URI
+// extension) used by the inventory and transfer helpers.
+const erc1155ABIJSON = `[
+	{"type":"function","name":"supportsInterface","stateMutability":"view","inputs":[{"name":"interfaceId","type":"bytes4"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"},{"name":"id","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"balanceOfBatch","stateMutability":"view","inputs":[{"name":"accounts","type":"address[]"},{"name":"ids","type":"uint256[]"}],"outputs":[{"name":"","type":"uint256[]"}]},
+	{"type":"function","name":"uri","stateMutability":"view","inputs":[{"name":"id","type":"uint256"}],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"safeTransferFrom","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"id","type":"uint256"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[]},
+	{"type":"event","name":"TransferSingle","anonymous":false,"inputs":[{"name":"operator","type":"address","indexed":true},{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"id","type":"uint256","indexed":false},{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"event","name":"TransferBatch","anonymous":false,"inputs":[{"name":"operator","type":"address","indexed":true},{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"ids","type":"uint256[]","indexed":false},{"name":"values","type":"uint256[]","indexed":false}]}
+]`
+
+// contractABI holds both the repository ABI representation (used by the
+// transport and signer) and the go-ethereum ABI (used for decoding).
+type contractABI struct {
+	custom customabi.ABI
+	eth    ethabi.ABI
+}
+
+var (
+	erc721ABI  = mustParseABI(erc721ABIJSON)
+	erc1155ABI = mustParseABI(erc1155ABIJSON)
+)
+
+func mustParseABI(abiJSON string) contractABI {
+	var custom customabi.ABI
+	if err := json.Unmarshal([]byte(abiJSON), &custom); err != nil {
+		panic(fmt.Sprintf("invalid built-in NFT ABI: %v", err))
+	}
+
+	eth, err := ethabi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in NFT ABI: %v", err))
+	}
+
+	return contractABI{custom: custom, eth: eth}
+}
+
+// call performs a read-only call and decodes the result.
+func (c contractABI) call(tr transport.Transport, address common.Address, method string, args ...any) ([]any, error) {
+	raw, err := tr.CallContract(address, c.custom, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := c.eth.Unpack(method, raw)
+	if err != nil {
+		return nil, errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, fmt.Sprintf("failed to unpack result of %s", method))
+	}
+
+	if len(values) == 0 {
+		return nil, errors.NewABIError(errors.ErrCodeABIUnpackFailed, fmt.Sprintf("%s returned no values", method))
+	}
+
+	return values, nil
+}
@@ -0,0 +1,87 @@
+package nft
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+)
+
+// TransportFactory creates a transport for an RPC endpoint URL.
+type TransportFactory func(endpoint string) (transport.Transport, error)
+
+// OwnedToken is a token together with its resolved metadata.
+type OwnedToken struct {
+	Token
+	// Metadata is nil if the token has no URI or it could not be resolved.
+	Metadata *Metadata
+}
+
+// Collection is the inventory of a single registered NFT contract.
+type Collection struct {
+	ContractName string
+	EndpointURL  string
+	Info         *ContractInfo
+	Tokens       []OwnedToken
+	// Truncated is set when the owner holds more tokens than were listed, see MaxEnumeratedTokens.
+	Truncated bool
+	// Err is set when the contract is an NFT contract but its inventory could not be loaded.
+	Err error
+}
+
+// LoadCollections detects which of the registered contracts are ERC-721 or
+// ERC-1155 contracts and lists the tokens owned by owner in each of them.
+// Contracts that are not NFT contracts, or whose endpoint is unreachable, are skipped.
+func LoadCollections(contracts []models.EVMContract, owner common.Address, newTransport TransportFactory) []Collection {
+	transports := make(map[string]transport.Transport)
+	collections := make([]Collection, 0)
+
+	for _, contract := range contracts {
+		if contract.Address == "" || contract.Endpoint == nil || contract.Endpoint.Url == "" {
+			continue
+		}
+
+		endpoint := contract.Endpoint.Url
+		tr, ok := transports[endpoint]
+		if !ok {
+			created, err := newTransport(endpoint)
+			if err != nil {
+				continue
+			}
+			transports[endpoint] = created
+			tr = created
+		}
+
+		info, err := DetectStandard(tr, common.HexToAddress(contract.Address))
+		if err != nil {
+			continue
+		}
+
+		collection := Collection{
+			ContractName: contract.Name,
+			EndpointURL:  endpoint,
+			Info:         info,
+		}
+
+		tokens, truncated, err := ListOwnedTokens(tr, info, owner)
+		if err != nil {
+			collection.Err = err
+			collections = append(collections, collection)
+			continue
+		}
+
+		collection.Truncated = truncated
+		for _, token := range tokens {
+			owned := OwnedToken{Token: token}
+			if token.URI != "" {
+				if metadata, err := FetchMetadata(token.URI); err == nil {
+					owned.Metadata = metadata
+				}
+			}
+			collection.Tokens = append(collection.Tokens, owned)
+		}
+
+		collections = append(collections, collection)
+	}
+
+	return collections
+}
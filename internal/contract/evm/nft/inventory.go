@@ -0,0 +1,280 @@
+package nft
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Token is a single NFT (or ERC-1155 balance) owned by an address.
+type Token struct {
+	Contract common.Address
+	Standard Standard
+	TokenID  *big.Int
+	// Balance is always 1 for ERC-721 tokens.
+	Balance *big.Int
+	// URI is the token metadata URI, empty if the contract does not expose one.
+	URI string
+}
+
+// MaxEnumeratedTokens caps the tokens enumerated from an ERC-721 contract. The count comes from the
+// balanceOf of the contract, which is not trusted to size allocations or bound loops.
+const MaxEnumeratedTokens = 1000
+
+// ListOwnedTokens returns the tokens of the contract currently owned by owner.
+// ERC-721 contracts implementing the enumerable extension are enumerated
+// directly, up to MaxEnumeratedTokens, otherwise token IDs are discovered from
+// transfer logs and their current ownership is verified on-chain. Truncated
+// reports whether the owner holds more tokens than were listed.
+func ListOwnedTokens(tr transport.Transport, info *ContractInfo, owner common.Address) (tokens []Token, truncated bool, err error) {
+	switch info.Standard {
+	case StandardERC721:
+		if info.Enumerable {
+			tokens, truncated, err = listEnumerableERC721(tr, info, owner)
+		} else {
+			tokens, err = listERC721FromLogs(tr, info, owner)
+		}
+	case StandardERC1155:
+		tokens, err = listERC1155FromLogs(tr, info, owner)
+	default:
+		return nil, false, errors.NewNFTError(errors.ErrCodeUnsupportedTokenStandard, fmt.Sprintf("unsupported token standard %q", info.Standard))
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].TokenID.Cmp(tokens[j].TokenID) < 0
+	})
+
+	for i := range tokens {
+		tokens[i].URI = tokenURI(tr, info, tokens[i].TokenID)
+	}
+
+	return tokens, truncated, nil
+}
+
+func listEnumerableERC721(tr transport.Transport, info *ContractInfo, owner common.Address) ([]Token, bool, error) {
+	values, err := erc721ABI.call(tr, info.Address, "balanceOf", owner)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query balance: %w", err)
+	}
+	balance, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, false, errors.NewABIError(errors.ErrCodeABIUnpackFailed, "unexpected balanceOf result type")
+	}
+
+	count := balance.Int64()
+	truncated := !balance.IsInt64() || count > MaxEnumeratedTokens
+	if truncated {
+		count = MaxEnumeratedTokens
+	}
+
+	tokens := []Token{}
+	for index := int64(0); index < count; index++ {
+		values, err := erc721ABI.call(tr, info.Address, "tokenOfOwnerByIndex", owner, big.NewInt(index))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to query token at index %d: %w", index, err)
+		}
+		tokenID, ok := values[0].(*big.Int)
+		if !ok {
+			return nil, false, errors.NewABIError(errors.ErrCodeABIUnpackFailed, "unexpected tokenOfOwnerByIndex result type")
+		}
+		tokens = append(tokens, newERC721Token(info, tokenID))
+	}
+
+	return tokens, truncated, nil
+}
+
+func listERC721FromLogs(tr transport.Transport, info *ContractInfo, owner common.Address) ([]Token, error) {
+	transferEvent := erc721ABI.eth.Events["Transfer"]
+
+	// Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+	logs, err := tr.FilterLogs(ethereum.FilterQuery{
+		FromBlock: big.NewInt(0),
+		Addresses: []common.Address{info.Address},
+		Topics:    [][]common.Hash{{transferEvent.ID}, nil, {common.BytesToHash(owner.Bytes())}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfer logs: %w", err)
+	}
+
+	candidates := make(map[string]*big.Int)
+	for _, log := range logs {
+		if len(log.Topics) < 4 {
+			continue
+		}
+		tokenID := new(big.Int).SetBytes(log.Topics[3].Bytes())
+		candidates[tokenID.String()] = tokenID
+	}
+
+	tokens := make([]Token, 0, len(candidates))
+	for _, tokenID := range candidates {
+		currentOwner, err := OwnerOf(tr, info.Address, tokenID)
+		if err != nil {
+			// Burned tokens revert on ownerOf.
+			continue
+		}
+		if currentOwner == owner {
+			tokens = append(tokens, newERC721Token(info, tokenID))
+		}
+	}
+
+	return tokens, nil
+}
+
+func listERC1155FromLogs(tr transport.Transport, info *ContractInfo, owner common.Address) ([]Token, error) {
+	singleEvent := erc1155ABI.eth.Events["TransferSingle"]
+	batchEvent := erc1155ABI.eth.Events["TransferBatch"]
+
+	// TransferSingle/TransferBatch(address indexed operator, address indexed from, address indexed to, ...)
+	logs, err := tr.FilterLogs(ethereum.FilterQuery{
+		FromBlock: big.NewInt(0),
+		Addresses: []common.Address{info.Address},
+		Topics:    [][]common.Hash{{singleEvent.ID, batchEvent.ID}, nil, nil, {common.BytesToHash(owner.Bytes())}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfer logs: %w", err)
+	}
+
+	candidates := make(map[string]*big.Int)
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		var ids []*big.Int
+		switch log.Topics[0] {
+		case singleEvent.ID:
+			ids, err = unpackTransferIDs(singleEvent, log.Data)
+		case batchEvent.ID:
+			ids, err = unpackTransferIDs(batchEvent, log.Data)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			candidates[id.String()] = id
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]*big.Int, 0, len(candidates))
+	owners := make([]common.Address, 0, len(candidates))
+	for _, id := range candidates {
+		ids = append(ids, id)
+		owners = append(owners, owner)
+	}
+
+	values, err := erc1155ABI.call(tr, info.Address, "balanceOfBatch", owners, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balances: %w", err)
+	}
+	balances, ok := values[0].([]*big.Int)
+	if !ok || len(balances) != len(ids) {
+		return nil, errors.NewABIError(errors.ErrCodeABIUnpackFailed, "unexpected balanceOfBatch result")
+	}
+
+	tokens := make([]Token, 0, len(ids))
+	for i, id := range ids {
+		if balances[i].Sign() > 0 {
+			tokens = append(tokens, Token{
+				Contract: info.Address,
+				Standard: StandardERC1155,
+				TokenID:  id,
+				Balance:  balances[i],
+			})
+		}
+	}
+
+	return tokens, nil
+}
+
+// unpackTransferIDs extracts the token IDs from the non-indexed data of a
+// TransferSingle or TransferBatch event.
+func unpackTransferIDs(event ethabi.Event, data []byte) ([]*big.Int, error) {
+	values, err := event.Inputs.NonIndexed().Unpack(data)
+	if err != nil || len(values) == 0 {
+		return nil, errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, fmt.Sprintf("failed to unpack %s log", event.Name))
+	}
+
+	switch ids := values[0].(type) {
+	case *big.Int:
+		return []*big.Int{ids}, nil
+	case []*big.Int:
+		return ids, nil
+	default:
+		return nil, errors.NewABIError(errors.ErrCodeABIUnpackFailed, fmt.Sprintf("unexpected token id type in %s log", event.Name))
+	}
+}
+
+func newERC721Token(info *ContractInfo, tokenID *big.Int) Token {
+	return Token{
+		Contract: info.Address,
+		Standard: StandardERC721,
+		TokenID:  tokenID,
+		Balance:  big.NewInt(1),
+	}
+}
+
+// OwnerOf returns the current owner of an ERC-721 token.
+func OwnerOf(tr transport.Transport, address common.Address, tokenID *big.Int) (common.Address, error) {
+	values, err := erc721ABI.call(tr, address, "ownerOf", tokenID)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	owner, ok := values[0].(common.Address)
+	if !ok {
+		return common.Address{}, errors.NewABIError(errors.ErrCodeABIUnpackFailed, "unexpected ownerOf result type")
+	}
+	return owner, nil
+}
+
+// BalanceOf returns the ERC-1155 balance of account for the given token ID.
+func BalanceOf(tr transport.Transport, address common.Address, account common.Address, tokenID *big.Int) (*big.Int, error) {
+	values, err := erc1155ABI.call(tr, address, "balanceOf", account, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, errors.NewABIError(errors.ErrCodeABIUnpackFailed, "unexpected balanceOf result type")
+	}
+	return balance, nil
+}
+
+// tokenURI returns the metadata URI of a token, or an empty string when the
+// contract does not expose one.
+func tokenURI(tr transport.Transport, info *ContractInfo, tokenID *big.Int) string {
+	contractABI, method := erc721ABI, "tokenURI"
+	if info.Standard == StandardERC1155 {
+		contractABI, method = erc1155ABI, "uri"
+	}
+
+	values, err := contractABI.call(tr, info.Address, method, tokenID)
+	if err != nil {
+		return ""
+	}
+
+	uri, ok := values[0].(string)
+	if !ok {
+		return ""
+	}
+
+	// ERC-1155 clients must substitute {id} with the lowercase, zero-padded hex token ID.
+	if info.Standard == StandardERC1155 {
+		uri = strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", tokenID))
+	}
+	return uri
+}
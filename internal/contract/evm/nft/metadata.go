@@ -0,0 +1,95 @@
+package nft
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Attribute is a single trait in the token metadata.
+type Attribute struct {
+	TraitType string `json:"trait_type"`
+	Value     any    `json:"value"`
+}
+
+// Metadata is the ERC-721/ERC-1155 metadata JSON document of a token.
+type Metadata struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Image       string      `json:"image"`
+	ExternalURL string      `json:"external_url,omitempty"`
+	Attributes  []Attribute `json:"attributes,omitempty"`
+}
+
+// FetchMetadata reads and parses token metadata from a data: URI, a file://
+// URI or a local file path. Remote URIs are not fetched.
+func FetchMetadata(uri string) (*Metadata, error) {
+	content, err := readURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata Metadata
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		return nil, errors.WrapNFTError(err, errors.ErrCodeMetadataFetchFailed, "failed to parse token metadata")
+	}
+
+	return &metadata, nil
+}
+
+func readURI(uri string) ([]byte, error) {
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return nil, errors.NewNFTError(errors.ErrCodeInvalidTokenURI, "token URI is empty")
+	}
+
+	switch {
+	case strings.HasPrefix(uri, "data:"):
+		return decodeDataURI(uri)
+	case strings.HasPrefix(uri, "file://"):
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return nil, errors.WrapNFTError(err, errors.ErrCodeInvalidTokenURI, "invalid file URI")
+		}
+		return readLocalFile(parsed.Path)
+	case strings.Contains(uri, "://"):
+		return nil, errors.NewNFTError(errors.ErrCodeInvalidTokenURI, fmt.Sprintf("unsupported token URI scheme: %s", uri))
+	default:
+		return readLocalFile(uri)
+	}
+}
+
+func readLocalFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapNFTError(err, errors.ErrCodeMetadataFetchFailed, fmt.Sprintf("failed to read metadata file %s", path))
+	}
+	return content, nil
+}
+
+// decodeDataURI decodes an RFC 2397 data URI (data:[<mediatype>][;base64],<data>).
+func decodeDataURI(uri string) ([]byte, error) {
+	header, payload, found := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !found {
+		return nil, errors.NewNFTError(errors.ErrCodeInvalidTokenURI, "invalid data URI: missing ','")
+	}
+
+	if strings.HasSuffix(header, ";base64") {
+		content, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return nil, errors.WrapNFTError(err, errors.ErrCodeInvalidTokenURI, "invalid base64 data URI")
+		}
+		return content, nil
+	}
+
+	content, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, errors.WrapNFTError(err, errors.ErrCodeInvalidTokenURI, "invalid percent-encoded data URI")
+	}
+	return []byte(content), nil
+}
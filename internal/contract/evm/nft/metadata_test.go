@@ -0,0 +1,87 @@
+package nft
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadataJSON = `{"name":"Token #1","description":"First token","image":"data:image/svg+xml;base64,PHN2Zy8+","attributes":[{"trait_type":"Color","value":"Blue"}]}`
+
+func TestFetchMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+	metadataPath := filepath.Join(tmpDir, "1.json")
+	require.NoError(t, os.WriteFile(metadataPath, []byte(testMetadataJSON), 0o600))
+
+	tests := []struct {
+		name     string
+		uri      string
+		wantCode errors.ErrorCode
+	}{
+		{
+			name: "base64 data URI",
+			uri:  "data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(testMetadataJSON)),
+		},
+		{
+			name: "percent-encoded data URI",
+			uri:  "data:application/json," + `%7B%22name%22%3A%22Token%20%231%22%2C%22description%22%3A%22First%20token%22%2C%22image%22%3A%22data%3Aimage%2Fsvg%2Bxml%3Bbase64%2CPHN2Zy8%2B%22%2C%22attributes%22%3A%5B%7B%22trait_type%22%3A%22Color%22%2C%22value%22%3A%22Blue%22%7D%5D%7D`,
+		},
+		{
+			name: "local file path",
+			uri:  metadataPath,
+		},
+		{
+			name: "file URI",
+			uri:  "file://" + metadataPath,
+		},
+		{
+			name:     "remote URI is not supported",
+			uri:      "https://example.com/1.json",
+			wantCode: errors.ErrCodeInvalidTokenURI,
+		},
+		{
+			name:     "empty URI",
+			uri:      "",
+			wantCode: errors.ErrCodeInvalidTokenURI,
+		},
+		{
+			name:     "data URI without payload separator",
+			uri:      "data:application/json;base64",
+			wantCode: errors.ErrCodeInvalidTokenURI,
+		},
+		{
+			name:     "missing file",
+			uri:      filepath.Join(tmpDir, "missing.json"),
+			wantCode: errors.ErrCodeMetadataFetchFailed,
+		},
+		{
+			name:     "invalid JSON",
+			uri:      "data:application/json,not-json",
+			wantCode: errors.ErrCodeMetadataFetchFailed,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			metadata, err := FetchMetadata(testCase.uri)
+			if testCase.wantCode != "" {
+				require.Error(t, err)
+				assert.True(t, errors.HasCode(err, testCase.wantCode), "unexpected error: %v", err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "Token #1", metadata.Name)
+			assert.Equal(t, "First token", metadata.Description)
+			assert.Equal(t, "data:image/svg+xml;base64,PHN2Zy8+", metadata.Image)
+			require.Len(t, metadata.Attributes, 1)
+			assert.Equal(t, "Color", metadata.Attributes[0].TraitType)
+			assert.Equal(t, "Blue", metadata.Attributes[0].Value)
+		})
+	}
+}
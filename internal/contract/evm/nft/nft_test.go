@@ -0,0 +1,445 @@
+package nft
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	customabi "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type callHandler func(args []any) ([]any, error)
+
+// fakeTransport answers contract calls from per-method handlers and serves
+// a fixed set of logs, so NFT logic can be tested without a running node.
+type fakeTransport struct {
+	transport.Transport
+	calls map[string]callHandler
+	logs  []types.Log
+}
+
+func (f *fakeTransport) CallContract(_ common.Address, customABI customabi.ABI, functionName string, args ...any) ([]byte, error) {
+	handler, ok := f.calls[functionName]
+	if !ok {
+		return nil, fmt.Errorf("execution reverted")
+	}
+
+	values, err := handler(args)
+	if err != nil {
+		return nil, err
+	}
+
+	abiJSON, err := customABI.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	ethABI, err := ethabi.JSON(strings.NewReader(string(abiJSON)))
+	if err != nil {
+		return nil, err
+	}
+	return ethABI.Methods[functionName].Outputs.Pack(values...)
+}
+
+func (f *fakeTransport) FilterLogs(query ethereum.FilterQuery) ([]types.Log, error) {
+	var matched []types.Log
+	for _, log := range f.logs {
+		if topicsMatch(query.Topics, log.Topics) {
+			matched = append(matched, log)
+		}
+	}
+	return matched, nil
+}
+
+func topicsMatch(filter [][]common.Hash, topics []common.Hash) bool {
+	for position, allowed := range filter {
+		if len(allowed) == 0 {
+			continue
+		}
+		if position >= len(topics) {
+			return false
+		}
+		found := false
+		for _, topic := range allowed {
+			if topic == topics[position] {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fakeTransport) GetChainID() (*big.Int, error) {
+	return big.NewInt(31337), nil
+}
+
+func (f *fakeTransport) Multicall(calls []transport.Call) ([]transport.CallResult, error) {
+	results := make([]transport.CallResult, len(calls))
+	for i, call := range calls {
+		results[i].Data, results[i].Err = f.CallContract(call.Target, call.ABI, call.FunctionName, call.Args...)
+	}
+	return results, nil
+}
+
+func (f *fakeTransport) CallContractWithOptions(address common.Address, customABI customabi.ABI, functionName string, _ transport.CallOptions, args ...any) ([]byte, error) {
+	return f.CallContract(address, customABI, functionName, args...)
+}
+
+var _ transport.Transport = (*fakeTransport)(nil)
+
+// fakeSigner records the contract method invoked through CallContractMethod.
+type fakeSigner struct {
+	signer.SignerWithTransport
+	address common.Address
+	status  uint64
+	method  string
+	inputs  int
+	args    []any
+}
+
+func (f *fakeSigner) CallContractMethod(_ common.Address, contractABI customabi.ABI, methodName string, _ *big.Int, _ uint64, _ *big.Int, args ...any) ([]any, error) {
+	for _, element := range contractABI.Elements() {
+		if element.Type == "function" && element.Name == methodName {
+			f.inputs = len(element.Inputs)
+		}
+	}
+	f.method = methodName
+	f.args = args
+	return []any{f.status, "0xabc"}, nil
+}
+
+func (f *fakeSigner) GetAddress() (common.Address, error) {
+	return f.address, nil
+}
+
+var _ signer.SignerWithTransport = (*fakeSigner)(nil)
+
+func supportsInterfaces(ids ...[4]byte) callHandler {
+	return func(args []any) ([]any, error) {
+		requested, _ := args[0].([4]byte)
+		for _, id := range ids {
+			if id == requested {
+				return []any{true}, nil
+			}
+		}
+		return []any{false}, nil
+	}
+}
+
+func addressTopic(address common.Address) common.Hash {
+	return common.BytesToHash(address.Bytes())
+}
+
+type NFTTestSuite struct {
+	suite.Suite
+	contract common.Address
+	owner    common.Address
+	other    common.Address
+}
+
+func TestNFTTestSuite(t *testing.T) {
+	suite.Run(t, new(NFTTestSuite))
+}
+
+func (suite *NFTTestSuite) SetupTest() {
+	suite.contract = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	suite.owner = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	suite.other = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+}
+
+func (suite *NFTTestSuite) newERC721Transport(enumerable bool) *fakeTransport {
+	interfaces := [][4]byte{InterfaceIDERC165, InterfaceIDERC721, InterfaceIDERC721Metadata}
+	if enumerable {
+		interfaces = append(interfaces, InterfaceIDERC721Enumerable)
+	}
+
+	return &fakeTransport{
+		calls: map[string]callHandler{
+			"supportsInterface": supportsInterfaces(interfaces...),
+			"tokenURI": func(args []any) ([]any, error) {
+				tokenID, _ := args[0].(*big.Int)
+				return []any{fmt.Sprintf(`data:application/json,{"name":"Token %s"}`, tokenID)}, nil
+			},
+		},
+	}
+}
+
+func (suite *NFTTestSuite) TestDetectStandard() {
+	suite.Run("enumerable ERC-721", func() {
+		info, err := DetectStandard(suite.newERC721Transport(true), suite.contract)
+		suite.Require().NoError(err)
+		suite.Equal(StandardERC721, info.Standard)
+		suite.True(info.Enumerable)
+		suite.True(info.HasMetadata)
+	})
+
+	suite.Run("ERC-1155", func() {
+		tr := &fakeTransport{calls: map[string]callHandler{
+			"supportsInterface": supportsInterfaces(InterfaceIDERC165, InterfaceIDERC1155),
+		}}
+		info, err := DetectStandard(tr, suite.contract)
+		suite.Require().NoError(err)
+		suite.Equal(StandardERC1155, info.Standard)
+		suite.False(info.HasMetadata)
+	})
+
+	suite.Run("contract without ERC-165", func() {
+		_, err := DetectStandard(&fakeTransport{}, suite.contract)
+		suite.True(errors.HasCode(err, errors.ErrCodeUnsupportedTokenStandard))
+	})
+
+	suite.Run("ERC-165 contract that is not an NFT", func() {
+		tr := &fakeTransport{calls: map[string]callHandler{
+			"supportsInterface": supportsInterfaces(InterfaceIDERC165),
+		}}
+		_, err := DetectStandard(tr, suite.contract)
+		suite.True(errors.HasCode(err, errors.ErrCodeUnsupportedTokenStandard))
+	})
+}
+
+func (suite *NFTTestSuite) TestListOwnedTokensEnumerable() {
+	tr := suite.newERC721Transport(true)
+	tr.calls["balanceOf"] = func([]any) ([]any, error) {
+		return []any{big.NewInt(2)}, nil
+	}
+	tr.calls["tokenOfOwnerByIndex"] = func(args []any) ([]any, error) {
+		index, _ := args[1].(*big.Int)
+		return []any{[]*big.Int{big.NewInt(5), big.NewInt(3)}[index.Int64()]}, nil
+	}
+
+	info, err := DetectStandard(tr, suite.contract)
+	suite.Require().NoError(err)
+
+	tokens, truncated, err := ListOwnedTokens(tr, info, suite.owner)
+	suite.Require().NoError(err)
+	suite.False(truncated)
+	suite.Require().Len(tokens, 2)
+	suite.Equal(int64(3), tokens[0].TokenID.Int64())
+	suite.Equal(int64(5), tokens[1].TokenID.Int64())
+	suite.Equal(int64(1), tokens[0].Balance.Int64())
+	suite.Equal(`data:application/json,{"name":"Token 3"}`, tokens[0].URI)
+}
+
+// TestListOwnedTokensCapsUntrustedBalance tests that a balanceOf beyond int64 or MaxEnumeratedTokens
+// lists only the first tokens and reports the list as truncated.
+func (suite *NFTTestSuite) TestListOwnedTokensCapsUntrustedBalance() {
+	tr := suite.newERC721Transport(true)
+	tr.calls["tokenOfOwnerByIndex"] = func(args []any) ([]any, error) {
+		index, _ := args[1].(*big.Int)
+		return []any{index}, nil
+	}
+
+	info, err := DetectStandard(tr, suite.contract)
+	suite.Require().NoError(err)
+
+	for _, balance := range []*big.Int{new(big.Int).Lsh(big.NewInt(1), 63), big.NewInt(MaxEnumeratedTokens + 1)} {
+		tr.calls["balanceOf"] = func([]any) ([]any, error) {
+			return []any{balance}, nil
+		}
+
+		tokens, truncated, err := ListOwnedTokens(tr, info, suite.owner)
+		suite.Require().NoError(err)
+		suite.True(truncated)
+		suite.Len(tokens, MaxEnumeratedTokens)
+	}
+}
+
+func (suite *NFTTestSuite) TestListOwnedTokensERC721FromLogs() {
+	transferID := erc721ABI.eth.Events["Transfer"].ID
+	transferLog := func(from, to common.Address, tokenID int64) types.Log {
+		return types.Log{
+			Address: suite.contract,
+			Topics:  []common.Hash{transferID, addressTopic(from), addressTopic(to), common.BigToHash(big.NewInt(tokenID))},
+		}
+	}
+
+	tr := suite.newERC721Transport(false)
+	tr.logs = []types.Log{
+		transferLog(common.Address{}, suite.owner, 1),
+		transferLog(common.Address{}, suite.owner, 2),
+		transferLog(suite.owner, suite.other, 2),
+		transferLog(common.Address{}, suite.other, 3),
+	}
+	tr.calls["ownerOf"] = func(args []any) ([]any, error) {
+		tokenID, _ := args[0].(*big.Int)
+		if tokenID.Int64() == 1 {
+			return []any{suite.owner}, nil
+		}
+		return []any{suite.other}, nil
+	}
+
+	info, err := DetectStandard(tr, suite.contract)
+	suite.Require().NoError(err)
+	suite.False(info.Enumerable)
+
+	tokens, truncated, err := ListOwnedTokens(tr, info, suite.owner)
+	suite.Require().NoError(err)
+	suite.False(truncated)
+	suite.Require().Len(tokens, 1)
+	suite.Equal(int64(1), tokens[0].TokenID.Int64())
+}
+
+func (suite *NFTTestSuite) TestListOwnedTokensERC1155FromLogs() {
+	singleEvent := erc1155ABI.eth.Events["TransferSingle"]
+	batchEvent := erc1155ABI.eth.Events["TransferBatch"]
+
+	singleData, err := singleEvent.Inputs.NonIndexed().Pack(big.NewInt(7), big.NewInt(3))
+	suite.Require().NoError(err)
+	batchData, err := batchEvent.Inputs.NonIndexed().Pack(
+		[]*big.Int{big.NewInt(8), big.NewInt(9)},
+		[]*big.Int{big.NewInt(1), big.NewInt(1)},
+	)
+	suite.Require().NoError(err)
+
+	balances := map[int64]int64{7: 3, 8: 0, 9: 1}
+	tr := &fakeTransport{
+		calls: map[string]callHandler{
+			"supportsInterface": supportsInterfaces(InterfaceIDERC165, InterfaceIDERC1155, InterfaceIDERC1155MetadataURI),
+			"balanceOfBatch": func(args []any) ([]any, error) {
+				ids, _ := args[1].([]*big.Int)
+				result := make([]*big.Int, len(ids))
+				for i, id := range ids {
+					result[i] = big.NewInt(balances[id.Int64()])
+				}
+				return []any{result}, nil
+			},
+			"uri": func([]any) ([]any, error) {
+				return []any{"metadata/{id}.json"}, nil
+			},
+		},
+		logs: []types.Log{
+			{
+				Address: suite.contract,
+				Topics:  []common.Hash{singleEvent.ID, addressTopic(suite.owner), addressTopic(common.Address{}), addressTopic(suite.owner)},
+				Data:    singleData,
+			},
+			{
+				Address: suite.contract,
+				Topics:  []common.Hash{batchEvent.ID, addressTopic(suite.owner), addressTopic(common.Address{}), addressTopic(suite.owner)},
+				Data:    batchData,
+			},
+		},
+	}
+
+	info, err := DetectStandard(tr, suite.contract)
+	suite.Require().NoError(err)
+
+	tokens, truncated, err := ListOwnedTokens(tr, info, suite.owner)
+	suite.Require().NoError(err)
+	suite.False(truncated)
+	suite.Require().Len(tokens, 2)
+	suite.Equal(int64(7), tokens[0].TokenID.Int64())
+	suite.Equal(int64(3), tokens[0].Balance.Int64())
+	suite.Equal(int64(9), tokens[1].TokenID.Int64())
+	suite.Equal("metadata/"+strings.Repeat("0", 63)+"9.json", tokens[1].URI)
+}
+
+func (suite *NFTTestSuite) TestLoadCollections() {
+	tr := suite.newERC721Transport(true)
+	tr.calls["balanceOf"] = func([]any) ([]any, error) {
+		return []any{big.NewInt(1)}, nil
+	}
+	tr.calls["tokenOfOwnerByIndex"] = func([]any) ([]any, error) {
+		return []any{big.NewInt(42)}, nil
+	}
+
+	endpoint := &models.EVMEndpoint{Name: "anvil", Url: "http://localhost:8545"}
+	contracts := []models.EVMContract{
+		{Name: "Collectibles", Address: suite.contract.Hex(), Endpoint: endpoint},
+		{Name: "Undeployed", Address: "", Endpoint: endpoint},
+		{Name: "No endpoint", Address: suite.other.Hex()},
+	}
+
+	collections := LoadCollections(contracts, suite.owner, func(string) (transport.Transport, error) {
+		return tr, nil
+	})
+
+	suite.Require().Len(collections, 1)
+	suite.Equal("Collectibles", collections[0].ContractName)
+	suite.Equal(endpoint.Url, collections[0].EndpointURL)
+	suite.Require().NoError(collections[0].Err)
+	suite.Require().Len(collections[0].Tokens, 1)
+	suite.Equal(int64(42), collections[0].Tokens[0].TokenID.Int64())
+	suite.Require().NotNil(collections[0].Tokens[0].Metadata)
+	suite.Equal("Token 42", collections[0].Tokens[0].Metadata.Name)
+}
+
+func (suite *NFTTestSuite) TestSafeTransfer() {
+	suite.Run("ERC-721 uses the three argument variant", func() {
+		tr := suite.newERC721Transport(false)
+		tr.calls["ownerOf"] = func([]any) ([]any, error) {
+			return []any{suite.owner}, nil
+		}
+		sgn := &fakeSigner{address: suite.owner, status: types.ReceiptStatusSuccessful}
+
+		txHash, err := SafeTransfer(tr, sgn, suite.contract, suite.other, big.NewInt(1), nil)
+		suite.Require().NoError(err)
+		suite.Equal("0xabc", txHash)
+		suite.Equal("safeTransferFrom", sgn.method)
+		suite.Equal(3, sgn.inputs)
+		suite.Equal([]any{suite.owner, suite.other, big.NewInt(1)}, sgn.args)
+	})
+
+	suite.Run("ERC-721 token owned by someone else", func() {
+		tr := suite.newERC721Transport(false)
+		tr.calls["ownerOf"] = func([]any) ([]any, error) {
+			return []any{suite.other}, nil
+		}
+		sgn := &fakeSigner{address: suite.owner}
+
+		_, err := SafeTransfer(tr, sgn, suite.contract, suite.other, big.NewInt(1), nil)
+		suite.True(errors.HasCode(err, errors.ErrCodeTokenNotOwned))
+		suite.Empty(sgn.method)
+	})
+
+	suite.Run("ERC-1155 uses the five argument variant", func() {
+		tr := &fakeTransport{calls: map[string]callHandler{
+			"supportsInterface": supportsInterfaces(InterfaceIDERC165, InterfaceIDERC1155),
+			"balanceOf": func([]any) ([]any, error) {
+				return []any{big.NewInt(5)}, nil
+			},
+		}}
+		sgn := &fakeSigner{address: suite.owner, status: types.ReceiptStatusSuccessful}
+
+		_, err := SafeTransfer(tr, sgn, suite.contract, suite.other, big.NewInt(7), big.NewInt(2))
+		suite.Require().NoError(err)
+		suite.Equal(5, sgn.inputs)
+		suite.Equal([]any{suite.owner, suite.other, big.NewInt(7), big.NewInt(2), []byte{}}, sgn.args)
+	})
+
+	suite.Run("ERC-1155 insufficient balance", func() {
+		tr := &fakeTransport{calls: map[string]callHandler{
+			"supportsInterface": supportsInterfaces(InterfaceIDERC165, InterfaceIDERC1155),
+			"balanceOf": func([]any) ([]any, error) {
+				return []any{big.NewInt(1)}, nil
+			},
+		}}
+		sgn := &fakeSigner{address: suite.owner}
+
+		_, err := SafeTransfer(tr, sgn, suite.contract, suite.other, big.NewInt(7), big.NewInt(2))
+		suite.True(errors.HasCode(err, errors.ErrCodeTokenNotOwned))
+	})
+
+	suite.Run("reverted transfer", func() {
+		tr := suite.newERC721Transport(false)
+		tr.calls["ownerOf"] = func([]any) ([]any, error) {
+			return []any{suite.owner}, nil
+		}
+		sgn := &fakeSigner{address: suite.owner, status: types.ReceiptStatusFailed}
+
+		_, err := SafeTransfer(tr, sgn, suite.contract, suite.other, big.NewInt(1), nil)
+		suite.True(errors.HasCode(err, errors.ErrCodeTransferFailed))
+	})
+}
@@ -0,0 +1,75 @@
+package nft
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Standard identifies the token standard implemented by an NFT contract.
+type Standard string
+
+const (
+	StandardERC721  Standard = "ERC-721"
+	StandardERC1155 Standard = "ERC-1155"
+)
+
+// ERC-165 interface identifiers.
+var (
+	InterfaceIDERC165             = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	InterfaceIDInvalid            = [4]byte{0xff, 0xff, 0xff, 0xff}
+	InterfaceIDERC721             = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	InterfaceIDERC721Metadata     = [4]byte{0x5b, 0x5e, 0x13, 0x9f}
+	InterfaceIDERC721Enumerable   = [4]byte{0x78, 0x0e, 0x9d, 0x63}
+	InterfaceIDERC1155            = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	InterfaceIDERC1155MetadataURI = [4]byte{0x0e, 0x89, 0x34, 0x1c}
+)
+
+// ContractInfo describes the NFT interfaces supported by a contract.
+type ContractInfo struct {
+	Address     common.Address
+	Standard    Standard
+	Enumerable  bool
+	HasMetadata bool
+}
+
+// SupportsInterface queries ERC-165 supportsInterface on the given contract.
+// Calls that revert are reported as unsupported rather than as errors, since
+// contracts without ERC-165 usually revert on unknown selectors.
+func SupportsInterface(tr transport.Transport, address common.Address, interfaceID [4]byte) bool {
+	values, err := erc721ABI.call(tr, address, "supportsInterface", interfaceID)
+	if err != nil {
+		return false
+	}
+
+	supported, ok := values[0].(bool)
+	return ok && supported
+}
+
+// DetectStandard uses ERC-165 to determine whether the contract implements
+// ERC-721 or ERC-1155 and which optional extensions it supports.
+func DetectStandard(tr transport.Transport, address common.Address) (*ContractInfo, error) {
+	if !SupportsInterface(tr, address, InterfaceIDERC165) || SupportsInterface(tr, address, InterfaceIDInvalid) {
+		return nil, errors.NewNFTError(errors.ErrCodeUnsupportedTokenStandard, fmt.Sprintf("contract %s does not implement ERC-165", address.Hex()))
+	}
+
+	switch {
+	case SupportsInterface(tr, address, InterfaceIDERC721):
+		return &ContractInfo{
+			Address:     address,
+			Standard:    StandardERC721,
+			Enumerable:  SupportsInterface(tr, address, InterfaceIDERC721Enumerable),
+			HasMetadata: SupportsInterface(tr, address, InterfaceIDERC721Metadata),
+		}, nil
+	case SupportsInterface(tr, address, InterfaceIDERC1155):
+		return &ContractInfo{
+			Address:     address,
+			Standard:    StandardERC1155,
+			HasMetadata: SupportsInterface(tr, address, InterfaceIDERC1155MetadataURI),
+		}, nil
+	default:
+		return nil, errors.NewNFTError(errors.ErrCodeUnsupportedTokenStandard, fmt.Sprintf("contract %s implements neither ERC-721 nor ERC-1155", address.Hex()))
+	}
+}
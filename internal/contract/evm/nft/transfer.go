@@ -0,0 +1,79 @@
+package nft
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// SafeTransfer transfers a token owned by the signer to the recipient using
+// the safeTransferFrom variant of the contract's standard, detected through
+// ERC-165. Amount is ignored for ERC-721 tokens.
+func SafeTransfer(tr transport.Transport, sgn signer.SignerWithTransport, contract common.Address, recipient common.Address, tokenID *big.Int, amount *big.Int) (txHash string, err error) {
+	info, err := DetectStandard(tr, contract)
+	if err != nil {
+		return "", err
+	}
+
+	from, err := sgn.GetAddress()
+	if err != nil {
+		return "", fmt.Errorf("failed to get signer address: %w", err)
+	}
+
+	var result []any
+	switch info.Standard {
+	case StandardERC721:
+		owner, err := OwnerOf(tr, contract, tokenID)
+		if err != nil {
+			return "", fmt.Errorf("failed to query token owner: %w", err)
+		}
+		if owner != from {
+			return "", errors.NewNFTError(errors.ErrCodeTokenNotOwned, fmt.Sprintf("token %s is owned by %s", tokenID, owner.Hex()))
+		}
+
+		result, err = sgn.CallContractMethod(contract, erc721ABI.custom, "safeTransferFrom", nil, 0, nil, from, recipient, tokenID)
+		if err != nil {
+			return "", fmt.Errorf("failed to transfer token: %w", err)
+		}
+	case StandardERC1155:
+		if amount == nil || amount.Sign() <= 0 {
+			return "", errors.NewNFTError(errors.ErrCodeInvalidTransferAmount, "transfer amount must be positive")
+		}
+
+		balance, err := BalanceOf(tr, contract, from, tokenID)
+		if err != nil {
+			return "", fmt.Errorf("failed to query token balance: %w", err)
+		}
+		if balance.Cmp(amount) < 0 {
+			return "", errors.NewNFTError(errors.ErrCodeTokenNotOwned, fmt.Sprintf("insufficient balance of token %s: have %s, want %s", tokenID, balance, amount))
+		}
+
+		result, err = sgn.CallContractMethod(contract, erc1155ABI.custom, "safeTransferFrom", nil, 0, nil, from, recipient, tokenID, amount, []byte{})
+		if err != nil {
+			return "", fmt.Errorf("failed to transfer token: %w", err)
+		}
+	}
+
+	return parseTransferResult(result)
+}
+
+// parseTransferResult extracts the transaction hash from the status/hash pair
+// returned by CallContractMethod for write operations.
+func parseTransferResult(result []any) (string, error) {
+	if len(result) != 2 {
+		return "", errors.NewNFTError(errors.ErrCodeTransferFailed, "unexpected transaction result")
+	}
+
+	status, _ := result[0].(uint64)
+	txHash, _ := result[1].(string)
+	if status != types.ReceiptStatusSuccessful {
+		return "", errors.NewNFTError(errors.ErrCodeTransferFailed, fmt.Sprintf("transfer transaction %s reverted", txHash))
+	}
+
+	return txHash, nil
+}
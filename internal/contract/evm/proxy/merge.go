@@ -0,0 +1,58 @@
+package proxy
+
+import (
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+)
+
+// eip1967Events are the upgrade events emitted by every EIP-1967 proxy, whatever ABI the proxy
+// contract was stored with.
+var eip1967Events = abi.AbiArray{
+	{Type: "event", Name: "Upgraded", Inputs: []abi.ABIParam{{Name: "implementation", Type: "address", Indexed: true}}},
+	{Type: "event", Name: "AdminChanged", Inputs: []abi.ABIParam{{Name: "previousAdmin", Type: "address"}, {Name: "newAdmin", Type: "address"}}},
+	{Type: "event", Name: "BeaconUpgraded", Inputs: []abi.ABIParam{{Name: "beacon", Type: "address", Indexed: true}}},
+}
+
+// ABI returns the elements that the proxy pattern itself adds to the proxy address.
+func (p *Proxy) ABI() abi.AbiArray {
+	switch p.Kind {
+	case KindTransparent, KindUUPS, KindBeacon, KindEIP1967:
+		return append(abi.AbiArray{}, eip1967Events...)
+	default:
+		return abi.AbiArray{}
+	}
+}
+
+// MergeABIs combines the ABI of a proxy with the ABI of its implementation. Calls to the proxy
+// address are delegated to the implementation, so its elements come first and win when both
+// declare the same signature. The implementation's constructor is dropped since it never runs in
+// the context of the proxy.
+func MergeABIs(proxyABI abi.AbiArray, implementationABI abi.AbiArray) abi.AbiArray {
+	merged := abi.AbiArray{}
+	seen := map[string]bool{}
+	add := func(element abi.ABIElement) {
+		key := mergeKey(element)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		merged = append(merged, element)
+	}
+
+	for _, element := range implementationABI {
+		if element.Type != "constructor" {
+			add(element)
+		}
+	}
+	for _, element := range proxyABI {
+		add(element)
+	}
+	return merged
+}
+
+// mergeKey identifies an element by its type and, for named elements, its canonical signature.
+func mergeKey(element abi.ABIElement) string {
+	if signature, err := element.Signature(); err == nil && element.Name != "" {
+		return element.Type + " " + signature
+	}
+	return element.Type
+}
@@ -0,0 +1,220 @@
+// Package proxy detects upgradeable proxy contracts from their storage slots and bytecode and
+// resolves the implementation that holds their logic, so the implementation ABI can be used to
+// interact with the proxy address.
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Kind identifies the proxy pattern used by a contract.
+type Kind string
+
+const (
+	KindNone        Kind = ""
+	KindTransparent Kind = "transparent"
+	KindUUPS        Kind = "uups"
+	KindBeacon      Kind = "beacon"
+	KindEIP1967     Kind = "eip1967"
+	KindMinimal     Kind = "minimal"
+)
+
+// Storage slots defined by EIP-1967 and EIP-1822.
+var (
+	ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	AdminSlot          = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+	BeaconSlot         = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+	ProxiableSlot      = common.HexToHash("0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7")
+)
+
+// EIP-1167 minimal proxy runtime code surrounding the 20 byte implementation address.
+var (
+	minimalProxyPrefix = common.FromHex("0x363d3d373d3d3d363d73")
+	minimalProxySuffix = common.FromHex("0x5af43d82803e903d91602b57fd5bf3")
+)
+
+// implementationABI is queried on beacons for the implementation and on UUPS implementations for
+// the slot they upgrade.
+var implementationABI = abi.AbiArray{
+	{Type: "function", Name: "implementation", StateMutability: "view", Outputs: []abi.ABIParam{{Name: "", Type: "address"}}},
+	{Type: "function", Name: "proxiableUUID", StateMutability: "view", Outputs: []abi.ABIParam{{Name: "", Type: "bytes32"}}},
+}
+
+// Proxy describes the proxy pattern detected at an address. Addresses that do not apply to the
+// pattern are left zero.
+type Proxy struct {
+	Kind           Kind           `json:"kind"`
+	Address        common.Address `json:"address"`
+	Implementation common.Address `json:"implementation"`
+	Admin          common.Address `json:"admin"`
+	Beacon         common.Address `json:"beacon"`
+}
+
+// IsProxy reports whether a proxy pattern was detected.
+func (p *Proxy) IsProxy() bool {
+	return p.Kind != KindNone
+}
+
+// Description returns a human-readable name for the detected pattern.
+func (p *Proxy) Description() string {
+	switch p.Kind {
+	case KindTransparent:
+		return "Transparent proxy (EIP-1967)"
+	case KindUUPS:
+		return "UUPS proxy (EIP-1822)"
+	case KindBeacon:
+		return "Beacon proxy (EIP-1967)"
+	case KindEIP1967:
+		return "EIP-1967 proxy"
+	case KindMinimal:
+		return "Minimal proxy (EIP-1167)"
+	default:
+		return "Not a proxy"
+	}
+}
+
+// Resolve detects the proxy pattern of the contract at address. Beacon proxies are checked first,
+// then the EIP-1967 implementation slot, which is classified as transparent when an admin is set
+// and as UUPS when the implementation reports the slot through proxiableUUID. The legacy EIP-1822
+// slot and EIP-1167 clones are checked last. A contract matching none of them is returned with
+// KindNone.
+func Resolve(tr transport.Transport, address common.Address) (*Proxy, error) {
+	result := &Proxy{Address: address}
+
+	beacon, err := readAddress(tr, address, BeaconSlot)
+	if err != nil {
+		return nil, err
+	}
+	if beacon != (common.Address{}) {
+		implementation, err := callAddress(tr, beacon, "implementation")
+		if err != nil {
+			return nil, errors.WrapContractError(err, errors.ErrCodeProxyResolveFailed, fmt.Sprintf("failed to get the implementation from beacon %s", beacon.Hex()))
+		}
+		result.Kind = KindBeacon
+		result.Beacon = beacon
+		result.Implementation = implementation
+		return result, nil
+	}
+
+	implementation, err := readAddress(tr, address, ImplementationSlot)
+	if err != nil {
+		return nil, err
+	}
+	if implementation != (common.Address{}) {
+		admin, err := readAddress(tr, address, AdminSlot)
+		if err != nil {
+			return nil, err
+		}
+		result.Implementation = implementation
+		result.Admin = admin
+		switch {
+		case admin != (common.Address{}):
+			result.Kind = KindTransparent
+		case isUUPS(tr, implementation):
+			result.Kind = KindUUPS
+		default:
+			result.Kind = KindEIP1967
+		}
+		return result, nil
+	}
+
+	implementation, err = readAddress(tr, address, ProxiableSlot)
+	if err != nil {
+		return nil, err
+	}
+	if implementation != (common.Address{}) {
+		result.Kind = KindUUPS
+		result.Implementation = implementation
+		return result, nil
+	}
+
+	code, err := tr.GetCode(address)
+	if err != nil {
+		return nil, err
+	}
+	if implementation, ok := minimalProxyImplementation(code); ok {
+		result.Kind = KindMinimal
+		result.Implementation = implementation
+	}
+	return result, nil
+}
+
+// ResolveContract resolves the proxy pattern of a stored contract.
+func ResolveContract(tr transport.Transport, contract models.EVMContract) (*Proxy, error) {
+	if !common.IsHexAddress(contract.Address) {
+		return nil, errors.NewContractError(errors.ErrCodeInvalidAddress, fmt.Sprintf("contract %s has no valid address", contract.Name))
+	}
+	return Resolve(tr, common.HexToAddress(contract.Address))
+}
+
+// readAddress reads a storage slot holding an address in its lower 20 bytes.
+func readAddress(tr transport.Transport, address common.Address, slot common.Hash) (common.Address, error) {
+	value, err := tr.GetStorageAt(address, slot)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(value.Bytes()), nil
+}
+
+// isUUPS reports whether the implementation is UUPS upgradeable, i.e. proxiableUUID returns the
+// EIP-1967 implementation slot. Implementations without the function revert and are not UUPS.
+func isUUPS(tr transport.Transport, implementation common.Address) bool {
+	values, err := call(tr, implementation, "proxiableUUID")
+	if err != nil {
+		return false
+	}
+	uuid, ok := values[0].([32]byte)
+	return ok && common.Hash(uuid) == ImplementationSlot
+}
+
+func callAddress(tr transport.Transport, address common.Address, functionName string) (common.Address, error) {
+	values, err := call(tr, address, functionName)
+	if err != nil {
+		return common.Address{}, err
+	}
+	result, ok := values[0].(common.Address)
+	if !ok {
+		return common.Address{}, errors.NewABIError(errors.ErrCodeABIUnpackFailed, fmt.Sprintf("%s did not return an address", functionName))
+	}
+	return result, nil
+}
+
+func call(tr transport.Transport, address common.Address, functionName string) ([]any, error) {
+	for _, element := range implementationABI {
+		if element.Name != functionName {
+			continue
+		}
+		contractABI := abi.ABI{}
+		contractABI.SetElements(abi.ABIArray{element})
+		data, err := tr.CallContract(address, contractABI, functionName)
+		if err != nil {
+			return nil, err
+		}
+		outputs, err := abi.Arguments(element.Outputs)
+		if err != nil {
+			return nil, err
+		}
+		values, err := outputs.Unpack(data)
+		if err != nil {
+			return nil, errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, fmt.Sprintf("failed to unpack %s result", functionName))
+		}
+		return values, nil
+	}
+	return nil, errors.NewABIError(errors.ErrCodeMethodNotFound, fmt.Sprintf("method %s not found", functionName))
+}
+
+// minimalProxyImplementation extracts the implementation from EIP-1167 clone bytecode.
+func minimalProxyImplementation(code []byte) (common.Address, bool) {
+	if len(code) != len(minimalProxyPrefix)+common.AddressLength+len(minimalProxySuffix) ||
+		!bytes.HasPrefix(code, minimalProxyPrefix) || !bytes.HasSuffix(code, minimalProxySuffix) {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(code[len(minimalProxyPrefix) : len(minimalProxyPrefix)+common.AddressLength]), true
+}
@@ -0,0 +1,182 @@
+package proxy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	proxyAddress          = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	implementationAddress = common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	adminAddress          = common.HexToAddress("0x00000000000000000000000000000000000000c3")
+	beaconAddress         = common.HexToAddress("0x00000000000000000000000000000000000000d4")
+)
+
+// fakeChain serves storage slots, code and the implementation()/proxiableUUID() calls.
+type fakeChain struct {
+	transport.Transport
+	storage map[common.Address]map[common.Hash]common.Hash
+	code    map[common.Address][]byte
+	returns map[string][]byte
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		storage: map[common.Address]map[common.Hash]common.Hash{},
+		code:    map[common.Address][]byte{},
+		returns: map[string][]byte{},
+	}
+}
+
+func (f *fakeChain) setSlot(address common.Address, slot common.Hash, value common.Address) {
+	if f.storage[address] == nil {
+		f.storage[address] = map[common.Hash]common.Hash{}
+	}
+	f.storage[address][slot] = common.BytesToHash(value.Bytes())
+}
+
+func (f *fakeChain) GetStorageAt(address common.Address, slot common.Hash) (common.Hash, error) {
+	return f.storage[address][slot], nil
+}
+
+func (f *fakeChain) GetCode(address common.Address) ([]byte, error) {
+	return f.code[address], nil
+}
+
+func (f *fakeChain) CallContract(address common.Address, _ abi.ABI, functionName string, _ ...any) ([]byte, error) {
+	data, ok := f.returns[address.Hex()+"."+functionName]
+	if !ok {
+		return nil, errors.NewTransportError(errors.ErrCodeCallReverted, fmt.Sprintf("%s reverted", functionName))
+	}
+	return data, nil
+}
+
+type ProxyTestSuite struct {
+	suite.Suite
+	chain *fakeChain
+}
+
+func TestProxyTestSuite(t *testing.T) {
+	suite.Run(t, new(ProxyTestSuite))
+}
+
+func (s *ProxyTestSuite) SetupTest() {
+	s.chain = newFakeChain()
+}
+
+func (s *ProxyTestSuite) TestTransparentProxy() {
+	s.chain.setSlot(proxyAddress, ImplementationSlot, implementationAddress)
+	s.chain.setSlot(proxyAddress, AdminSlot, adminAddress)
+
+	result, err := Resolve(s.chain, proxyAddress)
+	s.Require().NoError(err)
+	s.Equal(KindTransparent, result.Kind)
+	s.Equal(implementationAddress, result.Implementation)
+	s.Equal(adminAddress, result.Admin)
+	s.True(result.IsProxy())
+}
+
+func (s *ProxyTestSuite) TestUUPSProxy() {
+	s.chain.setSlot(proxyAddress, ImplementationSlot, implementationAddress)
+	s.chain.returns[implementationAddress.Hex()+".proxiableUUID"] = ImplementationSlot.Bytes()
+
+	result, err := Resolve(s.chain, proxyAddress)
+	s.Require().NoError(err)
+	s.Equal(KindUUPS, result.Kind)
+	s.Equal(implementationAddress, result.Implementation)
+}
+
+func (s *ProxyTestSuite) TestPlainEIP1967Proxy() {
+	s.chain.setSlot(proxyAddress, ImplementationSlot, implementationAddress)
+
+	result, err := Resolve(s.chain, proxyAddress)
+	s.Require().NoError(err)
+	s.Equal(KindEIP1967, result.Kind)
+}
+
+func (s *ProxyTestSuite) TestLegacyProxiableSlot() {
+	s.chain.setSlot(proxyAddress, ProxiableSlot, implementationAddress)
+
+	result, err := Resolve(s.chain, proxyAddress)
+	s.Require().NoError(err)
+	s.Equal(KindUUPS, result.Kind)
+	s.Equal(implementationAddress, result.Implementation)
+}
+
+func (s *ProxyTestSuite) TestBeaconProxy() {
+	s.chain.setSlot(proxyAddress, BeaconSlot, beaconAddress)
+	s.chain.returns[beaconAddress.Hex()+".implementation"] = common.LeftPadBytes(implementationAddress.Bytes(), 32)
+
+	result, err := Resolve(s.chain, proxyAddress)
+	s.Require().NoError(err)
+	s.Equal(KindBeacon, result.Kind)
+	s.Equal(beaconAddress, result.Beacon)
+	s.Equal(implementationAddress, result.Implementation)
+}
+
+func (s *ProxyTestSuite) TestBeaconWithoutImplementationFails() {
+	s.chain.setSlot(proxyAddress, BeaconSlot, beaconAddress)
+
+	_, err := Resolve(s.chain, proxyAddress)
+	s.True(errors.HasCode(err, errors.ErrCodeProxyResolveFailed))
+}
+
+func (s *ProxyTestSuite) TestMinimalProxy() {
+	code := append(append(append([]byte{}, minimalProxyPrefix...), implementationAddress.Bytes()...), minimalProxySuffix...)
+	s.chain.code[proxyAddress] = code
+
+	result, err := Resolve(s.chain, proxyAddress)
+	s.Require().NoError(err)
+	s.Equal(KindMinimal, result.Kind)
+	s.Equal(implementationAddress, result.Implementation)
+	s.Empty(result.ABI())
+}
+
+func (s *ProxyTestSuite) TestNotAProxy() {
+	s.chain.code[proxyAddress] = common.FromHex("0x6080604052")
+
+	result, err := Resolve(s.chain, proxyAddress)
+	s.Require().NoError(err)
+	s.False(result.IsProxy())
+	s.Equal("Not a proxy", result.Description())
+}
+
+func (s *ProxyTestSuite) TestResolveContractRequiresAddress() {
+	_, err := ResolveContract(s.chain, models.EVMContract{Name: "Pending"})
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidAddress))
+}
+
+func (s *ProxyTestSuite) TestMergeABIs() {
+	proxyABI, err := abi.ParseHumanReadable([]string{
+		"constructor(address logic, bytes data)",
+		"function upgradeToAndCall(address implementation, bytes data) payable",
+		"function balanceOf(address account) view returns (uint256)",
+	})
+	s.Require().NoError(err)
+	implementationABI, err := abi.ParseHumanReadable([]string{
+		"constructor()",
+		"function balanceOf(address owner) view returns (uint256 balance)",
+		"function transfer(address to, uint256 amount) returns (bool)",
+	})
+	s.Require().NoError(err)
+
+	result := &Proxy{Kind: KindUUPS}
+	merged := MergeABIs(append(proxyABI, result.ABI()...), implementationABI)
+
+	s.Equal([]string{
+		"function balanceOf(address owner) view returns (uint256 balance)",
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"constructor(address logic, bytes data)",
+		"function upgradeToAndCall(address implementation, bytes data) payable",
+		"event Upgraded(address indexed implementation)",
+		"event AdminChanged(address previousAdmin, address newAdmin)",
+		"event BeaconUpgraded(address indexed beacon)",
+	}, abi.FormatHumanReadable(merged))
+}
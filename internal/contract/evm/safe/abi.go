@@ -0,0 +1,66 @@
+package safe
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	customabi "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// safeABIJSON contains the subset of the Safe (GnosisSafe) ABI used to
+// inspect a Safe, hash its transactions and execute them.
+const safeABIJSON = `[
+	{"type":"function","name":"VERSION","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"getOwners","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address[]"}]},
+	{"type":"function","name":"getThreshold","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"nonce","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getTransactionHash","stateMutability":"view","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},{"name":"_nonce","type":"uint256"}],"outputs":[{"name":"","type":"bytes32"}]},
+	{"type":"function","name":"execTransaction","stateMutability":"payable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},{"name":"signatures","type":"bytes"}],"outputs":[{"name":"success","type":"bool"}]}
+]`
+
+// contractABI holds both the repository ABI representation (used by the
+// transport and signer) and the go-ethereum ABI (used for decoding).
+type contractABI struct {
+	custom customabi.ABI
+	eth    ethabi.ABI
+}
+
+var safeABI = mustParseABI(safeABIJSON)
+
+func mustParseABI(abiJSON string) contractABI {
+	var custom customabi.ABI
+	if err := json.Unmarshal([]byte(abiJSON), &custom); err != nil {
+		panic(fmt.Sprintf("invalid built-in Safe ABI: %v", err))
+	}
+
+	eth, err := ethabi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in Safe ABI: %v", err))
+	}
+
+	return contractABI{custom: custom, eth: eth}
+}
+
+// call performs a read-only call and decodes the single return value.
+func (c contractABI) call(tr transport.Transport, address common.Address, method string, args ...any) (any, error) {
+	raw, err := tr.CallContract(address, c.custom, method, args...)
+	if err != nil {
+		return nil, errors.WrapSafeError(err, errors.ErrCodeSafeQueryFailed, fmt.Sprintf("failed to call %s on Safe %s", method, address.Hex()))
+	}
+
+	values, err := c.eth.Unpack(method, raw)
+	if err != nil {
+		return nil, errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, fmt.Sprintf("failed to unpack result of %s", method))
+	}
+
+	if len(values) == 0 {
+		return nil, errors.NewABIError(errors.ErrCodeABIUnpackFailed, fmt.Sprintf("%s returned no values", method))
+	}
+
+	return values[0], nil
+}
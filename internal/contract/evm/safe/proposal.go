@@ -0,0 +1,106 @@
+package safe
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Propose builds a call from the Safe at its current nonce, checks the SafeTx hash against the Safe and
+// returns an unsaved proposal. When proposer is an owner, its signature is added to the proposal.
+func Propose(tr transport.Transport, proposer signer.AccountSigner, safeAddress common.Address, endpointID uint, to common.Address, value *big.Int, data []byte, description string) (models.SafeProposal, error) {
+	safe, err := Load(tr, safeAddress)
+	if err != nil {
+		return models.SafeProposal{}, err
+	}
+
+	tx := NewTransaction(to, value, data, safe.Nonce)
+	hash, err := safe.CheckTransactionHash(tr, tx)
+	if err != nil {
+		return models.SafeProposal{}, err
+	}
+
+	proposal := NewProposal(safe, endpointID, tx, hash, description)
+	if proposer != nil && safe.IsOwner(proposer.GetAddress()) {
+		signature, err := safe.Sign(proposer, tx)
+		if err != nil {
+			return models.SafeProposal{}, err
+		}
+		proposal.Signatures = []models.SafeSignature{{Owner: proposer.GetAddress().Hex(), Signature: signature}}
+	}
+	return proposal, nil
+}
+
+// NewProposal creates the pending proposal record of a transaction of the Safe.
+func NewProposal(safe *Safe, endpointID uint, tx Transaction, hash common.Hash, description string) models.SafeProposal {
+	return models.SafeProposal{
+		SafeAddress:    safe.Address.Hex(),
+		ChainID:        safe.ChainID.String(),
+		Version:        safe.Version,
+		Description:    description,
+		To:             tx.To.Hex(),
+		Value:          bigString(tx.Value),
+		Data:           hexutil.Encode(tx.Data),
+		Operation:      uint8(tx.Operation),
+		SafeTxGas:      bigString(tx.SafeTxGas),
+		BaseGas:        bigString(tx.BaseGas),
+		GasPrice:       bigString(tx.GasPrice),
+		GasToken:       tx.GasToken.Hex(),
+		RefundReceiver: tx.RefundReceiver.Hex(),
+		Nonce:          bigOrZero(tx.Nonce).Uint64(),
+		SafeTxHash:     hash.Hex(),
+		Status:         models.SafeProposalStatusPending,
+		EndpointId:     endpointID,
+	}
+}
+
+// TransactionFromProposal rebuilds the SafeTx of a stored proposal.
+func TransactionFromProposal(proposal models.SafeProposal) (Transaction, error) {
+	data, err := hexutil.Decode(proposal.Data)
+	if err != nil {
+		return Transaction{}, errors.WrapABIError(err, errors.ErrCodeInvalidCalldata, "invalid proposal data")
+	}
+
+	amounts := map[string]string{
+		"value":     proposal.Value,
+		"safeTxGas": proposal.SafeTxGas,
+		"baseGas":   proposal.BaseGas,
+		"gasPrice":  proposal.GasPrice,
+	}
+	parsed := map[string]*big.Int{}
+	for name, amount := range amounts {
+		value, ok := new(big.Int).SetString(amount, 10)
+		if !ok {
+			return Transaction{}, errors.NewSafeError(errors.ErrCodeInvalidTransaction, fmt.Sprintf("invalid proposal %s %q", name, amount))
+		}
+		parsed[name] = value
+	}
+
+	return Transaction{
+		To:             common.HexToAddress(proposal.To),
+		Value:          parsed["value"],
+		Data:           data,
+		Operation:      Operation(proposal.Operation),
+		SafeTxGas:      parsed["safeTxGas"],
+		BaseGas:        parsed["baseGas"],
+		GasPrice:       parsed["gasPrice"],
+		GasToken:       common.HexToAddress(proposal.GasToken),
+		RefundReceiver: common.HexToAddress(proposal.RefundReceiver),
+		Nonce:          new(big.Int).SetUint64(proposal.Nonce),
+	}, nil
+}
+
+// SignaturesOf returns the stored signatures of a proposal by owner.
+func SignaturesOf(proposal models.SafeProposal) map[common.Address]string {
+	signatures := make(map[common.Address]string, len(proposal.Signatures))
+	for _, signature := range proposal.Signatures {
+		signatures[common.HexToAddress(signature.Owner)] = signature.Signature
+	}
+	return signatures
+}
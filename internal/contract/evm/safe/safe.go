@@ -0,0 +1,236 @@
+package safe
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Safe is the on-chain state of a Safe multisig needed to hash, sign and execute its transactions.
+type Safe struct {
+	Address   common.Address
+	ChainID   *big.Int
+	Version   string
+	Owners    []common.Address
+	Threshold uint64
+	Nonce     *big.Int
+}
+
+// Load reads the version, owners, threshold and nonce of the Safe deployed at the address.
+func Load(tr transport.Transport, address common.Address) (*Safe, error) {
+	code, err := tr.GetCode(address)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, errors.NewSafeError(errors.ErrCodeSafeQueryFailed, fmt.Sprintf("no contract deployed at %s", address.Hex()))
+	}
+
+	chainID, err := tr.GetChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := safeABI.call(tr, address, "VERSION")
+	if err != nil {
+		return nil, err
+	}
+	owners, err := safeABI.call(tr, address, "getOwners")
+	if err != nil {
+		return nil, err
+	}
+	threshold, err := safeABI.call(tr, address, "getThreshold")
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := safeABI.call(tr, address, "nonce")
+	if err != nil {
+		return nil, err
+	}
+
+	safe := &Safe{Address: address, ChainID: chainID}
+	var ok bool
+	if safe.Version, ok = version.(string); !ok {
+		return nil, errors.NewSafeError(errors.ErrCodeSafeQueryFailed, "unexpected VERSION result")
+	}
+	if safe.Owners, ok = owners.([]common.Address); !ok {
+		return nil, errors.NewSafeError(errors.ErrCodeSafeQueryFailed, "unexpected getOwners result")
+	}
+	thresholdValue, ok := threshold.(*big.Int)
+	if !ok || !thresholdValue.IsUint64() {
+		return nil, errors.NewSafeError(errors.ErrCodeSafeQueryFailed, "unexpected getThreshold result")
+	}
+	safe.Threshold = thresholdValue.Uint64()
+	if safe.Nonce, ok = nonce.(*big.Int); !ok {
+		return nil, errors.NewSafeError(errors.ErrCodeSafeQueryFailed, "unexpected nonce result")
+	}
+
+	return safe, nil
+}
+
+// IsOwner reports whether the address is an owner of the Safe.
+func (s *Safe) IsOwner(address common.Address) bool {
+	for _, owner := range s.Owners {
+		if owner == address {
+			return true
+		}
+	}
+	return false
+}
+
+// TransactionHash returns the SafeTx hash of the transaction for this Safe.
+func (s *Safe) TransactionHash(tx Transaction) (common.Hash, error) {
+	return tx.Hash(s.ChainID, s.Address, s.Version)
+}
+
+// CheckTransactionHash computes the SafeTx hash locally and checks it against getTransactionHash of the
+// Safe, so signatures are never collected over a hash the contract would not accept.
+func (s *Safe) CheckTransactionHash(tr transport.Transport, tx Transaction) (common.Hash, error) {
+	hash, err := s.TransactionHash(tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	onChain, err := safeABI.call(tr, s.Address, "getTransactionHash",
+		tx.To, bigOrZero(tx.Value), tx.Data, uint8(tx.Operation), bigOrZero(tx.SafeTxGas), bigOrZero(tx.BaseGas),
+		bigOrZero(tx.GasPrice), tx.GasToken, tx.RefundReceiver, bigOrZero(tx.Nonce))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	onChainHash, ok := onChain.([32]byte)
+	if !ok {
+		return common.Hash{}, errors.NewSafeError(errors.ErrCodeSafeQueryFailed, "unexpected getTransactionHash result")
+	}
+	if common.Hash(onChainHash) != hash {
+		return common.Hash{}, errors.NewSafeError(errors.ErrCodeSafeHashMismatch,
+			fmt.Sprintf("SafeTx hash %s does not match the hash %s of Safe %s", hash.Hex(), common.Hash(onChainHash).Hex(), s.Version))
+	}
+	return hash, nil
+}
+
+// Sign signs the SafeTx hash as EIP-712 typed data with the account, which must be an owner of the Safe.
+func (s *Safe) Sign(accountSigner signer.AccountSigner, tx Transaction) (signature string, err error) {
+	owner := accountSigner.GetAddress()
+	if !s.IsOwner(owner) {
+		return "", errors.NewSafeError(errors.ErrCodeSafeNotOwner, fmt.Sprintf("%s is not an owner of Safe %s", owner.Hex(), s.Address.Hex()))
+	}
+
+	typedData, err := tx.TypedDataJSON(s.ChainID, s.Address, s.Version)
+	if err != nil {
+		return "", err
+	}
+	signature, err = accountSigner.SignTypedData(typedData)
+	if err != nil {
+		return "", err
+	}
+	if err := s.VerifySignature(tx, owner, signature); err != nil {
+		return "", err
+	}
+	return signature, nil
+}
+
+// VerifySignature checks that the signature over the SafeTx hash was made by the owner.
+func (s *Safe) VerifySignature(tx Transaction, owner common.Address, signature string) error {
+	hash, err := s.TransactionHash(tx)
+	if err != nil {
+		return err
+	}
+	recovered, err := signer.RecoverAddress(hash, signature)
+	if err != nil {
+		return err
+	}
+	if recovered != owner {
+		return errors.NewSignerError(errors.ErrCodeInvalidSignature, fmt.Sprintf("signature was made by %s, not by owner %s", recovered.Hex(), owner.Hex()))
+	}
+	return nil
+}
+
+// ValidSignatures returns the signatures made by current owners of the Safe over the SafeTx hash.
+func (s *Safe) ValidSignatures(tx Transaction, signatures map[common.Address]string) map[common.Address]string {
+	valid := map[common.Address]string{}
+	for owner, signature := range signatures {
+		if s.IsOwner(owner) && s.VerifySignature(tx, owner, signature) == nil {
+			valid[owner] = signature
+		}
+	}
+	return valid
+}
+
+// Execute sends execTransaction with the owner signatures once the threshold is met and returns the
+// transaction hash. Any account can execute, it does not have to be an owner.
+func (s *Safe) Execute(sgn signer.SignerWithTransport, tx Transaction, signatures map[common.Address]string) (txHash string, err error) {
+	if tx.Nonce == nil || tx.Nonce.Cmp(s.Nonce) != 0 {
+		return "", errors.NewSafeError(errors.ErrCodeSafeNonceMismatch, fmt.Sprintf("transaction nonce %s does not match Safe nonce %s", bigOrZero(tx.Nonce), s.Nonce))
+	}
+
+	valid := s.ValidSignatures(tx, signatures)
+	if uint64(len(valid)) < s.Threshold {
+		return "", errors.NewSafeError(errors.ErrCodeSafeThresholdNotMet, fmt.Sprintf("%d of %d required owner signatures", len(valid), s.Threshold))
+	}
+
+	packed, err := EncodeSignatures(valid)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := sgn.CallContractMethod(s.Address, safeABI.custom, "execTransaction", nil, 0, nil,
+		tx.To, bigOrZero(tx.Value), tx.Data, uint8(tx.Operation), bigOrZero(tx.SafeTxGas), bigOrZero(tx.BaseGas),
+		bigOrZero(tx.GasPrice), tx.GasToken, tx.RefundReceiver, packed)
+	if err != nil {
+		return "", errors.WrapSafeError(err, errors.ErrCodeSafeExecutionFailed, "failed to execute Safe transaction")
+	}
+	if len(result) != 2 {
+		return "", errors.NewSafeError(errors.ErrCodeSafeExecutionFailed, "unexpected transaction result")
+	}
+
+	status, _ := result[0].(uint64)
+	txHash, _ = result[1].(string)
+	if status != types.ReceiptStatusSuccessful {
+		return txHash, errors.NewSafeError(errors.ErrCodeSafeExecutionFailed, fmt.Sprintf("execTransaction %s reverted", txHash))
+	}
+	return txHash, nil
+}
+
+// EncodeSignatures concatenates the 65 byte ECDSA signatures sorted by owner address, the order
+// checkSignatures of the Safe requires. Recovery ids are normalized to 27/28.
+func EncodeSignatures(signatures map[common.Address]string) ([]byte, error) {
+	owners := make([]common.Address, 0, len(signatures))
+	for owner := range signatures {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		return bytes.Compare(owners[i].Bytes(), owners[j].Bytes()) < 0
+	})
+
+	packed := make([]byte, 0, len(owners)*65)
+	for _, owner := range owners {
+		signature, err := hexutil.Decode(signatures[owner])
+		if err != nil {
+			return nil, errors.WrapSignerError(err, errors.ErrCodeSignatureDecode, fmt.Sprintf("failed to decode signature of %s", owner.Hex()))
+		}
+		if len(signature) != 65 {
+			return nil, errors.NewSignerErrorWithDetails(errors.ErrCodeInvalidSignatureLength, "invalid signature length",
+				fmt.Sprintf("expected 65 bytes, got %d", len(signature)))
+		}
+		if signature[64] < 27 {
+			signature[64] += 27
+		}
+		packed = append(packed, signature...)
+	}
+	return packed, nil
+}
+
+func bigOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value
+}
@@ -0,0 +1,359 @@
+package safe
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	customabi "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	// Anvil default test accounts 0 to 2.
+	ownerKey0 = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	ownerKey1 = "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+	ownerKey2 = "5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a"
+	// outsiderKey is anvil test account 3, which is not an owner.
+	outsiderKey = "7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+
+	// safeTxTypeHash is SAFE_TX_TYPEHASH of the Safe contracts.
+	safeTxTypeHash = "0xbb8310d486368db6bd6f849402fdd73ad53d316b5a4b2644ad6efe0f941286d8"
+	// domainTypeHash is DOMAIN_SEPARATOR_TYPEHASH of Safe 1.3.0 and later.
+	domainTypeHash = "0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218"
+	// legacyDomainTypeHash is DOMAIN_SEPARATOR_TYPEHASH of Safes before 1.3.0.
+	legacyDomainTypeHash = "0x035aff83d86937d35b32e04f0ddc6ff469290eef2f1b692d8a815c89404d4749"
+)
+
+var (
+	safeAddress   = common.HexToAddress("0x5afe5afE5afE5afE5afE5aFe5aFe5Afe5Afe5AfE")
+	targetAddress = common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+	testChainID   = big.NewInt(31337)
+)
+
+type callHandler func(args []any) ([]any, error)
+
+// fakeTransport answers Safe calls from per-method handlers, so Safe logic can
+// be tested without a running node.
+type fakeTransport struct {
+	transport.Transport
+	calls map[string]callHandler
+}
+
+func (f *fakeTransport) CallContract(_ common.Address, customABI customabi.ABI, functionName string, args ...any) ([]byte, error) {
+	handler, ok := f.calls[functionName]
+	if !ok {
+		return nil, fmt.Errorf("execution reverted")
+	}
+
+	values, err := handler(args)
+	if err != nil {
+		return nil, err
+	}
+
+	abiJSON, err := customABI.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	ethABI, err := ethabi.JSON(strings.NewReader(string(abiJSON)))
+	if err != nil {
+		return nil, err
+	}
+	return ethABI.Methods[functionName].Outputs.Pack(values...)
+}
+
+func (f *fakeTransport) GetChainID() (*big.Int, error) {
+	return testChainID, nil
+}
+
+func (f *fakeTransport) GetCode(common.Address) ([]byte, error) {
+	if len(f.calls) == 0 {
+		return nil, nil
+	}
+	return []byte{0x60, 0x80}, nil
+}
+
+func (f *fakeTransport) CallContractWithOptions(address common.Address, customABI customabi.ABI, functionName string, _ transport.CallOptions, args ...any) ([]byte, error) {
+	return f.CallContract(address, customABI, functionName, args...)
+}
+
+var _ transport.Transport = (*fakeTransport)(nil)
+
+// fakeSigner records the contract method invoked through CallContractMethod.
+type fakeSigner struct {
+	signer.SignerWithTransport
+	status uint64
+	method string
+	args   []any
+}
+
+func (f *fakeSigner) CallContractMethod(_ common.Address, _ customabi.ABI, methodName string, _ *big.Int, _ uint64, _ *big.Int, args ...any) ([]any, error) {
+	f.method = methodName
+	f.args = args
+	return []any{f.status, "0xabc"}, nil
+}
+
+var _ signer.SignerWithTransport = (*fakeSigner)(nil)
+
+type SafeTestSuite struct {
+	suite.Suite
+	owners    []*signer.PrivateKeySigner
+	outsider  *signer.PrivateKeySigner
+	transport *fakeTransport
+}
+
+func TestSafeTestSuite(t *testing.T) {
+	suite.Run(t, new(SafeTestSuite))
+}
+
+func (s *SafeTestSuite) SetupTest() {
+	s.owners = nil
+	for _, key := range []string{ownerKey0, ownerKey1, ownerKey2} {
+		s.owners = append(s.owners, s.newSigner(key))
+	}
+	s.outsider = s.newSigner(outsiderKey)
+
+	s.transport = &fakeTransport{calls: map[string]callHandler{
+		"VERSION":      func([]any) ([]any, error) { return []any{"1.4.1"}, nil },
+		"getOwners":    func([]any) ([]any, error) { return []any{s.ownerAddresses()}, nil },
+		"getThreshold": func([]any) ([]any, error) { return []any{big.NewInt(2)}, nil },
+		"nonce":        func([]any) ([]any, error) { return []any{big.NewInt(7)}, nil },
+		"getTransactionHash": func(args []any) ([]any, error) {
+			tx := Transaction{
+				To: args[0].(common.Address), Value: args[1].(*big.Int), Data: args[2].([]byte), Operation: Operation(args[3].(uint8)),
+				SafeTxGas: args[4].(*big.Int), BaseGas: args[5].(*big.Int), GasPrice: args[6].(*big.Int),
+				GasToken: args[7].(common.Address), RefundReceiver: args[8].(common.Address), Nonce: args[9].(*big.Int),
+			}
+			return []any{s.manualHash(tx, "1.4.1")}, nil
+		},
+	}}
+}
+
+func (s *SafeTestSuite) newSigner(key string) *signer.PrivateKeySigner {
+	baseSigner, err := signer.NewPrivateKeySigner(key)
+	s.Require().NoError(err)
+	return baseSigner.(*signer.PrivateKeySigner)
+}
+
+func (s *SafeTestSuite) ownerAddresses() []common.Address {
+	addresses := []common.Address{}
+	for _, owner := range s.owners {
+		addresses = append(addresses, owner.GetAddress())
+	}
+	return addresses
+}
+
+func (s *SafeTestSuite) load() *Safe {
+	safe, err := Load(s.transport, safeAddress)
+	s.Require().NoError(err)
+	return safe
+}
+
+func (s *SafeTestSuite) transaction() Transaction {
+	return NewTransaction(targetAddress, big.NewInt(1000), hexutil.MustDecode("0x55241077000000000000000000000000000000000000000000000000000000000000002a"), big.NewInt(7))
+}
+
+// manualHash encodes the SafeTx hash the way the Safe contracts do, independently of the typed data encoder.
+func (s *SafeTestSuite) manualHash(tx Transaction, version string) [32]byte {
+	word := func(value *big.Int) []byte { return common.LeftPadBytes(value.Bytes(), 32) }
+	address := func(value common.Address) []byte { return common.LeftPadBytes(value.Bytes(), 32) }
+
+	structHash := crypto.Keccak256(
+		common.HexToHash(safeTxTypeHash).Bytes(),
+		address(tx.To), word(tx.Value), crypto.Keccak256(tx.Data), word(big.NewInt(int64(tx.Operation))),
+		word(tx.SafeTxGas), word(tx.BaseGas), word(tx.GasPrice), address(tx.GasToken), address(tx.RefundReceiver), word(tx.Nonce),
+	)
+	domainSeparator := crypto.Keccak256(common.HexToHash(domainTypeHash).Bytes(), word(testChainID), address(safeAddress))
+	if version == "1.1.1" {
+		domainSeparator = crypto.Keccak256(common.HexToHash(legacyDomainTypeHash).Bytes(), address(safeAddress))
+	}
+	return [32]byte(crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash))
+}
+
+func (s *SafeTestSuite) TestHashMatchesSafeEncoding() {
+	tx := s.transaction()
+
+	hash, err := tx.Hash(testChainID, safeAddress, "1.4.1")
+	s.Require().NoError(err)
+	s.Equal(common.Hash(s.manualHash(tx, "1.4.1")), hash)
+
+	hash, err = tx.Hash(testChainID, safeAddress, "1.3.0+L2")
+	s.Require().NoError(err)
+	s.Equal(common.Hash(s.manualHash(tx, "1.3.0")), hash)
+}
+
+func (s *SafeTestSuite) TestHashOfLegacySafeOmitsChainID() {
+	tx := s.transaction()
+
+	hash, err := tx.Hash(testChainID, safeAddress, "1.1.1")
+	s.Require().NoError(err)
+	s.Equal(common.Hash(s.manualHash(tx, "1.1.1")), hash)
+}
+
+func (s *SafeTestSuite) TestLoad() {
+	safe := s.load()
+
+	s.Equal("1.4.1", safe.Version)
+	s.Equal(s.ownerAddresses(), safe.Owners)
+	s.Equal(uint64(2), safe.Threshold)
+	s.Equal(int64(7), safe.Nonce.Int64())
+	s.Equal(testChainID, safe.ChainID)
+	s.True(safe.IsOwner(s.owners[1].GetAddress()))
+	s.False(safe.IsOwner(s.outsider.GetAddress()))
+}
+
+func (s *SafeTestSuite) TestLoadWithoutContract() {
+	_, err := Load(&fakeTransport{}, safeAddress)
+	s.Require().Error(err)
+	s.True(errors.HasCode(err, errors.ErrCodeSafeQueryFailed))
+}
+
+func (s *SafeTestSuite) TestCheckTransactionHash() {
+	safe := s.load()
+	tx := s.transaction()
+
+	hash, err := safe.CheckTransactionHash(s.transport, tx)
+	s.Require().NoError(err)
+	s.Equal(common.Hash(s.manualHash(tx, "1.4.1")), hash)
+
+	safe.Version = "1.1.1"
+	_, err = safe.CheckTransactionHash(s.transport, tx)
+	s.Require().Error(err)
+	s.True(errors.HasCode(err, errors.ErrCodeSafeHashMismatch))
+}
+
+func (s *SafeTestSuite) TestSign() {
+	safe := s.load()
+	tx := s.transaction()
+
+	signature, err := safe.Sign(s.owners[1], tx)
+	s.Require().NoError(err)
+	s.NoError(safe.VerifySignature(tx, s.owners[1].GetAddress(), signature))
+	s.Error(safe.VerifySignature(tx, s.owners[0].GetAddress(), signature))
+
+	_, err = safe.Sign(s.outsider, tx)
+	s.Require().Error(err)
+	s.True(errors.HasCode(err, errors.ErrCodeSafeNotOwner))
+}
+
+func (s *SafeTestSuite) TestEncodeSignaturesSortsByOwner() {
+	signatures := map[common.Address]string{}
+	for _, owner := range s.owners {
+		signature := make([]byte, 65)
+		copy(signature, owner.GetAddress().Bytes())
+		signatures[owner.GetAddress()] = hexutil.Encode(signature)
+	}
+
+	packed, err := EncodeSignatures(signatures)
+	s.Require().NoError(err)
+	s.Require().Len(packed, 3*65)
+
+	// 0x3C44… < 0x7099… < 0xf39F…
+	for position, owner := range []*signer.PrivateKeySigner{s.owners[2], s.owners[1], s.owners[0]} {
+		chunk := packed[position*65 : (position+1)*65]
+		s.Equal(owner.GetAddress().Bytes(), chunk[:20])
+		s.Equal(byte(27), chunk[64], "recovery id is normalized")
+	}
+
+	_, err = EncodeSignatures(map[common.Address]string{s.owners[0].GetAddress(): "0x1234"})
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidSignatureLength))
+}
+
+func (s *SafeTestSuite) TestExecuteRequiresThreshold() {
+	safe := s.load()
+	tx := s.transaction()
+	sender := &fakeSigner{status: types.ReceiptStatusSuccessful}
+
+	signature, err := safe.Sign(s.owners[0], tx)
+	s.Require().NoError(err)
+	outsiderSignature, err := s.outsider.SignTypedData(s.typedData(tx))
+	s.Require().NoError(err)
+
+	_, err = safe.Execute(sender, tx, map[common.Address]string{
+		s.owners[0].GetAddress(): signature,
+		s.outsider.GetAddress():  outsiderSignature,
+	})
+	s.Require().Error(err)
+	s.True(errors.HasCode(err, errors.ErrCodeSafeThresholdNotMet))
+	s.Empty(sender.method)
+}
+
+func (s *SafeTestSuite) TestExecuteRequiresCurrentNonce() {
+	safe := s.load()
+	tx := s.transaction()
+	tx.Nonce = big.NewInt(6)
+
+	_, err := safe.Execute(&fakeSigner{}, tx, nil)
+	s.Require().Error(err)
+	s.True(errors.HasCode(err, errors.ErrCodeSafeNonceMismatch))
+}
+
+func (s *SafeTestSuite) TestExecute() {
+	safe := s.load()
+	tx := s.transaction()
+	signatures := map[common.Address]string{}
+	for _, owner := range []*signer.PrivateKeySigner{s.owners[0], s.owners[2]} {
+		signature, err := safe.Sign(owner, tx)
+		s.Require().NoError(err)
+		signatures[owner.GetAddress()] = signature
+	}
+
+	sender := &fakeSigner{status: types.ReceiptStatusSuccessful}
+	txHash, err := safe.Execute(sender, tx, signatures)
+	s.Require().NoError(err)
+	s.Equal("0xabc", txHash)
+	s.Equal("execTransaction", sender.method)
+	s.Require().Len(sender.args, 10)
+	s.Equal(targetAddress, sender.args[0])
+	s.Equal(tx.Data, sender.args[2])
+	s.Equal(uint8(0), sender.args[3])
+
+	packed := sender.args[9].([]byte)
+	s.Require().Len(packed, 2*65)
+	s.Equal(signatures[s.owners[2].GetAddress()], hexutil.Encode(packed[:65]))
+	s.Equal(signatures[s.owners[0].GetAddress()], hexutil.Encode(packed[65:]))
+
+	sender.status = types.ReceiptStatusFailed
+	_, err = safe.Execute(sender, tx, signatures)
+	s.True(errors.HasCode(err, errors.ErrCodeSafeExecutionFailed))
+}
+
+func (s *SafeTestSuite) TestProposeAndRestore() {
+	tx := s.transaction()
+
+	proposal, err := Propose(s.transport, s.owners[1], safeAddress, 3, tx.To, tx.Value, tx.Data, "setValue(uint256 newValue)")
+	s.Require().NoError(err)
+	s.Equal(safeAddress.Hex(), proposal.SafeAddress)
+	s.Equal("31337", proposal.ChainID)
+	s.Equal(uint64(7), proposal.Nonce)
+	s.Equal(uint(3), proposal.EndpointId)
+	s.Equal(common.Hash(s.manualHash(tx, "1.4.1")).Hex(), proposal.SafeTxHash)
+	s.Require().Len(proposal.Signatures, 1)
+	s.Equal(s.owners[1].GetAddress().Hex(), proposal.Signatures[0].Owner)
+
+	restored, err := TransactionFromProposal(proposal)
+	s.Require().NoError(err)
+	s.Equal(tx, restored)
+
+	signatures := SignaturesOf(proposal)
+	s.NoError(s.load().VerifySignature(restored, s.owners[1].GetAddress(), signatures[s.owners[1].GetAddress()]))
+
+	proposal, err = Propose(s.transport, s.outsider, safeAddress, 3, tx.To, tx.Value, tx.Data, "")
+	s.Require().NoError(err)
+	s.Empty(proposal.Signatures, "only owners sign when proposing")
+}
+
+func (s *SafeTestSuite) typedData(tx Transaction) string {
+	typedData, err := tx.TypedDataJSON(testChainID, safeAddress, "1.4.1")
+	s.Require().NoError(err)
+	return typedData
+}
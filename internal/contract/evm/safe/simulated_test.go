@@ -0,0 +1,199 @@
+package safe
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	customabi "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/compiler"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+// The Safe contracts are deployed from their creation bytecode in testdata:
+//   - GnosisSafe_v1.3.0.bin is the published Safe 1.3.0 singleton, the creation code of the canonical
+//     deployment whose runtime code hash is 0xbba688fbdb21ad2bb58bc320638b43d94e7d100f6f3ebaab0a4e4de6304b1c2e.
+//   - SafeProxyFactory_v1.4.0.bin is the SafeProxyFactory of the safe-contracts v1.4.0 sources.
+//
+// Owners use a proxy created by the factory, as with a Safe created in the Safe app.
+var (
+	safeSingletonABI = parseHumanReadable(
+		"function setup(address[] owners, uint256 threshold, address to, bytes data, address fallbackHandler, address paymentToken, uint256 payment, address paymentReceiver)",
+	)
+	proxyFactoryABI = parseHumanReadable(
+		"function createProxyWithNonce(address singleton, bytes initializer, uint256 saltNonce) returns (address proxy)",
+		"event ProxyCreation(address indexed proxy, address singleton)",
+	)
+)
+
+// safeOwnedSource is the contract the Safe calls, it only accepts calls from its owner.
+const safeOwnedSource = `
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract SafeOwned {
+    address public owner;
+    uint256 public value;
+
+    constructor(address _owner) {
+        owner = _owner;
+    }
+
+    function setValue(uint256 newValue) public {
+        require(msg.sender == owner, "not owner");
+        value = newValue;
+    }
+}
+`
+
+func parseHumanReadable(signatures ...string) customabi.ABI {
+	elements, err := customabi.ParseHumanReadable(signatures)
+	if err != nil {
+		panic(err)
+	}
+	contractABI := customabi.ABI{}
+	contractABI.SetElements(customabi.ABIArray(elements))
+	return contractABI
+}
+
+func readBytecode(name string) ([]byte, error) {
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Decode("0x" + strings.TrimSpace(string(raw)))
+}
+
+// SimulatedSafeTestSuite proposes, signs and executes Safe transactions on an in-process simulated chain.
+type SimulatedSafeTestSuite struct {
+	suite.Suite
+	transport    *transport.SimulatedTransport
+	owners       []*signer.PrivateKeySigner
+	executor     signer.SignerWithTransport
+	safeAddress  common.Address
+	targetAddr   common.Address
+	targetABI    customabi.ABI
+	setValueData []byte
+}
+
+func TestSimulatedSafeTestSuite(t *testing.T) {
+	suite.Run(t, new(SimulatedSafeTestSuite))
+}
+
+func (s *SimulatedSafeTestSuite) SetupSuite() {
+	result, err := compiler.Compile(safeOwnedSource, compiler.Options{FileName: "SafeOwned.sol"})
+	s.Require().NoError(err)
+	ownedContract, err := result.Contract("SafeOwned.sol", "SafeOwned")
+	s.Require().NoError(err)
+
+	tr, err := transport.NewSimulatedTransport(nil)
+	s.Require().NoError(err)
+	s.transport = tr
+
+	for _, key := range []string{ownerKey0, ownerKey1, ownerKey2} {
+		baseSigner, err := signer.NewPrivateKeySigner(key)
+		s.Require().NoError(err)
+		s.owners = append(s.owners, baseSigner.(*signer.PrivateKeySigner))
+	}
+	s.executor = s.owners[0].WithTransport(tr)
+
+	owners := []common.Address{}
+	for _, owner := range s.owners {
+		owners = append(owners, owner.GetAddress())
+	}
+	s.safeAddress = s.createSafe(owners, big.NewInt(2))
+
+	s.targetABI = customabi.ABI{}
+	s.targetABI.SetElements(customabi.ABIArray(ownedContract.ABI))
+	s.targetAddr, _, err = s.executor.DeployContract(s.targetABI, hexutil.MustDecode(ownedContract.Bytecode), nil, 0, nil, s.safeAddress)
+	s.Require().NoError(err)
+
+	// setValue(42)
+	s.setValueData = hexutil.MustDecode("0x55241077000000000000000000000000000000000000000000000000000000000000002a")
+}
+
+// createSafe deploys the Safe singleton and proxy factory, then creates a Safe proxy for the owners.
+func (s *SimulatedSafeTestSuite) createSafe(owners []common.Address, threshold *big.Int) common.Address {
+	singletonCode, err := readBytecode("GnosisSafe_v1.3.0.bin")
+	s.Require().NoError(err)
+	singleton, _, err := s.executor.DeployContract(customabi.ABI{}, singletonCode, nil, 0, nil)
+	s.Require().NoError(err)
+
+	factoryCode, err := readBytecode("SafeProxyFactory_v1.4.0.bin")
+	s.Require().NoError(err)
+	factory, _, err := s.executor.DeployContract(customabi.ABI{}, factoryCode, nil, 0, nil)
+	s.Require().NoError(err)
+
+	setup, err := safeSingletonABI.Elements()[0].Selector()
+	s.Require().NoError(err)
+	arguments, err := customabi.Arguments(safeSingletonABI.Elements()[0].Inputs)
+	s.Require().NoError(err)
+	setupArgs, err := arguments.Pack(owners, threshold, common.Address{}, []byte{}, common.Address{}, common.Address{}, big.NewInt(0), common.Address{})
+	s.Require().NoError(err)
+	initializer := append(setup[:], setupArgs...)
+
+	result, err := s.executor.CallContractMethod(factory, proxyFactoryABI, "createProxyWithNonce", nil, 0, nil, singleton, initializer, big.NewInt(0))
+	s.Require().NoError(err)
+	receipt, err := s.transport.WaitForTransactionReceipt(common.HexToHash(result[1].(string)))
+	s.Require().NoError(err)
+
+	creation, err := proxyFactoryABI.Elements()[1].Topic()
+	s.Require().NoError(err)
+	for _, log := range receipt.Logs {
+		if log.Address == factory && len(log.Topics) == 2 && log.Topics[0] == creation {
+			return common.BytesToAddress(log.Topics[1].Bytes())
+		}
+	}
+	s.FailNow("factory did not emit ProxyCreation")
+	return common.Address{}
+}
+
+func (s *SimulatedSafeTestSuite) TearDownSuite() {
+	s.NoError(s.transport.Close())
+}
+
+func (s *SimulatedSafeTestSuite) targetValue() *big.Int {
+	raw, err := s.transport.CallContract(s.targetAddr, s.targetABI, "value")
+	s.Require().NoError(err)
+	return new(big.Int).SetBytes(raw)
+}
+
+func (s *SimulatedSafeTestSuite) TestProposeSignAndExecute() {
+	proposal, err := Propose(s.transport, s.owners[1], s.safeAddress, 1, s.targetAddr, nil, s.setValueData, "setValue(uint256 newValue)")
+	s.Require().NoError(err, "local SafeTx hash must match getTransactionHash")
+	s.Require().Len(proposal.Signatures, 1)
+
+	safe, err := Load(s.transport, s.safeAddress)
+	s.Require().NoError(err)
+	s.Equal("1.3.0", safe.Version)
+	s.Equal(uint64(2), safe.Threshold)
+	tx, err := TransactionFromProposal(proposal)
+	s.Require().NoError(err)
+	signatures := SignaturesOf(proposal)
+
+	_, err = safe.Execute(s.executor, tx, signatures)
+	s.True(errors.HasCode(err, errors.ErrCodeSafeThresholdNotMet))
+
+	signature, err := safe.Sign(s.owners[2], tx)
+	s.Require().NoError(err)
+	signatures[s.owners[2].GetAddress()] = signature
+
+	txHash, err := safe.Execute(s.executor, tx, signatures)
+	s.Require().NoError(err)
+	s.NotEmpty(txHash)
+	s.Equal(int64(42), s.targetValue().Int64())
+
+	safe, err = Load(s.transport, s.safeAddress)
+	s.Require().NoError(err)
+	s.Equal(new(big.Int).Add(tx.Nonce, big.NewInt(1)), safe.Nonce)
+
+	_, err = safe.Execute(s.executor, tx, signatures)
+	s.True(errors.HasCode(err, errors.ErrCodeSafeNonceMismatch), "executed proposals cannot be replayed")
+}
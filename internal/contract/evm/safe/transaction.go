@@ -0,0 +1,136 @@
+package safe
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Operation is the kind of call a Safe makes when executing a transaction.
+type Operation uint8
+
+const (
+	OperationCall         Operation = 0
+	OperationDelegateCall Operation = 1
+)
+
+func (o Operation) String() string {
+	if o == OperationDelegateCall {
+		return "delegatecall"
+	}
+	return "call"
+}
+
+// Transaction is a SafeTx, the transaction the owners of a Safe sign and execTransaction executes.
+type Transaction struct {
+	To             common.Address
+	Value          *big.Int
+	Data           []byte
+	Operation      Operation
+	SafeTxGas      *big.Int
+	BaseGas        *big.Int
+	GasPrice       *big.Int
+	GasToken       common.Address
+	RefundReceiver common.Address
+	Nonce          *big.Int
+}
+
+// NewTransaction creates a call from the Safe without gas refunds, the only kind proposed by the runner.
+// A zero safeTxGas and gasPrice make execTransaction revert when the call fails.
+func NewTransaction(to common.Address, value *big.Int, data []byte, nonce *big.Int) Transaction {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	return Transaction{
+		To:        to,
+		Value:     value,
+		Data:      data,
+		Operation: OperationCall,
+		SafeTxGas: big.NewInt(0),
+		BaseGas:   big.NewInt(0),
+		GasPrice:  big.NewInt(0),
+		Nonce:     nonce,
+	}
+}
+
+// domainHasChainID reports whether the EIP-712 domain of the Safe version includes the chain ID.
+// Safes before 1.3.0 only use the verifying contract. Unknown versions are treated as current ones.
+func domainHasChainID(version string) bool {
+	var major, minor int
+	if _, err := fmt.Sscanf(version, "%d.%d", &major, &minor); err != nil {
+		return true
+	}
+	return major > 1 || (major == 1 && minor >= 3)
+}
+
+// TypedData returns the SafeTx as EIP-712 typed data for the Safe deployed at the address.
+func (t Transaction) TypedData(chainID *big.Int, safeAddress common.Address, version string) apitypes.TypedData {
+	domainType := []apitypes.Type{{Name: "verifyingContract", Type: "address"}}
+	domain := apitypes.TypedDataDomain{VerifyingContract: safeAddress.Hex()}
+	if domainHasChainID(version) {
+		domainType = append([]apitypes.Type{{Name: "chainId", Type: "uint256"}}, domainType...)
+		domain.ChainId = (*math.HexOrDecimal256)(chainID)
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": domainType,
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"to":             t.To.Hex(),
+			"value":          bigString(t.Value),
+			"data":           hexutil.Encode(t.Data),
+			"operation":      fmt.Sprintf("%d", t.Operation),
+			"safeTxGas":      bigString(t.SafeTxGas),
+			"baseGas":        bigString(t.BaseGas),
+			"gasPrice":       bigString(t.GasPrice),
+			"gasToken":       t.GasToken.Hex(),
+			"refundReceiver": t.RefundReceiver.Hex(),
+			"nonce":          bigString(t.Nonce),
+		},
+	}
+}
+
+// TypedDataJSON returns the typed data in the eth_signTypedData_v4 JSON format accepted by signers.
+func (t Transaction) TypedDataJSON(chainID *big.Int, safeAddress common.Address, version string) (string, error) {
+	typedData := t.TypedData(chainID, safeAddress, version)
+	data, err := json.Marshal(typedData)
+	if err != nil {
+		return "", errors.WrapSignerError(err, errors.ErrCodeInvalidTypedData, "failed to encode SafeTx typed data")
+	}
+	return string(data), nil
+}
+
+// Hash returns the SafeTx hash, the EIP-712 digest the owners sign.
+func (t Transaction) Hash(chainID *big.Int, safeAddress common.Address, version string) (common.Hash, error) {
+	typedData := t.TypedData(chainID, safeAddress, version)
+	return signer.HashTypedData(&typedData)
+}
+
+func bigString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+	return value.String()
+}
@@ -0,0 +1,266 @@
+// Package signatures maintains the offline database mapping 4-byte selectors and event topics to
+// signatures, imported from signature dumps and looked up when decoding unknown contracts.
+package signatures
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// BundledSource is the source of the signatures imported from the bundled dump.
+const BundledSource = "bundled"
+
+//go:embed bundled.txt
+var bundled []byte
+
+// ParseDump parses a signature dump. Three formats are accepted:
+//
+//   - text, one signature per line, optionally preceded by its hash and a function/event/error keyword:
+//     "0xa9059cbb transfer(address,uint256)" or "event Transfer(address indexed from, address indexed to, uint256 value)"
+//   - a JSON object keyed by hash, with a signature or a list of signatures as values (the 4byte.directory format)
+//   - a JSON array of signatures
+//
+// Lines without a keyword are functions, unless their hash is 32 bytes long, which makes them events.
+// A hash that does not match the signature is an error.
+func ParseDump(data []byte, source string) ([]models.EvmSignature, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return parseJSONDump(trimmed, source)
+	}
+
+	signatures := []models.EvmSignature{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if comment := strings.Index(line, "//"); comment >= 0 {
+			line = strings.TrimSpace(line[:comment])
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash := ""
+		if strings.HasPrefix(line, "0x") {
+			fields := strings.SplitN(line, " ", 2)
+			if len(fields) != 2 {
+				return nil, errors.NewABIError(errors.ErrCodeInvalidABIFormat, fmt.Sprintf("line %d: missing signature after %s", lineNumber, fields[0]))
+			}
+			hash, line = fields[0], strings.TrimSpace(fields[1])
+		}
+
+		signature, err := parseEntry(hash, line, source)
+		if err != nil {
+			return nil, errors.WrapABIError(err, errors.ErrCodeInvalidABIFormat, fmt.Sprintf("line %d", lineNumber))
+		}
+		signatures = append(signatures, signature)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WrapABIError(err, errors.ErrCodeInvalidABIFormat, "failed to read signature dump")
+	}
+	return signatures, nil
+}
+
+func parseJSONDump(data []byte, source string) ([]models.EvmSignature, error) {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		signatures := make([]models.EvmSignature, 0, len(list))
+		for index, text := range list {
+			signature, err := parseEntry("", text, source)
+			if err != nil {
+				return nil, errors.WrapABIError(err, errors.ErrCodeInvalidABIFormat, fmt.Sprintf("entry %d", index))
+			}
+			signatures = append(signatures, signature)
+		}
+		return signatures, nil
+	}
+
+	var byHash map[string]json.RawMessage
+	if err := json.Unmarshal(data, &byHash); err != nil {
+		return nil, errors.WrapABIError(err, errors.ErrCodeInvalidABIFormat, "signature dump must be text, a JSON object keyed by hash or a JSON array")
+	}
+
+	hashes := make([]string, 0, len(byHash))
+	for hash := range byHash {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	signatures := []models.EvmSignature{}
+	for _, hash := range hashes {
+		var texts []string
+		if err := json.Unmarshal(byHash[hash], &texts); err != nil {
+			var text string
+			if err := json.Unmarshal(byHash[hash], &text); err != nil {
+				return nil, errors.WrapABIError(err, errors.ErrCodeInvalidABIFormat, fmt.Sprintf("%s: expected a signature or a list of signatures", hash))
+			}
+			texts = []string{text}
+		}
+		for _, text := range texts {
+			signature, err := parseEntry(hash, text, source)
+			if err != nil {
+				return nil, errors.WrapABIError(err, errors.ErrCodeInvalidABIFormat, hash)
+			}
+			signatures = append(signatures, signature)
+		}
+	}
+	return signatures, nil
+}
+
+// parseEntry computes the signature record of one dump entry and checks it against the expected hash.
+func parseEntry(hash string, text string, source string) (models.EvmSignature, error) {
+	text = strings.TrimSpace(text)
+	keyword := strings.SplitN(text, " ", 2)[0]
+	switch models.SignatureKind(keyword) {
+	case models.SignatureKindFunction, models.SignatureKindEvent, models.SignatureKindError:
+	default:
+		kind := models.SignatureKindFunction
+		if len(hash) == 2+2*common.HashLength {
+			kind = models.SignatureKindEvent
+		}
+		text = string(kind) + " " + text
+	}
+
+	elements, err := abi.ParseHumanReadable([]string{text})
+	if err != nil {
+		return models.EvmSignature{}, err
+	}
+	signature, err := models.NewSignature(elements[0], source)
+	if err != nil {
+		return models.EvmSignature{}, err
+	}
+	if hash != "" && !strings.EqualFold(hash, signature.Hash) {
+		return models.EvmSignature{}, fmt.Errorf("hash %s does not match %s (%s)", hash, signature.Signature, signature.Hash)
+	}
+	if !hasNames(elements[0]) {
+		// Dumps usually only have types, leave the names to be filled in by a stored ABI
+		signature.HumanReadable = ""
+	}
+	return signature, nil
+}
+
+func hasNames(element abi.ABIElement) bool {
+	for _, input := range element.Inputs {
+		if input.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Import parses a signature dump and saves its signatures. It returns the number of signatures in the dump.
+func Import(storage sql.Storage, data []byte, source string) (int, error) {
+	signatures, err := ParseDump(data, source)
+	if err != nil {
+		return 0, err
+	}
+	if err := storage.SaveSignatures(signatures); err != nil {
+		return 0, err
+	}
+	return len(signatures), nil
+}
+
+// ImportFile imports the signature dump at the path, using the path as the source.
+func ImportFile(storage sql.Storage, path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, errors.WrapABIError(err, errors.ErrCodeInvalidABIFormat, fmt.Sprintf("failed to read %s", path))
+	}
+	return Import(storage, data, path)
+}
+
+// ImportBundled imports the signature dump bundled with the CLI.
+func ImportBundled(storage sql.Storage) (int, error) {
+	return Import(storage, bundled, BundledSource)
+}
+
+// Bundled returns the elements of the signature dump bundled with the CLI, the decoder knows them
+// without importing the dump.
+func Bundled() (abi.AbiArray, error) {
+	parsed, err := ParseDump(bundled, BundledSource)
+	if err != nil {
+		return nil, err
+	}
+	elements := make(abi.AbiArray, 0, len(parsed))
+	for _, signature := range parsed {
+		element, err := signature.ABIElement()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+// ImportReader imports a signature dump read from the reader.
+func ImportReader(storage sql.Storage, reader io.Reader, source string) (int, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, errors.WrapABIError(err, errors.ErrCodeInvalidABIFormat, "failed to read signature dump")
+	}
+	return Import(storage, data, source)
+}
+
+// Database looks up signatures in the storage.
+type Database struct {
+	storage sql.Storage
+}
+
+// NewDatabase creates a signature database backed by the storage.
+func NewDatabase(storage sql.Storage) *Database {
+	return &Database{storage: storage}
+}
+
+// Lookup returns the stored signatures of every kind with the selector or topic.
+func (d *Database) Lookup(hash string) ([]models.EvmSignature, error) {
+	return d.storage.ListSignaturesByHash(strings.ToLower(hash))
+}
+
+// Functions returns the functions with the selector.
+func (d *Database) Functions(selector [4]byte) ([]abi.ABIElement, error) {
+	return d.elements(hexutil.Encode(selector[:]), models.SignatureKindFunction)
+}
+
+// Errors returns the custom errors with the selector.
+func (d *Database) Errors(selector [4]byte) ([]abi.ABIElement, error) {
+	return d.elements(hexutil.Encode(selector[:]), models.SignatureKindError)
+}
+
+// Events returns the events with the topic.
+func (d *Database) Events(topic common.Hash) ([]abi.ABIElement, error) {
+	return d.elements(topic.Hex(), models.SignatureKindEvent)
+}
+
+func (d *Database) elements(hash string, kind models.SignatureKind) ([]abi.ABIElement, error) {
+	signatures, err := d.Lookup(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := []abi.ABIElement{}
+	for _, signature := range signatures {
+		if signature.Kind != kind {
+			continue
+		}
+		element, err := signature.ABIElement()
+		if err != nil {
+			continue
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
@@ -0,0 +1,122 @@
+package signatures
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStorage(t *testing.T) sql.Storage {
+	t.Helper()
+	storage, err := sql.NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	return storage
+}
+
+func TestParseTextDump(t *testing.T) {
+	dump := `
+# comment
+0xa9059cbb transfer(address,uint256)
+0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef Transfer(address,address,uint256)
+error Unauthorized(address caller) // trailing comment
+approve(address,uint)
+`
+	signatures, err := ParseDump([]byte(dump), "dump.txt")
+	require.NoError(t, err)
+	require.Len(t, signatures, 4)
+
+	assert.Equal(t, models.EvmSignature{Kind: models.SignatureKindFunction, Hash: "0xa9059cbb", Signature: "transfer(address,uint256)", Source: "dump.txt"}, signatures[0])
+	assert.Equal(t, models.SignatureKindEvent, signatures[1].Kind)
+	assert.Equal(t, "Transfer(address,address,uint256)", signatures[1].Signature)
+	assert.Equal(t, models.SignatureKindError, signatures[2].Kind)
+	assert.Equal(t, "error Unauthorized(address caller)", signatures[2].HumanReadable)
+	assert.Equal(t, "approve(address,uint256)", signatures[3].Signature)
+	assert.Equal(t, "0x095ea7b3", signatures[3].Hash)
+}
+
+func TestParseJSONDumps(t *testing.T) {
+	signatures, err := ParseDump([]byte(`{"0xa9059cbb": ["transfer(address,uint256)"], "0x095ea7b3": "approve(address,uint256)"}`), "4byte.json")
+	require.NoError(t, err)
+	require.Len(t, signatures, 2)
+	assert.Equal(t, "approve(address,uint256)", signatures[0].Signature)
+	assert.Equal(t, "transfer(address,uint256)", signatures[1].Signature)
+
+	signatures, err = ParseDump([]byte(`["function transfer(address to, uint256 amount)", "event Approval(address indexed owner, address indexed spender, uint256 value)"]`), "list.json")
+	require.NoError(t, err)
+	require.Len(t, signatures, 2)
+	assert.Equal(t, models.SignatureKindEvent, signatures[1].Kind)
+}
+
+func TestParseDumpErrors(t *testing.T) {
+	_, err := ParseDump([]byte("transfer(address,uint256)\n0x12345678 transfer(address,uint256)"), "dump.txt")
+	require.Error(t, err)
+	assert.True(t, errors.HasCode(err, errors.ErrCodeInvalidABIFormat))
+	assert.ErrorContains(t, err, "line 2")
+	assert.ErrorContains(t, err, "does not match")
+
+	_, err = ParseDump([]byte("0xa9059cbb"), "dump.txt")
+	assert.ErrorContains(t, err, "missing signature")
+
+	_, err = ParseDump([]byte(`{"0xa9059cbb": 1}`), "dump.json")
+	assert.ErrorContains(t, err, "expected a signature")
+}
+
+func TestImportBundled(t *testing.T) {
+	storage := newStorage(t)
+
+	count, err := ImportBundled(storage)
+	require.NoError(t, err)
+	assert.Greater(t, count, 100)
+
+	// Importing twice does not duplicate signatures
+	_, err = ImportBundled(storage)
+	require.NoError(t, err)
+	total, err := storage.CountSignatures()
+	require.NoError(t, err)
+	assert.Equal(t, int64(count), total)
+
+	database := NewDatabase(storage)
+	functions, err := database.Functions([4]byte{0xa9, 0x05, 0x9c, 0xbb})
+	require.NoError(t, err)
+	require.Len(t, functions, 1)
+	assert.Equal(t, "to", functions[0].Inputs[0].Name)
+
+	errs, err := database.Errors([4]byte{0x08, 0xc3, 0x79, 0xa0})
+	require.NoError(t, err)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Error", errs[0].Name)
+
+	events, err := database.Events(common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.True(t, events[0].Inputs[0].Indexed)
+
+	functions, err = database.Functions([4]byte{0xde, 0xad, 0xbe, 0xef})
+	require.NoError(t, err)
+	assert.Empty(t, functions)
+}
+
+func TestImportFile(t *testing.T) {
+	storage := newStorage(t)
+	path := filepath.Join(t.TempDir(), "dump.txt")
+	require.NoError(t, os.WriteFile(path, []byte("0x18160ddd totalSupply()\n"), 0o600))
+
+	count, err := ImportFile(storage, path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	found, err := NewDatabase(storage).Lookup("0x18160DDD")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, path, found[0].Source)
+
+	_, err = ImportFile(storage, filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,213 @@
+// Package simulation runs a contract call against the pending state before it is sent, so the user
+// sees its return values or revert reason, the events it emits and the balances it changes.
+package simulation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/trace"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Call is a contract call to simulate.
+type Call struct {
+	From     common.Address
+	To       common.Address
+	Value    *big.Int
+	Data     []byte
+	Function abi.ABIElement
+	// ABI is used to decode custom errors and events, usually the ABI of the called contract.
+	ABI abi.AbiArray
+	// Signatures decodes the custom errors and events that ABI doesn't know, when set.
+	Signatures decoder.SignatureLookup
+}
+
+// BalanceChange is the change of the ether balance of an address caused by the call.
+type BalanceChange struct {
+	Address common.Address `json:"address"`
+	Before  *big.Int       `json:"before"`
+	After   *big.Int       `json:"after"`
+}
+
+// Delta returns the balance after the call minus the balance before it.
+func (b BalanceChange) Delta() *big.Int {
+	return new(big.Int).Sub(b.After, b.Before)
+}
+
+// Log is an event emitted by the call, decoded when the ABI knows it.
+type Log struct {
+	Log   types.Log      `json:"log"`
+	Event *decoder.Event `json:"event,omitempty"`
+}
+
+// Result is the outcome of a simulated call.
+type Result struct {
+	Reverted     bool            `json:"reverted"`
+	RevertReason string          `json:"revert_reason,omitempty"`
+	ReturnValues []decoder.Value `json:"return_values,omitempty"`
+
+	// Traced is false when the node does not support debug_traceCall, TraceError says why.
+	Traced         bool            `json:"traced"`
+	TraceError     string          `json:"trace_error,omitempty"`
+	GasUsed        uint64          `json:"gas_used,omitempty"`
+	Logs           []Log           `json:"logs,omitempty"`
+	BalanceChanges []BalanceChange `json:"balance_changes,omitempty"`
+}
+
+// prestateAccount is an account of the prestateTracer output in diff mode.
+type prestateAccount struct {
+	Balance *hexutil.Big `json:"balance"`
+}
+
+type prestateDiff struct {
+	Pre  map[common.Address]prestateAccount `json:"pre"`
+	Post map[common.Address]prestateAccount `json:"post"`
+}
+
+// Simulate runs the call with eth_call at the pending block, then traces it for events and balance
+// changes. A revert is part of the result; only failures to reach the node are returned as errors.
+func Simulate(tr transport.Transport, call Call) (*Result, error) {
+	msg := ethereum.CallMsg{
+		From:  call.From,
+		To:    &call.To,
+		Value: call.Value,
+		Data:  call.Data,
+	}
+
+	result := &Result{}
+	data, err := tr.SimulateCall(msg)
+	if err != nil {
+		revertData, ok := transport.RevertData(err)
+		if !ok && !errors.HasCode(err, errors.ErrCodeCallReverted) {
+			return nil, err
+		}
+		result.Reverted = true
+		result.RevertReason = decoder.DecodeRevert(revertData, call.ABI, call.Signatures)
+	} else if len(call.Function.Outputs) > 0 {
+		values, err := decoder.DecodeValues(call.Function.Outputs, data)
+		if err != nil {
+			return nil, err
+		}
+		result.ReturnValues = values
+	}
+
+	if result.Reverted {
+		return result, nil
+	}
+
+	frame, err := traceCall(tr, msg)
+	if err != nil {
+		result.TraceError = err.Error()
+		return result, nil
+	}
+	changes, err := traceBalanceChanges(tr, msg)
+	if err != nil {
+		result.TraceError = err.Error()
+		return result, nil
+	}
+
+	result.Traced = true
+	result.GasUsed = uint64(frame.GasUsed)
+	result.BalanceChanges = changes
+	for _, log := range frame.CollectLogs(nil) {
+		simulated := Log{Log: log}
+		if event, ok := decoder.DecodeEvent(log, call.ABI, call.Signatures); ok {
+			simulated.Event = &event
+		}
+		result.Logs = append(result.Logs, simulated)
+	}
+	return result, nil
+}
+
+// traceCall traces the call with the callTracer, for the logs it emits and the gas it uses.
+func traceCall(tr transport.Transport, msg ethereum.CallMsg) (*trace.Frame, error) {
+	raw, err := tr.TraceCall(msg, "callTracer", trace.TracerConfig)
+	if err != nil {
+		return nil, err
+	}
+	return trace.ParseFrame(raw)
+}
+
+// traceBalanceChanges compares the balances before and after the call, sorted by address.
+func traceBalanceChanges(tr transport.Transport, msg ethereum.CallMsg) ([]BalanceChange, error) {
+	raw, err := tr.TraceCall(msg, "prestateTracer", map[string]any{"diffMode": true})
+	if err != nil {
+		return nil, err
+	}
+	var diff prestateDiff
+	if err := json.Unmarshal(raw, &diff); err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeTraceFailed, "failed to decode state diff")
+	}
+
+	changes := []BalanceChange{}
+	for address, post := range diff.Post {
+		if post.Balance == nil {
+			continue
+		}
+		before := big.NewInt(0)
+		if pre, ok := diff.Pre[address]; ok && pre.Balance != nil {
+			before = pre.Balance.ToInt()
+		}
+		after := post.Balance.ToInt()
+		if before.Cmp(after) != 0 {
+			changes = append(changes, BalanceChange{Address: address, Before: before, After: after})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Address.Bytes(), changes[j].Address.Bytes()) < 0
+	})
+	return changes, nil
+}
+
+// Lines renders the result for display.
+func (r *Result) Lines() []string {
+	if r.Reverted {
+		return []string{"Reverts: " + r.RevertReason}
+	}
+
+	lines := []string{"Succeeds"}
+	for _, value := range r.ReturnValues {
+		for _, line := range value.Lines() {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	if !r.Traced {
+		return append(lines, "Events and balance changes unavailable: the node does not support debug_traceCall")
+	}
+
+	lines = append(lines, fmt.Sprintf("Events (%d):", len(r.Logs)))
+	for _, log := range r.Logs {
+		if log.Event != nil {
+			lines = append(lines, fmt.Sprintf("  %s  [%s]", log.Event.Line(), log.Log.Address.Hex()))
+			continue
+		}
+		topic := "anonymous"
+		if len(log.Log.Topics) > 0 {
+			topic = log.Log.Topics[0].Hex()
+		}
+		lines = append(lines, fmt.Sprintf("  unknown event %s  [%s]", topic, log.Log.Address.Hex()))
+	}
+
+	lines = append(lines, fmt.Sprintf("Balance changes (%d):", len(r.BalanceChanges)))
+	for _, change := range r.BalanceChanges {
+		delta := change.Delta()
+		sign := ""
+		if delta.Sign() > 0 {
+			sign = "+"
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s%s wei", change.Address.Hex(), sign, delta))
+	}
+	return lines
+}
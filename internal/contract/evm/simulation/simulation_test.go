@@ -0,0 +1,185 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sender = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	token  = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	vault  = common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+)
+
+// revertError is a JSON-RPC error carrying revert data, like the one returned by eth_call.
+type revertError struct {
+	data string
+}
+
+func (e revertError) Error() string  { return "execution reverted" }
+func (e revertError) ErrorCode() int { return 3 }
+func (e revertError) ErrorData() any { return e.data }
+
+// fakeTransport answers SimulateCall and TraceCall with canned results.
+type fakeTransport struct {
+	transport.Transport
+	result   []byte
+	err      error
+	traces   map[string]string
+	traceErr error
+	msg      ethereum.CallMsg
+}
+
+func (f *fakeTransport) SimulateCall(msg ethereum.CallMsg) ([]byte, error) {
+	f.msg = msg
+	return f.result, f.err
+}
+
+func (f *fakeTransport) TraceCall(_ ethereum.CallMsg, tracer string, _ map[string]any) (json.RawMessage, error) {
+	if f.traceErr != nil {
+		return nil, f.traceErr
+	}
+	return json.RawMessage(f.traces[tracer]), nil
+}
+
+func parse(t *testing.T, signatures ...string) abi.AbiArray {
+	t.Helper()
+	elements, err := abi.ParseHumanReadable(signatures)
+	require.NoError(t, err)
+	return elements
+}
+
+func word(value int64) []byte {
+	return common.LeftPadBytes(big.NewInt(value).Bytes(), 32)
+}
+
+func TestSimulateSuccessWithTrace(t *testing.T) {
+	elements := parse(t,
+		"function deposit(uint256 amount) payable returns (uint256 shares)",
+		"event Deposited(address indexed owner, uint256 amount)",
+	)
+	topic, err := elements[1].Topic()
+	require.NoError(t, err)
+
+	callTrace := fmt.Sprintf(`{"type":"CALL","gasUsed":"0xb411","calls":[{"type":"CALL","logs":[{"address":"%s","topics":["0x%x"],"data":"0x","position":"0x0"}]}],
+		"logs":[{"address":"%s","topics":["%s","%s"],"data":"%s","position":"0x1"}]}`,
+		vault.Hex(), word(9), token.Hex(), topic.Hex(), common.BytesToHash(sender.Bytes()).Hex(), hexutil.Encode(word(5)))
+	prestate := fmt.Sprintf(`{"pre":{"%s":{"balance":"0x10"},"%s":{"balance":"0x0","nonce":1}},"post":{"%s":{"balance":"0xb"},"%s":{"balance":"0x5"},"%s":{"nonce":2}}}`,
+		sender.Hex(), token.Hex(), sender.Hex(), token.Hex(), vault.Hex())
+
+	tr := &fakeTransport{
+		result: word(5),
+		traces: map[string]string{"callTracer": callTrace, "prestateTracer": prestate},
+	}
+	result, err := Simulate(tr, Call{From: sender, To: token, Value: big.NewInt(5), Data: []byte{1}, Function: elements[0], ABI: elements})
+	require.NoError(t, err)
+
+	assert.Equal(t, sender, tr.msg.From)
+	assert.Equal(t, token, *tr.msg.To)
+	assert.False(t, result.Reverted)
+	assert.True(t, result.Traced)
+	assert.Equal(t, uint64(46097), result.GasUsed)
+	require.Len(t, result.ReturnValues, 1)
+	assert.Equal(t, "5", result.ReturnValues[0].Value)
+
+	require.Len(t, result.Logs, 2)
+	assert.Nil(t, result.Logs[0].Event)
+	assert.Equal(t, vault, result.Logs[0].Log.Address)
+	require.NotNil(t, result.Logs[1].Event)
+	assert.Equal(t, "Deposited(owner="+sender.Hex()+", amount=5)", result.Logs[1].Event.Line())
+
+	require.Len(t, result.BalanceChanges, 2)
+	assert.Equal(t, token, result.BalanceChanges[0].Address)
+	assert.Equal(t, big.NewInt(5), result.BalanceChanges[0].Delta())
+	assert.Equal(t, big.NewInt(-5), result.BalanceChanges[1].Delta())
+
+	assert.Equal(t, []string{
+		"Succeeds",
+		"  shares (uint256): 5",
+		"Events (2):",
+		fmt.Sprintf("  unknown event 0x%x  [%s]", word(9), vault.Hex()),
+		"  Deposited(owner=" + sender.Hex() + ", amount=5)  [" + token.Hex() + "]",
+		"Balance changes (2):",
+		"  " + token.Hex() + ": +5 wei",
+		"  " + sender.Hex() + ": -5 wei",
+	}, result.Lines())
+}
+
+func TestSimulateRevertDecodesCustomError(t *testing.T) {
+	elements := parse(t, "function withdraw(uint256 amount)", "error InsufficientBalance(uint256 available, uint256 required)")
+	selector, err := elements[1].Selector()
+	require.NoError(t, err)
+	data := append(selector[:], append(word(1), word(2)...)...)
+
+	tr := &fakeTransport{err: errors.WrapTransportError(revertError{data: hexutil.Encode(data)}, errors.ErrCodeCallReverted, "simulated call reverted")}
+	result, err := Simulate(tr, Call{From: sender, To: token, Function: elements[0], ABI: elements})
+	require.NoError(t, err)
+
+	assert.True(t, result.Reverted)
+	assert.False(t, result.Traced)
+	assert.Equal(t, []string{"Reverts: InsufficientBalance(available=1, required=2)"}, result.Lines())
+}
+
+// errorSignatures is a signature database that only knows custom errors.
+type errorSignatures struct {
+	decoder.SignatureLookup
+	errors abi.AbiArray
+}
+
+func (e errorSignatures) Errors([4]byte) ([]abi.ABIElement, error) {
+	return e.errors, nil
+}
+
+func TestSimulateRevertDecodesErrorFromSignatures(t *testing.T) {
+	elements := parse(t, "function withdraw(uint256 amount)")
+	unauthorized := parse(t, "error Unauthorized(address caller)")
+	selector, err := unauthorized[0].Selector()
+	require.NoError(t, err)
+	data := append(selector[:], common.LeftPadBytes(sender.Bytes(), 32)...)
+
+	tr := &fakeTransport{err: errors.WrapTransportError(revertError{data: hexutil.Encode(data)}, errors.ErrCodeCallReverted, "simulated call reverted")}
+	result, err := Simulate(tr, Call{From: sender, To: token, Function: elements[0], ABI: elements, Signatures: errorSignatures{errors: unauthorized}})
+	require.NoError(t, err)
+	assert.Equal(t, "Unauthorized(caller="+sender.Hex()+")", result.RevertReason)
+}
+
+func TestSimulateRevertWithoutData(t *testing.T) {
+	elements := parse(t, "function pause()")
+	tr := &fakeTransport{err: errors.NewTransportError(errors.ErrCodeCallReverted, "simulated call reverted")}
+
+	result, err := Simulate(tr, Call{From: sender, To: token, Function: elements[0]})
+	require.NoError(t, err)
+	assert.True(t, result.Reverted)
+	assert.Equal(t, "execution reverted without data", result.RevertReason)
+}
+
+func TestSimulateWithoutTraceSupport(t *testing.T) {
+	elements := parse(t, "function pause()")
+	tr := &fakeTransport{traceErr: fmt.Errorf("the method debug_traceCall does not exist")}
+
+	result, err := Simulate(tr, Call{From: sender, To: token, Function: elements[0]})
+	require.NoError(t, err)
+	assert.False(t, result.Traced)
+	assert.Contains(t, result.TraceError, "debug_traceCall")
+	assert.Equal(t, []string{"Succeeds", "Events and balance changes unavailable: the node does not support debug_traceCall"}, result.Lines())
+}
+
+func TestSimulateConnectionFailure(t *testing.T) {
+	elements := parse(t, "function pause()")
+	tr := &fakeTransport{err: errors.NewTransportError(errors.ErrCodeRPCCallFailed, "failed to simulate call")}
+
+	_, err := Simulate(tr, Call{From: sender, To: token, Function: elements[0]})
+	assert.True(t, errors.HasCode(err, errors.ErrCodeRPCCallFailed))
+}
@@ -22,6 +22,11 @@ func (EvmAbi) TableName() string {
 	return "evm_abis"
 }
 
+// DiffFrom compares the ABI of a previous version of the record with this one.
+func (e EvmAbi) DiffFrom(previous EvmAbi) abi.ABIDiff {
+	return abi.Diff(previous.Abi.AbiArray, e.Abi.AbiArray)
+}
+
 // AbiArrayType wraps abi.AbiArray for database serialization.
 type AbiArrayType struct {
 	abi.AbiArray
@@ -1,9 +1,12 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
-	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storagelayout"
 )
 
 type DeploymentStatus string
@@ -22,10 +25,11 @@ type EVMContract struct {
 	Abi     *EvmAbi          `json:"abi,omitempty" gorm:"foreignKey:AbiId;references:ID"`
 	Status  DeploymentStatus `json:"status" gorm:"default:pending"`
 
-	ContractCode *string   `json:"contract_code" gorm:"type:text"`
-	Bytecode     *string   `json:"bytecode" gorm:"type:text"`
-	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ContractCode  *string           `json:"contract_code" gorm:"type:text"`
+	Bytecode      *string           `json:"bytecode" gorm:"type:text"`
+	StorageLayout StorageLayoutType `json:"storage_layout" gorm:"type:text"`
+	CreatedAt     time.Time         `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
 
 	EndpointId uint         `json:"endpoint_id" gorm:"not null;index;uniqueIndex:idx_contract_name_address_endpoint;constraint:OnDelete:CASCADE"`
 	Endpoint   *EVMEndpoint `json:"endpoint,omitempty" gorm:"foreignKey:EndpointId;references:ID"`
@@ -41,10 +45,41 @@ func (c *EVMContract) IsDeployable() bool {
 	return c.Status == DeploymentStatusPending && c.Bytecode != nil
 }
 
-func (c *EVMContract) Compile() error {
-	if c.ContractCode == nil {
-		return errors.NewContractError(errors.ErrCodeContractCodeRequired, "contract code is required")
+// StorageLayoutType wraps storagelayout.Layout for database serialization.
+type StorageLayoutType struct {
+	*storagelayout.Layout
+}
+
+// Scan implements sql.Scanner interface for reading from database.
+func (s *StorageLayoutType) Scan(value any) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		s.Layout = nil
+		return nil
 	}
 
+	layout := &storagelayout.Layout{}
+	if err := json.Unmarshal(bytes, layout); err != nil {
+		return fmt.Errorf("failed to parse storage layout: %w", err)
+	}
+	s.Layout = layout
 	return nil
 }
+
+// Value implements driver.Valuer interface for writing to database.
+func (s StorageLayoutType) Value() (driver.Value, error) {
+	if s.Layout == nil {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal(s.Layout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal storage layout: %w", err)
+	}
+	return string(bytes), nil
+}
@@ -9,6 +9,13 @@ type EVMEndpoint struct {
 	ChainId   string    `json:"chain_id" gorm:"not null"`
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Confirmations is the number of blocks, the block of a transaction included, to wait for before
+	// its receipt is final. 0 and 1 accept the first receipt.
+	Confirmations uint `json:"confirmations" gorm:"not null;default:0"`
+	// Priority orders the endpoints of a chain for failover, lower first. Endpoints with the same
+	// priority share the reads round-robin, transactions stay on the first healthy one.
+	Priority int `json:"priority" gorm:"not null;default:0"`
 }
 
 // TableName specifies the table name for EVMEndpoint.
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storagelayout"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -566,6 +567,86 @@ func (suite *ModelsTestSuite) TestConcurrentOperations() {
 	suite.Assert().Equal(int64(10), count)
 }
 
+// TestEVMContract_StorageLayout tests that the storage layout of a contract survives a round trip.
+func (suite *ModelsTestSuite) TestEVMContract_StorageLayout() {
+	endpoint := EVMEndpoint{Name: "Local", Url: "http://localhost:8545", ChainId: "31337"}
+	suite.Require().NoError(suite.db.Create(&endpoint).Error)
+
+	contract := EVMContract{
+		Name:       "Counter",
+		Address:    "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		EndpointId: endpoint.ID,
+		StorageLayout: StorageLayoutType{Layout: &storagelayout.Layout{
+			Storage: []storagelayout.Variable{{Label: "count", Slot: "0", Type: "t_uint256", Contract: "Contract.sol:Counter"}},
+			Types:   map[string]storagelayout.Type{"t_uint256": {Encoding: storagelayout.EncodingInplace, Label: "uint256", NumberOfBytes: "32"}},
+		}},
+	}
+	suite.Require().NoError(suite.db.Create(&contract).Error)
+
+	var stored EVMContract
+	suite.Require().NoError(suite.db.First(&stored, contract.ID).Error)
+	suite.Equal(contract.StorageLayout.Layout, stored.StorageLayout.Layout, "Storage layout should survive a round trip")
+
+	withoutLayout := EVMContract{Name: "Empty", Address: "0x01", EndpointId: endpoint.ID}
+	suite.Require().NoError(suite.db.Create(&withoutLayout).Error)
+	var storedWithoutLayout EVMContract
+	suite.Require().NoError(suite.db.First(&storedWithoutLayout, withoutLayout.ID).Error)
+	suite.Nil(storedWithoutLayout.StorageLayout.Layout)
+}
+
+// TestSignaturesFromABI tests extracting the function, event and error signatures of an ABI.
+func (suite *ModelsTestSuite) TestSignaturesFromABI() {
+	elements, err := abi.ParseHumanReadable([]string{
+		"constructor(uint256 supply)",
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"error InsufficientBalance(uint256 available, uint256 required)",
+	})
+	suite.Require().NoError(err)
+
+	signatures := SignaturesFromABI(elements, "Token")
+	suite.Require().Len(signatures, 3, "Constructors have no signature")
+
+	suite.Equal(SignatureKindFunction, signatures[0].Kind)
+	suite.Equal("0xa9059cbb", signatures[0].Hash)
+	suite.Equal("transfer(address,uint256)", signatures[0].Signature)
+	suite.Equal("Token", signatures[0].Source)
+
+	suite.Equal(SignatureKindEvent, signatures[1].Kind)
+	suite.Equal("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", signatures[1].Hash)
+
+	suite.Equal(SignatureKindError, signatures[2].Kind)
+	suite.Equal("InsufficientBalance(uint256,uint256)", signatures[2].Signature)
+
+	element, err := signatures[1].ABIElement()
+	suite.Require().NoError(err)
+	suite.Equal("from", element.Inputs[0].Name)
+	suite.True(element.Inputs[0].Indexed)
+
+	unnamed := EvmSignature{Kind: SignatureKindFunction, Signature: "approve(address,uint256)"}
+	element, err = unnamed.ABIElement()
+	suite.Require().NoError(err)
+	suite.Equal("approve", element.Name)
+	suite.Len(element.Inputs, 2)
+}
+
+// TestEvmAbiDiffFrom tests diffing an ABI against its previous version.
+func (suite *ModelsTestSuite) TestEvmAbiDiffFrom() {
+	previousElements, err := abi.ParseHumanReadable([]string{"function mint(address to, uint256 amount)"})
+	suite.Require().NoError(err)
+	currentElements, err := abi.ParseHumanReadable([]string{"function mint(address to, uint256 amount) payable"})
+	suite.Require().NoError(err)
+
+	previous := EvmAbi{Name: "Token", Abi: AbiArrayType{AbiArray: previousElements}}
+	current := EvmAbi{Name: "Token", Abi: AbiArrayType{AbiArray: currentElements}}
+
+	diff := current.DiffFrom(previous)
+	suite.Require().Len(diff.Changes, 1)
+	suite.Equal(abi.ChangeModified, diff.Changes[0].Kind)
+	suite.False(diff.HasBreakingChanges())
+	suite.True(previous.DiffFrom(previous).IsEmpty())
+}
+
 // TestRunSuite runs the test suite.
 func TestRunSuite(t *testing.T) {
 	suite.Run(t, new(ModelsTestSuite))
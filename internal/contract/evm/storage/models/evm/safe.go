@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// SafeProposalStatus is the lifecycle state of a Safe transaction proposal.
+type SafeProposalStatus string
+
+const (
+	SafeProposalStatusPending  SafeProposalStatus = "pending"
+	SafeProposalStatusExecuted SafeProposalStatus = "executed"
+)
+
+// SafeProposal is a Safe multisig transaction collecting owner signatures until it can be executed.
+// Amounts are stored as decimal strings and data as 0x-prefixed hex.
+type SafeProposal struct {
+	ID          uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	SafeAddress string `json:"safe_address" gorm:"not null;index"`
+	ChainID     string `json:"chain_id" gorm:"not null"`
+	// Version is the Safe contract version, which decides the EIP-712 domain of the SafeTx hash.
+	Version string `json:"version"`
+	// Description is a human readable summary of the call, e.g. "setValue(uint256 newValue)".
+	Description string `json:"description"`
+
+	To             string `json:"to" gorm:"not null"`
+	Value          string `json:"value" gorm:"not null;default:0"`
+	Data           string `json:"data" gorm:"type:text"`
+	Operation      uint8  `json:"operation" gorm:"not null;default:0"`
+	SafeTxGas      string `json:"safe_tx_gas" gorm:"not null;default:0"`
+	BaseGas        string `json:"base_gas" gorm:"not null;default:0"`
+	GasPrice       string `json:"gas_price" gorm:"not null;default:0"`
+	GasToken       string `json:"gas_token" gorm:"not null"`
+	RefundReceiver string `json:"refund_receiver" gorm:"not null"`
+	Nonce          uint64 `json:"nonce" gorm:"not null"`
+
+	// SafeTxHash is the EIP-712 hash the owners sign.
+	SafeTxHash     string             `json:"safe_tx_hash" gorm:"not null;uniqueIndex"`
+	Status         SafeProposalStatus `json:"status" gorm:"default:pending"`
+	ExecutedTxHash *string            `json:"executed_tx_hash" gorm:"type:varchar(66)"`
+
+	Signatures []SafeSignature `json:"signatures,omitempty" gorm:"foreignKey:ProposalID;constraint:OnDelete:CASCADE"`
+
+	EndpointId uint         `json:"endpoint_id" gorm:"not null;index;constraint:OnDelete:CASCADE"`
+	Endpoint   *EVMEndpoint `json:"endpoint,omitempty" gorm:"foreignKey:EndpointId;references:ID"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for SafeProposal.
+func (SafeProposal) TableName() string {
+	return "safe_proposals"
+}
+
+// SafeSignature is the EIP-712 signature of a Safe owner over the SafeTx hash of a proposal.
+type SafeSignature struct {
+	ID         uint `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProposalID uint `json:"proposal_id" gorm:"not null;uniqueIndex:idx_safe_signature"`
+	// Owner is the checksummed address of the signing owner.
+	Owner     string    `json:"owner" gorm:"not null;uniqueIndex:idx_safe_signature"`
+	Signature string    `json:"signature" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for SafeSignature.
+func (SafeSignature) TableName() string {
+	return "safe_signatures"
+}
+
+// IsExecuted returns true once execTransaction of the proposal has been mined.
+func (p *SafeProposal) IsExecuted() bool {
+	return p.Status == SafeProposalStatusExecuted
+}
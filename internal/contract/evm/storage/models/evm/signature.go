@@ -0,0 +1,101 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+)
+
+// SignatureKind is the kind of ABI element a signature belongs to.
+type SignatureKind string
+
+const (
+	SignatureKindFunction SignatureKind = "function"
+	SignatureKindEvent    SignatureKind = "event"
+	SignatureKindError    SignatureKind = "error"
+)
+
+// EvmSignature maps a 4-byte function or error selector, or a 32-byte event topic, to its signature.
+type EvmSignature struct {
+	ID   uint          `json:"id" gorm:"primaryKey;autoIncrement"`
+	Kind SignatureKind `json:"kind" gorm:"not null;uniqueIndex:idx_evm_signature"`
+	// Hash is the lowercase 0x-prefixed selector or topic.
+	Hash string `json:"hash" gorm:"not null;index;uniqueIndex:idx_evm_signature"`
+	// Signature is the canonical signature the hash is computed from, e.g. "transfer(address,uint256)".
+	Signature string `json:"signature" gorm:"not null;uniqueIndex:idx_evm_signature"`
+	// HumanReadable keeps the parameter names when known, e.g. "function transfer(address to, uint256 value)".
+	HumanReadable string    `json:"human_readable"`
+	Source        string    `json:"source"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for EvmSignature.
+func (EvmSignature) TableName() string {
+	return "evm_signatures"
+}
+
+// NewSignature computes the signature record of a function, event or error element.
+func NewSignature(element abi.ABIElement, source string) (EvmSignature, error) {
+	signature, err := element.Signature()
+	if err != nil {
+		return EvmSignature{}, err
+	}
+
+	kind := SignatureKind(element.Type)
+	var hash string
+	if kind == SignatureKindEvent {
+		topic, err := element.Topic()
+		if err != nil {
+			return EvmSignature{}, err
+		}
+		hash = topic.Hex()
+	} else {
+		selector, err := element.Selector()
+		if err != nil {
+			return EvmSignature{}, err
+		}
+		hash = hexutil.Encode(selector[:])
+	}
+
+	return EvmSignature{
+		Kind:          kind,
+		Hash:          strings.ToLower(hash),
+		Signature:     signature,
+		HumanReadable: element.HumanReadable(),
+		Source:        source,
+	}, nil
+}
+
+// SignaturesFromABI returns the signatures of every function, event and error in the ABI.
+// Elements with types that cannot be hashed are skipped.
+func SignaturesFromABI(elements abi.AbiArray, source string) []EvmSignature {
+	signatures := []EvmSignature{}
+	for _, element := range elements {
+		switch SignatureKind(element.Type) {
+		case SignatureKindFunction, SignatureKindEvent, SignatureKindError:
+		default:
+			continue
+		}
+		signature, err := NewSignature(element, source)
+		if err != nil {
+			continue
+		}
+		signatures = append(signatures, signature)
+	}
+	return signatures
+}
+
+// ABIElement parses the signature back into an ABI element, with parameter names when they are known.
+func (s EvmSignature) ABIElement() (abi.ABIElement, error) {
+	text := s.HumanReadable
+	if text == "" {
+		text = string(s.Kind) + " " + s.Signature
+	}
+	elements, err := abi.ParseHumanReadable([]string{text})
+	if err != nil {
+		return abi.ABIElement{}, err
+	}
+	return elements[0], nil
+}
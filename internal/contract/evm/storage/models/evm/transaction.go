@@ -0,0 +1,56 @@
+package models
+
+import (
+	"math/big"
+	"time"
+)
+
+// TransactionKind is how the gas of a recorded transaction was measured.
+type TransactionKind string
+
+const (
+	// TransactionKindDeployment is a mined contract creation.
+	TransactionKindDeployment TransactionKind = "deployment"
+	// TransactionKindCall is a mined call of a contract function.
+	TransactionKindCall TransactionKind = "call"
+	// TransactionKindSimulation is a call traced at the pending block before it was signed, no fee is paid.
+	TransactionKindSimulation TransactionKind = "simulation"
+)
+
+// DeploymentSelector is the selector recorded for contract creations.
+const DeploymentSelector = "constructor"
+
+// EVMTransaction records the gas used by a deployment or a function call of a contract, so gas
+// reports can compare functions, networks and deployments. Amounts are stored as decimal strings.
+type EVMTransaction struct {
+	ID   uint            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Kind TransactionKind `json:"kind" gorm:"not null;index"`
+	// Selector is the 0x-prefixed function selector, or DeploymentSelector for a deployment.
+	Selector string `json:"selector" gorm:"not null;index"`
+	// Function is the signature of the called function, e.g. "transfer(address,uint256)".
+	Function string  `json:"function"`
+	TxHash   *string `json:"tx_hash" gorm:"type:varchar(66)"`
+	Success  bool    `json:"success"`
+	GasUsed  uint64  `json:"gas_used" gorm:"not null"`
+	// GasPrice is the effective gas price in wei, zero for simulations.
+	GasPrice string `json:"gas_price" gorm:"not null;default:0"`
+
+	ContractId uint         `json:"contract_id" gorm:"not null;index;constraint:OnDelete:CASCADE"`
+	Contract   *EVMContract `json:"contract,omitempty" gorm:"foreignKey:ContractId;references:ID"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for EVMTransaction.
+func (EVMTransaction) TableName() string {
+	return "evm_transactions"
+}
+
+// Fee returns the fee paid in wei, the gas used times the effective gas price.
+func (t *EVMTransaction) Fee() *big.Int {
+	price, ok := new(big.Int).SetString(t.GasPrice, 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return price.Mul(price, new(big.Int).SetUint64(t.GasUsed))
+}
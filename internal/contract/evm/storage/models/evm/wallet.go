@@ -21,6 +21,14 @@ type EVMWallet struct {
 	// If false, only private key is stored
 	IsFromMnemonic bool `json:"is_from_mnemonic" gorm:"default:false"`
 
+	// IsRemote indicates the key is held by an external Clef-compatible signer
+	// If true, nothing is stored in secure storage and signing goes to RemoteSignerURL
+	IsRemote bool `json:"is_remote" gorm:"default:false"`
+
+	// RemoteSignerURL is the HTTP(S) URL or IPC socket path of the remote signer
+	// Only set when IsRemote is true
+	RemoteSignerURL *string `json:"remote_signer_url" gorm:"type:varchar(255)"`
+
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,43 @@
+package sql
+
+import (
+	"fmt"
+
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+)
+
+// SaveContractABI persists the ABI attached to the contract (e.g. after compiling it) and links
+// it through AbiId. A contract that is already linked to an ABI has that ABI's content replaced,
+// keeping its name; otherwise a new ABI is created, named after the compiled contract and, if the
+// name is taken, suffixed with the contract name. The contract itself is not saved.
+func SaveContractABI(storage Storage, contract *models.EVMContract) error {
+	if contract.Abi == nil {
+		return nil
+	}
+
+	if contract.AbiId != nil {
+		existing, err := storage.GetABIByID(*contract.AbiId)
+		if err == nil {
+			existing.Abi = contract.Abi.Abi
+			if err := storage.UpdateABI(existing.ID, existing); err != nil {
+				return err
+			}
+			contract.Abi = &existing
+			return nil
+		}
+	}
+
+	abi := models.EvmAbi{Name: contract.Abi.Name, Abi: contract.Abi.Abi}
+	id, err := storage.CreateABI(abi)
+	if err != nil {
+		abi.Name = fmt.Sprintf("%s (%s)", contract.Abi.Name, contract.Name)
+		if id, err = storage.CreateABI(abi); err != nil {
+			return err
+		}
+	}
+
+	abi.ID = id
+	contract.AbiId = &abi.ID
+	contract.Abi = &abi
+	return nil
+}
@@ -0,0 +1,76 @@
+package sql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type SaveContractABITestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockStorage *MockStorage
+}
+
+func TestSaveContractABITestSuite(t *testing.T) {
+	suite.Run(t, new(SaveContractABITestSuite))
+}
+
+func (s *SaveContractABITestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockStorage = NewMockStorage(s.mockCtrl)
+}
+
+func (s *SaveContractABITestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func compiledABI() *models.EvmAbi {
+	return &models.EvmAbi{Name: "Token", Abi: models.AbiArrayType{AbiArray: abi.AbiArray{{Type: "function", Name: "mint"}}}}
+}
+
+func (s *SaveContractABITestSuite) TestCreatesNewABI() {
+	contract := &models.EVMContract{Name: "My Token", Abi: compiledABI()}
+	s.mockStorage.EXPECT().CreateABI(gomock.Any()).DoAndReturn(func(created models.EvmAbi) (uint, error) {
+		s.Equal("Token", created.Name)
+		return 5, nil
+	})
+
+	s.Require().NoError(SaveContractABI(s.mockStorage, contract))
+	s.Require().NotNil(contract.AbiId)
+	s.Equal(uint(5), *contract.AbiId)
+	s.Equal(uint(5), contract.Abi.ID)
+}
+
+func (s *SaveContractABITestSuite) TestSuffixesTakenName() {
+	contract := &models.EVMContract{Name: "My Token", Abi: compiledABI()}
+	gomock.InOrder(
+		s.mockStorage.EXPECT().CreateABI(gomock.Any()).Return(uint(0), fmt.Errorf("UNIQUE constraint failed")),
+		s.mockStorage.EXPECT().CreateABI(gomock.Any()).DoAndReturn(func(created models.EvmAbi) (uint, error) {
+			s.Equal("Token (My Token)", created.Name)
+			return 6, nil
+		}),
+	)
+
+	s.Require().NoError(SaveContractABI(s.mockStorage, contract))
+	s.Equal(uint(6), *contract.AbiId)
+}
+
+func (s *SaveContractABITestSuite) TestUpdatesLinkedABI() {
+	abiID := uint(3)
+	contract := &models.EVMContract{Name: "My Token", AbiId: &abiID, Abi: compiledABI()}
+	s.mockStorage.EXPECT().GetABIByID(abiID).Return(models.EvmAbi{ID: abiID, Name: "Legacy"}, nil)
+	s.mockStorage.EXPECT().UpdateABI(abiID, gomock.Any()).DoAndReturn(func(_ uint, updated models.EvmAbi) error {
+		s.Equal("Legacy", updated.Name)
+		s.Len(updated.Abi.AbiArray, 1)
+		return nil
+	})
+
+	s.Require().NoError(SaveContractABI(s.mockStorage, contract))
+	s.Equal(abiID, *contract.AbiId)
+	s.Equal("Legacy", contract.Abi.Name)
+}
@@ -74,6 +74,26 @@ func (q *ABIQueries) Create(abi *models.EvmAbi) error {
 	return nil
 }
 
+// CreateWithSignatures creates an ABI and saves its signatures in one transaction.
+func (q *ABIQueries) CreateWithSignatures(abi *models.EvmAbi, signatures []models.EvmSignature) error {
+	return q.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewABIQueries(tx).Create(abi); err != nil {
+			return err
+		}
+		return NewSignatureQueries(tx).Save(signatures)
+	})
+}
+
+// UpdateWithSignatures updates an ABI by ID and saves its signatures in one transaction.
+func (q *ABIQueries) UpdateWithSignatures(id uint, updates map[string]interface{}, signatures []models.EvmSignature) error {
+	return q.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewABIQueries(tx).Update(id, updates); err != nil {
+			return err
+		}
+		return NewSignatureQueries(tx).Save(signatures)
+	})
+}
+
 // Update updates an ABI by ID with the provided updates.
 func (q *ABIQueries) Update(id uint, updates map[string]interface{}) error {
 	result := q.db.Model(&models.EvmAbi{}).Where("id = ?", id).Updates(updates)
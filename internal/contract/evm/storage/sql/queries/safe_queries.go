@@ -0,0 +1,126 @@
+package queries
+
+import (
+	"errors"
+
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/types"
+	customerrors "github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SafeQueries provides database operations for SafeProposal and SafeSignature models.
+type SafeQueries struct {
+	db *gorm.DB
+}
+
+// NewSafeQueries creates a new SafeQueries instance.
+func NewSafeQueries(db *gorm.DB) *SafeQueries {
+	return &SafeQueries{db: db}
+}
+
+// List retrieves a paginated list of proposals, newest first, with their signatures and endpoint.
+func (q *SafeQueries) List(page int64, pageSize int64) (*types.Pagination[models.SafeProposal], error) {
+	if page < 1 {
+		return nil, customerrors.NewDatabaseError(customerrors.ErrCodeInvalidPageNumber, "page number must be greater than 0")
+	}
+	if pageSize < 1 {
+		return nil, customerrors.NewDatabaseError(customerrors.ErrCodeInvalidPageSize, "page size must be greater than 0")
+	}
+
+	var items []models.SafeProposal
+	var totalItems int64
+
+	if err := q.db.Model(&models.SafeProposal{}).Count(&totalItems).Error; err != nil {
+		return nil, customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to count Safe proposals")
+	}
+
+	totalPages := (totalItems + pageSize - 1) / pageSize
+
+	offset := (page - 1) * pageSize
+	if err := q.db.Preload("Signatures").Preload("Endpoint").
+		Offset(int(offset)).Limit(int(pageSize)).
+		Order("id DESC").
+		Find(&items).Error; err != nil {
+		return nil, customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to list Safe proposals")
+	}
+
+	return &types.Pagination[models.SafeProposal]{
+		Items:       items,
+		TotalPages:  totalPages,
+		CurrentPage: page,
+		PageSize:    pageSize,
+		TotalItems:  totalItems,
+	}, nil
+}
+
+// GetByID retrieves a proposal by its ID with its signatures and endpoint.
+func (q *SafeQueries) GetByID(id uint) (*models.SafeProposal, error) {
+	var proposal models.SafeProposal
+	if err := q.db.Preload("Signatures", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Endpoint").First(&proposal, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.WrapDatabaseError(err, customerrors.ErrCodeRecordNotFound, "Safe proposal not found")
+		}
+		return nil, customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to get Safe proposal by ID")
+	}
+	return &proposal, nil
+}
+
+// Create creates a new proposal together with the signatures it already has.
+func (q *SafeQueries) Create(proposal *models.SafeProposal) error {
+	if err := q.db.Create(proposal).Error; err != nil {
+		return customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to create Safe proposal")
+	}
+	return nil
+}
+
+// AddSignature stores the signature of an owner, replacing the owner's previous signature of the proposal.
+func (q *SafeQueries) AddSignature(signature *models.SafeSignature) error {
+	var count int64
+	if err := q.db.Model(&models.SafeProposal{}).Where("id = ?", signature.ProposalID).Count(&count).Error; err != nil {
+		return customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to check Safe proposal existence")
+	}
+	if count == 0 {
+		return customerrors.NewDatabaseError(customerrors.ErrCodeRecordNotFound, "Safe proposal not found")
+	}
+
+	upsert := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "proposal_id"}, {Name: "owner"}},
+		DoUpdates: clause.AssignmentColumns([]string{"signature"}),
+	}
+	if err := q.db.Clauses(upsert).Create(signature).Error; err != nil {
+		return customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to save Safe signature")
+	}
+	return nil
+}
+
+// Update updates a proposal by ID with the provided updates.
+func (q *SafeQueries) Update(id uint, updates map[string]interface{}) error {
+	result := q.db.Model(&models.SafeProposal{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return customerrors.WrapDatabaseError(result.Error, customerrors.ErrCodeDatabaseOperationFailed, "failed to update Safe proposal")
+	}
+	if result.RowsAffected == 0 {
+		return customerrors.NewDatabaseError(customerrors.ErrCodeRecordNotFound, "Safe proposal not found")
+	}
+	return nil
+}
+
+// Delete deletes a proposal and its signatures by ID.
+func (q *SafeQueries) Delete(id uint) error {
+	return q.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("proposal_id = ?", id).Delete(&models.SafeSignature{}).Error; err != nil {
+			return customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to delete Safe signatures")
+		}
+		result := tx.Delete(&models.SafeProposal{}, id)
+		if result.Error != nil {
+			return customerrors.WrapDatabaseError(result.Error, customerrors.ErrCodeDatabaseOperationFailed, "failed to delete Safe proposal")
+		}
+		if result.RowsAffected == 0 {
+			return customerrors.NewDatabaseError(customerrors.ErrCodeRecordNotFound, "Safe proposal not found")
+		}
+		return nil
+	})
+}
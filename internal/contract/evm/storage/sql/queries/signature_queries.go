@@ -0,0 +1,59 @@
+package queries
+
+import (
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	customerrors "github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// signatureBatchSize limits the rows inserted per statement.
+const signatureBatchSize = 200
+
+// SignatureQueries provides database operations for EvmSignature model.
+type SignatureQueries struct {
+	db *gorm.DB
+}
+
+// NewSignatureQueries creates a new SignatureQueries instance.
+func NewSignatureQueries(db *gorm.DB) *SignatureQueries {
+	return &SignatureQueries{db: db}
+}
+
+// Save inserts the signatures, skipping the ones already stored. Stored signatures without
+// parameter names get the names of the new signature.
+func (q *SignatureQueries) Save(signatures []models.EvmSignature) error {
+	if len(signatures) == 0 {
+		return nil
+	}
+
+	upsert := clause.OnConflict{
+		Columns: []clause.Column{{Name: "kind"}, {Name: "hash"}, {Name: "signature"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "human_readable"}, Value: gorm.Expr("CASE WHEN human_readable = '' THEN excluded.human_readable ELSE human_readable END")},
+			{Column: clause.Column{Name: "source"}, Value: gorm.Expr("CASE WHEN human_readable = '' THEN excluded.source ELSE source END")},
+		},
+	}
+	if err := q.db.Clauses(upsert).CreateInBatches(&signatures, signatureBatchSize).Error; err != nil {
+		return customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to save signatures")
+	}
+	return nil
+}
+
+// ListByHash retrieves the signatures with the given selector or topic.
+func (q *SignatureQueries) ListByHash(hash string) ([]models.EvmSignature, error) {
+	var items []models.EvmSignature
+	if err := q.db.Where("hash = ?", hash).Order("id ASC").Find(&items).Error; err != nil {
+		return nil, customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to list signatures")
+	}
+	return items, nil
+}
+
+// Count returns the total number of signatures.
+func (q *SignatureQueries) Count() (int64, error) {
+	var count int64
+	if err := q.db.Model(&models.EvmSignature{}).Count(&count).Error; err != nil {
+		return 0, customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to count signatures")
+	}
+	return count, nil
+}
@@ -0,0 +1,39 @@
+package queries
+
+import (
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	customerrors "github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"gorm.io/gorm"
+)
+
+// TransactionQueries provides database operations for the EVMTransaction model.
+type TransactionQueries struct {
+	db *gorm.DB
+}
+
+// NewTransactionQueries creates a new TransactionQueries instance.
+func NewTransactionQueries(db *gorm.DB) *TransactionQueries {
+	return &TransactionQueries{db: db}
+}
+
+// Create records a transaction.
+func (q *TransactionQueries) Create(transaction *models.EVMTransaction) error {
+	if err := q.db.Create(transaction).Error; err != nil {
+		return customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to record transaction")
+	}
+	return nil
+}
+
+// ListByContractName retrieves the transactions of every contract with the name, oldest first, with
+// their contract and its endpoint.
+func (q *TransactionQueries) ListByContractName(name string) ([]models.EVMTransaction, error) {
+	var items []models.EVMTransaction
+	if err := q.db.Preload("Contract.Endpoint").
+		Joins("JOIN evm_contracts ON evm_contracts.id = evm_transactions.contract_id").
+		Where("evm_contracts.name = ?", name).
+		Order("evm_transactions.created_at ASC, evm_transactions.id ASC").
+		Find(&items).Error; err != nil {
+		return nil, customerrors.WrapDatabaseError(err, customerrors.ErrCodeDatabaseOperationFailed, "failed to list transactions")
+	}
+	return items, nil
+}
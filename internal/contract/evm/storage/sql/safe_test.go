@@ -0,0 +1,126 @@
+package sql
+
+import (
+	"path/filepath"
+	"testing"
+
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	ownerA = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
+	ownerB = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+)
+
+type SafeStorageTestSuite struct {
+	suite.Suite
+	storage    Storage
+	endpointID uint
+}
+
+func TestSafeStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(SafeStorageTestSuite))
+}
+
+func (s *SafeStorageTestSuite) SetupTest() {
+	storage, err := NewSQLiteDB(filepath.Join(s.T().TempDir(), "test.db"))
+	s.Require().NoError(err)
+	s.storage = storage
+
+	s.endpointID, err = s.storage.CreateEndpoint(models.EVMEndpoint{Name: "anvil", Url: "http://localhost:8545", ChainId: "31337"})
+	s.Require().NoError(err)
+}
+
+func (s *SafeStorageTestSuite) proposal(hash string) models.SafeProposal {
+	return models.SafeProposal{
+		SafeAddress:    "0x5afe5afE5afE5afE5afE5aFe5aFe5Afe5Afe5AfE",
+		ChainID:        "31337",
+		Version:        "1.4.1",
+		Description:    "setValue(uint256 newValue)",
+		To:             "0x000000000000000000000000000000000000bEEF",
+		Value:          "0",
+		Data:           "0x55241077",
+		SafeTxGas:      "0",
+		BaseGas:        "0",
+		GasPrice:       "0",
+		GasToken:       "0x0000000000000000000000000000000000000000",
+		RefundReceiver: "0x0000000000000000000000000000000000000000",
+		Nonce:          3,
+		SafeTxHash:     hash,
+		Status:         models.SafeProposalStatusPending,
+		EndpointId:     s.endpointID,
+	}
+}
+
+func (s *SafeStorageTestSuite) TestCreateAndGetProposal() {
+	proposal := s.proposal("0x01")
+	proposal.Signatures = []models.SafeSignature{{Owner: ownerB, Signature: "0xb1"}}
+	id, err := s.storage.CreateSafeProposal(proposal)
+	s.Require().NoError(err)
+
+	stored, err := s.storage.GetSafeProposalByID(id)
+	s.Require().NoError(err)
+	s.Equal("0x01", stored.SafeTxHash)
+	s.Equal(uint64(3), stored.Nonce)
+	s.Require().NotNil(stored.Endpoint)
+	s.Equal("anvil", stored.Endpoint.Name)
+	s.Require().Len(stored.Signatures, 1)
+	s.Equal(ownerB, stored.Signatures[0].Owner)
+	s.False(stored.IsExecuted())
+
+	_, err = s.storage.CreateSafeProposal(s.proposal("0x01"))
+	s.Error(err, "SafeTx hashes are unique")
+}
+
+func (s *SafeStorageTestSuite) TestAddSignatureReplacesOwnerSignature() {
+	id, err := s.storage.CreateSafeProposal(s.proposal("0x02"))
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.storage.AddSafeSignature(models.SafeSignature{ProposalID: id, Owner: ownerA, Signature: "0xa1"}))
+	s.Require().NoError(s.storage.AddSafeSignature(models.SafeSignature{ProposalID: id, Owner: ownerB, Signature: "0xb1"}))
+	s.Require().NoError(s.storage.AddSafeSignature(models.SafeSignature{ProposalID: id, Owner: ownerA, Signature: "0xa2"}))
+
+	stored, err := s.storage.GetSafeProposalByID(id)
+	s.Require().NoError(err)
+	s.Require().Len(stored.Signatures, 2)
+	s.Equal(ownerA, stored.Signatures[0].Owner)
+	s.Equal("0xa2", stored.Signatures[0].Signature)
+
+	err = s.storage.AddSafeSignature(models.SafeSignature{ProposalID: 99, Owner: ownerA, Signature: "0xa1"})
+	s.True(errors.HasCode(err, errors.ErrCodeRecordNotFound))
+}
+
+func (s *SafeStorageTestSuite) TestMarkExecuted() {
+	id, err := s.storage.CreateSafeProposal(s.proposal("0x03"))
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.storage.MarkSafeProposalExecuted(id, "0xabc"))
+
+	stored, err := s.storage.GetSafeProposalByID(id)
+	s.Require().NoError(err)
+	s.True(stored.IsExecuted())
+	s.Require().NotNil(stored.ExecutedTxHash)
+	s.Equal("0xabc", *stored.ExecutedTxHash)
+}
+
+func (s *SafeStorageTestSuite) TestListAndDelete() {
+	first, err := s.storage.CreateSafeProposal(s.proposal("0x04"))
+	s.Require().NoError(err)
+	second, err := s.storage.CreateSafeProposal(s.proposal("0x05"))
+	s.Require().NoError(err)
+	s.Require().NoError(s.storage.AddSafeSignature(models.SafeSignature{ProposalID: first, Owner: ownerA, Signature: "0xa1"}))
+
+	result, err := s.storage.ListSafeProposals(1, 10)
+	s.Require().NoError(err)
+	s.Equal(int64(2), result.TotalItems)
+	s.Require().Len(result.Items, 2)
+	s.Equal(second, result.Items[0].ID, "newest first")
+	s.Len(result.Items[1].Signatures, 1)
+
+	s.Require().NoError(s.storage.DeleteSafeProposal(first))
+	_, err = s.storage.GetSafeProposalByID(first)
+	s.True(errors.HasCode(err, errors.ErrCodeRecordNotFound))
+	s.True(errors.HasCode(s.storage.DeleteSafeProposal(first), errors.ErrCodeRecordNotFound))
+}
@@ -0,0 +1,119 @@
+package sql
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type SignatureStorageTestSuite struct {
+	suite.Suite
+	dbPath  string
+	storage Storage
+}
+
+func TestSignatureStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(SignatureStorageTestSuite))
+}
+
+func (s *SignatureStorageTestSuite) SetupTest() {
+	s.dbPath = filepath.Join(s.T().TempDir(), "test.db")
+	storage, err := NewSQLiteDB(s.dbPath)
+	s.Require().NoError(err)
+	s.storage = storage
+}
+
+func (s *SignatureStorageTestSuite) abi(signatures ...string) models.AbiArrayType {
+	elements, err := abi.ParseHumanReadable(signatures)
+	s.Require().NoError(err)
+	return models.AbiArrayType{AbiArray: elements}
+}
+
+func (s *SignatureStorageTestSuite) TestCreateABIStoresSignatures() {
+	_, err := s.storage.CreateABI(models.EvmAbi{Name: "Token", Abi: s.abi(
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+	)})
+	s.Require().NoError(err)
+
+	found, err := s.storage.ListSignaturesByHash("0xA9059CBB")
+	s.Require().NoError(err)
+	s.Require().Len(found, 1)
+	s.Equal("transfer(address,uint256)", found[0].Signature)
+	s.Equal("Token", found[0].Source)
+
+	found, err = s.storage.ListSignaturesByHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	s.Require().NoError(err)
+	s.Require().Len(found, 1)
+	s.Equal(models.SignatureKindEvent, found[0].Kind)
+}
+
+func (s *SignatureStorageTestSuite) TestUpdateABIAddsSignatures() {
+	id, err := s.storage.CreateABI(models.EvmAbi{Name: "Counter", Abi: s.abi("function increment()")})
+	s.Require().NoError(err)
+	s.Require().NoError(s.storage.UpdateABI(id, models.EvmAbi{Name: "Counter", Abi: s.abi("function increment()", "function reset()")}))
+
+	count, err := s.storage.CountSignatures()
+	s.Require().NoError(err)
+	s.Equal(int64(2), count, "Unchanged signatures are not duplicated")
+}
+
+func (s *SignatureStorageTestSuite) TestSaveFillsInParameterNames() {
+	s.Require().NoError(s.storage.SaveSignatures([]models.EvmSignature{
+		{Kind: models.SignatureKindFunction, Hash: "0xa9059cbb", Signature: "transfer(address,uint256)", Source: "dump"},
+	}))
+	_, err := s.storage.CreateABI(models.EvmAbi{Name: "Token", Abi: s.abi("function transfer(address to, uint256 amount)")})
+	s.Require().NoError(err)
+	_, err = s.storage.CreateABI(models.EvmAbi{Name: "Other", Abi: s.abi("function transfer(address recipient, uint256 value)")})
+	s.Require().NoError(err)
+
+	found, err := s.storage.ListSignaturesByHash("0xa9059cbb")
+	s.Require().NoError(err)
+	s.Require().Len(found, 1)
+	s.Equal("function transfer(address to, uint256 amount)", found[0].HumanReadable)
+	s.Equal("Token", found[0].Source, "The first names are kept")
+}
+
+func (s *SignatureStorageTestSuite) TestBackfillsExistingABIs() {
+	_, err := s.storage.CreateABI(models.EvmAbi{Name: "Counter", Abi: s.abi("function increment()")})
+	s.Require().NoError(err)
+
+	// Simulate a database created before the signatures table existed
+	database, err := gorm.Open(sqlite.Open(s.dbPath), &gorm.Config{})
+	s.Require().NoError(err)
+	s.Require().NoError(database.Exec("DELETE FROM evm_signatures").Error)
+
+	reopened, err := NewSQLiteDB(s.dbPath)
+	s.Require().NoError(err)
+	count, err := reopened.CountSignatures()
+	s.Require().NoError(err)
+	s.Equal(int64(1), count)
+}
+
+func (s *SignatureStorageTestSuite) TestABIIsNotStoredWhenSignaturesFail() {
+	id, err := s.storage.CreateABI(models.EvmAbi{Name: "Counter", Abi: s.abi("function increment()")})
+	s.Require().NoError(err)
+
+	database, err := gorm.Open(sqlite.Open(s.dbPath), &gorm.Config{})
+	s.Require().NoError(err)
+	s.Require().NoError(database.Migrator().DropTable(&models.EvmSignature{}))
+
+	_, err = s.storage.CreateABI(models.EvmAbi{Name: "Token", Abi: s.abi("function transfer(address to, uint256 amount)")})
+	s.Require().Error(err)
+	s.Require().Error(s.storage.UpdateABI(id, models.EvmAbi{Name: "Renamed", Abi: s.abi("function reset()")}))
+
+	result, err := s.storage.ListABIs(1, 10)
+	s.Require().NoError(err)
+	s.Require().Len(result.Items, 1, "The ABI is rolled back with its signatures")
+	s.Equal("Counter", result.Items[0].Name)
+
+	// The failed ABI can be created once the signatures can be saved
+	s.Require().NoError(database.AutoMigrate(&models.EvmSignature{}))
+	_, err = s.storage.CreateABI(models.EvmAbi{Name: "Token", Abi: s.abi("function transfer(address to, uint256 amount)")})
+	s.Require().NoError(err)
+}
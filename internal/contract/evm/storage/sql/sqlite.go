@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
 	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql/queries"
@@ -13,11 +14,15 @@ import (
 )
 
 type SQLiteStorage struct {
-	abiQueries      *queries.ABIQueries
-	endpointQueries *queries.EndpointQueries
-	contractQueries *queries.ContractQueries
-	configQueries   *queries.ConfigQueries
-	walletQueries   *queries.WalletQueries
+	database           *gorm.DB
+	abiQueries         *queries.ABIQueries
+	endpointQueries    *queries.EndpointQueries
+	contractQueries    *queries.ContractQueries
+	configQueries      *queries.ConfigQueries
+	walletQueries      *queries.WalletQueries
+	signatureQueries   *queries.SignatureQueries
+	safeQueries        *queries.SafeQueries
+	transactionQueries *queries.TransactionQueries
 }
 
 // ABI Methods
@@ -33,7 +38,7 @@ func (s *SQLiteStorage) CountABIs() (count int64, err error) {
 
 // CreateABI implements Storage.
 func (s *SQLiteStorage) CreateABI(abi models.EvmAbi) (id uint, err error) {
-	if err := s.abiQueries.Create(&abi); err != nil {
+	if err := s.abiQueries.CreateWithSignatures(&abi, models.SignaturesFromABI(abi.Abi.AbiArray, abi.Name)); err != nil {
 		return 0, fmt.Errorf("failed to create ABI: %w", err)
 	}
 	return abi.ID, nil
@@ -80,12 +85,147 @@ func (s *SQLiteStorage) UpdateABI(id uint, abi models.EvmAbi) (err error) {
 		"name": abi.Name,
 		"abi":  abi.Abi,
 	}
-	if err := s.abiQueries.Update(id, updates); err != nil {
+	if err := s.abiQueries.UpdateWithSignatures(id, updates, models.SignaturesFromABI(abi.Abi.AbiArray, abi.Name)); err != nil {
 		return fmt.Errorf("failed to update ABI: %w", err)
 	}
 	return nil
 }
 
+// Signature Methods
+
+// SaveSignatures implements Storage.
+func (s *SQLiteStorage) SaveSignatures(signatures []models.EvmSignature) (err error) {
+	if err := s.signatureQueries.Save(signatures); err != nil {
+		return fmt.Errorf("failed to save signatures: %w", err)
+	}
+	return nil
+}
+
+// ListSignaturesByHash implements Storage.
+func (s *SQLiteStorage) ListSignaturesByHash(hash string) (signatures []models.EvmSignature, err error) {
+	signatures, err = s.signatureQueries.ListByHash(strings.ToLower(hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signatures: %w", err)
+	}
+	return signatures, nil
+}
+
+// CountSignatures implements Storage.
+func (s *SQLiteStorage) CountSignatures() (count int64, err error) {
+	count, err = s.signatureQueries.Count()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count signatures: %w", err)
+	}
+	return count, nil
+}
+
+// Safe Proposal Methods
+
+// CreateSafeProposal implements Storage.
+func (s *SQLiteStorage) CreateSafeProposal(proposal models.SafeProposal) (id uint, err error) {
+	if err := s.safeQueries.Create(&proposal); err != nil {
+		return 0, fmt.Errorf("failed to create Safe proposal: %w", err)
+	}
+	return proposal.ID, nil
+}
+
+// ListSafeProposals implements Storage.
+func (s *SQLiteStorage) ListSafeProposals(page int64, pageSize int64) (proposals types.Pagination[models.SafeProposal], err error) {
+	result, err := s.safeQueries.List(page, pageSize)
+	if err != nil {
+		return types.Pagination[models.SafeProposal]{}, fmt.Errorf("failed to list Safe proposals: %w", err)
+	}
+	return *result, nil
+}
+
+// GetSafeProposalByID implements Storage.
+func (s *SQLiteStorage) GetSafeProposalByID(id uint) (proposal models.SafeProposal, err error) {
+	result, err := s.safeQueries.GetByID(id)
+	if err != nil {
+		return models.SafeProposal{}, fmt.Errorf("failed to get Safe proposal by ID: %w", err)
+	}
+	return *result, nil
+}
+
+// AddSafeSignature implements Storage.
+func (s *SQLiteStorage) AddSafeSignature(signature models.SafeSignature) (err error) {
+	if err := s.safeQueries.AddSignature(&signature); err != nil {
+		return fmt.Errorf("failed to add Safe signature: %w", err)
+	}
+	return nil
+}
+
+// MarkSafeProposalExecuted implements Storage.
+func (s *SQLiteStorage) MarkSafeProposalExecuted(id uint, txHash string) (err error) {
+	updates := map[string]interface{}{
+		"status":           models.SafeProposalStatusExecuted,
+		"executed_tx_hash": txHash,
+	}
+	if err := s.safeQueries.Update(id, updates); err != nil {
+		return fmt.Errorf("failed to mark Safe proposal as executed: %w", err)
+	}
+	return nil
+}
+
+// DeleteSafeProposal implements Storage.
+func (s *SQLiteStorage) DeleteSafeProposal(id uint) (err error) {
+	if err := s.safeQueries.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete Safe proposal: %w", err)
+	}
+	return nil
+}
+
+// Transaction History Methods
+
+// RecordTransaction implements Storage.
+func (s *SQLiteStorage) RecordTransaction(transaction models.EVMTransaction) (id uint, err error) {
+	if err := s.transactionQueries.Create(&transaction); err != nil {
+		return 0, fmt.Errorf("failed to record transaction: %w", err)
+	}
+	return transaction.ID, nil
+}
+
+// ListTransactionsByContractName implements Storage.
+func (s *SQLiteStorage) ListTransactionsByContractName(name string) (transactions []models.EVMTransaction, err error) {
+	transactions, err = s.transactionQueries.ListByContractName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// Close implements Storage.
+func (s *SQLiteStorage) Close() error {
+	sqlDB, err := s.database.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	return sqlDB.Close()
+}
+
+// backfillSignatures saves the signatures of the ABIs stored before the signatures table existed.
+func (s *SQLiteStorage) backfillSignatures() error {
+	count, err := s.CountSignatures()
+	if err != nil || count > 0 {
+		return err
+	}
+
+	for page := int64(1); ; page++ {
+		result, err := s.abiQueries.List(page, 100)
+		if err != nil {
+			return fmt.Errorf("failed to list ABIs: %w", err)
+		}
+		for _, abi := range result.Items {
+			if err := s.SaveSignatures(models.SignaturesFromABI(abi.Abi.AbiArray, abi.Name)); err != nil {
+				return err
+			}
+		}
+		if page >= result.TotalPages {
+			return nil
+		}
+	}
+}
+
 // Endpoint Methods
 
 // CountEndpoints implements Storage.
@@ -143,9 +283,11 @@ func (s *SQLiteStorage) SearchEndpoints(query string) (endpoints types.Paginatio
 // UpdateEndpoint implements Storage.
 func (s *SQLiteStorage) UpdateEndpoint(endpointID uint, endpoint models.EVMEndpoint) (err error) {
 	updates := map[string]any{
-		"name":     endpoint.Name,
-		"url":      endpoint.Url,
-		"chain_id": endpoint.ChainId,
+		"name":          endpoint.Name,
+		"url":           endpoint.Url,
+		"chain_id":      endpoint.ChainId,
+		"confirmations": endpoint.Confirmations,
+		"priority":      endpoint.Priority,
 	}
 	if err := s.endpointQueries.Update(endpointID, updates); err != nil {
 		return fmt.Errorf("failed to update endpoint: %w", err)
@@ -210,13 +352,14 @@ func (s *SQLiteStorage) SearchContracts(query string) (contracts types.Paginatio
 // UpdateContract implements Storage.
 func (s *SQLiteStorage) UpdateContract(contractID uint, contract models.EVMContract) (err error) {
 	updates := map[string]any{
-		"name":          contract.Name,
-		"address":       contract.Address,
-		"abi_id":        contract.AbiId,
-		"status":        contract.Status,
-		"contract_code": contract.ContractCode,
-		"bytecode":      contract.Bytecode,
-		"endpoint_id":   contract.EndpointId,
+		"name":           contract.Name,
+		"address":        contract.Address,
+		"abi_id":         contract.AbiId,
+		"status":         contract.Status,
+		"contract_code":  contract.ContractCode,
+		"bytecode":       contract.Bytecode,
+		"storage_layout": contract.StorageLayout,
+		"endpoint_id":    contract.EndpointId,
 	}
 	if err := s.contractQueries.Update(contractID, updates); err != nil {
 		return fmt.Errorf("failed to update contract: %w", err)
@@ -333,10 +476,12 @@ func (s *SQLiteStorage) SearchWallets(query string) (wallets types.Pagination[mo
 // UpdateWallet implements Storage.
 func (s *SQLiteStorage) UpdateWallet(walletID uint, wallet models.EVMWallet) (err error) {
 	updates := map[string]any{
-		"alias":            wallet.Alias,
-		"address":          wallet.Address,
-		"derivation_path":  wallet.DerivationPath,
-		"is_from_mnemonic": wallet.IsFromMnemonic,
+		"alias":             wallet.Alias,
+		"address":           wallet.Address,
+		"derivation_path":   wallet.DerivationPath,
+		"is_from_mnemonic":  wallet.IsFromMnemonic,
+		"is_remote":         wallet.IsRemote,
+		"remote_signer_url": wallet.RemoteSignerURL,
 	}
 	if err := s.walletQueries.Update(walletID, updates); err != nil {
 		return fmt.Errorf("failed to update wallet: %w", err)
@@ -402,16 +547,28 @@ func NewSQLiteDB(dbPath string) (Storage, error) {
 		&models.EVMContract{},
 		&models.EVMConfig{},
 		&models.EVMWallet{},
+		&models.EvmSignature{},
+		&models.SafeProposal{},
+		&models.SafeSignature{},
+		&models.EVMTransaction{},
 	); err != nil {
 		return nil, fmt.Errorf("failed to migrate database schema: %w", err)
 	}
 
 	// Initialize query helpers
-	return &SQLiteStorage{
-		abiQueries:      queries.NewABIQueries(database),
-		endpointQueries: queries.NewEndpointQueries(database),
-		contractQueries: queries.NewContractQueries(database),
-		configQueries:   queries.NewConfigQueries(database),
-		walletQueries:   queries.NewWalletQueries(database),
-	}, nil
+	storage := &SQLiteStorage{
+		database:           database,
+		abiQueries:         queries.NewABIQueries(database),
+		endpointQueries:    queries.NewEndpointQueries(database),
+		contractQueries:    queries.NewContractQueries(database),
+		configQueries:      queries.NewConfigQueries(database),
+		walletQueries:      queries.NewWalletQueries(database),
+		signatureQueries:   queries.NewSignatureQueries(database),
+		safeQueries:        queries.NewSafeQueries(database),
+		transactionQueries: queries.NewTransactionQueries(database),
+	}
+	if err := storage.backfillSignatures(); err != nil {
+		return nil, err
+	}
+	return storage, nil
 }
@@ -54,6 +54,26 @@ type Storage interface {
 	DeleteWallet(id uint) (err error)
 	WalletExistsByAddress(address string) (exists bool, err error)
 	WalletExistsByAlias(alias string) (exists bool, err error)
+
+	// Signature methods
+	SaveSignatures(signatures []models.EvmSignature) (err error)
+	ListSignaturesByHash(hash string) (signatures []models.EvmSignature, err error)
+	CountSignatures() (count int64, err error)
+
+	// Safe proposal methods
+	CreateSafeProposal(proposal models.SafeProposal) (id uint, err error)
+	ListSafeProposals(page int64, pageSize int64) (proposals types.Pagination[models.SafeProposal], err error)
+	GetSafeProposalByID(id uint) (proposal models.SafeProposal, err error)
+	AddSafeSignature(signature models.SafeSignature) (err error)
+	MarkSafeProposalExecuted(id uint, txHash string) (err error)
+	DeleteSafeProposal(id uint) (err error)
+
+	// Transaction history methods
+	RecordTransaction(transaction models.EVMTransaction) (id uint, err error)
+	ListTransactionsByContractName(name string) (transactions []models.EVMTransaction, err error)
+
+	// Close closes the database connection
+	Close() error
 }
 
 func GetStorage(storageType types.StorageClient, params ...any) (Storage, error) {
@@ -0,0 +1,68 @@
+package sql
+
+import (
+	"path/filepath"
+	"testing"
+
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransactionStorageTestSuite struct {
+	suite.Suite
+	storage Storage
+}
+
+func TestTransactionStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactionStorageTestSuite))
+}
+
+func (s *TransactionStorageTestSuite) SetupTest() {
+	storage, err := NewSQLiteDB(filepath.Join(s.T().TempDir(), "test.db"))
+	s.Require().NoError(err)
+	s.storage = storage
+}
+
+func (s *TransactionStorageTestSuite) contract(name, address string) uint {
+	endpointID, err := s.storage.CreateEndpoint(models.EVMEndpoint{Name: "anvil " + address, Url: "http://localhost:8545", ChainId: "31337"})
+	s.Require().NoError(err)
+	id, err := s.storage.CreateContract(models.EVMContract{Name: name, Address: address, EndpointId: endpointID, Status: models.DeploymentStatusDeployed})
+	s.Require().NoError(err)
+	return id
+}
+
+func (s *TransactionStorageTestSuite) TestListTransactionsOfEveryDeployment() {
+	first := s.contract("Token", "0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	second := s.contract("Token", "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+	other := s.contract("Vault", "0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0")
+
+	for _, transaction := range []models.EVMTransaction{
+		{ContractId: first, Kind: models.TransactionKindDeployment, Selector: models.DeploymentSelector, GasUsed: 500000, GasPrice: "2"},
+		{ContractId: second, Kind: models.TransactionKindCall, Selector: "0xa9059cbb", GasUsed: 51000, GasPrice: "3"},
+		{ContractId: other, Kind: models.TransactionKindCall, Selector: "0xa9059cbb", GasUsed: 40000, GasPrice: "1"},
+	} {
+		_, err := s.storage.RecordTransaction(transaction)
+		s.Require().NoError(err)
+	}
+
+	transactions, err := s.storage.ListTransactionsByContractName("Token")
+	s.Require().NoError(err)
+	s.Require().Len(transactions, 2)
+	s.Equal(first, transactions[0].ContractId)
+	s.Equal(second, transactions[1].ContractId)
+	s.Require().NotNil(transactions[1].Contract)
+	s.Require().NotNil(transactions[1].Contract.Endpoint)
+	s.Equal("31337", transactions[1].Contract.Endpoint.ChainId)
+	s.Equal("153000", transactions[1].Fee().String())
+}
+
+func (s *TransactionStorageTestSuite) TestDeletingContractDeletesItsTransactions() {
+	id := s.contract("Token", "0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	_, err := s.storage.RecordTransaction(models.EVMTransaction{ContractId: id, Kind: models.TransactionKindSimulation, Selector: "0xa9059cbb", GasUsed: 51000})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.storage.DeleteContract(id))
+	transactions, err := s.storage.ListTransactionsByContractName("Token")
+	s.Require().NoError(err)
+	s.Empty(transactions)
+}
@@ -0,0 +1,358 @@
+package storagelayout
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// astNode holds the fields of a solc compact AST node that the layout is built from.
+type astNode struct {
+	ID                      int       `json:"id"`
+	NodeType                string    `json:"nodeType"`
+	Name                    string    `json:"name"`
+	ContractKind            string    `json:"contractKind"`
+	StateVariable           bool      `json:"stateVariable"`
+	Constant                bool      `json:"constant"`
+	Mutability              string    `json:"mutability"`
+	StorageLocation         string    `json:"storageLocation"`
+	Visibility              string    `json:"visibility"`
+	LinearizedBaseContracts []int     `json:"linearizedBaseContracts"`
+	ReferencedDeclaration   int       `json:"referencedDeclaration"`
+	TypeName                *astNode  `json:"typeName"`
+	KeyType                 *astNode  `json:"keyType"`
+	ValueType               *astNode  `json:"valueType"`
+	BaseType                *astNode  `json:"baseType"`
+	UnderlyingType          *astNode  `json:"underlyingType"`
+	Nodes                   []astNode `json:"nodes"`
+	Members                 []astNode `json:"members"`
+	StorageLayout           *struct {
+		BaseSlotExpression *astNode `json:"baseSlotExpression"`
+	} `json:"storageLayout"`
+	TypeDescriptions struct {
+		TypeIdentifier string `json:"typeIdentifier"`
+		TypeString     string `json:"typeString"`
+	} `json:"typeDescriptions"`
+}
+
+// builder derives storage layouts from the AST, following the Solidity rules: value types are packed
+// into slots in declaration order, while mappings, arrays, structs, strings and bytes start a new slot
+// and make the next item start a new slot. The layouts are checked against the storageLayout output of
+// solc by the compiler tests.
+type builder struct {
+	declarations map[int]*astNode
+	files        map[int]string
+	// contract is the fully qualified name of the contract being laid out, which solc reports as the
+	// contract of every variable and struct member of its layout
+	contract string
+	types    map[string]Type
+}
+
+// FromAST builds the storage layout of every contract in the compact ASTs of a compilation, keyed by
+// source file name. The result is keyed by "file:Contract", matching solc's fully qualified names.
+func FromAST(asts map[string]json.RawMessage) (map[string]*Layout, error) {
+	builder := &builder{declarations: map[int]*astNode{}, files: map[int]string{}}
+	units := make(map[string]*astNode, len(asts))
+	for file, raw := range asts {
+		if len(raw) == 0 {
+			continue
+		}
+		unit := &astNode{}
+		if err := json.Unmarshal(raw, unit); err != nil {
+			return nil, errors.WrapContractError(err, errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("failed to parse the AST of %s", file))
+		}
+		units[file] = unit
+		builder.index(unit, file)
+	}
+
+	layouts := map[string]*Layout{}
+	for file, unit := range units {
+		for index := range unit.Nodes {
+			contract := &unit.Nodes[index]
+			if contract.NodeType != "ContractDefinition" || contract.ContractKind != "contract" {
+				continue
+			}
+			layout, err := builder.contractLayout(contract)
+			if err != nil {
+				return nil, err
+			}
+			layouts[file+":"+contract.Name] = layout
+		}
+	}
+	return layouts, nil
+}
+
+func (b *builder) index(node *astNode, file string) {
+	b.declarations[node.ID] = node
+	if node.NodeType == "ContractDefinition" {
+		b.files[node.ID] = file
+	}
+	for index := range node.Nodes {
+		b.index(&node.Nodes[index], file)
+	}
+}
+
+func (b *builder) contractLayout(contract *astNode) (*Layout, error) {
+	b.types = map[string]Type{}
+	b.contract = b.files[contract.ID] + ":" + contract.Name
+
+	variables := []*astNode{}
+	for index := len(contract.LinearizedBaseContracts) - 1; index >= 0; index-- {
+		base, ok := b.declarations[contract.LinearizedBaseContracts[index]]
+		if !ok {
+			return nil, errors.NewContractError(errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("base contract %d of %s is missing from the AST", contract.LinearizedBaseContracts[index], contract.Name))
+		}
+		for member := range base.Nodes {
+			variable := &base.Nodes[member]
+			if variable.NodeType != "VariableDeclaration" || !variable.StateVariable || variable.Constant {
+				continue
+			}
+			// Transient variables have a layout of their own, in transient storage
+			if variable.Mutability == "immutable" || variable.Mutability == "constant" || variable.StorageLocation == "transient" {
+				continue
+			}
+			variables = append(variables, variable)
+		}
+	}
+
+	storage, _, err := b.place(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	// A "layout at" specifier moves every variable, including inherited ones, by its base slot
+	if contract.StorageLayout != nil {
+		base, err := baseSlot(contract)
+		if err != nil {
+			return nil, err
+		}
+		for index := range storage {
+			slot, _ := new(big.Int).SetString(storage[index].Slot, 10)
+			storage[index].Slot = slot.Add(slot, base).String()
+		}
+	}
+	return &Layout{Storage: storage, Types: b.types, Derived: true}, nil
+}
+
+// baseSlot returns the base slot of a "layout at" specifier. Its expression is a compile-time constant,
+// whose value solc records in full in the type identifier, while the type string abbreviates it.
+func baseSlot(contract *astNode) (*big.Int, error) {
+	expression := contract.StorageLayout.BaseSlotExpression
+	if expression != nil {
+		digits, ok := strings.CutPrefix(expression.TypeDescriptions.TypeIdentifier, "t_rational_")
+		if digits, ok = strings.CutSuffix(digits, "_by_1"); ok {
+			if base, ok := new(big.Int).SetString(digits, 10); ok {
+				return base, nil
+			}
+		}
+	}
+	return nil, errors.NewContractError(errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("unsupported base slot of the layout of %s", contract.Name))
+}
+
+// place assigns slots and offsets to a list of variables and returns the number of slots used.
+func (b *builder) place(variables []*astNode) ([]Variable, int, error) {
+	placed := make([]Variable, 0, len(variables))
+	slot, offset := 0, 0
+	for _, variable := range variables {
+		typeID, err := b.typeOf(variable.TypeName)
+		if err != nil {
+			return nil, 0, err
+		}
+		storageType := b.types[typeID]
+		size := storageType.Size()
+
+		if packed(storageType) {
+			if offset+size > 32 {
+				slot, offset = slot+1, 0
+			}
+		} else if offset > 0 {
+			slot, offset = slot+1, 0
+		}
+
+		placed = append(placed, Variable{
+			AstID:    variable.ID,
+			Contract: b.contract,
+			Label:    variable.Name,
+			Offset:   offset,
+			Slot:     strconv.Itoa(slot),
+			Type:     typeID,
+		})
+
+		if packed(storageType) {
+			offset += size
+		} else {
+			slot += (size + 31) / 32
+		}
+	}
+	if offset > 0 {
+		slot++
+	}
+	return placed, slot, nil
+}
+
+// typeOf registers the type of a type name node and returns its identifier.
+func (b *builder) typeOf(node *astNode) (string, error) {
+	if node == nil {
+		return "", errors.NewContractError(errors.ErrCodeInvalidStorageLayout, "state variable without a type in the AST")
+	}
+	label := node.TypeDescriptions.TypeString
+
+	switch node.NodeType {
+	case "ElementaryTypeName":
+		return b.elementary(node.Name, label)
+
+	case "Mapping":
+		keyID, err := b.typeOf(node.KeyType)
+		if err != nil {
+			return "", err
+		}
+		valueID, err := b.typeOf(node.ValueType)
+		if err != nil {
+			return "", err
+		}
+		id := fmt.Sprintf("t_mapping(%s,%s)", keyID, valueID)
+		return b.register(id, Type{Encoding: EncodingMapping, Label: label, NumberOfBytes: "32", Key: keyID, Value: valueID}), nil
+
+	case "ArrayTypeName":
+		baseID, err := b.typeOf(node.BaseType)
+		if err != nil {
+			return "", err
+		}
+		length := arrayLength(label)
+		if strings.HasSuffix(label, "[]") {
+			id := fmt.Sprintf("t_array(%s)dyn_storage", baseID)
+			return b.register(id, Type{Encoding: EncodingDynamicArray, Label: label, NumberOfBytes: "32", Base: baseID}), nil
+		}
+		baseSize := b.types[baseID].Size()
+		slots := length * int64((baseSize+31)/32)
+		if baseSize < 32 {
+			perSlot := int64(32 / baseSize)
+			slots = (length + perSlot - 1) / perSlot
+		}
+		id := fmt.Sprintf("t_array(%s)%d_storage", baseID, length)
+		return b.register(id, Type{Encoding: EncodingInplace, Label: label, NumberOfBytes: strconv.FormatInt(slots*32, 10), Base: baseID}), nil
+
+	case "FunctionTypeName":
+		size := "8"
+		if node.Visibility == "external" {
+			size = "24"
+		}
+		return b.register(richIdentifier(node.TypeDescriptions.TypeIdentifier), Type{Encoding: EncodingInplace, Label: label, NumberOfBytes: size}), nil
+
+	case "UserDefinedTypeName":
+		return b.userDefined(node.ReferencedDeclaration, label)
+	}
+	return "", errors.NewContractError(errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("unsupported type %s (%s)", label, node.NodeType))
+}
+
+func (b *builder) elementary(name string, label string) (string, error) {
+	size := 32
+	encoding := EncodingInplace
+	switch {
+	case name == "bool":
+		size = 1
+	case name == "address":
+		size = 20
+		if label == "address payable" {
+			name = "address_payable"
+		}
+	case name == "string" || name == "bytes":
+		encoding = EncodingBytes
+		label = name
+		name += "_storage"
+	case name == "uint" || name == "int":
+		name += "256"
+	case strings.HasPrefix(name, "uint"):
+		bits, err := strconv.Atoi(strings.TrimPrefix(name, "uint"))
+		if err != nil {
+			return "", errors.WrapContractError(err, errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("unsupported type %s", name))
+		}
+		size = bits / 8
+	case strings.HasPrefix(name, "int"):
+		bits, err := strconv.Atoi(strings.TrimPrefix(name, "int"))
+		if err != nil {
+			return "", errors.WrapContractError(err, errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("unsupported type %s", name))
+		}
+		size = bits / 8
+	case strings.HasPrefix(name, "bytes"):
+		bytes, err := strconv.Atoi(strings.TrimPrefix(name, "bytes"))
+		if err != nil {
+			return "", errors.WrapContractError(err, errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("unsupported type %s", name))
+		}
+		size = bytes
+	}
+	return b.register("t_"+name, Type{Encoding: encoding, Label: label, NumberOfBytes: strconv.Itoa(size)}), nil
+}
+
+func (b *builder) userDefined(id int, label string) (string, error) {
+	declaration, ok := b.declarations[id]
+	if !ok {
+		return "", errors.NewContractError(errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("declaration of %s is missing from the AST", label))
+	}
+
+	switch declaration.NodeType {
+	case "ContractDefinition":
+		return b.register(fmt.Sprintf("t_contract(%s)%d", declaration.Name, id), Type{Encoding: EncodingInplace, Label: label, NumberOfBytes: "20"}), nil
+
+	case "EnumDefinition":
+		size := 1
+		for members := len(declaration.Members); members > 256; members /= 256 {
+			size++
+		}
+		return b.register(fmt.Sprintf("t_enum(%s)%d", declaration.Name, id), Type{Encoding: EncodingInplace, Label: label, NumberOfBytes: strconv.Itoa(size)}), nil
+
+	case "UserDefinedValueTypeDefinition":
+		underlyingID, err := b.typeOf(declaration.UnderlyingType)
+		if err != nil {
+			return "", err
+		}
+		underlying := b.types[underlyingID]
+		underlying.Label = label
+		return b.register(fmt.Sprintf("t_userDefinedValueType(%s)%d", declaration.Name, id), underlying), nil
+
+	case "StructDefinition":
+		typeID := fmt.Sprintf("t_struct(%s)%d_storage", declaration.Name, id)
+		if _, ok := b.types[typeID]; ok {
+			return typeID, nil
+		}
+		// Register the struct before its members so recursive structs (through mappings or arrays) resolve.
+		b.types[typeID] = Type{Encoding: EncodingInplace, Label: label, NumberOfBytes: "32"}
+
+		members := make([]*astNode, 0, len(declaration.Members))
+		for index := range declaration.Members {
+			members = append(members, &declaration.Members[index])
+		}
+		placed, slots, err := b.place(members)
+		if err != nil {
+			return "", err
+		}
+		if slots == 0 {
+			slots = 1
+		}
+		b.types[typeID] = Type{Encoding: EncodingInplace, Label: label, NumberOfBytes: strconv.Itoa(slots * 32), Members: placed}
+		return typeID, nil
+	}
+	return "", errors.NewContractError(errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("unsupported type %s (%s)", label, declaration.NodeType))
+}
+
+// richIdentifier turns the type identifier of the AST back into the identifier solc uses in layouts, which
+// spells out the parentheses and commas the AST escapes.
+func richIdentifier(identifier string) string {
+	return strings.NewReplacer("_$_", ",", "$_", "(", "_$", ")").Replace(identifier)
+}
+
+func (b *builder) register(id string, storageType Type) string {
+	if _, ok := b.types[id]; !ok {
+		b.types[id] = storageType
+	}
+	return id
+}
+
+// packed reports whether values of the type share slots with their neighbours.
+func packed(storageType Type) bool {
+	return storageType.Encoding == EncodingInplace && storageType.Base == "" && len(storageType.Members) == 0 && !strings.HasPrefix(storageType.Label, "struct ")
+}
@@ -0,0 +1,93 @@
+package inspector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// accessor is a struct member (.name) or a mapping key or array index ([key]).
+type accessor struct {
+	member string
+	key    string
+}
+
+func (a accessor) String() string {
+	if a.member != "" {
+		return "." + a.member
+	}
+	return "[" + a.key + "]"
+}
+
+// parseExpression splits an expression such as orders[2].maker into the variable label and its accessors.
+func parseExpression(expression string) (string, []accessor, error) {
+	end := strings.IndexAny(expression, ".[")
+	if end < 0 {
+		end = len(expression)
+	}
+	label := strings.TrimSpace(expression[:end])
+	if !isIdentifier(label) {
+		return "", nil, errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("invalid variable name %q", label))
+	}
+
+	var accessors []accessor
+	rest := expression[end:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			next := strings.IndexAny(rest[1:], ".[")
+			if next < 0 {
+				next = len(rest) - 1
+			}
+			member := strings.TrimSpace(rest[1 : next+1])
+			if !isIdentifier(member) {
+				return "", nil, errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("invalid member name %q", member))
+			}
+			accessors = append(accessors, accessor{member: member})
+			rest = rest[next+1:]
+		case '[':
+			closing := closingBracket(rest)
+			if closing < 0 {
+				return "", nil, errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("missing ] in %s", expression))
+			}
+			key := strings.TrimSpace(rest[1:closing])
+			if key == "" {
+				return "", nil, errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("empty key in %s", expression))
+			}
+			accessors = append(accessors, accessor{key: key})
+			rest = rest[closing+1:]
+		default:
+			return "", nil, errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("unexpected %q in %s", rest[0], expression))
+		}
+	}
+	return label, accessors, nil
+}
+
+// closingBracket returns the index of the ] matching the [ at the start of input, skipping quoted keys.
+func closingBracket(input string) int {
+	quoted := false
+	for index := 1; index < len(input); index++ {
+		switch {
+		case input[index] == '\\' && quoted:
+			index++
+		case input[index] == '"':
+			quoted = !quoted
+		case input[index] == ']' && !quoted:
+			return index
+		}
+	}
+	return -1
+}
+
+func isIdentifier(name string) bool {
+	if name == "" || isDigit(name[0]) {
+		return false
+	}
+	for _, char := range name {
+		if char != '_' && char != '$' && (char < 'a' || char > 'z') && (char < 'A' || char > 'Z') && (char < '0' || char > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,451 @@
+// Package inspector reads and decodes the state variables of a deployed contract with
+// eth_getStorageAt, using the contract's storage layout.
+package inspector
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storagelayout"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// MaxElements is the number of array elements decoded when a whole array is displayed.
+const MaxElements = 10
+
+// maxBytesLength is the number of bytes read for long strings and byte arrays.
+const maxBytesLength = 1024
+
+var slotModulus = new(big.Int).Lsh(big.NewInt(1), 256)
+
+// location is the position of a value in storage. Offset counts bytes from the lower-order end of the slot.
+type location struct {
+	slot   *big.Int
+	offset int
+}
+
+func (l location) add(slots *big.Int, offset int) location {
+	return location{slot: new(big.Int).Mod(new(big.Int).Add(l.slot, slots), slotModulus), offset: offset}
+}
+
+// Inspector reads the state variables of a contract. Slots are cached for the lifetime of the inspector.
+type Inspector struct {
+	transport transport.Transport
+	address   common.Address
+	layout    *storagelayout.Layout
+	words     map[common.Hash]common.Hash
+}
+
+// New creates an inspector for the contract at address. The layout may be nil, in which case only
+// raw slots can be read.
+func New(tr transport.Transport, address common.Address, layout *storagelayout.Layout) *Inspector {
+	return &Inspector{
+		transport: tr,
+		address:   address,
+		layout:    layout,
+		words:     map[common.Hash]common.Hash{},
+	}
+}
+
+// HasLayout reports whether the inspector can decode state variables.
+func (i *Inspector) HasLayout() bool {
+	return !i.layout.IsEmpty()
+}
+
+// Variables reads and decodes every state variable. Mappings are listed without entries and arrays
+// show their first MaxElements elements.
+func (i *Inspector) Variables() ([]decoder.Value, error) {
+	if !i.HasLayout() {
+		return nil, errors.NewContractError(errors.ErrCodeInvalidStorageLayout, "the contract has no storage layout")
+	}
+
+	values := make([]decoder.Value, 0, len(i.layout.Storage))
+	for _, variable := range i.layout.Storage {
+		start, err := variableLocation(variable, location{slot: new(big.Int)})
+		if err != nil {
+			return nil, err
+		}
+		value, err := i.decode(variable.Label, variable.Type, start)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Evaluate reads the value selected by an expression. An expression is either a raw slot, as a
+// decimal or 0x-prefixed number, or a state variable followed by mapping keys, array indices and
+// struct members, e.g. balances[0x70997970C51812dc3A010C7d01b50e0d17dc79C8] or orders[2].maker.
+// String mapping keys may be quoted.
+func (i *Inspector) Evaluate(expression string) (decoder.Value, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return decoder.Value{}, errors.NewContractError(errors.ErrCodeInvalidStorageExpression, "expression is empty")
+	}
+	if isDigit(expression[0]) {
+		slot, ok := new(big.Int).SetString(expression, 0)
+		if !ok || slot.Sign() < 0 || slot.Cmp(slotModulus) >= 0 {
+			return decoder.Value{}, errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("invalid slot %s", expression))
+		}
+		return i.ReadSlot(slot)
+	}
+	if !i.HasLayout() {
+		return decoder.Value{}, errors.NewContractError(errors.ErrCodeInvalidStorageLayout, "the contract has no storage layout, enter a slot number instead")
+	}
+
+	label, accessors, err := parseExpression(expression)
+	if err != nil {
+		return decoder.Value{}, err
+	}
+	variable, err := i.layout.Variable(label)
+	if err != nil {
+		return decoder.Value{}, err
+	}
+	current, err := variableLocation(variable, location{slot: new(big.Int)})
+	if err != nil {
+		return decoder.Value{}, err
+	}
+
+	typeID := variable.Type
+	name := label
+	for _, accessor := range accessors {
+		current, typeID, err = i.access(current, typeID, accessor)
+		if err != nil {
+			return decoder.Value{}, err
+		}
+		name += accessor.String()
+	}
+	return i.decode(name, typeID, current)
+}
+
+// ReadSlot reads a raw storage slot.
+func (i *Inspector) ReadSlot(slot *big.Int) (decoder.Value, error) {
+	word, err := i.word(slot)
+	if err != nil {
+		return decoder.Value{}, err
+	}
+	return decoder.Value{
+		Name: "slot " + hexutil.EncodeBig(slot),
+		Type: "bytes32",
+		Components: []decoder.Value{
+			{Name: "raw", Type: "bytes32", Value: word.Hex()},
+			{Name: "uint256", Type: "uint256", Value: word.Big().String()},
+			{Name: "address", Type: "address", Value: common.BytesToAddress(word.Bytes()).Hex()},
+		},
+	}, nil
+}
+
+func (i *Inspector) word(slot *big.Int) (common.Hash, error) {
+	key := common.BigToHash(slot)
+	if word, ok := i.words[key]; ok {
+		return word, nil
+	}
+	word, err := i.transport.GetStorageAt(i.address, key)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	i.words[key] = word
+	return word, nil
+}
+
+// read returns the bytes of a value packed into a slot.
+func (i *Inspector) read(at location, size int) ([]byte, error) {
+	word, err := i.word(at.slot)
+	if err != nil {
+		return nil, err
+	}
+	if size > common.HashLength-at.offset {
+		size = common.HashLength - at.offset
+	}
+	end := common.HashLength - at.offset
+	return word[end-size : end], nil
+}
+
+// access applies a member or index accessor to the value of typeID at the location.
+func (i *Inspector) access(current location, typeID string, accessor accessor) (location, string, error) {
+	storageType, err := i.layout.Type(typeID)
+	if err != nil {
+		return location{}, "", err
+	}
+
+	if accessor.member != "" {
+		for _, member := range storageType.Members {
+			if member.Label == accessor.member {
+				next, err := variableLocation(member, current)
+				return next, member.Type, err
+			}
+		}
+		return location{}, "", errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("%s has no member %s", storageType.Label, accessor.member))
+	}
+
+	switch {
+	case storageType.Encoding == storagelayout.EncodingMapping:
+		keyType, err := i.layout.Type(storageType.Key)
+		if err != nil {
+			return location{}, "", err
+		}
+		key, err := encodeKey(keyType, accessor.key)
+		if err != nil {
+			return location{}, "", err
+		}
+		slot := crypto.Keccak256(key, common.BigToHash(current.slot).Bytes())
+		return location{slot: new(big.Int).SetBytes(slot)}, storageType.Value, nil
+
+	case storageType.Encoding == storagelayout.EncodingDynamicArray:
+		index, err := parseIndex(accessor.key)
+		if err != nil {
+			return location{}, "", err
+		}
+		length, err := i.word(current.slot)
+		if err != nil {
+			return location{}, "", err
+		}
+		if index.Cmp(length.Big()) >= 0 {
+			return location{}, "", errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("index %s out of range, the array has %s elements", index, length.Big()))
+		}
+		next, err := i.elementLocation(dataLocation(current.slot), storageType.Base, index)
+		return next, storageType.Base, err
+
+	case storageType.Base != "":
+		index, err := parseIndex(accessor.key)
+		if err != nil {
+			return location{}, "", err
+		}
+		if length := storageType.Length(); index.Cmp(big.NewInt(length)) >= 0 {
+			return location{}, "", errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("index %s out of range, the array has %d elements", index, length))
+		}
+		next, err := i.elementLocation(current, storageType.Base, index)
+		return next, storageType.Base, err
+
+	default:
+		return location{}, "", errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("%s cannot be indexed", storageType.Label))
+	}
+}
+
+// elementLocation returns the location of an array element. Elements smaller than a slot are packed.
+func (i *Inspector) elementLocation(start location, baseID string, index *big.Int) (location, error) {
+	base, err := i.layout.Type(baseID)
+	if err != nil {
+		return location{}, err
+	}
+	size := base.Size()
+	if size > 0 && size < common.HashLength {
+		perSlot := big.NewInt(int64(common.HashLength / size))
+		slot, position := new(big.Int).DivMod(index, perSlot, new(big.Int))
+		return start.add(slot, int(position.Int64())*size), nil
+	}
+	slots := big.NewInt(int64((size + common.HashLength - 1) / common.HashLength))
+	return start.add(new(big.Int).Mul(index, slots), 0), nil
+}
+
+// decode reads the value of typeID at the location into a value tree.
+func (i *Inspector) decode(name string, typeID string, at location) (decoder.Value, error) {
+	storageType, err := i.layout.Type(typeID)
+	if err != nil {
+		return decoder.Value{}, err
+	}
+	value := decoder.Value{Name: name, Type: storageType.Label}
+
+	switch {
+	case storageType.Encoding == storagelayout.EncodingMapping:
+		value.Value = fmt.Sprintf("(mapping, enter %s[key] to read an entry)", name)
+		return value, nil
+
+	case storageType.Encoding == storagelayout.EncodingBytes:
+		data, truncated, err := i.readBytes(at.slot)
+		if err != nil {
+			return decoder.Value{}, err
+		}
+		value.Value = hexutil.Encode(data)
+		if storageType.Label == "string" {
+			value.Value = strconv.Quote(string(data))
+		}
+		if truncated {
+			value.Value += " … (truncated)"
+		}
+		return value, nil
+
+	case storageType.Encoding == storagelayout.EncodingDynamicArray:
+		length, err := i.word(at.slot)
+		if err != nil {
+			return decoder.Value{}, err
+		}
+		return i.decodeElements(value, storageType.Base, dataLocation(at.slot), length.Big())
+
+	case len(storageType.Members) > 0:
+		for _, member := range storageType.Members {
+			memberLocation, err := variableLocation(member, at)
+			if err != nil {
+				return decoder.Value{}, err
+			}
+			decoded, err := i.decode(member.Label, member.Type, memberLocation)
+			if err != nil {
+				return decoder.Value{}, err
+			}
+			value.Components = append(value.Components, decoded)
+		}
+		return value, nil
+
+	case storageType.Base != "":
+		return i.decodeElements(value, storageType.Base, at, big.NewInt(storageType.Length()))
+	}
+
+	data, err := i.read(at, storageType.Size())
+	if err != nil {
+		return decoder.Value{}, err
+	}
+	value.Value = formatValue(storageType.Label, data)
+	return value, nil
+}
+
+func (i *Inspector) decodeElements(value decoder.Value, baseID string, start location, length *big.Int) (decoder.Value, error) {
+	value.Components = []decoder.Value{}
+	count := length.Int64()
+	if !length.IsInt64() || count > MaxElements {
+		count = MaxElements
+	}
+	for index := int64(0); index < count; index++ {
+		elementLocation, err := i.elementLocation(start, baseID, big.NewInt(index))
+		if err != nil {
+			return decoder.Value{}, err
+		}
+		element, err := i.decode(fmt.Sprintf("[%d]", index), baseID, elementLocation)
+		if err != nil {
+			return decoder.Value{}, err
+		}
+		value.Components = append(value.Components, element)
+	}
+	if remaining := new(big.Int).Sub(length, big.NewInt(count)); remaining.Sign() > 0 {
+		value.Components = append(value.Components, decoder.Value{Name: "…", Type: "more", Value: remaining.String() + " more elements"})
+	}
+	return value, nil
+}
+
+// readBytes reads a string or bytes value. Short values (up to 31 bytes) are stored in the slot with
+// twice their length in the lowest byte; long values store twice their length plus one and keep their
+// data from keccak256(slot). A short length over 31 means the slot doesn't hold a string or bytes
+// value, e.g. when the layout doesn't match the contract.
+func (i *Inspector) readBytes(slot *big.Int) ([]byte, bool, error) {
+	word, err := i.word(slot)
+	if err != nil {
+		return nil, false, err
+	}
+	if word[common.HashLength-1]&1 == 0 {
+		length := int(word[common.HashLength-1]) / 2
+		if length >= common.HashLength {
+			return nil, false, errors.NewContractError(errors.ErrCodeInvalidStorageValue, fmt.Sprintf(
+				"slot %s does not hold a string or bytes value, its short length is %d", hexutil.EncodeBig(slot), length))
+		}
+		return word[:length], false, nil
+	}
+
+	length := new(big.Int).Rsh(word.Big(), 1)
+	truncated := !length.IsInt64() || length.Int64() > maxBytesLength
+	size := int64(maxBytesLength)
+	if !truncated {
+		size = length.Int64()
+	}
+
+	data := make([]byte, 0, size)
+	start := dataLocation(slot)
+	for index := int64(0); int64(len(data)) < size; index++ {
+		chunk, err := i.word(start.add(big.NewInt(index), 0).slot)
+		if err != nil {
+			return nil, false, err
+		}
+		data = append(data, chunk.Bytes()...)
+	}
+	return data[:size], truncated, nil
+}
+
+// variableLocation offsets a variable's slot, which is relative for struct members, from start.
+func variableLocation(variable storagelayout.Variable, start location) (location, error) {
+	slot, ok := new(big.Int).SetString(variable.Slot, 10)
+	if !ok {
+		return location{}, errors.NewContractError(errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("invalid slot %q for %s", variable.Slot, variable.Label))
+	}
+	return start.add(slot, variable.Offset), nil
+}
+
+// dataLocation is where the data of a dynamic array, or a long string or bytes value, starts.
+func dataLocation(slot *big.Int) location {
+	return location{slot: new(big.Int).SetBytes(crypto.Keccak256(common.BigToHash(slot).Bytes()))}
+}
+
+func formatValue(label string, data []byte) string {
+	switch {
+	case label == "bool":
+		return strconv.FormatBool(new(big.Int).SetBytes(data).Sign() != 0)
+	case label == "address" || label == "address payable" || strings.HasPrefix(label, "contract "):
+		return common.BytesToAddress(data).Hex()
+	case strings.HasPrefix(label, "uint") || strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(data).String()
+	case strings.HasPrefix(label, "int"):
+		value := new(big.Int).SetBytes(data)
+		if len(data) > 0 && data[0]&0x80 != 0 {
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+		}
+		return value.String()
+	default:
+		return hexutil.Encode(data)
+	}
+}
+
+// encodeKey encodes a mapping key the way Solidity hashes it: value types are padded to 32 bytes,
+// strings and bytes are used as is.
+func encodeKey(keyType storagelayout.Type, input string) ([]byte, error) {
+	input = strings.TrimSpace(input)
+	if unquoted, err := strconv.Unquote(input); err == nil {
+		input = unquoted
+	}
+
+	switch keyType.Label {
+	case "string":
+		return []byte(input), nil
+	case "bytes":
+		data, err := hexutil.Decode(input)
+		if err != nil {
+			return nil, errors.WrapContractError(err, errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("invalid bytes key %s", input))
+		}
+		return data, nil
+	}
+
+	typeName := keyType.Label
+	switch {
+	case typeName == "address payable" || strings.HasPrefix(typeName, "contract "):
+		typeName = "address"
+	case strings.HasPrefix(typeName, "enum "):
+		typeName = "uint8"
+	}
+	param := abi.ABIParam{Name: "key", Type: typeName}
+	value, err := abi.ParseArgument(param, input)
+	if err != nil {
+		return nil, errors.WrapContractError(err, errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("invalid %s key %s", keyType.Label, input))
+	}
+	arguments, err := abi.Arguments([]abi.ABIParam{param})
+	if err != nil {
+		return nil, err
+	}
+	return arguments.Pack(value)
+}
+
+func parseIndex(input string) (*big.Int, error) {
+	index, ok := new(big.Int).SetString(strings.TrimSpace(input), 0)
+	if !ok || index.Sign() < 0 {
+		return nil, errors.NewContractError(errors.ErrCodeInvalidStorageExpression, fmt.Sprintf("invalid array index %s", input))
+	}
+	return index, nil
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
@@ -0,0 +1,252 @@
+package inspector
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/compiler"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storagelayout"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+const vaultSource = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Vault {
+    struct Position { uint128 amount; bool active; uint256 opened; }
+
+    uint8 internal version;
+    address internal owner;
+    bool internal paused;
+    mapping(address => uint256) internal balances;
+    mapping(string => Position) internal positions;
+    uint16[] internal history;
+    uint64[3] internal limits;
+    string internal name;
+    bytes internal data;
+    int16 internal delta;
+    mapping(address => mapping(uint256 => bool)) internal approvals;
+}
+`
+
+var (
+	vaultAddress = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	ownerAddress = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+)
+
+// fakeStorage serves storage slots of a single contract and counts the reads.
+type fakeStorage struct {
+	transport.Transport
+	words map[common.Hash]common.Hash
+	reads int
+}
+
+func (f *fakeStorage) GetStorageAt(address common.Address, slot common.Hash) (common.Hash, error) {
+	f.reads++
+	if address != vaultAddress {
+		return common.Hash{}, nil
+	}
+	return f.words[slot], nil
+}
+
+func (f *fakeStorage) set(slot *big.Int, word []byte) {
+	f.words[common.BigToHash(slot)] = common.BytesToHash(word)
+}
+
+func slotOf(data ...[]byte) *big.Int {
+	return new(big.Int).SetBytes(crypto.Keccak256(data...))
+}
+
+func word(value int64) []byte {
+	return common.LeftPadBytes(big.NewInt(value).Bytes(), 32)
+}
+
+type InspectorTestSuite struct {
+	suite.Suite
+	layout    *storagelayout.Layout
+	storage   *fakeStorage
+	inspector *Inspector
+}
+
+func TestInspectorTestSuite(t *testing.T) {
+	suite.Run(t, new(InspectorTestSuite))
+}
+
+func (s *InspectorTestSuite) SetupSuite() {
+	result, err := compiler.Compile(vaultSource, compiler.Options{FileName: "Vault.sol"})
+	s.Require().NoError(err)
+	contract, err := result.Contract("Vault.sol", "Vault")
+	s.Require().NoError(err)
+	s.layout = contract.StorageLayout
+}
+
+func (s *InspectorTestSuite) SetupTest() {
+	s.storage = &fakeStorage{words: map[common.Hash]common.Hash{}}
+	s.inspector = New(s.storage, vaultAddress, s.layout)
+
+	// version = 3, owner, paused = true share slot 0.
+	slot0 := make([]byte, 32)
+	slot0[31] = 3
+	copy(slot0[11:31], ownerAddress.Bytes())
+	slot0[10] = 1
+	s.storage.set(big.NewInt(0), slot0)
+
+	// balances[owner] = 100
+	s.storage.set(slotOf(common.LeftPadBytes(ownerAddress.Bytes(), 32), word(1)), word(100))
+
+	// positions["alice"] = {amount: 5, active: true, opened: 7}
+	position := slotOf([]byte("alice"), word(2))
+	positionWord := word(5)
+	positionWord[15] = 1
+	s.storage.set(position, positionWord)
+	s.storage.set(new(big.Int).Add(position, big.NewInt(1)), word(7))
+
+	// history = [1, 2, ..., 12], sixteen uint16 per slot.
+	s.storage.set(big.NewInt(3), word(12))
+	historyWord := make([]byte, 32)
+	for index := 0; index < 12; index++ {
+		historyWord[31-index*2] = byte(index + 1)
+	}
+	s.storage.set(slotOf(word(3)), historyWord)
+
+	// limits = [10, 20, 0]
+	limits := make([]byte, 32)
+	limits[31] = 10
+	limits[23] = 20
+	s.storage.set(big.NewInt(4), limits)
+
+	// name = "vault", stored in place with twice its length in the last byte.
+	name := make([]byte, 32)
+	copy(name, "vault")
+	name[31] = 10
+	s.storage.set(big.NewInt(5), name)
+
+	// data is 40 bytes, stored from keccak256(6) with 2 * length + 1 in the slot.
+	s.storage.set(big.NewInt(6), word(81))
+	data := slotOf(word(6))
+	s.storage.set(data, []byte(strings.Repeat("\xab", 32)))
+	s.storage.set(new(big.Int).Add(data, big.NewInt(1)), append([]byte(strings.Repeat("\xcd", 8)), make([]byte, 24)...))
+
+	// delta = -2
+	s.storage.set(big.NewInt(7), common.LeftPadBytes([]byte{0xff, 0xfe}, 32))
+
+	// approvals[owner][42] = true
+	approvals := slotOf(common.LeftPadBytes(ownerAddress.Bytes(), 32), word(8))
+	s.storage.set(slotOf(word(42), common.BigToHash(approvals).Bytes()), word(1))
+}
+
+func (s *InspectorTestSuite) TestVariables() {
+	values, err := s.inspector.Variables()
+	s.Require().NoError(err)
+
+	lines := []string{}
+	for _, value := range values {
+		lines = append(lines, value.Lines()...)
+	}
+	output := strings.Join(lines, "\n")
+
+	s.Contains(output, "version (uint8): 3")
+	s.Contains(output, "owner (address): "+ownerAddress.Hex())
+	s.Contains(output, "paused (bool): true")
+	s.Contains(output, "balances (mapping(address => uint256)): (mapping, enter balances[key] to read an entry)")
+	s.Contains(output, "history (uint16[]):\n  [0] (uint16): 1\n  [1] (uint16): 2")
+	s.Contains(output, "  [9] (uint16): 10\n  … (more): 2 more elements")
+	s.Contains(output, "limits (uint64[3]):\n  [0] (uint64): 10\n  [1] (uint64): 20\n  [2] (uint64): 0")
+	s.Contains(output, `name (string): "vault"`)
+	s.Contains(output, "data (bytes): 0x"+strings.Repeat("ab", 32)+strings.Repeat("cd", 8))
+	s.Contains(output, "delta (int16): -2")
+}
+
+func (s *InspectorTestSuite) TestEvaluateMappingsArraysAndMembers() {
+	cases := map[string]string{
+		"balances[" + ownerAddress.Hex() + "]":                 "balances[" + ownerAddress.Hex() + "] (uint256): 100",
+		`positions["alice"].amount`:                            `positions["alice"].amount (uint128): 5`,
+		"positions[alice].active":                              "positions[alice].active (bool): true",
+		`positions["alice"].opened`:                            `positions["alice"].opened (uint256): 7`,
+		"history[11]":                                          "history[11] (uint16): 12",
+		"limits[1]":                                            "limits[1] (uint64): 20",
+		"approvals[" + ownerAddress.Hex() + "][42]":            "approvals[" + ownerAddress.Hex() + "][42] (bool): true",
+		"approvals[" + ownerAddress.Hex() + "][0x2b]":          "approvals[" + ownerAddress.Hex() + "][0x2b] (bool): false",
+		"balances[0x0000000000000000000000000000000000000001]": "balances[0x0000000000000000000000000000000000000001] (uint256): 0",
+	}
+	for expression, expected := range cases {
+		value, err := s.inspector.Evaluate(expression)
+		s.Require().NoError(err, expression)
+		s.Equal([]string{expected}, value.Lines(), expression)
+	}
+
+	value, err := s.inspector.Evaluate(`positions["alice"]`)
+	s.Require().NoError(err)
+	s.Equal([]string{
+		`positions["alice"] (struct Vault.Position):`,
+		"  amount (uint128): 5",
+		"  active (bool): true",
+		"  opened (uint256): 7",
+	}, value.Lines())
+}
+
+func (s *InspectorTestSuite) TestEvaluateErrors() {
+	cases := map[string]errors.ErrorCode{
+		"":                 errors.ErrCodeInvalidStorageExpression,
+		"missing":          errors.ErrCodeStorageVariableNotFound,
+		"history[12]":      errors.ErrCodeInvalidStorageExpression,
+		"limits[3]":        errors.ErrCodeInvalidStorageExpression,
+		"balances[nope]":   errors.ErrCodeInvalidStorageExpression,
+		"balances[0x1":     errors.ErrCodeInvalidStorageExpression,
+		"version[0]":       errors.ErrCodeInvalidStorageExpression,
+		"positions[a].foo": errors.ErrCodeInvalidStorageExpression,
+		"name..x":          errors.ErrCodeInvalidStorageExpression,
+	}
+	for expression, code := range cases {
+		_, err := s.inspector.Evaluate(expression)
+		s.True(errors.HasCode(err, code), "%s: %v", expression, err)
+	}
+}
+
+func (s *InspectorTestSuite) TestMalformedShortString() {
+	// An even lowest byte above 62 is not a valid short string length
+	name := make([]byte, 32)
+	name[31] = 0xfe
+	s.storage.set(big.NewInt(5), name)
+
+	_, err := s.inspector.Evaluate("name")
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidStorageValue), "%v", err)
+	_, err = s.inspector.Variables()
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidStorageValue), "%v", err)
+}
+
+func (s *InspectorTestSuite) TestRawSlots() {
+	value, err := s.inspector.Evaluate("0x3")
+	s.Require().NoError(err)
+	s.Equal("slot 0x3", value.Name)
+
+	withoutLayout := New(s.storage, vaultAddress, nil)
+	s.False(withoutLayout.HasLayout())
+
+	value, err = withoutLayout.Evaluate("7")
+	s.Require().NoError(err)
+	s.Equal([]string{
+		"slot 0x7 (bytes32):",
+		"  raw (bytes32): 0x000000000000000000000000000000000000000000000000000000000000fffe",
+		"  uint256 (uint256): 65534",
+		"  address (address): 0x000000000000000000000000000000000000FFFE",
+	}, value.Lines())
+
+	_, err = withoutLayout.Evaluate("owner")
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidStorageLayout))
+	_, err = withoutLayout.Variables()
+	s.True(errors.HasCode(err, errors.ErrCodeInvalidStorageLayout))
+}
+
+func (s *InspectorTestSuite) TestSlotsAreCached() {
+	_, err := s.inspector.Evaluate("version")
+	s.Require().NoError(err)
+	_, err = s.inspector.Evaluate("owner")
+	s.Require().NoError(err)
+	s.Equal(1, s.storage.reads)
+}
@@ -0,0 +1,102 @@
+// Package storagelayout describes where a contract keeps its state variables, in the storageLayout
+// format emitted by solc, and reads and decodes them from a node with eth_getStorageAt.
+package storagelayout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Encoding is how a type is stored, as reported by solc.
+type Encoding string
+
+const (
+	EncodingInplace      Encoding = "inplace"
+	EncodingMapping      Encoding = "mapping"
+	EncodingDynamicArray Encoding = "dynamic_array"
+	EncodingBytes        Encoding = "bytes"
+)
+
+// Layout is the storage layout of a contract.
+type Layout struct {
+	Storage []Variable      `json:"storage"`
+	Types   map[string]Type `json:"types"`
+	// Derived is set for layouts built from the AST by FromAST rather than emitted by solc.
+	Derived bool `json:"derived,omitempty"`
+}
+
+// Variable is a state variable, or a struct member when it is listed in a Type.
+type Variable struct {
+	AstID    int    `json:"astId"`
+	Contract string `json:"contract"`
+	Label    string `json:"label"`
+	Offset   int    `json:"offset"`
+	Slot     string `json:"slot"`
+	Type     string `json:"type"`
+}
+
+// Type describes a type referenced by the layout. Key and Value are set for mappings, Base for
+// arrays and Members for structs.
+type Type struct {
+	Encoding      Encoding   `json:"encoding"`
+	Label         string     `json:"label"`
+	NumberOfBytes string     `json:"numberOfBytes"`
+	Key           string     `json:"key,omitempty"`
+	Value         string     `json:"value,omitempty"`
+	Base          string     `json:"base,omitempty"`
+	Members       []Variable `json:"members,omitempty"`
+}
+
+// Size returns the number of bytes the type occupies.
+func (t Type) Size() int {
+	size, err := strconv.Atoi(t.NumberOfBytes)
+	if err != nil {
+		return 32
+	}
+	return size
+}
+
+// Length returns the length of a static array type, e.g. 3 for uint256[3].
+func (t Type) Length() int64 {
+	return arrayLength(t.Label)
+}
+
+// IsEmpty reports whether the layout has no state variables.
+func (l *Layout) IsEmpty() bool {
+	return l == nil || len(l.Storage) == 0
+}
+
+// Variable returns the state variable with the label.
+func (l *Layout) Variable(label string) (Variable, error) {
+	for _, variable := range l.Storage {
+		if variable.Label == label {
+			return variable, nil
+		}
+	}
+	return Variable{}, errors.NewContractError(errors.ErrCodeStorageVariableNotFound, fmt.Sprintf("state variable %s not found", label))
+}
+
+// Type returns the type with the identifier.
+func (l *Layout) Type(id string) (Type, error) {
+	storageType, ok := l.Types[id]
+	if !ok {
+		return Type{}, errors.NewContractError(errors.ErrCodeInvalidStorageLayout, fmt.Sprintf("type %s is missing from the storage layout", id))
+	}
+	return storageType, nil
+}
+
+// arrayLength parses the length of a static array from its label.
+func arrayLength(label string) int64 {
+	open := strings.LastIndex(label, "[")
+	if open < 0 || !strings.HasSuffix(label, "]") {
+		return 0
+	}
+	length, err := strconv.ParseInt(label[open+1:len(label)-1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return length
+}
@@ -0,0 +1,278 @@
+// Package trace fetches the call tree of a mined transaction with the callTracer and decodes every
+// frame against the known functions, errors and events, so the user sees which contract called which
+// and where it reverted.
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Frame is a call frame of the callTracer output.
+type Frame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []Frame         `json:"calls,omitempty"`
+	Logs         []FrameLog      `json:"logs,omitempty"`
+}
+
+// FrameLog is a log emitted by a frame, recorded when the tracer runs with withLog.
+type FrameLog struct {
+	Address  common.Address `json:"address"`
+	Topics   []common.Hash  `json:"topics"`
+	Data     hexutil.Bytes  `json:"data"`
+	Position hexutil.Uint   `json:"position"`
+}
+
+// Call is a frame decoded against the known functions, with its subcalls in execution order.
+type Call struct {
+	Frame *Frame
+	// Function is nil when no known function matches the selector of the input.
+	Function     *decoder.Call
+	ReturnValues []decoder.Value
+	Events       []decoder.Event
+	// RevertReason is the decoded reason of a failed frame, empty when the frame succeeded.
+	RevertReason string
+	Calls        []*Call
+}
+
+// TracerConfig is the callTracer configuration used for transactions, logs are needed for events.
+var TracerConfig = map[string]any{"withLog": true}
+
+// ParseFrame decodes the output of the callTracer.
+func ParseFrame(raw json.RawMessage) (*Frame, error) {
+	var frame Frame
+	if err := json.Unmarshal(raw, &frame); err != nil {
+		return nil, errors.WrapTransportError(err, errors.ErrCodeTraceFailed, "failed to decode call trace")
+	}
+	return &frame, nil
+}
+
+// Transaction traces a mined transaction with the callTracer. The node must support debug_traceTransaction.
+func Transaction(tr transport.Transport, txHash common.Hash) (*Frame, error) {
+	raw, err := tr.TraceTransaction(txHash, "callTracer", TracerConfig)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFrame(raw)
+}
+
+// CollectLogs appends the logs of the frame, interleaved with the logs of its subcalls. The position
+// of a log is the number of subcalls made before it was emitted.
+func (f Frame) CollectLogs(logs []types.Log) []types.Log {
+	logIndex := 0
+	for callIndex := 0; callIndex <= len(f.Calls); callIndex++ {
+		for logIndex < len(f.Logs) && int(f.Logs[logIndex].Position) <= callIndex {
+			logs = append(logs, f.Logs[logIndex].log())
+			logIndex++
+		}
+		if callIndex < len(f.Calls) {
+			logs = f.Calls[callIndex].CollectLogs(logs)
+		}
+	}
+	return logs
+}
+
+func (l FrameLog) log() types.Log {
+	return types.Log{Address: l.Address, Topics: l.Topics, Data: l.Data}
+}
+
+// Failed reports whether the frame reverted or ran out of gas.
+func (f Frame) Failed() bool {
+	return f.Error != ""
+}
+
+// ValueWei returns the ether sent with the frame, zero when the tracer omits it.
+func (f Frame) ValueWei() *big.Int {
+	if f.Value == nil {
+		return big.NewInt(0)
+	}
+	return f.Value.ToInt()
+}
+
+// Decode decodes the frame and its subcalls. Functions are matched by the call decoder, custom errors
+// and events by the elements, usually the stored ABIs, then by the signature database of the call
+// decoder.
+func Decode(frame *Frame, callDecoder *decoder.Decoder, elements abi.AbiArray) *Call {
+	call := &Call{Frame: frame}
+
+	if !isCreate(frame.Type) && len(frame.Input) >= 4 {
+		if calls, err := callDecoder.Decode(frame.Input); err == nil && len(calls) > 0 {
+			call.Function = &calls[0]
+		}
+	}
+
+	switch {
+	case frame.Failed():
+		call.RevertReason = revertReason(frame, elements, callDecoder.Signatures())
+	case call.Function != nil && len(call.Function.Function.Outputs) > 0:
+		if values, err := decoder.DecodeValues(call.Function.Function.Outputs, frame.Output); err == nil {
+			call.ReturnValues = values
+		}
+	}
+
+	for _, log := range frame.Logs {
+		if event, ok := decoder.DecodeEvent(log.log(), elements, callDecoder.Signatures()); ok {
+			call.Events = append(call.Events, event)
+		}
+	}
+
+	for index := range frame.Calls {
+		call.Calls = append(call.Calls, Decode(&frame.Calls[index], callDecoder, elements))
+	}
+	return call
+}
+
+// revertReason prefers the revert data, which may hold a custom error, over the error of the tracer.
+func revertReason(frame *Frame, elements abi.AbiArray, lookup decoder.SignatureLookup) string {
+	switch {
+	case len(frame.Output) > 0:
+		return decoder.DecodeRevert(frame.Output, elements, lookup)
+	case frame.RevertReason != "":
+		return frame.RevertReason
+	default:
+		return frame.Error
+	}
+}
+
+func isCreate(frameType string) bool {
+	return frameType == "CREATE" || frameType == "CREATE2"
+}
+
+// FailedHere reports whether the call is where the transaction failed: it failed without bubbling up
+// the revert data of a failed subcall. A subcall whose revert was caught does not count.
+func (c *Call) FailedHere() bool {
+	if !c.Frame.Failed() {
+		return false
+	}
+	for _, subcall := range c.Calls {
+		if subcall.Frame.Failed() && bytes.Equal(subcall.Frame.Output, c.Frame.Output) {
+			return false
+		}
+	}
+	return true
+}
+
+// Label renders the call on a single line, e.g. "CALL 0x... Token.transfer(to=0x..., amount=1)".
+func (c *Call) Label() string {
+	target := "?"
+	if c.Frame.To != nil {
+		target = c.Frame.To.Hex()
+	}
+
+	var function string
+	switch {
+	case isCreate(c.Frame.Type):
+		function = "new contract"
+	case c.Function != nil:
+		name := c.Function.Function.Name
+		if c.Function.Source != "" && c.Function.Source != decoder.BuiltinSource {
+			name = c.Function.Source + "." + name
+		}
+		arguments := make([]string, 0, len(c.Function.Arguments))
+		for _, argument := range c.Function.Arguments {
+			arguments = append(arguments, argument.String())
+		}
+		function = fmt.Sprintf("%s(%s)", name, strings.Join(arguments, ", "))
+	case len(c.Frame.Input) >= 4:
+		function = "unknown " + hexutil.Encode(c.Frame.Input[:4])
+	default:
+		function = "(no calldata)"
+	}
+
+	label := fmt.Sprintf("%s %s %s", c.Frame.Type, target, function)
+	if value := c.Frame.ValueWei(); value.Sign() > 0 {
+		label += fmt.Sprintf(" value %s wei", value)
+	}
+	label += fmt.Sprintf(" • gas %d", uint64(c.Frame.GasUsed))
+	if c.Frame.Failed() {
+		label += " ✗ " + c.RevertReason
+	}
+	return label
+}
+
+// Details describes the call, one field per line with decoded values indented.
+func (c *Call) Details() []string {
+	lines := []string{
+		"Type: " + c.Frame.Type,
+		"From: " + c.Frame.From.Hex(),
+	}
+	if c.Frame.To != nil {
+		lines = append(lines, "To: "+c.Frame.To.Hex())
+	}
+	lines = append(lines,
+		fmt.Sprintf("Value: %s wei", c.Frame.ValueWei()),
+		fmt.Sprintf("Gas used: %d of %d", uint64(c.Frame.GasUsed), uint64(c.Frame.Gas)),
+	)
+
+	if c.Function != nil {
+		lines = append(lines, fmt.Sprintf("Function: %s [%s]", c.Function.Signature, c.Function.Source))
+		for _, argument := range c.Function.Arguments {
+			lines = append(lines, indent(argument.Lines())...)
+		}
+	} else if len(c.Frame.Input) > 0 {
+		lines = append(lines, "Input: "+hexutil.Encode(c.Frame.Input))
+	}
+
+	if len(c.ReturnValues) > 0 {
+		lines = append(lines, "Returns:")
+		for _, value := range c.ReturnValues {
+			lines = append(lines, indent(value.Lines())...)
+		}
+	}
+
+	if len(c.Events) > 0 {
+		lines = append(lines, "Events:")
+		for _, event := range c.Events {
+			lines = append(lines, "  "+event.Line())
+		}
+	}
+
+	if c.Frame.Failed() {
+		line := "Error: " + c.RevertReason
+		if c.FailedHere() {
+			line += " (failed here)"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Lines renders the call tree, one call per line indented by depth.
+func (c *Call) Lines() []string {
+	return c.lines("")
+}
+
+func (c *Call) lines(prefix string) []string {
+	lines := []string{prefix + c.Label()}
+	for _, subcall := range c.Calls {
+		lines = append(lines, subcall.lines(prefix+"  ")...)
+	}
+	return lines
+}
+
+func indent(lines []string) []string {
+	for index := range lines {
+		lines[index] = "  " + lines[index]
+	}
+	return lines
+}
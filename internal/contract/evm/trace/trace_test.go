@@ -0,0 +1,266 @@
+package trace
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/decoder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sender = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	token  = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	vault  = common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+)
+
+// fakeTransport answers TraceTransaction with a canned trace.
+type fakeTransport struct {
+	transport.Transport
+	trace  json.RawMessage
+	tracer string
+}
+
+func (f *fakeTransport) TraceTransaction(_ common.Hash, tracer string, _ map[string]any) (json.RawMessage, error) {
+	f.tracer = tracer
+	return f.trace, nil
+}
+
+func parse(t *testing.T, signatures ...string) abi.AbiArray {
+	t.Helper()
+	elements, err := abi.ParseHumanReadable(signatures)
+	require.NoError(t, err)
+	return elements
+}
+
+// pack encodes the selector of the element followed by the arguments.
+func pack(t *testing.T, element abi.ABIElement, args ...any) []byte {
+	t.Helper()
+	arguments, err := abi.Arguments(element.Inputs)
+	require.NoError(t, err)
+	data, err := arguments.Pack(args...)
+	require.NoError(t, err)
+	selector, err := element.Selector()
+	require.NoError(t, err)
+	return append(selector[:], data...)
+}
+
+// vaultTrace is a withdrawal from a vault that reads the token balance, approves the token and then
+// reverts in the token transfer with a custom error.
+func vaultTrace(t *testing.T, elements abi.AbiArray) json.RawMessage {
+	t.Helper()
+	byName := map[string]abi.ABIElement{}
+	for _, element := range elements {
+		byName[element.Name] = element
+	}
+	transferABI := parse(t, "function transfer(address to, uint256 value) returns (bool)")[0]
+	approveABI := parse(t, "function approve(address spender, uint256 value) returns (bool)")[0]
+	revertData := pack(t, byName["InsufficientBalance"], big.NewInt(3), big.NewInt(5))
+
+	frame := Frame{
+		Type: "CALL", From: sender, To: &vault, Gas: 100000, GasUsed: 42000,
+		Input:  pack(t, byName["withdraw"], big.NewInt(5)),
+		Output: revertData, Error: "execution reverted",
+		Calls: []Frame{
+			{
+				Type: "STATICCALL", From: vault, To: &token, Gas: 60000, GasUsed: 2600,
+				Input:  pack(t, byName["balanceOf"], vault),
+				Output: common.LeftPadBytes([]byte{3}, 32),
+			},
+			{
+				Type: "CALL", From: vault, To: &token, Gas: 50000, GasUsed: 24000,
+				Input:  pack(t, approveABI, sender, big.NewInt(5)),
+				Output: common.LeftPadBytes([]byte{1}, 32),
+				Logs: []FrameLog{{
+					Address: token,
+					Topics:  []common.Hash{crypto.Keccak256Hash([]byte("Approval(address,address,uint256)")), common.BytesToHash(vault.Bytes()), common.BytesToHash(sender.Bytes())},
+					Data:    common.LeftPadBytes([]byte{5}, 32),
+				}},
+			},
+			{
+				Type: "CALL", From: vault, To: &token, Gas: 20000, GasUsed: 3000,
+				Input:  pack(t, transferABI, sender, big.NewInt(5)),
+				Output: revertData, Error: "execution reverted",
+			},
+		},
+	}
+	raw, err := json.Marshal(frame)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestDecodeTransactionTrace(t *testing.T) {
+	elements := parse(t,
+		"function withdraw(uint256 amount)",
+		"function balanceOf(address owner) view returns (uint256)",
+		"error InsufficientBalance(uint256 available, uint256 required)",
+		"event Approval(address indexed owner, address indexed spender, uint256 value)",
+	)
+	tr := &fakeTransport{trace: vaultTrace(t, elements)}
+
+	frame, err := Transaction(tr, common.HexToHash("0x01"))
+	require.NoError(t, err)
+	assert.Equal(t, "callTracer", tr.tracer)
+
+	callDecoder := decoder.New()
+	callDecoder.AddABI("Vault", elements)
+	root := Decode(frame, callDecoder, elements)
+	require.Len(t, root.Calls, 3)
+
+	assert.Equal(t, "InsufficientBalance(available=3, required=5)", root.RevertReason)
+	assert.False(t, root.FailedHere())
+	assert.True(t, root.Calls[2].FailedHere())
+	assert.Equal(t, "Vault", root.Function.Source)
+
+	balance := root.Calls[0]
+	require.Len(t, balance.ReturnValues, 1)
+	assert.Equal(t, "3", balance.ReturnValues[0].Value)
+	require.Len(t, root.Calls[1].Events, 1)
+	assert.Equal(t, "Approval(owner="+vault.Hex()+", spender="+sender.Hex()+", value=5)", root.Calls[1].Events[0].Line())
+
+	assert.Equal(t, []string{
+		"CALL " + vault.Hex() + " Vault.withdraw(amount=5) • gas 42000 ✗ InsufficientBalance(available=3, required=5)",
+		"  STATICCALL " + token.Hex() + " Vault.balanceOf(owner=" + vault.Hex() + ") • gas 2600",
+		"  CALL " + token.Hex() + " approve(spender=" + sender.Hex() + ", value=5) • gas 24000",
+		"  CALL " + token.Hex() + " transfer(to=" + sender.Hex() + ", value=5) • gas 3000 ✗ InsufficientBalance(available=3, required=5)",
+	}, root.Lines())
+
+	assert.Equal(t, []string{
+		"Type: CALL",
+		"From: " + vault.Hex(),
+		"To: " + token.Hex(),
+		"Value: 0 wei",
+		"Gas used: 3000 of 20000",
+		"Function: transfer(address,uint256) [" + decoder.BuiltinSource + "]",
+		"  to (address): " + sender.Hex(),
+		"  value (uint256): 5",
+		"Error: InsufficientBalance(available=3, required=5) (failed here)",
+	}, root.Calls[2].Details())
+}
+
+func TestFailedHereAfterCaughtRevert(t *testing.T) {
+	elements := parse(t,
+		"error InsufficientBalance(uint256 available, uint256 required)",
+		"error TransferFailed()",
+	)
+	// The vault catches the reverted transfer and reverts with its own error.
+	frame := &Frame{
+		Type: "CALL", From: sender, To: &vault, Gas: 100000, GasUsed: 30000,
+		Output: pack(t, elements[1]), Error: "execution reverted",
+		Calls: []Frame{{
+			Type: "CALL", From: vault, To: &token, Gas: 20000, GasUsed: 3000,
+			Output: pack(t, elements[0], big.NewInt(3), big.NewInt(5)), Error: "execution reverted",
+		}},
+	}
+
+	root := Decode(frame, decoder.New(), elements)
+	assert.Equal(t, "TransferFailed()", root.RevertReason)
+	assert.True(t, root.FailedHere())
+	assert.True(t, root.Calls[0].FailedHere())
+	assert.Contains(t, root.Details(), "Error: TransferFailed() (failed here)")
+}
+
+// fakeSignatures is a signature database that knows the elements under any selector or topic.
+type fakeSignatures struct {
+	elements abi.AbiArray
+}
+
+func (f fakeSignatures) Functions([4]byte) ([]abi.ABIElement, error)  { return nil, nil }
+func (f fakeSignatures) Errors([4]byte) ([]abi.ABIElement, error)     { return f.elements, nil }
+func (f fakeSignatures) Events(common.Hash) ([]abi.ABIElement, error) { return f.elements, nil }
+
+func TestDecodeWithSignatureDatabase(t *testing.T) {
+	known := parse(t, "error Unauthorized(address caller)", "event Swept(address indexed to, uint256 amount)")
+	frame := &Frame{
+		Type: "CALL", From: sender, To: &vault, Gas: 30000, GasUsed: 30000,
+		Output: pack(t, known[0], sender), Error: "execution reverted",
+		Logs: []FrameLog{{
+			Address: vault,
+			Topics:  []common.Hash{crypto.Keccak256Hash([]byte("Swept(address,uint256)")), common.BytesToHash(sender.Bytes())},
+			Data:    common.LeftPadBytes([]byte{7}, 32),
+		}},
+	}
+
+	root := Decode(frame, decoder.New(), nil)
+	assert.Equal(t, "custom error "+hexutil.Encode(frame.Output), root.RevertReason)
+	assert.Empty(t, root.Events)
+
+	callDecoder := decoder.New()
+	callDecoder.UseSignatures(fakeSignatures{elements: known})
+	root = Decode(frame, callDecoder, nil)
+	assert.Equal(t, "Unauthorized(caller="+sender.Hex()+")", root.RevertReason)
+	require.Len(t, root.Events, 1)
+	assert.Equal(t, "Swept(to="+sender.Hex()+", amount=7)", root.Events[0].Line())
+}
+
+func TestDecodeUnknownFrames(t *testing.T) {
+	raw := json.RawMessage(`{"type":"CALL","from":"` + sender.Hex() + `","to":"` + token.Hex() + `","value":"0x3e8","gas":"0x5208","gasUsed":"0x5208","input":"0xdeadbeef","error":"out of gas","calls":[` +
+		`{"type":"CREATE","from":"` + token.Hex() + `","to":"` + vault.Hex() + `","gas":"0x10","gasUsed":"0x10","input":"0x6000"}]}`)
+	frame, err := ParseFrame(raw)
+	require.NoError(t, err)
+
+	root := Decode(frame, decoder.New(), nil)
+	assert.Nil(t, root.Function)
+	assert.Equal(t, "out of gas", root.RevertReason)
+	assert.True(t, root.FailedHere())
+	assert.Equal(t, []string{
+		"CALL " + token.Hex() + " unknown 0xdeadbeef value 1000 wei • gas 21000 ✗ out of gas",
+		"  CREATE " + vault.Hex() + " new contract • gas 16",
+	}, root.Lines())
+	assert.Contains(t, root.Details(), "Input: 0xdeadbeef")
+}
+
+func TestCollectLogsInExecutionOrder(t *testing.T) {
+	topic := func(n byte) []common.Hash { return []common.Hash{common.BytesToHash([]byte{n})} }
+	frame := Frame{
+		Logs: []FrameLog{{Topics: topic(1), Position: 0}, {Topics: topic(3), Position: 1}},
+		Calls: []Frame{
+			{Logs: []FrameLog{{Topics: topic(2)}}},
+			{Logs: []FrameLog{{Topics: topic(4)}}},
+		},
+	}
+
+	logs := frame.CollectLogs(nil)
+	require.Len(t, logs, 4)
+	for index, log := range logs {
+		assert.Equal(t, common.BytesToHash([]byte{byte(index + 1)}), log.Topics[0])
+	}
+}
+
+func TestTraceSimulatedTransfer(t *testing.T) {
+	tr, err := transport.NewSimulatedTransport(nil)
+	require.NoError(t, err)
+	defer tr.Close()
+
+	key, err := transport.DevPrivateKey(0)
+	require.NoError(t, err)
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(transport.SimulatedChainID)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(transport.SimulatedChainID),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10_000_000_000),
+		Gas:       21000,
+		To:        &recipient,
+		Value:     big.NewInt(1000),
+	})
+	require.NoError(t, err)
+	txHash, err := tr.SendTransaction(tx)
+	require.NoError(t, err)
+
+	frame, err := Transaction(tr, txHash)
+	require.NoError(t, err)
+	assert.Equal(t, "CALL", frame.Type)
+	assert.Equal(t, sender, frame.From)
+	assert.Equal(t, recipient, *frame.To)
+	assert.Equal(t, big.NewInt(1000), frame.ValueWei())
+	assert.Empty(t, frame.Input)
+	assert.False(t, frame.Failed())
+}
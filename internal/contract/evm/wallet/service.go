@@ -10,7 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/endpointgroup"
 	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
 	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
 	"github.com/rxtech-lab/smart-contract-cli/internal/storage"
@@ -28,6 +28,9 @@ type WalletService interface {
 	// ImportMnemonic imports a wallet from a mnemonic phrase with a derivation path
 	ImportMnemonic(alias string, mnemonic string, derivationPath string) (*models.EVMWallet, error)
 
+	// ImportRemoteWallet adds a wallet whose key is held by a Clef-compatible remote signer
+	ImportRemoteWallet(alias string, signerURL string, address string) (*models.EVMWallet, error)
+
 	// GenerateWallet generates a new wallet with a random mnemonic
 	GenerateWallet(alias string) (wallet *models.EVMWallet, mnemonic string, privateKey string, err error)
 
@@ -216,6 +219,53 @@ func (s *WalletServiceImpl) ImportMnemonic(alias string, mnemonic string, deriva
 	return &wallet, nil
 }
 
+// ImportRemoteWallet adds a wallet whose key is held by a Clef-compatible remote signer.
+// Nothing is written to secure storage; the signer is only contacted when signing.
+func (s *WalletServiceImpl) ImportRemoteWallet(alias string, signerURL string, address string) (*models.EVMWallet, error) {
+	signerURL = strings.TrimSpace(signerURL)
+	if signerURL == "" {
+		return nil, fmt.Errorf("remote signer URL cannot be empty")
+	}
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address: %s", address)
+	}
+	checksumAddress := common.HexToAddress(address).Hex()
+
+	// Check if wallet with this address already exists
+	exists, err := s.storage.WalletExistsByAddress(checksumAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check wallet existence: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("wallet with address %s already exists", checksumAddress)
+	}
+
+	// Check if alias already exists
+	exists, err = s.storage.WalletExistsByAlias(alias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check alias existence: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("wallet with alias %s already exists", alias)
+	}
+
+	// Create wallet in database
+	wallet := models.EVMWallet{
+		Alias:           alias,
+		Address:         checksumAddress,
+		IsRemote:        true,
+		RemoteSignerURL: &signerURL,
+	}
+
+	walletID, err := s.storage.CreateWallet(wallet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet: %w", err)
+	}
+
+	wallet.ID = walletID
+	return &wallet, nil
+}
+
 // GenerateWallet generates a new wallet with a random mnemonic.
 func (s *WalletServiceImpl) GenerateWallet(alias string) (wallet *models.EVMWallet, mnemonic string, privateKey string, err error) {
 	// Generate entropy (128 bits = 12 words, 256 bits = 24 words)
@@ -259,7 +309,7 @@ func (s *WalletServiceImpl) GetWalletWithBalance(walletID uint, rpcEndpoint stri
 	}
 
 	// Create transport to fetch balance
-	httpTransport, err := transport.NewHTTPTransport(rpcEndpoint, 30*time.Second)
+	httpTransport, err := endpointgroup.NewTransportForURL(s.storage, rpcEndpoint, 30*time.Second)
 	if err != nil {
 		return &WalletWithBalance{
 			Wallet:  wallet,
@@ -297,7 +347,7 @@ func (s *WalletServiceImpl) ListWalletsWithBalances(page int64, pageSize int64,
 	wallets = make([]WalletWithBalance, len(pagination.Items))
 	for index, walletData := range pagination.Items {
 		// Create transport to fetch balance
-		httpTransport, err := transport.NewHTTPTransport(rpcEndpoint, 30*time.Second)
+		httpTransport, err := endpointgroup.NewTransportForURL(s.storage, rpcEndpoint, 30*time.Second)
 		if err != nil {
 			wallets[index] = WalletWithBalance{
 				Wallet:  walletData,
@@ -437,6 +487,9 @@ func (s *WalletServiceImpl) UpdateWalletPrivateKey(walletID uint, newPrivateKeyH
 	// If updating private key, it's no longer from mnemonic
 	wallet.IsFromMnemonic = false
 	wallet.DerivationPath = nil
+	// The key is stored locally from now on
+	wallet.IsRemote = false
+	wallet.RemoteSignerURL = nil
 
 	if err := s.storage.UpdateWallet(walletID, wallet); err != nil {
 		return fmt.Errorf("failed to update wallet: %w", err)
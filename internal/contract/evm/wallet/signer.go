@@ -0,0 +1,96 @@
+package wallet
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+)
+
+// NewSigner creates a signer for a stored wallet that sends transactions through the given transport.
+func NewSigner(walletService WalletService, walletID uint, tr transport.Transport) (signer.SignerWithTransport, error) {
+	walletData, err := walletService.GetWallet(walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	accountSigner, err := NewAccountSigner(walletService, walletData)
+	if err != nil {
+		return nil, err
+	}
+	return signer.NewAccountSignerWithTransport(accountSigner, tr), nil
+}
+
+// NewAccountSigner creates a signer for a stored wallet, delegating to its remote signer for remote wallets.
+func NewAccountSigner(walletService WalletService, walletData *models.EVMWallet) (signer.AccountSigner, error) {
+	if walletData.IsRemote {
+		return NewRemoteSigner(walletData)
+	}
+	return NewOfflineSigner(walletService, walletData.ID)
+}
+
+var (
+	remoteSignersMu sync.Mutex
+	// remoteSigners holds one connection per remote wallet, reused by every operation of the wallet.
+	remoteSigners = map[uint]*signer.ClefSigner{}
+)
+
+// NewRemoteSigner returns the connection to the Clef-compatible signer of a remote wallet, connecting
+// on first use. The connection is replaced when the signer URL or address of the wallet changes.
+func NewRemoteSigner(walletData *models.EVMWallet) (*signer.ClefSigner, error) {
+	if !walletData.IsRemote || walletData.RemoteSignerURL == nil {
+		return nil, fmt.Errorf("wallet %s has no remote signer", walletData.Alias)
+	}
+
+	remoteSignersMu.Lock()
+	defer remoteSignersMu.Unlock()
+
+	address := common.HexToAddress(walletData.Address)
+	if cached, ok := remoteSigners[walletData.ID]; ok {
+		if cached.Endpoint == *walletData.RemoteSignerURL && cached.GetAddress() == address {
+			return cached, nil
+		}
+		cached.Close()
+		delete(remoteSigners, walletData.ID)
+	}
+
+	clefSigner, err := signer.NewClefSigner(*walletData.RemoteSignerURL, address, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer: %w", err)
+	}
+	remoteSigners[walletData.ID] = clefSigner
+	return clefSigner, nil
+}
+
+// CloseRemoteSigners closes the connections to the remote signers.
+func CloseRemoteSigners() {
+	remoteSignersMu.Lock()
+	defer remoteSignersMu.Unlock()
+
+	for walletID, clefSigner := range remoteSigners {
+		clefSigner.Close()
+		delete(remoteSigners, walletID)
+	}
+}
+
+// NewOfflineSigner creates a signer for a stored wallet that signs without a transport.
+func NewOfflineSigner(walletService WalletService, walletID uint) (*signer.PrivateKeySigner, error) {
+	privateKey, err := walletService.GetPrivateKey(walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	baseSigner, err := signer.NewPrivateKeySigner(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer: %w", err)
+	}
+
+	pkSigner, ok := baseSigner.(*signer.PrivateKeySigner)
+	if !ok {
+		return nil, fmt.Errorf("unsupported signer type %T", baseSigner)
+	}
+	return pkSigner, nil
+}
@@ -0,0 +1,58 @@
+package wallet
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	models "github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/models/evm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionOnlyClef answers the connectivity check of the Clef signer.
+type versionOnlyClef struct{}
+
+func (versionOnlyClef) Version(context.Context) (string, error) {
+	return "6.1.0", nil
+}
+
+func newClefServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", versionOnlyClef{}))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+// TestRemoteSignerIsReused tests that a remote wallet keeps one connection until its signer changes.
+func TestRemoteSignerIsReused(t *testing.T) {
+	t.Cleanup(CloseRemoteSigners)
+	first, second := newClefServer(t).URL, newClefServer(t).URL
+	walletData := &models.EVMWallet{
+		ID:              1,
+		Alias:           "remote",
+		Address:         "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		IsRemote:        true,
+		RemoteSignerURL: &first,
+	}
+
+	signer, err := NewRemoteSigner(walletData)
+	require.NoError(t, err)
+	again, err := NewRemoteSigner(walletData)
+	require.NoError(t, err)
+	assert.Same(t, signer, again)
+
+	walletData.RemoteSignerURL = &second
+	moved, err := NewRemoteSigner(walletData)
+	require.NoError(t, err)
+	assert.NotSame(t, signer, moved)
+	assert.Equal(t, second, moved.Endpoint)
+
+	CloseRemoteSigners()
+	reconnected, err := NewRemoteSigner(walletData)
+	require.NoError(t, err)
+	assert.NotSame(t, moved, reconnected)
+}
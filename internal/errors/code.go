@@ -12,6 +12,10 @@ const (
 	ErrCodeABIPackFailed       ErrorCode = "ABI_PACK_FAILED"
 	ErrCodeABIUnpackFailed     ErrorCode = "ABI_UNPACK_FAILED"
 	ErrCodeMethodNotFound      ErrorCode = "METHOD_NOT_FOUND"
+	ErrCodeInvalidCalldata     ErrorCode = "INVALID_CALLDATA"
+	ErrCodeSelectorNotFound    ErrorCode = "SELECTOR_NOT_FOUND"
+	ErrCodeEventNotFound       ErrorCode = "EVENT_NOT_FOUND"
+	ErrCodeEventMismatch       ErrorCode = "EVENT_MISMATCH"
 
 	// Signer Domain Error Codes.
 	ErrCodeInvalidPrivateKey      ErrorCode = "INVALID_PRIVATE_KEY"
@@ -21,23 +25,73 @@ const (
 	ErrCodeInvalidSignatureLength ErrorCode = "INVALID_SIGNATURE_LENGTH"
 	ErrCodeSignatureDecode        ErrorCode = "SIGNATURE_DECODE_FAILED"
 	ErrCodePublicKeyRecovery      ErrorCode = "PUBLIC_KEY_RECOVERY_FAILED"
+	ErrCodeInvalidTransaction     ErrorCode = "INVALID_TRANSACTION"
+	ErrCodeSignerMismatch         ErrorCode = "SIGNER_MISMATCH"
+	ErrCodeInvalidTypedData       ErrorCode = "INVALID_TYPED_DATA"
+	ErrCodeInvalidMessageHash     ErrorCode = "INVALID_MESSAGE_HASH"
+	ErrCodeRemoteSignerFailed     ErrorCode = "REMOTE_SIGNER_FAILED"
+	ErrCodeRemoteSignerRejected   ErrorCode = "REMOTE_SIGNER_REJECTED"
 
 	// Transport Domain Error Codes.
-	ErrCodeEndpointRequired      ErrorCode = "ENDPOINT_REQUIRED"
-	ErrCodeConnectionFailed      ErrorCode = "CONNECTION_FAILED"
-	ErrCodeRPCCallFailed         ErrorCode = "RPC_CALL_FAILED"
-	ErrCodeTransactionTimeout    ErrorCode = "TRANSACTION_TIMEOUT"
-	ErrCodeInvalidChainID        ErrorCode = "INVALID_CHAIN_ID"
-	ErrCodeTransactionSendFailed ErrorCode = "TRANSACTION_SEND_FAILED"
-	ErrCodeGasEstimateFailed     ErrorCode = "GAS_ESTIMATE_FAILED"
-	ErrCodeBalanceQueryFailed    ErrorCode = "BALANCE_QUERY_FAILED"
-	ErrCodeNonceQueryFailed      ErrorCode = "NONCE_QUERY_FAILED"
-	ErrCodeReceiptQueryFailed    ErrorCode = "RECEIPT_QUERY_FAILED"
-	ErrCodeChainIDQueryFailed    ErrorCode = "CHAIN_ID_QUERY_FAILED"
+	ErrCodeEndpointRequired       ErrorCode = "ENDPOINT_REQUIRED"
+	ErrCodeConnectionFailed       ErrorCode = "CONNECTION_FAILED"
+	ErrCodeRPCCallFailed          ErrorCode = "RPC_CALL_FAILED"
+	ErrCodeTransactionTimeout     ErrorCode = "TRANSACTION_TIMEOUT"
+	ErrCodeInvalidChainID         ErrorCode = "INVALID_CHAIN_ID"
+	ErrCodeTransactionSendFailed  ErrorCode = "TRANSACTION_SEND_FAILED"
+	ErrCodeGasEstimateFailed      ErrorCode = "GAS_ESTIMATE_FAILED"
+	ErrCodeBalanceQueryFailed     ErrorCode = "BALANCE_QUERY_FAILED"
+	ErrCodeNonceQueryFailed       ErrorCode = "NONCE_QUERY_FAILED"
+	ErrCodeReceiptQueryFailed     ErrorCode = "RECEIPT_QUERY_FAILED"
+	ErrCodeChainIDQueryFailed     ErrorCode = "CHAIN_ID_QUERY_FAILED"
+	ErrCodeLogQueryFailed         ErrorCode = "LOG_QUERY_FAILED"
+	ErrCodeCodeQueryFailed        ErrorCode = "CODE_QUERY_FAILED"
+	ErrCodeTransactionNotFound    ErrorCode = "TRANSACTION_NOT_FOUND"
+	ErrCodeTransactionQueryFailed ErrorCode = "TRANSACTION_QUERY_FAILED"
+	ErrCodeMulticallFailed        ErrorCode = "MULTICALL_FAILED"
+	ErrCodeCallReverted           ErrorCode = "CALL_REVERTED"
+	ErrCodeStorageQueryFailed     ErrorCode = "STORAGE_QUERY_FAILED"
+	ErrCodeTraceFailed            ErrorCode = "TRACE_FAILED"
+	ErrCodeInvalidBlock           ErrorCode = "INVALID_BLOCK"
+	ErrCodeInvalidStateOverride   ErrorCode = "INVALID_STATE_OVERRIDE"
+	ErrCodeTransactionPending     ErrorCode = "TRANSACTION_PENDING"
+	ErrCodeTransactionDropped     ErrorCode = "TRANSACTION_DROPPED"
 
 	// Contract Domain Error Codes.
-	ErrCodeContractCodeRequired  ErrorCode = "CONTRACT_CODE_REQUIRED"
-	ErrCodeContractCompileFailed ErrorCode = "CONTRACT_COMPILE_FAILED"
+	ErrCodeContractCodeRequired     ErrorCode = "CONTRACT_CODE_REQUIRED"
+	ErrCodeContractCompileFailed    ErrorCode = "CONTRACT_COMPILE_FAILED"
+	ErrCodeBytecodeRequired         ErrorCode = "BYTECODE_REQUIRED"
+	ErrCodeInvalidBytecode          ErrorCode = "INVALID_BYTECODE"
+	ErrCodeContractNotDeployable    ErrorCode = "CONTRACT_NOT_DEPLOYABLE"
+	ErrCodeContractDeployFailed     ErrorCode = "CONTRACT_DEPLOY_FAILED"
+	ErrCodeCompilerLoadFailed       ErrorCode = "COMPILER_LOAD_FAILED"
+	ErrCodeImportNotFound           ErrorCode = "IMPORT_NOT_FOUND"
+	ErrCodeContractNotFound         ErrorCode = "CONTRACT_NOT_FOUND"
+	ErrCodeInvalidArtifact          ErrorCode = "INVALID_ARTIFACT"
+	ErrCodeProjectScanFailed        ErrorCode = "PROJECT_SCAN_FAILED"
+	ErrCodeTransactionReverted      ErrorCode = "TRANSACTION_REVERTED"
+	ErrCodeInvalidAddress           ErrorCode = "INVALID_ADDRESS"
+	ErrCodeProxyResolveFailed       ErrorCode = "PROXY_RESOLVE_FAILED"
+	ErrCodeInvalidStorageLayout     ErrorCode = "INVALID_STORAGE_LAYOUT"
+	ErrCodeStorageVariableNotFound  ErrorCode = "STORAGE_VARIABLE_NOT_FOUND"
+	ErrCodeInvalidStorageExpression ErrorCode = "INVALID_STORAGE_EXPRESSION"
+	ErrCodeInvalidStorageValue      ErrorCode = "INVALID_STORAGE_VALUE"
+
+	// NFT Domain Error Codes.
+	ErrCodeUnsupportedTokenStandard ErrorCode = "UNSUPPORTED_TOKEN_STANDARD"
+	ErrCodeInvalidTokenURI          ErrorCode = "INVALID_TOKEN_URI"
+	ErrCodeMetadataFetchFailed      ErrorCode = "METADATA_FETCH_FAILED"
+	ErrCodeTokenNotOwned            ErrorCode = "TOKEN_NOT_OWNED"
+	ErrCodeInvalidTransferAmount    ErrorCode = "INVALID_TRANSFER_AMOUNT"
+	ErrCodeTransferFailed           ErrorCode = "NFT_TRANSFER_FAILED"
+
+	// Safe Domain Error Codes.
+	ErrCodeSafeQueryFailed     ErrorCode = "SAFE_QUERY_FAILED"
+	ErrCodeSafeHashMismatch    ErrorCode = "SAFE_HASH_MISMATCH"
+	ErrCodeSafeNotOwner        ErrorCode = "SAFE_NOT_OWNER"
+	ErrCodeSafeThresholdNotMet ErrorCode = "SAFE_THRESHOLD_NOT_MET"
+	ErrCodeSafeNonceMismatch   ErrorCode = "SAFE_NONCE_MISMATCH"
+	ErrCodeSafeExecutionFailed ErrorCode = "SAFE_EXECUTION_FAILED"
 
 	// Database Domain Error Codes.
 	ErrCodeRecordNotFound          ErrorCode = "RECORD_NOT_FOUND"
@@ -105,6 +105,30 @@ func WrapContractError(err error, code ErrorCode, message string) *CustomError {
 	return Wrap(err, code, message)
 }
 
+// NFT Domain Error Constructors
+
+// NewNFTError creates a new NFT-related error.
+func NewNFTError(code ErrorCode, message string) *CustomError {
+	return New(code, message)
+}
+
+// WrapNFTError wraps an error with an NFT error code.
+func WrapNFTError(err error, code ErrorCode, message string) *CustomError {
+	return Wrap(err, code, message)
+}
+
+// Safe Domain Error Constructors
+
+// NewSafeError creates a new Safe multisig-related error.
+func NewSafeError(code ErrorCode, message string) *CustomError {
+	return New(code, message)
+}
+
+// WrapSafeError wraps an error with a Safe multisig error code.
+func WrapSafeError(err error, code ErrorCode, message string) *CustomError {
+	return Wrap(err, code, message)
+}
+
 // Database Domain Error Constructors
 
 // NewDatabaseError creates a new database-related error.
@@ -2,17 +2,30 @@ package main
 
 import (
 	"log"
+	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rxtech-lab/smart-contract-cli/app"
+	"github.com/rxtech-lab/smart-contract-cli/internal/cli"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/compiler"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/wallet"
 	"github.com/rxtech-lab/smart-contract-cli/internal/view"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		code := cli.Run(os.Args[1:], os.Stdout, os.Stderr)
+		_ = compiler.Close()
+		wallet.CloseRemoteSigners()
+		os.Exit(code)
+	}
+
 	router := view.NewRouter()
 	router.SetRoutes(app.GetRoutes())
 	program := tea.NewProgram(router)
 	_, err := program.Run()
+	_ = compiler.Close()
+	wallet.CloseRemoteSigners()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,273 @@
+// Package bindings is the runtime used by the Go bindings generated with tools/bindgen. It is public so
+// bindings compile in other modules. Calls go through a Caller and transactions through a Transactor,
+// which the transports and signers of the CLI implement.
+package bindings
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+)
+
+// Caller runs the read-only calls and log queries of a binding.
+type Caller interface {
+	// Call executes a call with its raw calldata at the latest block and returns the result
+	Call(msg ethereum.CallMsg) (result []byte, err error)
+
+	// FilterLogs returns the logs matching the given filter query
+	FilterLogs(query ethereum.FilterQuery) (logs []types.Log, err error)
+}
+
+// Transactor sends the transactions of a binding.
+type Transactor interface {
+	// Transact sends a transaction with the calldata to the contract and waits for its receipt.
+	// Zero gasLimit estimates the gas and nil gasPrice uses the default gas price
+	Transact(contractAddress common.Address, data []byte, value *big.Int, gasLimit uint64, gasPrice *big.Int) (receipt *types.Receipt, err error)
+}
+
+// TransactOpts are the optional parameters of a transaction. Zero values let the signer estimate the gas
+// and use its default gas price.
+type TransactOpts struct {
+	Value    *big.Int
+	GasLimit uint64
+	GasPrice *big.Int
+}
+
+// TransactionResult is a mined transaction.
+type TransactionResult struct {
+	Hash   common.Hash
+	Status uint64
+}
+
+// BoundContract calls the functions and decodes the events of a contract deployed at an address.
+// Functions and events are referenced by their canonical signature, so overloads are unambiguous.
+type BoundContract struct {
+	address   common.Address
+	caller    Caller
+	functions map[string]abi.ABIElement
+	events    map[string]abi.ABIElement
+}
+
+// NewBoundContract binds the contract at the address, parsing its ABI JSON.
+func NewBoundContract(address common.Address, abiJSON string, caller Caller) (*BoundContract, error) {
+	elements, err := abi.ParseAbi(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := &BoundContract{
+		address:   address,
+		caller:    caller,
+		functions: map[string]abi.ABIElement{},
+		events:    map[string]abi.ABIElement{},
+	}
+	for _, element := range elements {
+		if element.Type != "function" && element.Type != "event" {
+			continue
+		}
+		signature, err := element.Signature()
+		if err != nil {
+			return nil, err
+		}
+		if element.Type == "function" {
+			contract.functions[signature] = element
+		} else {
+			contract.events[signature] = element
+		}
+	}
+	return contract, nil
+}
+
+// Address returns the address of the contract.
+func (b *BoundContract) Address() common.Address {
+	return b.address
+}
+
+func (b *BoundContract) function(signature string) (abi.ABIElement, error) {
+	function, ok := b.functions[signature]
+	if !ok {
+		return abi.ABIElement{}, errors.NewABIError(errors.ErrCodeMethodNotFound, fmt.Sprintf("function %s not found in ABI", signature))
+	}
+	return function, nil
+}
+
+func (b *BoundContract) event(signature string) (abi.ABIElement, error) {
+	event, ok := b.events[signature]
+	if !ok {
+		return abi.ABIElement{}, errors.NewABIError(errors.ErrCodeEventNotFound, fmt.Sprintf("event %s not found in ABI", signature))
+	}
+	return event, nil
+}
+
+// encodeCall returns the calldata calling the function with the arguments.
+func encodeCall(function abi.ABIElement, signature string, args ...any) ([]byte, error) {
+	data, err := function.EncodeCall(args...)
+	if err != nil {
+		return nil, errors.WrapABIError(err, errors.ErrCodeABIPackFailed, fmt.Sprintf("failed to pack arguments of %s", signature))
+	}
+	return data, nil
+}
+
+// Call calls a read-only function and returns its unpacked return values.
+func (b *BoundContract) Call(signature string, args ...any) ([]any, error) {
+	function, err := b.function(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encodeCall(function, signature, args...)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := b.caller.Call(ethereum.CallMsg{To: &b.address, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", signature, err)
+	}
+
+	outputs, err := abi.Arguments(function.Outputs)
+	if err != nil {
+		return nil, errors.WrapABIError(err, errors.ErrCodeABIConversionFailed, fmt.Sprintf("invalid outputs of %s", signature))
+	}
+	values, err := outputs.Unpack(raw)
+	if err != nil {
+		return nil, errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, fmt.Sprintf("failed to unpack result of %s", signature))
+	}
+	if len(values) != len(outputs) {
+		return nil, errors.NewABIError(errors.ErrCodeABIUnpackFailed, fmt.Sprintf("%s returned %d values, expected %d", signature, len(values), len(outputs)))
+	}
+	return values, nil
+}
+
+// Transact sends a transaction calling a state-changing function and waits until it is mined.
+// A reverted transaction returns its result together with an error.
+func (b *BoundContract) Transact(sgn Transactor, opts *TransactOpts, signature string, args ...any) (*TransactionResult, error) {
+	function, err := b.function(signature)
+	if err != nil {
+		return nil, err
+	}
+	if function.IsReadOnly() {
+		return nil, errors.NewABIError(errors.ErrCodeMethodNotFound, fmt.Sprintf("%s is read-only, use Call instead", signature))
+	}
+	if opts == nil {
+		opts = &TransactOpts{}
+	}
+
+	data, err := encodeCall(function, signature, args...)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := sgn.Transact(b.address, data, opts.Value, opts.GasLimit, opts.GasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s: %w", signature, err)
+	}
+
+	transaction := &TransactionResult{Hash: receipt.TxHash, Status: receipt.Status}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return transaction, errors.NewContractError(errors.ErrCodeTransactionReverted, fmt.Sprintf("transaction %s calling %s reverted", receipt.TxHash.Hex(), signature))
+	}
+	return transaction, nil
+}
+
+// FilterLogs returns the logs of an event emitted by the contract between two blocks, nil meaning the
+// genesis and the latest block.
+func (b *BoundContract) FilterLogs(signature string, fromBlock *big.Int, toBlock *big.Int) ([]types.Log, error) {
+	event, err := b.event(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	query := ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: []common.Address{b.address},
+	}
+	if !event.Anonymous {
+		topic, err := event.Topic()
+		if err != nil {
+			return nil, err
+		}
+		query.Topics = [][]common.Hash{{topic}}
+	}
+	return b.caller.FilterLogs(query)
+}
+
+// UnpackLog decodes a log of the event into out, a pointer to a struct with a field per event parameter.
+// Unnamed parameters are named argN, and indexed strings, bytes, arrays and tuples are decoded as the
+// common.Hash stored in their topic.
+func (b *BoundContract) UnpackLog(out any, signature string, log types.Log) error {
+	event, err := b.event(signature)
+	if err != nil {
+		return err
+	}
+
+	arguments, err := abi.Arguments(event.Inputs)
+	if err != nil {
+		return errors.WrapABIError(err, errors.ErrCodeABIConversionFailed, fmt.Sprintf("invalid parameters of %s", signature))
+	}
+	indexed := ethabi.Arguments{}
+	for index := range arguments {
+		if arguments[index].Name == "" {
+			arguments[index].Name = fmt.Sprintf("arg%d", index)
+		}
+		if arguments[index].Indexed {
+			indexed = append(indexed, arguments[index])
+		}
+	}
+
+	topics := log.Topics
+	if !event.Anonymous {
+		topic, err := event.Topic()
+		if err != nil {
+			return err
+		}
+		if len(topics) == 0 || topics[0] != topic {
+			return errors.NewABIError(errors.ErrCodeEventMismatch, fmt.Sprintf("log is not a %s event", signature))
+		}
+		topics = topics[1:]
+	}
+	if len(topics) != len(indexed) {
+		return errors.NewABIError(errors.ErrCodeEventMismatch, fmt.Sprintf("log has %d indexed topics, %s expects %d", len(topics), signature, len(indexed)))
+	}
+
+	values, err := arguments.Unpack(log.Data)
+	if err == nil {
+		err = arguments.Copy(out, values)
+	}
+	if err != nil {
+		return errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, fmt.Sprintf("failed to unpack data of %s", signature))
+	}
+	if err := ethabi.ParseTopics(out, indexed, topics); err != nil {
+		return errors.WrapABIError(err, errors.ErrCodeABIUnpackFailed, fmt.Sprintf("failed to unpack topics of %s", signature))
+	}
+	return nil
+}
+
+// Convert converts a value unpacked by go-ethereum into the type used by the binding, e.g. the
+// anonymous struct of a tuple into the generated struct with the same fields.
+func Convert[T any](value any) (result T, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errors.NewABIError(errors.ErrCodeABIUnpackFailed, fmt.Sprintf("cannot convert %T to %T: %v", value, result, recovered))
+		}
+	}()
+	if typed, ok := value.(T); ok {
+		return typed, nil
+	}
+	// ConvertType silently leaves the zero value behind when the kinds differ, so only let it map
+	// between values of the same shape.
+	if value == nil || reflect.TypeOf(value).Kind() != reflect.TypeOf(&result).Elem().Kind() {
+		return result, errors.NewABIError(errors.ErrCodeABIUnpackFailed, fmt.Sprintf("cannot convert %T to %T", value, result))
+	}
+	converted, ok := ethabi.ConvertType(value, new(T)).(*T)
+	if !ok {
+		return result, errors.NewABIError(errors.ErrCodeABIUnpackFailed, fmt.Sprintf("cannot convert %T to %T", value, result))
+	}
+	return *converted, nil
+}
@@ -0,0 +1,134 @@
+package bindings
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/signer"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/contract/transport"
+	"github.com/rxtech-lab/smart-contract-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tokenABI = `[
+	"function balanceOf(address owner) view returns (uint256)",
+	"function transfer(address to, uint256 amount) returns (bool)",
+	"function transfer(address to, uint256 amount, bytes data) returns (bool)",
+	"event Transfer(address indexed from, address indexed to, uint256 value)"
+]`
+
+var tokenAddress = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+
+// The transports and signers of the CLI are the Caller and Transactor of the bindings.
+var (
+	_ Caller     = transport.Transport(nil)
+	_ Transactor = signer.SignerWithTransport(nil)
+)
+
+type fakeCaller struct {
+	data   []byte
+	result []byte
+	query  ethereum.FilterQuery
+}
+
+func (f *fakeCaller) Call(msg ethereum.CallMsg) ([]byte, error) {
+	f.data = msg.Data
+	return f.result, nil
+}
+
+func (f *fakeCaller) FilterLogs(query ethereum.FilterQuery) ([]types.Log, error) {
+	f.query = query
+	return nil, nil
+}
+
+type fakeTransactor struct {
+	data   []byte
+	status uint64
+}
+
+func (f *fakeTransactor) Transact(_ common.Address, data []byte, _ *big.Int, _ uint64, _ *big.Int) (*types.Receipt, error) {
+	f.data = data
+	return &types.Receipt{Status: f.status, TxHash: common.HexToHash("0xaa")}, nil
+}
+
+func newToken(t *testing.T, caller Caller) *BoundContract {
+	t.Helper()
+	contract, err := NewBoundContract(tokenAddress, tokenABI, caller)
+	require.NoError(t, err)
+	return contract
+}
+
+func TestCall(t *testing.T) {
+	caller := &fakeCaller{result: common.LeftPadBytes(big.NewInt(1000).Bytes(), 32)}
+	contract := newToken(t, caller)
+
+	values, err := contract.Call("balanceOf(address)", tokenAddress)
+	require.NoError(t, err)
+	balance, err := Convert[*big.Int](values[0])
+	require.NoError(t, err)
+	assert.Equal(t, "1000", balance.String())
+	assert.Equal(t, "0x70a08231", hexutil.Encode(caller.data[:4]))
+
+	_, err = contract.Call("balanceOf(uint256)")
+	assert.True(t, errors.HasCode(err, errors.ErrCodeMethodNotFound))
+}
+
+func TestTransactSendsOnlyTheOverload(t *testing.T) {
+	contract := newToken(t, &fakeCaller{})
+	sgn := &fakeTransactor{status: types.ReceiptStatusSuccessful}
+
+	result, err := contract.Transact(sgn, nil, "transfer(address,uint256,bytes)", tokenAddress, big.NewInt(1), []byte{})
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0xaa"), result.Hash)
+	assert.Equal(t, "0xbe45fd62", hexutil.Encode(sgn.data[:4]))
+
+	sgn.status = types.ReceiptStatusFailed
+	result, err = contract.Transact(sgn, nil, "transfer(address,uint256)", tokenAddress, big.NewInt(1))
+	assert.True(t, errors.HasCode(err, errors.ErrCodeTransactionReverted))
+	require.NotNil(t, result)
+	assert.Equal(t, types.ReceiptStatusFailed, result.Status)
+
+	_, err = contract.Transact(sgn, nil, "balanceOf(address)", tokenAddress)
+	assert.Error(t, err)
+}
+
+func TestFilterLogsAndUnpackLog(t *testing.T) {
+	caller := &fakeCaller{}
+	contract := newToken(t, caller)
+
+	_, err := contract.FilterLogs("Transfer(address,address,uint256)", big.NewInt(10), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{tokenAddress}, caller.query.Addresses)
+	assert.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", caller.query.Topics[0][0].Hex())
+
+	type transfer struct {
+		From  common.Address
+		To    common.Address
+		Value *big.Int
+	}
+	log := types.Log{
+		Topics: []common.Hash{caller.query.Topics[0][0], common.BytesToHash(tokenAddress.Bytes()), {}},
+		Data:   common.LeftPadBytes(big.NewInt(5).Bytes(), 32),
+	}
+	var event transfer
+	require.NoError(t, contract.UnpackLog(&event, "Transfer(address,address,uint256)", log))
+	assert.Equal(t, tokenAddress, event.From)
+	assert.Equal(t, "5", event.Value.String())
+
+	log.Topics[0] = common.Hash{}
+	err = contract.UnpackLog(&event, "Transfer(address,address,uint256)", log)
+	assert.True(t, errors.HasCode(err, errors.ErrCodeEventMismatch))
+
+	err = contract.UnpackLog(&event, "Approval(address,address,uint256)", log)
+	assert.True(t, errors.HasCode(err, errors.ErrCodeEventNotFound))
+}
+
+func TestConvertReportsMismatchedTypes(t *testing.T) {
+	_, err := Convert[common.Address]("not an address")
+	assert.True(t, errors.HasCode(err, errors.ErrCodeABIUnpackFailed))
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strings"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+)
+
+// Options configure the generated bindings.
+type Options struct {
+	// Package is the package name of the generated file.
+	Package string
+	// Type is the name of the binding type, e.g. "Token" generates Token, NewToken and TokenABI.
+	Type string
+}
+
+// reservedNames are identifiers used by the generated code that parameters must not shadow.
+var reservedNames = []string{"c", "sgn", "opts", "values", "result", "err", "bindings", "common", "big", "types"}
+
+type generator struct {
+	options Options
+
+	structs     map[string]string
+	structOrder []string
+	methods     map[string]bool
+
+	body strings.Builder
+}
+
+// Generate generates typed Go bindings for the ABI. Read-only functions are called through a
+// bindings.Caller, state-changing functions through a bindings.Transactor, and every event
+// gets a typed struct with Filter and Parse methods.
+func Generate(elements abi.AbiArray, options Options) ([]byte, error) {
+	if options.Type == "" || options.Package == "" {
+		return nil, fmt.Errorf("type and package names are required")
+	}
+
+	g := &generator{
+		options: options,
+		structs: map[string]string{},
+		methods: map[string]bool{"Address": true},
+	}
+
+	// The runtime tells calls from transactions by stateMutability, which old ABIs lack
+	normalized := make(abi.AbiArray, 0, len(elements))
+	for _, element := range elements {
+		if element.Type == "function" {
+			element.StateMutability = string(element.EffectiveStateMutability())
+		}
+		normalized = append(normalized, element)
+	}
+
+	overloads := map[string]int{}
+	for _, element := range normalized {
+		if element.Type != "function" && element.Type != "event" {
+			continue
+		}
+
+		// Overloads are numbered like go-ethereum does: transfer, transfer0, transfer1
+		key := element.Type + " " + element.Name
+		name := ethabi.ToCamelCase(element.Name)
+		if count := overloads[key]; count > 0 {
+			name = fmt.Sprintf("%s%d", name, count-1)
+		}
+		overloads[key]++
+
+		var err error
+		if element.Type == "function" {
+			err = g.writeFunction(element, name)
+		} else {
+			err = g.writeEvent(element, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", element.Type, element.Name, err)
+		}
+	}
+
+	abiJSON, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling ABI: %w", err)
+	}
+
+	source, err := format.Source([]byte(g.file(string(abiJSON))))
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return source, nil
+}
+
+// methodName returns a unique method name on the binding type.
+func (g *generator) methodName(name string) string {
+	result := name
+	for suffix := 0; g.methods[result]; suffix++ {
+		result = fmt.Sprintf("%s%d", name, suffix)
+	}
+	g.methods[result] = true
+	return result
+}
+
+func newTakenNames() map[string]bool {
+	taken := map[string]bool{}
+	for _, name := range reservedNames {
+		taken[name] = true
+	}
+	return taken
+}
+
+func (g *generator) writeFunction(function abi.ABIElement, name string) error {
+	signature, err := function.Signature()
+	if err != nil {
+		return err
+	}
+	name = g.methodName(name)
+
+	taken := newTakenNames()
+	params := []string{}
+	args := []string{}
+	for index, input := range function.Inputs {
+		inputType, err := g.goType(input, name+ethabi.ToCamelCase(abi.ArgumentName(input, index)))
+		if err != nil {
+			return err
+		}
+		paramName := parameterName(input.Name, index, taken)
+		params = append(params, paramName+" "+inputType)
+		args = append(args, ", "+paramName)
+	}
+
+	description := strings.TrimPrefix(function.HumanReadable(), "function ")
+	if !function.IsReadOnly() {
+		fmt.Fprintf(&g.body, "// %s sends a transaction calling %s.\n", name, description)
+		fmt.Fprintf(&g.body, "func (c *%s) %s(%s) (*bindings.TransactionResult, error) {\n",
+			g.options.Type, name, strings.Join(append([]string{"sgn bindings.Transactor", "opts *bindings.TransactOpts"}, params...), ", "))
+		fmt.Fprintf(&g.body, "\treturn c.contract.Transact(sgn, opts, %q%s)\n}\n\n", signature, strings.Join(args, ""))
+		return nil
+	}
+
+	fmt.Fprintf(&g.body, "// %s calls %s.\n", name, description)
+	switch len(function.Outputs) {
+	case 0:
+		fmt.Fprintf(&g.body, "func (c *%s) %s(%s) error {\n", g.options.Type, name, strings.Join(params, ", "))
+		fmt.Fprintf(&g.body, "\t_, err := c.contract.Call(%q%s)\n\treturn err\n}\n\n", signature, strings.Join(args, ""))
+	case 1:
+		outputType, err := g.goType(function.Outputs[0], name+"Output")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&g.body, "func (c *%s) %s(%s) (result %s, err error) {\n", g.options.Type, name, strings.Join(params, ", "), outputType)
+		fmt.Fprintf(&g.body, "\tvalues, err := c.contract.Call(%q%s)\n", signature, strings.Join(args, ""))
+		fmt.Fprintf(&g.body, "\tif err != nil {\n\t\treturn result, err\n\t}\n")
+		fmt.Fprintf(&g.body, "\treturn bindings.Convert[%s](values[0])\n}\n\n", outputType)
+	default:
+		return g.writeMultipleOutputs(function, name, signature, params, args)
+	}
+	return nil
+}
+
+// writeMultipleOutputs writes a read-only function returning a struct with a field per return value.
+func (g *generator) writeMultipleOutputs(function abi.ABIElement, name string, signature string, params []string, args []string) error {
+	outputName := g.options.Type + name + "Output"
+	fields := strings.Builder{}
+	conversions := strings.Builder{}
+	taken := map[string]bool{}
+	for index, output := range function.Outputs {
+		fieldName := ethabi.ToCamelCase(output.Name)
+		if fieldName == "" || taken[fieldName] {
+			fieldName = fmt.Sprintf("Arg%d", index)
+		}
+		taken[fieldName] = true
+
+		outputType, err := g.goType(output, name+fieldName)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&fields, "\t%s %s\n", fieldName, outputType)
+		fmt.Fprintf(&conversions, "\tif result.%s, err = bindings.Convert[%s](values[%d]); err != nil {\n\t\treturn result, err\n\t}\n", fieldName, outputType, index)
+	}
+
+	fmt.Fprintf(&g.body, "func (c *%s) %s(%s) (result %s, err error) {\n", g.options.Type, name, strings.Join(params, ", "), outputName)
+	fmt.Fprintf(&g.body, "\tvalues, err := c.contract.Call(%q%s)\n", signature, strings.Join(args, ""))
+	fmt.Fprintf(&g.body, "\tif err != nil {\n\t\treturn result, err\n\t}\n")
+	g.body.WriteString(conversions.String())
+	g.body.WriteString("\treturn result, nil\n}\n\n")
+
+	fmt.Fprintf(&g.body, "// %s holds the return values of %s.\n", outputName, name)
+	fmt.Fprintf(&g.body, "type %s struct {\n%s}\n\n", outputName, fields.String())
+	return nil
+}
+
+func (g *generator) writeEvent(event abi.ABIElement, name string) error {
+	signature, err := event.Signature()
+	if err != nil {
+		return err
+	}
+	eventType := g.options.Type + name
+	filterName := g.methodName("Filter" + name)
+	parseName := g.methodName("Parse" + name)
+
+	fields := strings.Builder{}
+	for index, input := range event.Inputs {
+		// go-ethereum names unnamed event parameters argN
+		fieldName := ethabi.ToCamelCase(abi.ArgumentName(input, index))
+		if fieldName == "Raw" {
+			return fmt.Errorf("parameter %s conflicts with the Raw field", input.Name)
+		}
+		fieldType, err := g.eventFieldType(input, name+fieldName)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&fields, "\t%s %s\n", fieldName, fieldType)
+	}
+
+	fmt.Fprintf(&g.body, "// %s is a %s event emitted by the %s contract.\n", eventType, name, g.options.Type)
+	fmt.Fprintf(&g.body, "type %s struct {\n%s\tRaw types.Log // the log the event was decoded from\n}\n\n", eventType, fields.String())
+
+	fmt.Fprintf(&g.body, "// %s returns the %s events emitted between two blocks, nil meaning the genesis and the latest block.\n", filterName, strings.TrimPrefix(event.HumanReadable(), "event "))
+	fmt.Fprintf(&g.body, "func (c *%s) %s(fromBlock *big.Int, toBlock *big.Int) ([]%s, error) {\n", g.options.Type, filterName, eventType)
+	fmt.Fprintf(&g.body, "\tlogs, err := c.contract.FilterLogs(%q, fromBlock, toBlock)\n", signature)
+	g.body.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n\n")
+	fmt.Fprintf(&g.body, "\tevents := make([]%s, 0, len(logs))\n", eventType)
+	g.body.WriteString("\tfor _, log := range logs {\n")
+	fmt.Fprintf(&g.body, "\t\tevent, err := c.%s(log)\n", parseName)
+	g.body.WriteString("\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t\tevents = append(events, *event)\n\t}\n\treturn events, nil\n}\n\n")
+
+	fmt.Fprintf(&g.body, "// %s decodes a %s event from a log.\n", parseName, name)
+	fmt.Fprintf(&g.body, "func (c *%s) %s(log types.Log) (*%s, error) {\n", g.options.Type, parseName, eventType)
+	fmt.Fprintf(&g.body, "\tevent := &%s{Raw: log}\n", eventType)
+	fmt.Fprintf(&g.body, "\tif err := c.contract.UnpackLog(event, %q, log); err != nil {\n\t\treturn nil, err\n\t}\n\treturn event, nil\n}\n\n", signature)
+	return nil
+}
+
+// file assembles the generated file, importing only the packages the body uses.
+func (g *generator) file(abiJSON string) string {
+	var file strings.Builder
+	file.WriteString("// Code generated by bindgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&file, "package %s\n\n", g.options.Package)
+
+	body := g.body.String()
+	var structs strings.Builder
+	for _, name := range g.structOrder {
+		fmt.Fprintf(&structs, "// %s is a struct of the %s contract.\n", name, g.options.Type)
+		fmt.Fprintf(&structs, "type %s struct {\n%s}\n\n", name, g.structs[name])
+	}
+	used := body + structs.String()
+
+	file.WriteString("import (\n")
+	if strings.Contains(used, "big.Int") {
+		file.WriteString("\t\"math/big\"\n\n")
+	}
+	file.WriteString("\t\"github.com/ethereum/go-ethereum/common\"\n")
+	if strings.Contains(used, "types.Log") {
+		file.WriteString("\t\"github.com/ethereum/go-ethereum/core/types\"\n")
+	}
+	file.WriteString("\t\"github.com/rxtech-lab/smart-contract-cli/pkg/bindings\"\n")
+	file.WriteString(")\n\n")
+
+	name := g.options.Type
+	fmt.Fprintf(&file, "// %sABI is the ABI the bindings were generated from.\n", name)
+	if strings.Contains(abiJSON, "`") {
+		fmt.Fprintf(&file, "const %sABI = %q\n\n", name, abiJSON)
+	} else {
+		fmt.Fprintf(&file, "const %sABI = `%s`\n\n", name, abiJSON)
+	}
+
+	fmt.Fprintf(&file, "// %s is a binding of the %s contract.\n", name, name)
+	fmt.Fprintf(&file, "type %s struct {\n\tcontract *bindings.BoundContract\n}\n\n", name)
+	fmt.Fprintf(&file, "// New%s binds the %s contract deployed at the address, calling it through the caller.\n", name, name)
+	fmt.Fprintf(&file, "func New%s(address common.Address, caller bindings.Caller) (*%s, error) {\n", name, name)
+	fmt.Fprintf(&file, "\tcontract, err := bindings.NewBoundContract(address, %sABI, caller)\n", name)
+	file.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	fmt.Fprintf(&file, "\treturn &%s{contract: contract}, nil\n}\n\n", name)
+	file.WriteString("// Address returns the address of the contract.\n")
+	fmt.Fprintf(&file, "func (c *%s) Address() common.Address {\n\treturn c.contract.Address()\n}\n\n", name)
+
+	file.WriteString(body)
+	file.WriteString(structs.String())
+	return file.String()
+}
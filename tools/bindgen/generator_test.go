@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/stretchr/testify/suite"
+)
+
+const modulePath = "github.com/rxtech-lab/smart-contract-cli"
+
+type GeneratorTestSuite struct {
+	suite.Suite
+	elements abi.AbiArray
+}
+
+func (s *GeneratorTestSuite) SetupTest() {
+	elements, err := abi.ReadAbi(filepath.Join("testdata", "exchange.json"))
+	s.Require().NoError(err)
+	s.elements = elements
+}
+
+func (s *GeneratorTestSuite) generate() string {
+	source, err := Generate(s.elements, Options{Package: "exchange", Type: "Exchange"})
+	s.Require().NoError(err)
+	return string(source)
+}
+
+func (s *GeneratorTestSuite) TestGenerateTypedMethods() {
+	source := s.generate()
+
+	s.Contains(source, "// Code generated by bindgen. DO NOT EDIT.")
+	s.Contains(source, "func NewExchange(address common.Address, caller bindings.Caller) (*Exchange, error)")
+	s.Contains(source, "func (c *Exchange) BalanceOf(owner common.Address) (result *big.Int, err error)")
+	s.Contains(source, "func (c *Exchange) Decimals() (result uint8, err error)")
+	s.Contains(source, "func (c *Exchange) GetReserves() (result ExchangeGetReservesOutput, err error)")
+	s.Contains(source, "func (c *Exchange) Order(id *big.Int) (result ExchangeOrder, err error)")
+	s.Contains(source, "func (c *Exchange) Transfer(sgn bindings.Transactor, opts *bindings.TransactOpts, to common.Address, amount *big.Int) (*bindings.TransactionResult, error)")
+	s.Contains(source, `c.contract.Transact(sgn, opts, "transfer(address,uint256,bytes)", to, amount, data)`)
+	s.Contains(source, "func (c *Exchange) Submit(sgn bindings.Transactor, opts *bindings.TransactOpts, orders []ExchangeOrder, arg1 [32]byte)")
+	s.Contains(source, "func (c *Exchange) FilterTransfer(fromBlock *big.Int, toBlock *big.Int) ([]ExchangeTransfer, error)")
+	s.Contains(source, "func (c *Exchange) ParseMemo(log types.Log) (*ExchangeMemo, error)")
+	s.Contains(source, "Tag  common.Hash")
+	s.NotContains(source, "func (c *Exchange) Unauthorized")
+}
+
+func (s *GeneratorTestSuite) TestLegacyConstantFunctionIsCalled() {
+	source := s.generate()
+
+	s.Contains(source, "func (c *Exchange) Name() (result string, err error)")
+	s.Contains(source, `"name":"name","outputs":[{"type":"string"}],"stateMutability":"view"`)
+}
+
+func (s *GeneratorTestSuite) TestGenerateRequiresNames() {
+	_, err := Generate(s.elements, Options{Package: "exchange"})
+	s.Error(err)
+}
+
+func (s *GeneratorTestSuite) TestGeneratedBindingsCompileAndRun() {
+	goBinary, err := exec.LookPath("go")
+	if err != nil {
+		s.T().Skip("go toolchain not found")
+	}
+
+	// The bindings are built in a module of their own, as in the services using them, where the
+	// internal packages of this module cannot be imported
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	s.Require().NoError(err)
+	dir := s.T().TempDir()
+	goMod := fmt.Sprintf("module example.com/exchange\n\ngo 1.25.0\n\nrequire %s v0.0.0\n\nreplace %s => %s\n", modulePath, modulePath, root)
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0600))
+	goSum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	s.Require().NoError(err)
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "go.sum"), goSum, 0600))
+
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "exchange.go"), []byte(s.generate()), 0600))
+	usage, err := os.ReadFile(filepath.Join("testdata", "binding_test.go"))
+	s.Require().NoError(err)
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "binding_test.go"), usage, 0600))
+
+	for _, args := range [][]string{{"mod", "tidy"}, {"test", "./..."}} {
+		command := exec.Command(goBinary, args...)
+		command.Dir = dir
+		command.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+		output, err := command.CombinedOutput()
+		s.Require().NoError(err, string(output))
+	}
+}
+
+func (s *GeneratorTestSuite) TestExportedName() {
+	s.Equal("MyTokenV2", exportedName("my-token_v2"))
+	s.Equal("ERC20", exportedName("ERC20"))
+	s.Equal("C1inch", exportedName("1inch"))
+}
+
+func (s *GeneratorTestSuite) TestParameterName() {
+	taken := newTakenNames()
+	s.Equal("owner", parameterName("_owner", 0, taken))
+	s.Equal("arg1", parameterName("owner", 1, taken))
+	s.Equal("arg2", parameterName("type", 2, taken))
+	s.Equal("arg3", parameterName("opts", 3, taken))
+	s.Equal("arg4", parameterName("", 4, taken))
+}
+
+func TestGeneratorTestSuite(t *testing.T) {
+	suite.Run(t, new(GeneratorTestSuite))
+}
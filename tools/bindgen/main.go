@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/storage/sql"
+)
+
+func main() {
+	config, err := parseFlags()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	elements, name, err := readABI(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	options := Options{Package: config.packageName, Type: config.typeName}
+	if options.Type == "" {
+		options.Type = exportedName(name)
+	}
+	if options.Package == "" {
+		options.Package = strings.ToLower(options.Type)
+	}
+
+	source, err := Generate(elements, options)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if config.outputFile == "" {
+		_, _ = os.Stdout.Write(source)
+		return
+	}
+	if err := os.WriteFile(config.outputFile, source, 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: writing output file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Successfully generated %s bindings: %s\n", options.Type, config.outputFile)
+}
+
+// config holds the configuration parsed from CLI flags.
+type config struct {
+	abiPath     string
+	dbPath      string
+	abiID       uint
+	typeName    string
+	packageName string
+	outputFile  string
+}
+
+// parseFlags parses CLI flags and returns configuration.
+func parseFlags() (*config, error) {
+	abiFlag := flag.String("abi", "", "ABI file or URL (JSON, artifact or human-readable signatures)")
+	dbFlag := flag.String("db", "", "SQLite database holding the ABI given by -id")
+	idFlag := flag.Uint("id", 0, "ID of the stored ABI to generate bindings for")
+	typeFlag := flag.String("type", "", "Name of the binding type (default: derived from the ABI name or file)")
+	packageFlag := flag.String("pkg", "", "Package name of the generated file (default: lowercase type name)")
+	outputFlag := flag.String("out", "", "Output file path (default: stdout)")
+
+	flag.Parse()
+
+	if (*abiFlag == "") == (*dbFlag == "") {
+		return nil, fmt.Errorf("either -abi or -db with -id is required")
+	}
+	if *dbFlag != "" && *idFlag == 0 {
+		return nil, fmt.Errorf("-id is required with -db")
+	}
+
+	return &config{
+		abiPath:     *abiFlag,
+		dbPath:      *dbFlag,
+		abiID:       *idFlag,
+		typeName:    *typeFlag,
+		packageName: *packageFlag,
+		outputFile:  *outputFlag,
+	}, nil
+}
+
+// readABI reads the ABI from a file or URL, or from the EvmAbi record, returning it with its name.
+func readABI(cfg *config) (abi.AbiArray, string, error) {
+	if cfg.abiPath != "" {
+		elements, err := abi.ReadAbi(cfg.abiPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading ABI: %w", err)
+		}
+		name := strings.TrimSuffix(filepath.Base(cfg.abiPath), filepath.Ext(cfg.abiPath))
+		return elements, name, nil
+	}
+
+	if _, err := os.Stat(cfg.dbPath); err != nil {
+		return nil, "", fmt.Errorf("database %s: %w", cfg.dbPath, err)
+	}
+	storage, err := sql.NewSQLiteDB(cfg.dbPath)
+	if err != nil {
+		return nil, "", err
+	}
+	record, err := storage.GetABIByID(cfg.abiID)
+	if err != nil {
+		return nil, "", err
+	}
+	return record.Abi.AbiArray, record.Name, nil
+}
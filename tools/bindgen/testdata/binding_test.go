@@ -0,0 +1,182 @@
+package exchange
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rxtech-lab/smart-contract-cli/pkg/bindings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// This file is copied next to the bindings generated from exchange.json, in a module of its own, and run
+// by the generator tests. It may only import public packages.
+
+var (
+	contractAddress = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	maker           = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+)
+
+// decodeCall returns the function called by the calldata and its arguments.
+func decodeCall(data []byte) (*ethabi.Method, []any, error) {
+	parsed, err := ethabi.JSON(strings.NewReader(ExchangeABI))
+	if err != nil {
+		return nil, nil, err
+	}
+	method, err := parsed.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return method, args, nil
+}
+
+type fakeCaller struct {
+	outputs map[string][]any
+	calls   map[string][]any
+	logs    []types.Log
+	query   ethereum.FilterQuery
+}
+
+func (f *fakeCaller) Call(msg ethereum.CallMsg) ([]byte, error) {
+	method, args, err := decodeCall(msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	f.calls[method.RawName] = args
+	return method.Outputs.Pack(f.outputs[method.RawName]...)
+}
+
+func (f *fakeCaller) FilterLogs(query ethereum.FilterQuery) ([]types.Log, error) {
+	f.query = query
+	return f.logs, nil
+}
+
+type fakeTransactor struct {
+	method string
+	value  *big.Int
+	args   []any
+}
+
+func (f *fakeTransactor) Transact(address common.Address, data []byte, value *big.Int, gasLimit uint64, gasPrice *big.Int) (*types.Receipt, error) {
+	method, args, err := decodeCall(data)
+	if err != nil {
+		return nil, err
+	}
+	f.method, f.value, f.args = method.RawName, value, args
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: common.HexToHash("0xaa")}, nil
+}
+
+func newExchange(t *testing.T) (*Exchange, *fakeCaller) {
+	t.Helper()
+	caller := &fakeCaller{outputs: map[string][]any{}, calls: map[string][]any{}}
+	contract, err := NewExchange(contractAddress, caller)
+	require.NoError(t, err)
+	assert.Equal(t, contractAddress, contract.Address())
+	return contract, caller
+}
+
+func TestReadOnlyFunctions(t *testing.T) {
+	contract, caller := newExchange(t)
+	caller.outputs["name"] = []any{"Exchange"}
+	caller.outputs["balanceOf"] = []any{big.NewInt(1000)}
+	caller.outputs["decimals"] = []any{uint8(18)}
+	caller.outputs["getReserves"] = []any{big.NewInt(5), big.NewInt(7), uint32(42)}
+	caller.outputs["order"] = []any{ExchangeOrder{Maker: maker, Amounts: []*big.Int{big.NewInt(1), big.NewInt(2)}}}
+
+	name, err := contract.Name()
+	require.NoError(t, err)
+	assert.Equal(t, "Exchange", name)
+
+	balance, err := contract.BalanceOf(maker)
+	require.NoError(t, err)
+	assert.Equal(t, "1000", balance.String())
+	assert.Equal(t, []any{maker}, caller.calls["balanceOf"])
+
+	decimals, err := contract.Decimals()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(18), decimals)
+
+	reserves, err := contract.GetReserves()
+	require.NoError(t, err)
+	assert.Equal(t, "7", reserves.Reserve1.String())
+	assert.Equal(t, uint32(42), reserves.BlockTimestampLast)
+
+	order, err := contract.Order(big.NewInt(3))
+	require.NoError(t, err)
+	assert.Equal(t, maker, order.Maker)
+	assert.Len(t, order.Amounts, 2)
+}
+
+func TestTransactions(t *testing.T) {
+	contract, _ := newExchange(t)
+	sgn := &fakeTransactor{}
+
+	result, err := contract.Transfer0(sgn, nil, maker, big.NewInt(5), []byte{0x01})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), result.Status)
+	assert.Equal(t, "transfer", sgn.method)
+	assert.Equal(t, []any{maker, big.NewInt(5), []byte{0x01}}, sgn.args)
+
+	orders := []ExchangeOrder{{Maker: maker, Amounts: []*big.Int{big.NewInt(9)}}}
+	_, err = contract.Submit(sgn, &bindings.TransactOpts{Value: big.NewInt(100)}, orders, [32]byte{0x02})
+	require.NoError(t, err)
+	assert.Equal(t, "submit", sgn.method)
+	assert.Equal(t, "100", sgn.value.String())
+}
+
+func TestEvents(t *testing.T) {
+	contract, caller := newExchange(t)
+
+	data, err := ethabi.Arguments{{Type: mustType(t, "uint256")}}.Pack(big.NewInt(77))
+	require.NoError(t, err)
+	caller.logs = []types.Log{{
+		Address: contractAddress,
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+			common.BytesToHash(maker.Bytes()),
+			common.BytesToHash(contractAddress.Bytes()),
+		},
+		Data: data,
+	}}
+
+	transfers, err := contract.FilterTransfer(big.NewInt(1), nil)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, maker, transfers[0].From)
+	assert.Equal(t, contractAddress, transfers[0].To)
+	assert.Equal(t, "77", transfers[0].Value.String())
+	assert.Equal(t, caller.logs[0], transfers[0].Raw)
+	assert.Equal(t, []common.Address{contractAddress}, caller.query.Addresses)
+
+	memoData, err := ethabi.Arguments{{Type: mustType(t, "bytes32")}, {Type: mustType(t, "string")}}.Pack([32]byte{0x03}, "hello")
+	require.NoError(t, err)
+	tag := crypto.Keccak256Hash([]byte("greeting"))
+	memo, err := contract.ParseMemo(types.Log{
+		Topics: []common.Hash{crypto.Keccak256Hash([]byte("Memo(string,bytes32,string)")), tag},
+		Data:   memoData,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tag, memo.Tag)
+	assert.Equal(t, [32]byte{0x03}, memo.Arg1)
+	assert.Equal(t, "hello", memo.Text)
+
+	_, err = contract.ParseMemo(caller.logs[0])
+	assert.Error(t, err)
+}
+
+func mustType(t *testing.T, name string) ethabi.Type {
+	t.Helper()
+	abiType, err := ethabi.NewType(name, "", nil)
+	require.NoError(t, err)
+	return abiType
+}
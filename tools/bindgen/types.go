@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/rxtech-lab/smart-contract-cli/internal/contract/evm/abi"
+)
+
+// goType returns the Go type of a parameter, matching the types go-ethereum unpacks values into.
+// Structs are declared for tuples, named after their Solidity struct or after structName.
+func (g *generator) goType(param abi.ABIParam, structName string) (string, error) {
+	if strings.HasSuffix(param.Type, "]") {
+		open := strings.LastIndex(param.Type, "[")
+		if open < 0 {
+			return "", fmt.Errorf("invalid type %s", param.Type)
+		}
+		element := param
+		element.Type = param.Type[:open]
+		if index := strings.LastIndex(param.InternalType, "["); index > 0 && strings.HasSuffix(param.InternalType, "]") {
+			element.InternalType = param.InternalType[:index]
+		}
+		elementType, err := g.goType(element, structName)
+		if err != nil {
+			return "", err
+		}
+		return param.Type[open:] + elementType, nil
+	}
+
+	if param.Type == "tuple" {
+		return g.declareStruct(param, structName)
+	}
+
+	elementary, err := ethabi.NewType(param.Type, "", nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid type %s: %w", param.Type, err)
+	}
+	switch elementary.T {
+	case ethabi.AddressTy:
+		return "common.Address", nil
+	case ethabi.BoolTy:
+		return "bool", nil
+	case ethabi.StringTy:
+		return "string", nil
+	case ethabi.BytesTy:
+		return "[]byte", nil
+	case ethabi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", elementary.Size), nil
+	case ethabi.FunctionTy:
+		return "[24]byte", nil
+	case ethabi.IntTy, ethabi.UintTy:
+		switch elementary.Size {
+		case 8, 16, 32, 64:
+			if elementary.T == ethabi.UintTy {
+				return fmt.Sprintf("uint%d", elementary.Size), nil
+			}
+			return fmt.Sprintf("int%d", elementary.Size), nil
+		}
+		return "*big.Int", nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", param.Type)
+	}
+}
+
+// eventFieldType returns the Go type of an event parameter. Indexed strings, bytes, arrays and tuples
+// are stored as their keccak256 hash, so only the hash can be decoded.
+func (g *generator) eventFieldType(param abi.ABIParam, structName string) (string, error) {
+	if param.Indexed && (param.Type == "string" || param.Type == "bytes" || param.Type == "tuple" || strings.HasSuffix(param.Type, "]")) {
+		return "common.Hash", nil
+	}
+	return g.goType(param, structName)
+}
+
+// declareStruct declares the struct of a tuple once and returns its name. Tuples with the same name
+// but different fields get numbered names.
+func (g *generator) declareStruct(param abi.ABIParam, structName string) (string, error) {
+	if name, ok := strings.CutPrefix(param.InternalType, "struct "); ok {
+		structName = exportedName(strings.ReplaceAll(name, ".", ""))
+	}
+
+	var fields strings.Builder
+	for _, component := range param.Components {
+		fieldName := ethabi.ToCamelCase(component.Name)
+		if fieldName == "" {
+			return "", fmt.Errorf("tuple %s has an unnamed component", structName)
+		}
+		fieldType, err := g.goType(component, structName+fieldName)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&fields, "\t%s %s\n", fieldName, fieldType)
+	}
+
+	name := structName
+	for suffix := 0; ; suffix++ {
+		existing, ok := g.structs[name]
+		if !ok {
+			break
+		}
+		if existing == fields.String() {
+			return name, nil
+		}
+		name = fmt.Sprintf("%s%d", structName, suffix)
+	}
+
+	g.structs[name] = fields.String()
+	g.structOrder = append(g.structOrder, name)
+	return name, nil
+}
+
+// exportedName converts a name such as "my-token_v2" into an exported Go identifier ("MyTokenV2").
+func exportedName(name string) string {
+	var builder strings.Builder
+	upper := true
+	for _, char := range name {
+		if !unicode.IsLetter(char) && !unicode.IsDigit(char) {
+			upper = true
+			continue
+		}
+		if upper {
+			char = unicode.ToUpper(char)
+			upper = false
+		}
+		builder.WriteRune(char)
+	}
+
+	result := builder.String()
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		result = "C" + result
+	}
+	return result
+}
+
+// parameterName returns the Go name of a function parameter, e.g. "_owner" becomes "owner". Unnamed
+// parameters, keywords and names already taken become argN.
+func parameterName(name string, index int, taken map[string]bool) string {
+	result := ethabi.ToCamelCase(name)
+	if result != "" {
+		runes := []rune(result)
+		runes[0] = unicode.ToLower(runes[0])
+		result = string(runes)
+	}
+	if result == "" || token.IsKeyword(result) || taken[result] {
+		result = fmt.Sprintf("arg%d", index)
+	}
+	taken[result] = true
+	return result
+}